@@ -0,0 +1,127 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// DefaultName is the name of the admin identified by the shared token.
+const DefaultName = "admin"
+
+// Tokens maps the tokens of admins to their names. Empty tokens are ignored.
+type Tokens map[string]string
+
+// ParseTokens parses admin tokens in the "<name>:<token>,<name>:<token>" form.
+func ParseTokens(s string) (Tokens, error) {
+	tokens := Tokens{}
+	if s == "" {
+		return tokens, nil
+	}
+
+	for pair := range strings.SplitSeq(s, ",") {
+		name, token, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || name == "" || token == "" {
+			return nil, fmt.Errorf(
+				"admin token %q is not in the <name>:<token> form",
+				pair,
+			)
+		}
+
+		if _, ok := tokens[token]; ok {
+			return nil, fmt.Errorf("admin token of %q is not unique", name)
+		}
+
+		tokens[token] = name
+	}
+
+	return tokens, nil
+}
+
+// contextKey is the type of the key of the admin name in a context.
+type contextKey struct{}
+
+// IsAdmin returns true if the request of the given context is made by an
+// admin.
+func IsAdmin(ctx context.Context) bool {
+	return Name(ctx) != ""
+}
+
+// Name returns the name of the admin making the request of the given context,
+// or an empty string if the request is not made by an admin.
+func Name(ctx context.Context) string {
+	name, _ := ctx.Value(contextKey{}).(string)
+	return name
+}
+
+// UnaryServerInterceptor returns a gRPC server interceptor that marks the
+// requests bearing one of the given tokens as made by the admin it identifies.
+// If there are no tokens, no request is made by an admin.
+func UnaryServerInterceptor(tokens Tokens) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		return handler(withAdmin(ctx, tokens), req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC server interceptor that marks the
+// streaming calls bearing one of the given tokens as made by the admin it
+// identifies. If there are no tokens, no call is made by an admin.
+func StreamServerInterceptor(tokens Tokens) grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		_ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return handler(
+			srv,
+			&serverStream{
+				ServerStream: ss,
+				ctx:          withAdmin(ss.Context(), tokens),
+			},
+		)
+	}
+}
+
+// withAdmin returns a context marked as made by an admin if the incoming
+// metadata of the given context bear one of the tokens.
+func withAdmin(ctx context.Context, tokens Tokens) context.Context {
+	for _, v := range metadata.ValueFromIncomingContext(ctx, "authorization") {
+		bearer, ok := strings.CutPrefix(v, "Bearer ")
+		if !ok {
+			continue
+		}
+
+		for token, name := range tokens {
+			if token == "" {
+				continue
+			}
+
+			if subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1 {
+				return context.WithValue(ctx, contextKey{}, name)
+			}
+		}
+	}
+
+	return ctx
+}
+
+// serverStream is a grpc.ServerStream with a replaced context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
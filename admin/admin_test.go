@@ -0,0 +1,145 @@
+package admin_test
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	. "github.com/danilvpetrov/entain/admin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	tokens := Tokens{
+		"<token-1>": "<admin-1>",
+		"<token-2>": "<admin-2>",
+	}
+
+	cases := []struct {
+		md       metadata.MD
+		tokens   Tokens
+		name     string
+		expected string
+	}{
+		{
+			name:     "matching token",
+			tokens:   tokens,
+			md:       metadata.Pairs("authorization", "Bearer <token-2>"),
+			expected: "<admin-2>",
+		},
+		{
+			name:   "different token",
+			tokens: tokens,
+			md:     metadata.Pairs("authorization", "Bearer <other>"),
+		},
+		{
+			name:   "token without bearer scheme",
+			tokens: tokens,
+			md:     metadata.Pairs("authorization", "<token-1>"),
+		},
+		{
+			name:   "missing token",
+			tokens: tokens,
+			md:     metadata.MD{},
+		},
+		{
+			name: "admin token not configured",
+			md:   metadata.Pairs("authorization", "Bearer "),
+		},
+		{
+			name:   "empty admin token",
+			tokens: Tokens{"": "<admin>"},
+			md:     metadata.Pairs("authorization", "Bearer "),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			interceptor := UnaryServerInterceptor(c.tokens)
+
+			var (
+				actual  string
+				isAdmin bool
+			)
+			_, err := interceptor(
+				metadata.NewIncomingContext(t.Context(), c.md),
+				nil,
+				&grpc.UnaryServerInfo{},
+				func(ctx context.Context, _ any) (any, error) {
+					actual = Name(ctx)
+					isAdmin = IsAdmin(ctx)
+					return nil, nil
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if actual != c.expected {
+				t.Fatalf("expected admin %q, got %q", c.expected, actual)
+			}
+
+			if isAdmin != (c.expected != "") {
+				t.Fatalf("expected admin to be %v, got %v", !isAdmin, isAdmin)
+			}
+		})
+	}
+}
+
+func TestParseTokens(t *testing.T) {
+	cases := []struct {
+		expected Tokens
+		name     string
+		input    string
+		invalid  bool
+	}{
+		{
+			name:     "empty",
+			expected: Tokens{},
+		},
+		{
+			name:  "multiple admins",
+			input: "<admin-1>:<token-1>, <admin-2>:<token-2>",
+			expected: Tokens{
+				"<token-1>": "<admin-1>",
+				"<token-2>": "<admin-2>",
+			},
+		},
+		{
+			name:    "missing name",
+			input:   ":<token>",
+			invalid: true,
+		},
+		{
+			name:    "missing token",
+			input:   "<admin>",
+			invalid: true,
+		},
+		{
+			name:    "duplicate token",
+			input:   "<admin-1>:<token>,<admin-2>:<token>",
+			invalid: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := ParseTokens(c.input)
+			if c.invalid {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if !maps.Equal(actual, c.expected) {
+				t.Fatalf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Package admin identifies the requests made by support staff.
+//
+// Admins authenticate by sending a token in the "authorization" metadata of
+// gRPC requests, in the "Bearer <token>" form. Each token identifies an admin
+// by name, so that the changes they make can be audited. The API gateway
+// forwards the Authorization header of HTTP requests as such metadata.
+package admin
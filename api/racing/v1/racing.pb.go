@@ -0,0 +1,2425 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v6.32.1
+// source: api/racing/v1/racing.proto
+
+package racingv1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ListRacesRequest_OrderBy int32
+
+const (
+	// UNSPECIFIED indicates no specific ordering.
+	ListRacesRequest_UNSPECIFIED ListRacesRequest_OrderBy = 0
+	// ADVERTISED_START_TIME_ASC orders by advertised start time in
+	// ascending order.
+	ListRacesRequest_ADVERTISED_START_TIME_ASC ListRacesRequest_OrderBy = 1
+	// ADVERTISED_START_TIME_DESC orders by advertised start time in
+	// descending order.
+	ListRacesRequest_ADVERTISED_START_TIME_DESC ListRacesRequest_OrderBy = 2
+	// NUMBER_ASC orders by race number in ascending order.
+	ListRacesRequest_NUMBER_ASC ListRacesRequest_OrderBy = 3
+	// NUMBER_DESC orders by race number in descending order.
+	ListRacesRequest_NUMBER_DESC ListRacesRequest_OrderBy = 4
+	// NAME_ASC orders by race name in ascending order.
+	ListRacesRequest_NAME_ASC ListRacesRequest_OrderBy = 5
+	// NAME_DESC orders by race name in descending order.
+	ListRacesRequest_NAME_DESC ListRacesRequest_OrderBy = 6
+	// MEETING_ID_ASC orders by meeting ID in ascending order.
+	ListRacesRequest_MEETING_ID_ASC ListRacesRequest_OrderBy = 7
+	// MEETING_ID_DESC orders by meeting ID in descending order.
+	ListRacesRequest_MEETING_ID_DESC ListRacesRequest_OrderBy = 8
+)
+
+// Enum value maps for ListRacesRequest_OrderBy.
+var (
+	ListRacesRequest_OrderBy_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "ADVERTISED_START_TIME_ASC",
+		2: "ADVERTISED_START_TIME_DESC",
+		3: "NUMBER_ASC",
+		4: "NUMBER_DESC",
+		5: "NAME_ASC",
+		6: "NAME_DESC",
+		7: "MEETING_ID_ASC",
+		8: "MEETING_ID_DESC",
+	}
+	ListRacesRequest_OrderBy_value = map[string]int32{
+		"UNSPECIFIED":                0,
+		"ADVERTISED_START_TIME_ASC":  1,
+		"ADVERTISED_START_TIME_DESC": 2,
+		"NUMBER_ASC":                 3,
+		"NUMBER_DESC":                4,
+		"NAME_ASC":                   5,
+		"NAME_DESC":                  6,
+		"MEETING_ID_ASC":             7,
+		"MEETING_ID_DESC":            8,
+	}
+)
+
+func (x ListRacesRequest_OrderBy) Enum() *ListRacesRequest_OrderBy {
+	p := new(ListRacesRequest_OrderBy)
+	*p = x
+	return p
+}
+
+func (x ListRacesRequest_OrderBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ListRacesRequest_OrderBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_racing_v1_racing_proto_enumTypes[0].Descriptor()
+}
+
+func (ListRacesRequest_OrderBy) Type() protoreflect.EnumType {
+	return &file_api_racing_v1_racing_proto_enumTypes[0]
+}
+
+func (x ListRacesRequest_OrderBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ListRacesRequest_OrderBy.Descriptor instead.
+func (ListRacesRequest_OrderBy) EnumDescriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// Status represents the current status of the race.
+type Race_Status int32
+
+const (
+	Race_UNSPECIFIED Race_Status = 0
+	// OPEN indicates the race is open for betting.
+	Race_OPEN Race_Status = 1
+	// CLOSED indicates the race is closed for betting.
+	Race_CLOSED Race_Status = 2
+	// ABANDONED indicates the race has been abandoned. It is only reported in
+	// the snapshots of the change log, the other calls report abandoned races
+	// as CLOSED.
+	Race_ABANDONED Race_Status = 3
+)
+
+// Enum value maps for Race_Status.
+var (
+	Race_Status_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "OPEN",
+		2: "CLOSED",
+		3: "ABANDONED",
+	}
+	Race_Status_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"OPEN":        1,
+		"CLOSED":      2,
+		"ABANDONED":   3,
+	}
+)
+
+func (x Race_Status) Enum() *Race_Status {
+	p := new(Race_Status)
+	*p = x
+	return p
+}
+
+func (x Race_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Race_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_racing_v1_racing_proto_enumTypes[1].Descriptor()
+}
+
+func (Race_Status) Type() protoreflect.EnumType {
+	return &file_api_racing_v1_racing_proto_enumTypes[1]
+}
+
+func (x Race_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Race_Status.Descriptor instead.
+func (Race_Status) EnumDescriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{6, 0}
+}
+
+// Operation represents the kind of a change.
+type Change_Operation int32
+
+const (
+	Change_UNSPECIFIED Change_Operation = 0
+	// CREATED indicates the race was created.
+	Change_CREATED Change_Operation = 1
+	// UPDATED indicates the race was updated, including its archival.
+	Change_UPDATED Change_Operation = 2
+	// DELETED indicates the race was deleted or purged by the retention
+	// policy.
+	Change_DELETED Change_Operation = 3
+)
+
+// Enum value maps for Change_Operation.
+var (
+	Change_Operation_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "CREATED",
+		2: "UPDATED",
+		3: "DELETED",
+	}
+	Change_Operation_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"CREATED":     1,
+		"UPDATED":     2,
+		"DELETED":     3,
+	}
+)
+
+func (x Change_Operation) Enum() *Change_Operation {
+	p := new(Change_Operation)
+	*p = x
+	return p
+}
+
+func (x Change_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Change_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_racing_v1_racing_proto_enumTypes[2].Descriptor()
+}
+
+func (Change_Operation) Type() protoreflect.EnumType {
+	return &file_api_racing_v1_racing_proto_enumTypes[2]
+}
+
+func (x Change_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Change_Operation.Descriptor instead.
+func (Change_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{16, 0}
+}
+
+// Visibility represents the visibility of a race for a tenant.
+type TenantOverride_Visibility int32
+
+const (
+	// UNSPECIFIED indicates the race is as visible for the tenant as it is
+	// for other clients.
+	TenantOverride_UNSPECIFIED TenantOverride_Visibility = 0
+	// VISIBLE indicates the race is visible for the tenant.
+	TenantOverride_VISIBLE TenantOverride_Visibility = 1
+	// HIDDEN indicates the race is not visible for the tenant.
+	TenantOverride_HIDDEN TenantOverride_Visibility = 2
+)
+
+// Enum value maps for TenantOverride_Visibility.
+var (
+	TenantOverride_Visibility_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "VISIBLE",
+		2: "HIDDEN",
+	}
+	TenantOverride_Visibility_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"VISIBLE":     1,
+		"HIDDEN":      2,
+	}
+)
+
+func (x TenantOverride_Visibility) Enum() *TenantOverride_Visibility {
+	p := new(TenantOverride_Visibility)
+	*p = x
+	return p
+}
+
+func (x TenantOverride_Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TenantOverride_Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_racing_v1_racing_proto_enumTypes[3].Descriptor()
+}
+
+func (TenantOverride_Visibility) Type() protoreflect.EnumType {
+	return &file_api_racing_v1_racing_proto_enumTypes[3]
+}
+
+func (x TenantOverride_Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TenantOverride_Visibility.Descriptor instead.
+func (TenantOverride_Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{21, 0}
+}
+
+// ListRacesRequest represents a request for the ListRaces call.
+type ListRacesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// MeetingId is an optional list of meeting IDs to filter the races.
+	MeetingId []int64 `protobuf:"varint,1,rep,packed,name=meeting_id,json=meetingId,proto3" json:"meeting_id,omitempty"`
+	// VisibleOnly indicates whether to return only visible races. The races
+	// are visible as overridden for the tenant of the request, if any.
+	VisibleOnly bool `protobuf:"varint,2,opt,name=visible_only,json=visibleOnly,proto3" json:"visible_only,omitempty"`
+	// OrderBy specifies the ordering of the returned races. The races
+	// positioned for the tenant of the request, if any, are returned first.
+	OrderBy []ListRacesRequest_OrderBy `protobuf:"varint,3,rep,packed,name=order_by,json=orderBy,proto3,enum=racing.v1.ListRacesRequest_OrderBy" json:"order_by,omitempty"`
+	// ReadMask is an optional list of fields of the returned races to read.
+	// If it is not set, all fields are read. In HTTP requests it is passed as
+	// the "fields" query parameter, for example "fields=id,name".
+	ReadMask *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=read_mask,json=fields,proto3" json:"read_mask,omitempty"`
+	// LocalDate is an optional date in the YYYY-MM-DD format to filter the
+	// races. A race matches if it is advertised to start on that date in the
+	// timezone of its meeting's venue.
+	LocalDate string `protobuf:"bytes,5,opt,name=local_date,json=localDate,proto3" json:"local_date,omitempty"`
+	// AsOf is an optional moment to compute the statuses of the returned races
+	// at, to see them as customers saw them at that moment. It can only be set
+	// by admins.
+	AsOf *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	// IncludeArchived indicates whether to return archived races as well.
+	// Archived races are not returned by default.
+	IncludeArchived bool `protobuf:"varint,7,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListRacesRequest) Reset() {
+	*x = ListRacesRequest{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRacesRequest) ProtoMessage() {}
+
+func (x *ListRacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRacesRequest.ProtoReflect.Descriptor instead.
+func (*ListRacesRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListRacesRequest) GetMeetingId() []int64 {
+	if x != nil {
+		return x.MeetingId
+	}
+	return nil
+}
+
+func (x *ListRacesRequest) GetVisibleOnly() bool {
+	if x != nil {
+		return x.VisibleOnly
+	}
+	return false
+}
+
+func (x *ListRacesRequest) GetOrderBy() []ListRacesRequest_OrderBy {
+	if x != nil {
+		return x.OrderBy
+	}
+	return nil
+}
+
+func (x *ListRacesRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *ListRacesRequest) GetLocalDate() string {
+	if x != nil {
+		return x.LocalDate
+	}
+	return ""
+}
+
+func (x *ListRacesRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *ListRacesRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+// ListRacesResponse represents a response to the ListRaces call.
+type ListRacesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Races is a list of horse racing events.
+	Races         []*Race `protobuf:"bytes,1,rep,name=races,proto3" json:"races,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRacesResponse) Reset() {
+	*x = ListRacesResponse{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRacesResponse) ProtoMessage() {}
+
+func (x *ListRacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRacesResponse.ProtoReflect.Descriptor instead.
+func (*ListRacesResponse) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListRacesResponse) GetRaces() []*Race {
+	if x != nil {
+		return x.Races
+	}
+	return nil
+}
+
+// GetRaceRequest represents a request for the GetRace call.
+type GetRaceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the race to retrieve.
+	RaceId int64 `protobuf:"varint,1,opt,name=race_id,json=raceId,proto3" json:"race_id,omitempty"`
+	// ReadMask is an optional list of fields of the returned race to read.
+	// If it is not set, all fields are read. In HTTP requests it is passed as
+	// the "fields" query parameter, for example "fields=id,name".
+	ReadMask *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=fields,proto3" json:"read_mask,omitempty"`
+	// IncludeArchived indicates whether to return the race if it is archived.
+	// Archived races are not returned by default.
+	IncludeArchived bool `protobuf:"varint,3,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetRaceRequest) Reset() {
+	*x = GetRaceRequest{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRaceRequest) ProtoMessage() {}
+
+func (x *GetRaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRaceRequest.ProtoReflect.Descriptor instead.
+func (*GetRaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRaceRequest) GetRaceId() int64 {
+	if x != nil {
+		return x.RaceId
+	}
+	return 0
+}
+
+func (x *GetRaceRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *GetRaceRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+// GetRaceByExternalIdRequest represents a request for the GetRaceByExternalId
+// call.
+type GetRaceByExternalIdRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Provider is the name of the provider the race was ingested from.
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	// ExternalId is the ID assigned to the race by the provider.
+	ExternalId string `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	// ReadMask is an optional list of fields of the returned race to read.
+	// If it is not set, all fields are read. In HTTP requests it is passed as
+	// the "fields" query parameter, for example "fields=id,name".
+	ReadMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=read_mask,json=fields,proto3" json:"read_mask,omitempty"`
+	// IncludeArchived indicates whether to return the race if it is archived.
+	// Archived races are not returned by default.
+	IncludeArchived bool `protobuf:"varint,4,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetRaceByExternalIdRequest) Reset() {
+	*x = GetRaceByExternalIdRequest{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRaceByExternalIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRaceByExternalIdRequest) ProtoMessage() {}
+
+func (x *GetRaceByExternalIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRaceByExternalIdRequest.ProtoReflect.Descriptor instead.
+func (*GetRaceByExternalIdRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRaceByExternalIdRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *GetRaceByExternalIdRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *GetRaceByExternalIdRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *GetRaceByExternalIdRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+// BatchGetRacesRequest represents a request for the BatchGetRaces call.
+type BatchGetRacesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// RaceId is a list of IDs of the races to retrieve. The maximum number of
+	// IDs is limited by the service configuration.
+	RaceId []int64 `protobuf:"varint,1,rep,packed,name=race_id,json=raceId,proto3" json:"race_id,omitempty"`
+	// IncludeArchived indicates whether to return archived races as well.
+	// Archived races are not returned by default.
+	IncludeArchived bool `protobuf:"varint,2,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BatchGetRacesRequest) Reset() {
+	*x = BatchGetRacesRequest{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetRacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetRacesRequest) ProtoMessage() {}
+
+func (x *BatchGetRacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetRacesRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetRacesRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetRacesRequest) GetRaceId() []int64 {
+	if x != nil {
+		return x.RaceId
+	}
+	return nil
+}
+
+func (x *BatchGetRacesRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+// BatchGetRacesResponse represents a response to the BatchGetRaces call.
+type BatchGetRacesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Races is a list of the found races, in the order of the requested IDs.
+	Races []*Race `protobuf:"bytes,1,rep,name=races,proto3" json:"races,omitempty"`
+	// MissingRaceId is a list of the requested IDs for which no race was found,
+	// including the races that cannot be offered in the jurisdiction of the
+	// request.
+	MissingRaceId []int64 `protobuf:"varint,2,rep,packed,name=missing_race_id,json=missingRaceId,proto3" json:"missing_race_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetRacesResponse) Reset() {
+	*x = BatchGetRacesResponse{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetRacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetRacesResponse) ProtoMessage() {}
+
+func (x *BatchGetRacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetRacesResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetRacesResponse) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchGetRacesResponse) GetRaces() []*Race {
+	if x != nil {
+		return x.Races
+	}
+	return nil
+}
+
+func (x *BatchGetRacesResponse) GetMissingRaceId() []int64 {
+	if x != nil {
+		return x.MissingRaceId
+	}
+	return nil
+}
+
+// Race represents a horse racing event.
+type Race struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID represents a unique identifier for the race.
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// MeetingID represents a unique identifier for the races meeting.
+	MeetingId int64 `protobuf:"varint,2,opt,name=meeting_id,json=meetingId,proto3" json:"meeting_id,omitempty"`
+	// Name is the official name given to the race.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Number represents the number of the race.
+	Number int64 `protobuf:"varint,4,opt,name=number,proto3" json:"number,omitempty"`
+	// Visible represents whether or not the race is visible. It reflects the
+	// visibility overridden for the tenant of the request, if any.
+	Visible bool `protobuf:"varint,5,opt,name=visible,proto3" json:"visible,omitempty"`
+	// AdvertisedStartTime is the time the race is advertised to run.
+	AdvertisedStartTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=advertised_start_time,json=advertisedStartTime,proto3" json:"advertised_start_time,omitempty"`
+	// Status represents the current status of the race.
+	Status Race_Status `protobuf:"varint,7,opt,name=status,proto3,enum=racing.v1.Race_Status" json:"status,omitempty"`
+	// Timezone is the IANA timezone of the venue of the race meeting, for
+	// example "Australia/Melbourne". It is "UTC" if the timezone of the venue is
+	// not known.
+	Timezone string `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// LocalAdvertisedStartTime is the time the race is advertised to run in the
+	// timezone of the venue, formatted as RFC 3339 with the local UTC offset,
+	// for example "2025-11-04T15:00:00+11:00".
+	LocalAdvertisedStartTime string `protobuf:"bytes,9,opt,name=local_advertised_start_time,json=localAdvertisedStartTime,proto3" json:"local_advertised_start_time,omitempty"`
+	// ArchiveTime is the time the race was archived by the retention policy. It
+	// is not set if the race is not archived.
+	ArchiveTime *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=archive_time,json=archiveTime,proto3" json:"archive_time,omitempty"`
+	// Etag is an opaque identifier of the current version of the race. It
+	// changes every time the race is changed, and must be passed to the
+	// requests updating or deleting the race.
+	Etag          string `protobuf:"bytes,11,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Race) Reset() {
+	*x = Race{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Race) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Race) ProtoMessage() {}
+
+func (x *Race) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Race.ProtoReflect.Descriptor instead.
+func (*Race) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Race) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Race) GetMeetingId() int64 {
+	if x != nil {
+		return x.MeetingId
+	}
+	return 0
+}
+
+func (x *Race) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Race) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Race) GetVisible() bool {
+	if x != nil {
+		return x.Visible
+	}
+	return false
+}
+
+func (x *Race) GetAdvertisedStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AdvertisedStartTime
+	}
+	return nil
+}
+
+func (x *Race) GetStatus() Race_Status {
+	if x != nil {
+		return x.Status
+	}
+	return Race_UNSPECIFIED
+}
+
+func (x *Race) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Race) GetLocalAdvertisedStartTime() string {
+	if x != nil {
+		return x.LocalAdvertisedStartTime
+	}
+	return ""
+}
+
+func (x *Race) GetArchiveTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchiveTime
+	}
+	return nil
+}
+
+func (x *Race) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// UpdateRaceRequest represents a request for the UpdateRace call.
+type UpdateRaceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Race is the race to update, identified by its ID. Only the fields listed
+	// in the update mask are updated.
+	Race *Race `protobuf:"bytes,1,opt,name=race,proto3" json:"race,omitempty"`
+	// UpdateMask is a list of the fields of the race to update. The name,
+	// number, visible and advertised_start_time fields can be updated. If the
+	// mask is not set, all of them are updated.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Etag is the etag of the race the update is based on. The update fails if
+	// the race has been changed since. If it is not set, the etag is taken from
+	// the "if-match" metadata of the request.
+	Etag          string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRaceRequest) Reset() {
+	*x = UpdateRaceRequest{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRaceRequest) ProtoMessage() {}
+
+func (x *UpdateRaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRaceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateRaceRequest) GetRace() *Race {
+	if x != nil {
+		return x.Race
+	}
+	return nil
+}
+
+func (x *UpdateRaceRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateRaceRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// DeleteRaceRequest represents a request for the DeleteRace call.
+type DeleteRaceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the race to delete.
+	RaceId int64 `protobuf:"varint,1,opt,name=race_id,json=raceId,proto3" json:"race_id,omitempty"`
+	// Etag is the etag of the race the deletion is based on. The deletion fails
+	// if the race has been changed since. If it is not set, the etag is taken
+	// from the "if-match" metadata of the request.
+	Etag          string `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRaceRequest) Reset() {
+	*x = DeleteRaceRequest{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRaceRequest) ProtoMessage() {}
+
+func (x *DeleteRaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRaceRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteRaceRequest) GetRaceId() int64 {
+	if x != nil {
+		return x.RaceId
+	}
+	return 0
+}
+
+func (x *DeleteRaceRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// ImportRacesRequest represents a message of the ImportRaces stream.
+type ImportRacesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Race is the race to import. A race without an ID is created, otherwise the
+	// race with the ID is updated. The meeting of an existing race cannot be
+	// changed. The status, timezone, local_advertised_start_time and
+	// archive_time fields are ignored. If the etag is set, the race is only
+	// updated if it has not been changed since.
+	Race *Race `protobuf:"bytes,1,opt,name=race,proto3" json:"race,omitempty"`
+	// Options are the options of the import. Only the options of the first
+	// message of the stream are used.
+	Options       *ImportOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRacesRequest) Reset() {
+	*x = ImportRacesRequest{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRacesRequest) ProtoMessage() {}
+
+func (x *ImportRacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRacesRequest.ProtoReflect.Descriptor instead.
+func (*ImportRacesRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ImportRacesRequest) GetRace() *Race {
+	if x != nil {
+		return x.Race
+	}
+	return nil
+}
+
+func (x *ImportRacesRequest) GetOptions() *ImportOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// ImportOptions represents the options of an import.
+type ImportOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// DryRun indicates whether to only validate the imported rows. The response
+	// reports the outcome the import would have, without changing anything.
+	DryRun bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// AllOrNothing indicates whether to import no rows at all if any row is
+	// invalid. Otherwise, the valid rows are imported and the invalid ones are
+	// skipped.
+	AllOrNothing  bool `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOptions) ProtoMessage() {}
+
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ImportOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportOptions) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+// ImportRacesResponse represents a response to the ImportRaces call.
+type ImportRacesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Created is the number of created races.
+	Created int64 `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	// Updated is the number of updated races.
+	Updated int64 `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	// Unchanged is the number of existing races imported as they were.
+	Unchanged int64 `protobuf:"varint,3,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	// Errors is a list of the errors of the invalid rows, which are not
+	// imported.
+	Errors []*ImportError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	// Committed indicates whether the import has been committed. It is false
+	// for dry runs, and for all-or-nothing imports with invalid rows.
+	Committed     bool `protobuf:"varint,5,opt,name=committed,proto3" json:"committed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRacesResponse) Reset() {
+	*x = ImportRacesResponse{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRacesResponse) ProtoMessage() {}
+
+func (x *ImportRacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRacesResponse.ProtoReflect.Descriptor instead.
+func (*ImportRacesResponse) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ImportRacesResponse) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportRacesResponse) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportRacesResponse) GetUnchanged() int64 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *ImportRacesResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportRacesResponse) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+// ImportError represents an error of an invalid row of an import.
+type ImportError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Row is the 1-based position of the invalid row in the stream, not
+	// counting the messages without a row.
+	Row int64 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	// Field is the name of the invalid field of the row, if the error is
+	// specific to a field, for example "advertised_start_time".
+	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	// Description is a human-readable description of the error.
+	Description   string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportError) ProtoMessage() {}
+
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ImportError) GetRow() int64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ImportError) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// ExportRacesRequest represents a request for the ExportRaces call.
+type ExportRacesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// MeetingId is an optional list of meeting IDs to filter the races.
+	MeetingId []int64 `protobuf:"varint,1,rep,packed,name=meeting_id,json=meetingId,proto3" json:"meeting_id,omitempty"`
+	// VisibleOnly indicates whether to export only visible races.
+	VisibleOnly bool `protobuf:"varint,2,opt,name=visible_only,json=visibleOnly,proto3" json:"visible_only,omitempty"`
+	// LocalDate is an optional date in the YYYY-MM-DD format to filter the
+	// races. A race matches if it is advertised to start on that date in the
+	// timezone of its meeting's venue.
+	LocalDate string `protobuf:"bytes,3,opt,name=local_date,json=localDate,proto3" json:"local_date,omitempty"`
+	// IncludeArchived indicates whether to export archived races as well.
+	IncludeArchived bool `protobuf:"varint,4,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExportRacesRequest) Reset() {
+	*x = ExportRacesRequest{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRacesRequest) ProtoMessage() {}
+
+func (x *ExportRacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRacesRequest.ProtoReflect.Descriptor instead.
+func (*ExportRacesRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExportRacesRequest) GetMeetingId() []int64 {
+	if x != nil {
+		return x.MeetingId
+	}
+	return nil
+}
+
+func (x *ExportRacesRequest) GetVisibleOnly() bool {
+	if x != nil {
+		return x.VisibleOnly
+	}
+	return false
+}
+
+func (x *ExportRacesRequest) GetLocalDate() string {
+	if x != nil {
+		return x.LocalDate
+	}
+	return ""
+}
+
+func (x *ExportRacesRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+// ListChangesRequest represents a request for the ListChanges call.
+type ListChangesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cursor is an optional position in the change log to list the changes
+	// after, as returned in the next_cursor field of a previous response. If it
+	// is not set, the changes are listed from the beginning of the change log.
+	Cursor string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// PageSize is the maximum number of changes to return. If it is not set,
+	// at most 100 changes are returned.
+	PageSize      int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChangesRequest) Reset() {
+	*x = ListChangesRequest{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChangesRequest) ProtoMessage() {}
+
+func (x *ListChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListChangesRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListChangesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListChangesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListChangesResponse represents a response to the ListChanges call.
+type ListChangesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Changes is a list of changes of races in the order they were made.
+	Changes []*Change `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	// NextCursor is the cursor to list the changes made after the returned
+	// ones. It is set even if there are no more changes yet, so that the
+	// changes can be polled for.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChangesResponse) Reset() {
+	*x = ListChangesResponse{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChangesResponse) ProtoMessage() {}
+
+func (x *ListChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChangesResponse.ProtoReflect.Descriptor instead.
+func (*ListChangesResponse) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListChangesResponse) GetChanges() []*Change {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ListChangesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// Change represents a change of a race.
+type Change struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sequence is the position of the change in the change log. Changes made
+	// later have greater sequence numbers.
+	Sequence int64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Operation is the kind of the change.
+	Operation Change_Operation `protobuf:"varint,2,opt,name=operation,proto3,enum=racing.v1.Change_Operation" json:"operation,omitempty"`
+	// ChangeTime is the time the change was made.
+	ChangeTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=change_time,json=changeTime,proto3" json:"change_time,omitempty"`
+	// Race is the race right after the change, or right before it was deleted.
+	// Its status is not set, as it depends on the time the race is read at
+	// rather than on the change, and neither is its etag.
+	Race          *Race `protobuf:"bytes,4,opt,name=race,proto3" json:"race,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Change) Reset() {
+	*x = Change{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Change) ProtoMessage() {}
+
+func (x *Change) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Change.ProtoReflect.Descriptor instead.
+func (*Change) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Change) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Change) GetOperation() Change_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return Change_UNSPECIFIED
+}
+
+func (x *Change) GetChangeTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangeTime
+	}
+	return nil
+}
+
+func (x *Change) GetRace() *Race {
+	if x != nil {
+		return x.Race
+	}
+	return nil
+}
+
+// ListAuditEntriesRequest represents a request for the ListAuditEntries call.
+type ListAuditEntriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// EntityType is an optional filter to list only the audit entries of
+	// entities of the given type.
+	EntityType string `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	// EntityId is an optional filter to list only the audit entries of the
+	// entity with the given ID.
+	EntityId int64 `protobuf:"varint,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// Actor is an optional filter to list only the audit entries of the changes
+	// made by the admin with the given name.
+	Actor string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	// StartTime is an optional filter to list only the audit entries of the
+	// changes made at or after the given time.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// EndTime is an optional filter to list only the audit entries of the
+	// changes made before the given time.
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Cursor is an optional position in the audit log to list the entries
+	// after, as returned in the next_cursor field of a previous response. If it
+	// is not set, the entries are listed from the beginning of the audit log.
+	Cursor string `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// PageSize is the maximum number of entries to return. If it is not set,
+	// at most 100 entries are returned.
+	PageSize      int32 `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEntriesRequest) Reset() {
+	*x = ListAuditEntriesRequest{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEntriesRequest) ProtoMessage() {}
+
+func (x *ListAuditEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListAuditEntriesRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *ListAuditEntriesRequest) GetEntityId() int64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *ListAuditEntriesRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ListAuditEntriesRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ListAuditEntriesRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ListAuditEntriesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListAuditEntriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListAuditEntriesResponse represents a response to the ListAuditEntries
+// call.
+type ListAuditEntriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entries is a list of audit entries in the order the changes were made.
+	Entries []*AuditEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// NextCursor is the cursor to list the entries after the returned ones. It
+	// is set even if there are no more entries yet.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEntriesResponse) Reset() {
+	*x = ListAuditEntriesResponse{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEntriesResponse) ProtoMessage() {}
+
+func (x *ListAuditEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListAuditEntriesResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListAuditEntriesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// AuditEntry represents a change made to an entity through the API.
+type AuditEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Id is the position of the entry in the audit log. Changes made later
+	// have greater IDs.
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Actor is the name of the admin who made the change, or empty if the
+	// change was made by a client that is not an admin.
+	Actor string `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Method is the full name of the RPC the change was made by, such as
+	// "/racing.Racing/UpdateRace".
+	Method string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	// EntityType is the type of the changed entity, such as "race".
+	EntityType string `protobuf:"bytes,4,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	// EntityId is the ID of the changed entity.
+	EntityId int64 `protobuf:"varint,5,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// EntryTime is the time the change was made.
+	EntryTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=entry_time,json=entryTime,proto3" json:"entry_time,omitempty"`
+	// Changes is a list of the changed fields of the entity, ordered by their
+	// names.
+	Changes       []*FieldChange `protobuf:"bytes,7,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AuditEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetEntityId() int64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetEntryTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EntryTime
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// FieldChange represents a change of a single field of an entity.
+type FieldChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Field is the name of the field, as it is encoded in JSON.
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// Before is the value of the field before the change. It is not set if the
+	// field was not set.
+	Before *structpb.Value `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	// After is the value of the field after the change. It is not set if the
+	// field is not set anymore.
+	After         *structpb.Value `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetBefore() *structpb.Value {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *FieldChange) GetAfter() *structpb.Value {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+// TenantOverride represents the override of the visibility and the ordering
+// of a race for a tenant.
+type TenantOverride struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tenant is the name of the tenant the override applies to.
+	Tenant string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// RaceId is the ID of the race the override applies to.
+	RaceId int64 `protobuf:"varint,2,opt,name=race_id,json=raceId,proto3" json:"race_id,omitempty"`
+	// Visibility overrides the visible field of the race for the tenant.
+	Visibility TenantOverride_Visibility `protobuf:"varint,3,opt,name=visibility,proto3,enum=racing.v1.TenantOverride_Visibility" json:"visibility,omitempty"`
+	// Position is the position of the race in the lists of races of the tenant,
+	// starting at 1. Races with a position are listed before the others, in the
+	// order of their positions. If it is not set, the race is listed in the
+	// requested order.
+	Position      int32 `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantOverride) Reset() {
+	*x = TenantOverride{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantOverride) ProtoMessage() {}
+
+func (x *TenantOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantOverride.ProtoReflect.Descriptor instead.
+func (*TenantOverride) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TenantOverride) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *TenantOverride) GetRaceId() int64 {
+	if x != nil {
+		return x.RaceId
+	}
+	return 0
+}
+
+func (x *TenantOverride) GetVisibility() TenantOverride_Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return TenantOverride_UNSPECIFIED
+}
+
+func (x *TenantOverride) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+// ListTenantOverridesRequest represents a request for the ListTenantOverrides
+// call.
+type ListTenantOverridesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tenant is the name of the tenant to list the overrides of.
+	Tenant        string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantOverridesRequest) Reset() {
+	*x = ListTenantOverridesRequest{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantOverridesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantOverridesRequest) ProtoMessage() {}
+
+func (x *ListTenantOverridesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantOverridesRequest.ProtoReflect.Descriptor instead.
+func (*ListTenantOverridesRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListTenantOverridesRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+// ListTenantOverridesResponse represents a response to the
+// ListTenantOverrides call.
+type ListTenantOverridesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Overrides is a list of the overrides of the tenant.
+	Overrides     []*TenantOverride `protobuf:"bytes,1,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantOverridesResponse) Reset() {
+	*x = ListTenantOverridesResponse{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantOverridesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantOverridesResponse) ProtoMessage() {}
+
+func (x *ListTenantOverridesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantOverridesResponse.ProtoReflect.Descriptor instead.
+func (*ListTenantOverridesResponse) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListTenantOverridesResponse) GetOverrides() []*TenantOverride {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+// SetTenantOverrideRequest represents a request for the SetTenantOverride
+// call.
+type SetTenantOverrideRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Override is the override to set. At least one of its visibility and
+	// position must be set.
+	Override      *TenantOverride `protobuf:"bytes,1,opt,name=override,proto3" json:"override,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTenantOverrideRequest) Reset() {
+	*x = SetTenantOverrideRequest{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTenantOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTenantOverrideRequest) ProtoMessage() {}
+
+func (x *SetTenantOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTenantOverrideRequest.ProtoReflect.Descriptor instead.
+func (*SetTenantOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetTenantOverrideRequest) GetOverride() *TenantOverride {
+	if x != nil {
+		return x.Override
+	}
+	return nil
+}
+
+// DeleteTenantOverrideRequest represents a request for the
+// DeleteTenantOverride call.
+type DeleteTenantOverrideRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tenant is the name of the tenant to delete the override of.
+	Tenant string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// RaceId is the ID of the race to delete the override of.
+	RaceId        int64 `protobuf:"varint,2,opt,name=race_id,json=raceId,proto3" json:"race_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTenantOverrideRequest) Reset() {
+	*x = DeleteTenantOverrideRequest{}
+	mi := &file_api_racing_v1_racing_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTenantOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTenantOverrideRequest) ProtoMessage() {}
+
+func (x *DeleteTenantOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v1_racing_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTenantOverrideRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTenantOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v1_racing_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteTenantOverrideRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *DeleteTenantOverrideRequest) GetRaceId() int64 {
+	if x != nil {
+		return x.RaceId
+	}
+	return 0
+}
+
+var File_api_racing_v1_racing_proto protoreflect.FileDescriptor
+
+const file_api_racing_v1_racing_proto_rawDesc = "" +
+	"\n" +
+	"\x1aapi/racing/v1/racing.proto\x12\tracing.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1bbuf/validate/validate.proto\"\xd4\x04\n" +
+	"\x10ListRacesRequest\x12-\n" +
+	"\n" +
+	"meeting_id\x18\x01 \x03(\x03B\x0e\xbaH\v\x92\x01\b\x18\x01\"\x04\"\x02 \x00R\tmeetingId\x12!\n" +
+	"\fvisible_only\x18\x02 \x01(\bR\vvisibleOnly\x12Q\n" +
+	"\border_by\x18\x03 \x03(\x0e2#.racing.v1.ListRacesRequest.OrderByB\x11\xbaH\x0e\x92\x01\v\x18\x01\"\a\x82\x01\x04\x10\x01 \x00R\aorderBy\x125\n" +
+	"\tread_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\x06fields\x12E\n" +
+	"\n" +
+	"local_date\x18\x05 \x01(\tB&\xbaH#\xd8\x01\x01r\x1e2\x1c^[0-9]{4}-[0-9]{2}-[0-9]{2}$R\tlocalDate\x12/\n" +
+	"\x05as_of\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\x12)\n" +
+	"\x10include_archived\x18\a \x01(\bR\x0fincludeArchived\"\xc0\x01\n" +
+	"\aOrderBy\x12\x0f\n" +
+	"\vUNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19ADVERTISED_START_TIME_ASC\x10\x01\x12\x1e\n" +
+	"\x1aADVERTISED_START_TIME_DESC\x10\x02\x12\x0e\n" +
+	"\n" +
+	"NUMBER_ASC\x10\x03\x12\x0f\n" +
+	"\vNUMBER_DESC\x10\x04\x12\f\n" +
+	"\bNAME_ASC\x10\x05\x12\r\n" +
+	"\tNAME_DESC\x10\x06\x12\x12\n" +
+	"\x0eMEETING_ID_ASC\x10\a\x12\x13\n" +
+	"\x0fMEETING_ID_DESC\x10\b\":\n" +
+	"\x11ListRacesResponse\x12%\n" +
+	"\x05races\x18\x01 \x03(\v2\x0f.racing.v1.RaceR\x05races\"\x94\x01\n" +
+	"\x0eGetRaceRequest\x12 \n" +
+	"\arace_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06raceId\x125\n" +
+	"\tread_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\x06fields\x12)\n" +
+	"\x10include_archived\x18\x03 \x01(\bR\x0fincludeArchived\"\xcd\x01\n" +
+	"\x1aGetRaceByExternalIdRequest\x12#\n" +
+	"\bprovider\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\bprovider\x12(\n" +
+	"\vexternal_id\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
+	"externalId\x125\n" +
+	"\tread_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\x06fields\x12)\n" +
+	"\x10include_archived\x18\x04 \x01(\bR\x0fincludeArchived\"l\n" +
+	"\x14BatchGetRacesRequest\x12)\n" +
+	"\arace_id\x18\x01 \x03(\x03B\x10\xbaH\r\x92\x01\n" +
+	"\b\x01\x18\x01\"\x04\"\x02 \x00R\x06raceId\x12)\n" +
+	"\x10include_archived\x18\x02 \x01(\bR\x0fincludeArchived\"f\n" +
+	"\x15BatchGetRacesResponse\x12%\n" +
+	"\x05races\x18\x01 \x03(\v2\x0f.racing.v1.RaceR\x05races\x12&\n" +
+	"\x0fmissing_race_id\x18\x02 \x03(\x03R\rmissingRaceId\"\xe9\x03\n" +
+	"\x04Race\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"meeting_id\x18\x02 \x01(\x03R\tmeetingId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06number\x18\x04 \x01(\x03R\x06number\x12\x18\n" +
+	"\avisible\x18\x05 \x01(\bR\avisible\x12N\n" +
+	"\x15advertised_start_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x13advertisedStartTime\x12.\n" +
+	"\x06status\x18\a \x01(\x0e2\x16.racing.v1.Race.StatusR\x06status\x12\x1a\n" +
+	"\btimezone\x18\b \x01(\tR\btimezone\x12=\n" +
+	"\x1blocal_advertised_start_time\x18\t \x01(\tR\x18localAdvertisedStartTime\x12=\n" +
+	"\farchive_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\varchiveTime\x12\x12\n" +
+	"\x04etag\x18\v \x01(\tR\x04etag\">\n" +
+	"\x06Status\x12\x0f\n" +
+	"\vUNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04OPEN\x10\x01\x12\n" +
+	"\n" +
+	"\x06CLOSED\x10\x02\x12\r\n" +
+	"\tABANDONED\x10\x03\"\x91\x01\n" +
+	"\x11UpdateRaceRequest\x12+\n" +
+	"\x04race\x18\x01 \x01(\v2\x0f.racing.v1.RaceB\x06\xbaH\x03\xc8\x01\x01R\x04race\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\"I\n" +
+	"\x11DeleteRaceRequest\x12 \n" +
+	"\arace_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06raceId\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\"m\n" +
+	"\x12ImportRacesRequest\x12#\n" +
+	"\x04race\x18\x01 \x01(\v2\x0f.racing.v1.RaceR\x04race\x122\n" +
+	"\aoptions\x18\x02 \x01(\v2\x18.racing.v1.ImportOptionsR\aoptions\"N\n" +
+	"\rImportOptions\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12$\n" +
+	"\x0eall_or_nothing\x18\x02 \x01(\bR\fallOrNothing\"\xb5\x01\n" +
+	"\x13ImportRacesResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x03R\acreated\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\x03R\aupdated\x12\x1c\n" +
+	"\tunchanged\x18\x03 \x01(\x03R\tunchanged\x12.\n" +
+	"\x06errors\x18\x04 \x03(\v2\x16.racing.v1.ImportErrorR\x06errors\x12\x1c\n" +
+	"\tcommitted\x18\x05 \x01(\bR\tcommitted\"W\n" +
+	"\vImportError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x03R\x03row\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"\xd8\x01\n" +
+	"\x12ExportRacesRequest\x12-\n" +
+	"\n" +
+	"meeting_id\x18\x01 \x03(\x03B\x0e\xbaH\v\x92\x01\b\x18\x01\"\x04\"\x02 \x00R\tmeetingId\x12!\n" +
+	"\fvisible_only\x18\x02 \x01(\bR\vvisibleOnly\x12E\n" +
+	"\n" +
+	"local_date\x18\x03 \x01(\tB&\xbaH#\xd8\x01\x01r\x1e2\x1c^[0-9]{4}-[0-9]{2}-[0-9]{2}$R\tlocalDate\x12)\n" +
+	"\x10include_archived\x18\x04 \x01(\bR\x0fincludeArchived\"U\n" +
+	"\x12ListChangesRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12'\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x00R\bpageSize\"c\n" +
+	"\x13ListChangesResponse\x12+\n" +
+	"\achanges\x18\x01 \x03(\v2\x11.racing.v1.ChangeR\achanges\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\x86\x02\n" +
+	"\x06Change\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x129\n" +
+	"\toperation\x18\x02 \x01(\x0e2\x1b.racing.v1.Change.OperationR\toperation\x12;\n" +
+	"\vchange_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"changeTime\x12#\n" +
+	"\x04race\x18\x04 \x01(\v2\x0f.racing.v1.RaceR\x04race\"C\n" +
+	"\tOperation\x12\x0f\n" +
+	"\vUNSPECIFIED\x10\x00\x12\v\n" +
+	"\aCREATED\x10\x01\x12\v\n" +
+	"\aUPDATED\x10\x02\x12\v\n" +
+	"\aDELETED\x10\x03\"\xb8\x02\n" +
+	"\x17ListAuditEntriesRequest\x12.\n" +
+	"\ventity_type\x18\x01 \x01(\tB\r\xbaH\n" +
+	"r\bR\x00R\x04raceR\n" +
+	"entityType\x12$\n" +
+	"\tentity_id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\bentityId\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x129\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\x12'\n" +
+	"\tpage_size\x18\a \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x00R\bpageSize\"l\n" +
+	"\x18ListAuditEntriesResponse\x12/\n" +
+	"\aentries\x18\x01 \x03(\v2\x15.racing.v1.AuditEntryR\aentries\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xf5\x01\n" +
+	"\n" +
+	"AuditEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x1f\n" +
+	"\ventity_type\x18\x04 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x05 \x01(\x03R\bentityId\x129\n" +
+	"\n" +
+	"entry_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tentryTime\x120\n" +
+	"\achanges\x18\a \x03(\v2\x16.racing.v1.FieldChangeR\achanges\"\x81\x01\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12.\n" +
+	"\x06before\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x06before\x12,\n" +
+	"\x05after\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x05after\"\x99\x02\n" +
+	"\x0eTenantOverride\x128\n" +
+	"\x06tenant\x18\x01 \x01(\tB \xbaH\x1dr\x1b2\x19^[a-z0-9][a-z0-9-]{0,62}$R\x06tenant\x12 \n" +
+	"\arace_id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06raceId\x12N\n" +
+	"\n" +
+	"visibility\x18\x03 \x01(\x0e2$.racing.v1.TenantOverride.VisibilityB\b\xbaH\x05\x82\x01\x02\x10\x01R\n" +
+	"visibility\x12#\n" +
+	"\bposition\x18\x04 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bposition\"6\n" +
+	"\n" +
+	"Visibility\x12\x0f\n" +
+	"\vUNSPECIFIED\x10\x00\x12\v\n" +
+	"\aVISIBLE\x10\x01\x12\n" +
+	"\n" +
+	"\x06HIDDEN\x10\x02\"V\n" +
+	"\x1aListTenantOverridesRequest\x128\n" +
+	"\x06tenant\x18\x01 \x01(\tB \xbaH\x1dr\x1b2\x19^[a-z0-9][a-z0-9-]{0,62}$R\x06tenant\"V\n" +
+	"\x1bListTenantOverridesResponse\x127\n" +
+	"\toverrides\x18\x01 \x03(\v2\x19.racing.v1.TenantOverrideR\toverrides\"Y\n" +
+	"\x18SetTenantOverrideRequest\x12=\n" +
+	"\boverride\x18\x01 \x01(\v2\x19.racing.v1.TenantOverrideB\x06\xbaH\x03\xc8\x01\x01R\boverride\"y\n" +
+	"\x1bDeleteTenantOverrideRequest\x128\n" +
+	"\x06tenant\x18\x01 \x01(\tB \xbaH\x1dr\x1b2\x19^[a-z0-9][a-z0-9-]{0,62}$R\x06tenant\x12 \n" +
+	"\arace_id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06raceId2\xb0\v\n" +
+	"\x06Racing\x12Y\n" +
+	"\tListRaces\x12\x1b.racing.v1.ListRacesRequest\x1a\x1c.racing.v1.ListRacesResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/races\x12R\n" +
+	"\aGetRace\x12\x19.racing.v1.GetRaceRequest\x1a\x0f.racing.v1.Race\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/races/{race_id}\x12m\n" +
+	"\x13GetRaceByExternalId\x12%.racing.v1.GetRaceByExternalIdRequest\x1a\x0f.racing.v1.Race\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/races:byExternalId\x12n\n" +
+	"\rBatchGetRaces\x12\x1f.racing.v1.BatchGetRacesRequest\x1a .racing.v1.BatchGetRacesResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/races:batchGet\x12^\n" +
+	"\n" +
+	"UpdateRace\x12\x1c.racing.v1.UpdateRaceRequest\x1a\x0f.racing.v1.Race\"!\x82\xd3\xe4\x93\x02\x1b:\x04race2\x13/v1/races/{race.id}\x12_\n" +
+	"\n" +
+	"DeleteRace\x12\x1c.racing.v1.DeleteRaceRequest\x1a\x16.google.protobuf.Empty\"\x1b\x82\xd3\xe4\x93\x02\x15*\x13/v1/races/{race_id}\x12k\n" +
+	"\vImportRaces\x12\x1d.racing.v1.ImportRacesRequest\x1a\x1e.racing.v1.ImportRacesResponse\"\x1b\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/races:import(\x01\x12Y\n" +
+	"\vExportRaces\x12\x1d.racing.v1.ExportRacesRequest\x1a\x0f.racing.v1.Race\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/races:export0\x01\x12g\n" +
+	"\vListChanges\x12\x1d.racing.v1.ListChangesRequest\x1a\x1e.racing.v1.ListChangesResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/races:changes\x12t\n" +
+	"\x10ListAuditEntries\x12\".racing.v1.ListAuditEntriesRequest\x1a#.racing.v1.ListAuditEntriesResponse\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/races:audit\x12\x88\x01\n" +
+	"\x13ListTenantOverrides\x12%.racing.v1.ListTenantOverridesRequest\x1a&.racing.v1.ListTenantOverridesResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/v1/tenants/{tenant}/races\x12\x9d\x01\n" +
+	"\x11SetTenantOverride\x12#.racing.v1.SetTenantOverrideRequest\x1a\x19.racing.v1.TenantOverride\"H\x82\xd3\xe4\x93\x02B:\boverride\x1a6/v1/tenants/{override.tenant}/races/{override.race_id}\x12\x84\x01\n" +
+	"\x14DeleteTenantOverride\x12&.racing.v1.DeleteTenantOverrideRequest\x1a\x16.google.protobuf.Empty\",\x82\xd3\xe4\x93\x02&*$/v1/tenants/{tenant}/races/{race_id}B7Z5github.com/danilvpetrov/entain/api/racing/v1;racingv1b\x06proto3"
+
+var (
+	file_api_racing_v1_racing_proto_rawDescOnce sync.Once
+	file_api_racing_v1_racing_proto_rawDescData []byte
+)
+
+func file_api_racing_v1_racing_proto_rawDescGZIP() []byte {
+	file_api_racing_v1_racing_proto_rawDescOnce.Do(func() {
+		file_api_racing_v1_racing_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_racing_v1_racing_proto_rawDesc), len(file_api_racing_v1_racing_proto_rawDesc)))
+	})
+	return file_api_racing_v1_racing_proto_rawDescData
+}
+
+var file_api_racing_v1_racing_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_api_racing_v1_racing_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_api_racing_v1_racing_proto_goTypes = []any{
+	(ListRacesRequest_OrderBy)(0),       // 0: racing.v1.ListRacesRequest.OrderBy
+	(Race_Status)(0),                    // 1: racing.v1.Race.Status
+	(Change_Operation)(0),               // 2: racing.v1.Change.Operation
+	(TenantOverride_Visibility)(0),      // 3: racing.v1.TenantOverride.Visibility
+	(*ListRacesRequest)(nil),            // 4: racing.v1.ListRacesRequest
+	(*ListRacesResponse)(nil),           // 5: racing.v1.ListRacesResponse
+	(*GetRaceRequest)(nil),              // 6: racing.v1.GetRaceRequest
+	(*GetRaceByExternalIdRequest)(nil),  // 7: racing.v1.GetRaceByExternalIdRequest
+	(*BatchGetRacesRequest)(nil),        // 8: racing.v1.BatchGetRacesRequest
+	(*BatchGetRacesResponse)(nil),       // 9: racing.v1.BatchGetRacesResponse
+	(*Race)(nil),                        // 10: racing.v1.Race
+	(*UpdateRaceRequest)(nil),           // 11: racing.v1.UpdateRaceRequest
+	(*DeleteRaceRequest)(nil),           // 12: racing.v1.DeleteRaceRequest
+	(*ImportRacesRequest)(nil),          // 13: racing.v1.ImportRacesRequest
+	(*ImportOptions)(nil),               // 14: racing.v1.ImportOptions
+	(*ImportRacesResponse)(nil),         // 15: racing.v1.ImportRacesResponse
+	(*ImportError)(nil),                 // 16: racing.v1.ImportError
+	(*ExportRacesRequest)(nil),          // 17: racing.v1.ExportRacesRequest
+	(*ListChangesRequest)(nil),          // 18: racing.v1.ListChangesRequest
+	(*ListChangesResponse)(nil),         // 19: racing.v1.ListChangesResponse
+	(*Change)(nil),                      // 20: racing.v1.Change
+	(*ListAuditEntriesRequest)(nil),     // 21: racing.v1.ListAuditEntriesRequest
+	(*ListAuditEntriesResponse)(nil),    // 22: racing.v1.ListAuditEntriesResponse
+	(*AuditEntry)(nil),                  // 23: racing.v1.AuditEntry
+	(*FieldChange)(nil),                 // 24: racing.v1.FieldChange
+	(*TenantOverride)(nil),              // 25: racing.v1.TenantOverride
+	(*ListTenantOverridesRequest)(nil),  // 26: racing.v1.ListTenantOverridesRequest
+	(*ListTenantOverridesResponse)(nil), // 27: racing.v1.ListTenantOverridesResponse
+	(*SetTenantOverrideRequest)(nil),    // 28: racing.v1.SetTenantOverrideRequest
+	(*DeleteTenantOverrideRequest)(nil), // 29: racing.v1.DeleteTenantOverrideRequest
+	(*fieldmaskpb.FieldMask)(nil),       // 30: google.protobuf.FieldMask
+	(*timestamppb.Timestamp)(nil),       // 31: google.protobuf.Timestamp
+	(*structpb.Value)(nil),              // 32: google.protobuf.Value
+	(*emptypb.Empty)(nil),               // 33: google.protobuf.Empty
+}
+var file_api_racing_v1_racing_proto_depIdxs = []int32{
+	0,  // 0: racing.v1.ListRacesRequest.order_by:type_name -> racing.v1.ListRacesRequest.OrderBy
+	30, // 1: racing.v1.ListRacesRequest.read_mask:type_name -> google.protobuf.FieldMask
+	31, // 2: racing.v1.ListRacesRequest.as_of:type_name -> google.protobuf.Timestamp
+	10, // 3: racing.v1.ListRacesResponse.races:type_name -> racing.v1.Race
+	30, // 4: racing.v1.GetRaceRequest.read_mask:type_name -> google.protobuf.FieldMask
+	30, // 5: racing.v1.GetRaceByExternalIdRequest.read_mask:type_name -> google.protobuf.FieldMask
+	10, // 6: racing.v1.BatchGetRacesResponse.races:type_name -> racing.v1.Race
+	31, // 7: racing.v1.Race.advertised_start_time:type_name -> google.protobuf.Timestamp
+	1,  // 8: racing.v1.Race.status:type_name -> racing.v1.Race.Status
+	31, // 9: racing.v1.Race.archive_time:type_name -> google.protobuf.Timestamp
+	10, // 10: racing.v1.UpdateRaceRequest.race:type_name -> racing.v1.Race
+	30, // 11: racing.v1.UpdateRaceRequest.update_mask:type_name -> google.protobuf.FieldMask
+	10, // 12: racing.v1.ImportRacesRequest.race:type_name -> racing.v1.Race
+	14, // 13: racing.v1.ImportRacesRequest.options:type_name -> racing.v1.ImportOptions
+	16, // 14: racing.v1.ImportRacesResponse.errors:type_name -> racing.v1.ImportError
+	20, // 15: racing.v1.ListChangesResponse.changes:type_name -> racing.v1.Change
+	2,  // 16: racing.v1.Change.operation:type_name -> racing.v1.Change.Operation
+	31, // 17: racing.v1.Change.change_time:type_name -> google.protobuf.Timestamp
+	10, // 18: racing.v1.Change.race:type_name -> racing.v1.Race
+	31, // 19: racing.v1.ListAuditEntriesRequest.start_time:type_name -> google.protobuf.Timestamp
+	31, // 20: racing.v1.ListAuditEntriesRequest.end_time:type_name -> google.protobuf.Timestamp
+	23, // 21: racing.v1.ListAuditEntriesResponse.entries:type_name -> racing.v1.AuditEntry
+	31, // 22: racing.v1.AuditEntry.entry_time:type_name -> google.protobuf.Timestamp
+	24, // 23: racing.v1.AuditEntry.changes:type_name -> racing.v1.FieldChange
+	32, // 24: racing.v1.FieldChange.before:type_name -> google.protobuf.Value
+	32, // 25: racing.v1.FieldChange.after:type_name -> google.protobuf.Value
+	3,  // 26: racing.v1.TenantOverride.visibility:type_name -> racing.v1.TenantOverride.Visibility
+	25, // 27: racing.v1.ListTenantOverridesResponse.overrides:type_name -> racing.v1.TenantOverride
+	25, // 28: racing.v1.SetTenantOverrideRequest.override:type_name -> racing.v1.TenantOverride
+	4,  // 29: racing.v1.Racing.ListRaces:input_type -> racing.v1.ListRacesRequest
+	6,  // 30: racing.v1.Racing.GetRace:input_type -> racing.v1.GetRaceRequest
+	7,  // 31: racing.v1.Racing.GetRaceByExternalId:input_type -> racing.v1.GetRaceByExternalIdRequest
+	8,  // 32: racing.v1.Racing.BatchGetRaces:input_type -> racing.v1.BatchGetRacesRequest
+	11, // 33: racing.v1.Racing.UpdateRace:input_type -> racing.v1.UpdateRaceRequest
+	12, // 34: racing.v1.Racing.DeleteRace:input_type -> racing.v1.DeleteRaceRequest
+	13, // 35: racing.v1.Racing.ImportRaces:input_type -> racing.v1.ImportRacesRequest
+	17, // 36: racing.v1.Racing.ExportRaces:input_type -> racing.v1.ExportRacesRequest
+	18, // 37: racing.v1.Racing.ListChanges:input_type -> racing.v1.ListChangesRequest
+	21, // 38: racing.v1.Racing.ListAuditEntries:input_type -> racing.v1.ListAuditEntriesRequest
+	26, // 39: racing.v1.Racing.ListTenantOverrides:input_type -> racing.v1.ListTenantOverridesRequest
+	28, // 40: racing.v1.Racing.SetTenantOverride:input_type -> racing.v1.SetTenantOverrideRequest
+	29, // 41: racing.v1.Racing.DeleteTenantOverride:input_type -> racing.v1.DeleteTenantOverrideRequest
+	5,  // 42: racing.v1.Racing.ListRaces:output_type -> racing.v1.ListRacesResponse
+	10, // 43: racing.v1.Racing.GetRace:output_type -> racing.v1.Race
+	10, // 44: racing.v1.Racing.GetRaceByExternalId:output_type -> racing.v1.Race
+	9,  // 45: racing.v1.Racing.BatchGetRaces:output_type -> racing.v1.BatchGetRacesResponse
+	10, // 46: racing.v1.Racing.UpdateRace:output_type -> racing.v1.Race
+	33, // 47: racing.v1.Racing.DeleteRace:output_type -> google.protobuf.Empty
+	15, // 48: racing.v1.Racing.ImportRaces:output_type -> racing.v1.ImportRacesResponse
+	10, // 49: racing.v1.Racing.ExportRaces:output_type -> racing.v1.Race
+	19, // 50: racing.v1.Racing.ListChanges:output_type -> racing.v1.ListChangesResponse
+	22, // 51: racing.v1.Racing.ListAuditEntries:output_type -> racing.v1.ListAuditEntriesResponse
+	27, // 52: racing.v1.Racing.ListTenantOverrides:output_type -> racing.v1.ListTenantOverridesResponse
+	25, // 53: racing.v1.Racing.SetTenantOverride:output_type -> racing.v1.TenantOverride
+	33, // 54: racing.v1.Racing.DeleteTenantOverride:output_type -> google.protobuf.Empty
+	42, // [42:55] is the sub-list for method output_type
+	29, // [29:42] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
+}
+
+func init() { file_api_racing_v1_racing_proto_init() }
+func file_api_racing_v1_racing_proto_init() {
+	if File_api_racing_v1_racing_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_racing_v1_racing_proto_rawDesc), len(file_api_racing_v1_racing_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   26,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_racing_v1_racing_proto_goTypes,
+		DependencyIndexes: file_api_racing_v1_racing_proto_depIdxs,
+		EnumInfos:         file_api_racing_v1_racing_proto_enumTypes,
+		MessageInfos:      file_api_racing_v1_racing_proto_msgTypes,
+	}.Build()
+	File_api_racing_v1_racing_proto = out.File
+	file_api_racing_v1_racing_proto_goTypes = nil
+	file_api_racing_v1_racing_proto_depIdxs = nil
+}
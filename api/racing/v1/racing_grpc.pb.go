@@ -0,0 +1,633 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.1
+// source: api/racing/v1/racing.proto
+
+package racingv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Racing_ListRaces_FullMethodName            = "/racing.v1.Racing/ListRaces"
+	Racing_GetRace_FullMethodName              = "/racing.v1.Racing/GetRace"
+	Racing_GetRaceByExternalId_FullMethodName  = "/racing.v1.Racing/GetRaceByExternalId"
+	Racing_BatchGetRaces_FullMethodName        = "/racing.v1.Racing/BatchGetRaces"
+	Racing_UpdateRace_FullMethodName           = "/racing.v1.Racing/UpdateRace"
+	Racing_DeleteRace_FullMethodName           = "/racing.v1.Racing/DeleteRace"
+	Racing_ImportRaces_FullMethodName          = "/racing.v1.Racing/ImportRaces"
+	Racing_ExportRaces_FullMethodName          = "/racing.v1.Racing/ExportRaces"
+	Racing_ListChanges_FullMethodName          = "/racing.v1.Racing/ListChanges"
+	Racing_ListAuditEntries_FullMethodName     = "/racing.v1.Racing/ListAuditEntries"
+	Racing_ListTenantOverrides_FullMethodName  = "/racing.v1.Racing/ListTenantOverrides"
+	Racing_SetTenantOverride_FullMethodName    = "/racing.v1.Racing/SetTenantOverride"
+	Racing_DeleteTenantOverride_FullMethodName = "/racing.v1.Racing/DeleteTenantOverride"
+)
+
+// RacingClient is the client API for Racing service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Racing service provides operations for managing horse racing events.
+type RacingClient interface {
+	// ListRaces returns a list of all races. The races of the meetings that
+	// cannot be offered in the jurisdiction of the request are not listed.
+	ListRaces(ctx context.Context, in *ListRacesRequest, opts ...grpc.CallOption) (*ListRacesResponse, error)
+	// GetRace returns a specific race by its ID. It fails with the
+	// PERMISSION_DENIED status and the RESTRICTED_IN_JURISDICTION reason if the
+	// meeting of the race cannot be offered in the jurisdiction of the request.
+	GetRace(ctx context.Context, in *GetRaceRequest, opts ...grpc.CallOption) (*Race, error)
+	// GetRaceByExternalId returns a specific race by the ID assigned to it by an
+	// external provider. It is restricted in jurisdictions as GetRace.
+	GetRaceByExternalId(ctx context.Context, in *GetRaceByExternalIdRequest, opts ...grpc.CallOption) (*Race, error)
+	// BatchGetRaces returns multiple races by their IDs.
+	BatchGetRaces(ctx context.Context, in *BatchGetRacesRequest, opts ...grpc.CallOption) (*BatchGetRacesResponse, error)
+	// UpdateRace updates the fields of a race listed in the update mask. Only
+	// admins can update races.
+	UpdateRace(ctx context.Context, in *UpdateRaceRequest, opts ...grpc.CallOption) (*Race, error)
+	// DeleteRace deletes a race. Only admins can delete races.
+	DeleteRace(ctx context.Context, in *DeleteRaceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ImportRaces creates and updates the races streamed by the client, and
+	// returns the outcome of the import once the stream is closed. Only admins
+	// can import races.
+	ImportRaces(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportRacesRequest, ImportRacesResponse], error)
+	// ExportRaces streams all races matching the request in the order of their
+	// IDs.
+	ExportRaces(ctx context.Context, in *ExportRacesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Race], error)
+	// ListChanges returns the changes of races in the order they were made.
+	ListChanges(ctx context.Context, in *ListChangesRequest, opts ...grpc.CallOption) (*ListChangesResponse, error)
+	// ListAuditEntries returns the audit entries of the changes made to
+	// races through the API, in the order they were made. Only admins can
+	// list the audit entries.
+	ListAuditEntries(ctx context.Context, in *ListAuditEntriesRequest, opts ...grpc.CallOption) (*ListAuditEntriesResponse, error)
+	// ListTenantOverrides returns the overrides of the visibility and the
+	// ordering of races for a tenant, in the order of the IDs of the races. Only
+	// admins can list the overrides.
+	ListTenantOverrides(ctx context.Context, in *ListTenantOverridesRequest, opts ...grpc.CallOption) (*ListTenantOverridesResponse, error)
+	// SetTenantOverride creates or replaces the override of the visibility and
+	// the ordering of a race for a tenant. Only admins can set the overrides.
+	SetTenantOverride(ctx context.Context, in *SetTenantOverrideRequest, opts ...grpc.CallOption) (*TenantOverride, error)
+	// DeleteTenantOverride deletes the override of a race for a tenant, so that
+	// the race is seen by the tenant as by any other client. Only admins can
+	// delete the overrides.
+	DeleteTenantOverride(ctx context.Context, in *DeleteTenantOverrideRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type racingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRacingClient(cc grpc.ClientConnInterface) RacingClient {
+	return &racingClient{cc}
+}
+
+func (c *racingClient) ListRaces(ctx context.Context, in *ListRacesRequest, opts ...grpc.CallOption) (*ListRacesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRacesResponse)
+	err := c.cc.Invoke(ctx, Racing_ListRaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *racingClient) GetRace(ctx context.Context, in *GetRaceRequest, opts ...grpc.CallOption) (*Race, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Race)
+	err := c.cc.Invoke(ctx, Racing_GetRace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *racingClient) GetRaceByExternalId(ctx context.Context, in *GetRaceByExternalIdRequest, opts ...grpc.CallOption) (*Race, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Race)
+	err := c.cc.Invoke(ctx, Racing_GetRaceByExternalId_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *racingClient) BatchGetRaces(ctx context.Context, in *BatchGetRacesRequest, opts ...grpc.CallOption) (*BatchGetRacesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetRacesResponse)
+	err := c.cc.Invoke(ctx, Racing_BatchGetRaces_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *racingClient) UpdateRace(ctx context.Context, in *UpdateRaceRequest, opts ...grpc.CallOption) (*Race, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Race)
+	err := c.cc.Invoke(ctx, Racing_UpdateRace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *racingClient) DeleteRace(ctx context.Context, in *DeleteRaceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Racing_DeleteRace_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *racingClient) ImportRaces(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportRacesRequest, ImportRacesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Racing_ServiceDesc.Streams[0], Racing_ImportRaces_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportRacesRequest, ImportRacesResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Racing_ImportRacesClient = grpc.ClientStreamingClient[ImportRacesRequest, ImportRacesResponse]
+
+func (c *racingClient) ExportRaces(ctx context.Context, in *ExportRacesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Race], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Racing_ServiceDesc.Streams[1], Racing_ExportRaces_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportRacesRequest, Race]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Racing_ExportRacesClient = grpc.ServerStreamingClient[Race]
+
+func (c *racingClient) ListChanges(ctx context.Context, in *ListChangesRequest, opts ...grpc.CallOption) (*ListChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChangesResponse)
+	err := c.cc.Invoke(ctx, Racing_ListChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *racingClient) ListAuditEntries(ctx context.Context, in *ListAuditEntriesRequest, opts ...grpc.CallOption) (*ListAuditEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEntriesResponse)
+	err := c.cc.Invoke(ctx, Racing_ListAuditEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *racingClient) ListTenantOverrides(ctx context.Context, in *ListTenantOverridesRequest, opts ...grpc.CallOption) (*ListTenantOverridesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTenantOverridesResponse)
+	err := c.cc.Invoke(ctx, Racing_ListTenantOverrides_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *racingClient) SetTenantOverride(ctx context.Context, in *SetTenantOverrideRequest, opts ...grpc.CallOption) (*TenantOverride, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TenantOverride)
+	err := c.cc.Invoke(ctx, Racing_SetTenantOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *racingClient) DeleteTenantOverride(ctx context.Context, in *DeleteTenantOverrideRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Racing_DeleteTenantOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RacingServer is the server API for Racing service.
+// All implementations should embed UnimplementedRacingServer
+// for forward compatibility.
+//
+// Racing service provides operations for managing horse racing events.
+type RacingServer interface {
+	// ListRaces returns a list of all races. The races of the meetings that
+	// cannot be offered in the jurisdiction of the request are not listed.
+	ListRaces(context.Context, *ListRacesRequest) (*ListRacesResponse, error)
+	// GetRace returns a specific race by its ID. It fails with the
+	// PERMISSION_DENIED status and the RESTRICTED_IN_JURISDICTION reason if the
+	// meeting of the race cannot be offered in the jurisdiction of the request.
+	GetRace(context.Context, *GetRaceRequest) (*Race, error)
+	// GetRaceByExternalId returns a specific race by the ID assigned to it by an
+	// external provider. It is restricted in jurisdictions as GetRace.
+	GetRaceByExternalId(context.Context, *GetRaceByExternalIdRequest) (*Race, error)
+	// BatchGetRaces returns multiple races by their IDs.
+	BatchGetRaces(context.Context, *BatchGetRacesRequest) (*BatchGetRacesResponse, error)
+	// UpdateRace updates the fields of a race listed in the update mask. Only
+	// admins can update races.
+	UpdateRace(context.Context, *UpdateRaceRequest) (*Race, error)
+	// DeleteRace deletes a race. Only admins can delete races.
+	DeleteRace(context.Context, *DeleteRaceRequest) (*emptypb.Empty, error)
+	// ImportRaces creates and updates the races streamed by the client, and
+	// returns the outcome of the import once the stream is closed. Only admins
+	// can import races.
+	ImportRaces(grpc.ClientStreamingServer[ImportRacesRequest, ImportRacesResponse]) error
+	// ExportRaces streams all races matching the request in the order of their
+	// IDs.
+	ExportRaces(*ExportRacesRequest, grpc.ServerStreamingServer[Race]) error
+	// ListChanges returns the changes of races in the order they were made.
+	ListChanges(context.Context, *ListChangesRequest) (*ListChangesResponse, error)
+	// ListAuditEntries returns the audit entries of the changes made to
+	// races through the API, in the order they were made. Only admins can
+	// list the audit entries.
+	ListAuditEntries(context.Context, *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error)
+	// ListTenantOverrides returns the overrides of the visibility and the
+	// ordering of races for a tenant, in the order of the IDs of the races. Only
+	// admins can list the overrides.
+	ListTenantOverrides(context.Context, *ListTenantOverridesRequest) (*ListTenantOverridesResponse, error)
+	// SetTenantOverride creates or replaces the override of the visibility and
+	// the ordering of a race for a tenant. Only admins can set the overrides.
+	SetTenantOverride(context.Context, *SetTenantOverrideRequest) (*TenantOverride, error)
+	// DeleteTenantOverride deletes the override of a race for a tenant, so that
+	// the race is seen by the tenant as by any other client. Only admins can
+	// delete the overrides.
+	DeleteTenantOverride(context.Context, *DeleteTenantOverrideRequest) (*emptypb.Empty, error)
+}
+
+// UnimplementedRacingServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRacingServer struct{}
+
+func (UnimplementedRacingServer) ListRaces(context.Context, *ListRacesRequest) (*ListRacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRaces not implemented")
+}
+func (UnimplementedRacingServer) GetRace(context.Context, *GetRaceRequest) (*Race, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRace not implemented")
+}
+func (UnimplementedRacingServer) GetRaceByExternalId(context.Context, *GetRaceByExternalIdRequest) (*Race, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRaceByExternalId not implemented")
+}
+func (UnimplementedRacingServer) BatchGetRaces(context.Context, *BatchGetRacesRequest) (*BatchGetRacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetRaces not implemented")
+}
+func (UnimplementedRacingServer) UpdateRace(context.Context, *UpdateRaceRequest) (*Race, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRace not implemented")
+}
+func (UnimplementedRacingServer) DeleteRace(context.Context, *DeleteRaceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRace not implemented")
+}
+func (UnimplementedRacingServer) ImportRaces(grpc.ClientStreamingServer[ImportRacesRequest, ImportRacesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportRaces not implemented")
+}
+func (UnimplementedRacingServer) ExportRaces(*ExportRacesRequest, grpc.ServerStreamingServer[Race]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportRaces not implemented")
+}
+func (UnimplementedRacingServer) ListChanges(context.Context, *ListChangesRequest) (*ListChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChanges not implemented")
+}
+func (UnimplementedRacingServer) ListAuditEntries(context.Context, *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEntries not implemented")
+}
+func (UnimplementedRacingServer) ListTenantOverrides(context.Context, *ListTenantOverridesRequest) (*ListTenantOverridesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTenantOverrides not implemented")
+}
+func (UnimplementedRacingServer) SetTenantOverride(context.Context, *SetTenantOverrideRequest) (*TenantOverride, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTenantOverride not implemented")
+}
+func (UnimplementedRacingServer) DeleteTenantOverride(context.Context, *DeleteTenantOverrideRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTenantOverride not implemented")
+}
+func (UnimplementedRacingServer) testEmbeddedByValue() {}
+
+// UnsafeRacingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RacingServer will
+// result in compilation errors.
+type UnsafeRacingServer interface {
+	mustEmbedUnimplementedRacingServer()
+}
+
+func RegisterRacingServer(s grpc.ServiceRegistrar, srv RacingServer) {
+	// If the following call pancis, it indicates UnimplementedRacingServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Racing_ServiceDesc, srv)
+}
+
+func _Racing_ListRaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RacingServer).ListRaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Racing_ListRaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RacingServer).ListRaces(ctx, req.(*ListRacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Racing_GetRace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RacingServer).GetRace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Racing_GetRace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RacingServer).GetRace(ctx, req.(*GetRaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Racing_GetRaceByExternalId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRaceByExternalIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RacingServer).GetRaceByExternalId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Racing_GetRaceByExternalId_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RacingServer).GetRaceByExternalId(ctx, req.(*GetRaceByExternalIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Racing_BatchGetRaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetRacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RacingServer).BatchGetRaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Racing_BatchGetRaces_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RacingServer).BatchGetRaces(ctx, req.(*BatchGetRacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Racing_UpdateRace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RacingServer).UpdateRace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Racing_UpdateRace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RacingServer).UpdateRace(ctx, req.(*UpdateRaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Racing_DeleteRace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RacingServer).DeleteRace(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Racing_DeleteRace_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RacingServer).DeleteRace(ctx, req.(*DeleteRaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Racing_ImportRaces_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RacingServer).ImportRaces(&grpc.GenericServerStream[ImportRacesRequest, ImportRacesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Racing_ImportRacesServer = grpc.ClientStreamingServer[ImportRacesRequest, ImportRacesResponse]
+
+func _Racing_ExportRaces_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRacesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RacingServer).ExportRaces(m, &grpc.GenericServerStream[ExportRacesRequest, Race]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Racing_ExportRacesServer = grpc.ServerStreamingServer[Race]
+
+func _Racing_ListChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RacingServer).ListChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Racing_ListChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RacingServer).ListChanges(ctx, req.(*ListChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Racing_ListAuditEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RacingServer).ListAuditEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Racing_ListAuditEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RacingServer).ListAuditEntries(ctx, req.(*ListAuditEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Racing_ListTenantOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTenantOverridesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RacingServer).ListTenantOverrides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Racing_ListTenantOverrides_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RacingServer).ListTenantOverrides(ctx, req.(*ListTenantOverridesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Racing_SetTenantOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTenantOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RacingServer).SetTenantOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Racing_SetTenantOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RacingServer).SetTenantOverride(ctx, req.(*SetTenantOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Racing_DeleteTenantOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTenantOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RacingServer).DeleteTenantOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Racing_DeleteTenantOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RacingServer).DeleteTenantOverride(ctx, req.(*DeleteTenantOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Racing_ServiceDesc is the grpc.ServiceDesc for Racing service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Racing_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "racing.v1.Racing",
+	HandlerType: (*RacingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListRaces",
+			Handler:    _Racing_ListRaces_Handler,
+		},
+		{
+			MethodName: "GetRace",
+			Handler:    _Racing_GetRace_Handler,
+		},
+		{
+			MethodName: "GetRaceByExternalId",
+			Handler:    _Racing_GetRaceByExternalId_Handler,
+		},
+		{
+			MethodName: "BatchGetRaces",
+			Handler:    _Racing_BatchGetRaces_Handler,
+		},
+		{
+			MethodName: "UpdateRace",
+			Handler:    _Racing_UpdateRace_Handler,
+		},
+		{
+			MethodName: "DeleteRace",
+			Handler:    _Racing_DeleteRace_Handler,
+		},
+		{
+			MethodName: "ListChanges",
+			Handler:    _Racing_ListChanges_Handler,
+		},
+		{
+			MethodName: "ListAuditEntries",
+			Handler:    _Racing_ListAuditEntries_Handler,
+		},
+		{
+			MethodName: "ListTenantOverrides",
+			Handler:    _Racing_ListTenantOverrides_Handler,
+		},
+		{
+			MethodName: "SetTenantOverride",
+			Handler:    _Racing_SetTenantOverride_Handler,
+		},
+		{
+			MethodName: "DeleteTenantOverride",
+			Handler:    _Racing_DeleteTenantOverride_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportRaces",
+			Handler:       _Racing_ImportRaces_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportRaces",
+			Handler:       _Racing_ExportRaces_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/racing/v1/racing.proto",
+}
@@ -0,0 +1,9 @@
+package racingv1
+
+import _ "embed"
+
+// SwaggerYAML is the OpenAPI v2 (Swagger) specification of the HTTP routes of
+// version 1 of the racing service, generated from racing.proto.
+//
+//go:embed racing.swagger.yaml
+var SwaggerYAML []byte
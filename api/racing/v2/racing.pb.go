@@ -0,0 +1,561 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v6.32.1
+// source: api/racing/v2/racing.proto
+
+package racingv2
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Status represents the status of a race.
+type Race_Status int32
+
+const (
+	Race_STATUS_UNSPECIFIED Race_Status = 0
+	// STATUS_OPEN indicates the race is open for betting.
+	Race_STATUS_OPEN Race_Status = 1
+	// STATUS_CLOSED indicates the race is closed for betting. Open races are
+	// closed once their advertised start time has passed.
+	Race_STATUS_CLOSED Race_Status = 2
+	// STATUS_ABANDONED indicates the race has been abandoned and will not
+	// run.
+	Race_STATUS_ABANDONED Race_Status = 3
+)
+
+// Enum value maps for Race_Status.
+var (
+	Race_Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_OPEN",
+		2: "STATUS_CLOSED",
+		3: "STATUS_ABANDONED",
+	}
+	Race_Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_OPEN":        1,
+		"STATUS_CLOSED":      2,
+		"STATUS_ABANDONED":   3,
+	}
+)
+
+func (x Race_Status) Enum() *Race_Status {
+	p := new(Race_Status)
+	*p = x
+	return p
+}
+
+func (x Race_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Race_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_racing_v2_racing_proto_enumTypes[0].Descriptor()
+}
+
+func (Race_Status) Type() protoreflect.EnumType {
+	return &file_api_racing_v2_racing_proto_enumTypes[0]
+}
+
+func (x Race_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Race_Status.Descriptor instead.
+func (Race_Status) EnumDescriptor() ([]byte, []int) {
+	return file_api_racing_v2_racing_proto_rawDescGZIP(), []int{4, 0}
+}
+
+// ListRacesRequest represents a request for the ListRaces call.
+type ListRacesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// MeetingId is an optional list of meeting IDs to filter the races.
+	MeetingId []int64 `protobuf:"varint,1,rep,packed,name=meeting_id,json=meetingId,proto3" json:"meeting_id,omitempty"`
+	// VisibleOnly indicates whether to return only visible races. The races
+	// are visible as overridden for the tenant of the request, if any.
+	VisibleOnly bool `protobuf:"varint,2,opt,name=visible_only,json=visibleOnly,proto3" json:"visible_only,omitempty"`
+	// Status is an optional list of statuses to filter the races.
+	Status []Race_Status `protobuf:"varint,3,rep,packed,name=status,proto3,enum=racing.v2.Race_Status" json:"status,omitempty"`
+	// PageSize is the maximum number of races to return. If it is zero, at
+	// most 100 races are returned.
+	PageSize int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// PageToken is an optional token of the page to return, as returned in the
+	// next_page_token field of a previous response. If it is not set, the first
+	// page is returned. The other fields of the request must not change between
+	// the pages.
+	PageToken     string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRacesRequest) Reset() {
+	*x = ListRacesRequest{}
+	mi := &file_api_racing_v2_racing_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRacesRequest) ProtoMessage() {}
+
+func (x *ListRacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v2_racing_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRacesRequest.ProtoReflect.Descriptor instead.
+func (*ListRacesRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v2_racing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListRacesRequest) GetMeetingId() []int64 {
+	if x != nil {
+		return x.MeetingId
+	}
+	return nil
+}
+
+func (x *ListRacesRequest) GetVisibleOnly() bool {
+	if x != nil {
+		return x.VisibleOnly
+	}
+	return false
+}
+
+func (x *ListRacesRequest) GetStatus() []Race_Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *ListRacesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRacesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListRacesResponse represents a response to the ListRaces call.
+type ListRacesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Races is a list of horse racing events.
+	Races []*Race `protobuf:"bytes,1,rep,name=races,proto3" json:"races,omitempty"`
+	// NextPageToken is the token of the next page of races. It is empty if
+	// there are no more races.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRacesResponse) Reset() {
+	*x = ListRacesResponse{}
+	mi := &file_api_racing_v2_racing_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRacesResponse) ProtoMessage() {}
+
+func (x *ListRacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v2_racing_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRacesResponse.ProtoReflect.Descriptor instead.
+func (*ListRacesResponse) Descriptor() ([]byte, []int) {
+	return file_api_racing_v2_racing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListRacesResponse) GetRaces() []*Race {
+	if x != nil {
+		return x.Races
+	}
+	return nil
+}
+
+func (x *ListRacesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// GetRaceRequest represents a request for the GetRace call.
+type GetRaceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the race to retrieve.
+	RaceId        int64 `protobuf:"varint,1,opt,name=race_id,json=raceId,proto3" json:"race_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRaceRequest) Reset() {
+	*x = GetRaceRequest{}
+	mi := &file_api_racing_v2_racing_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRaceRequest) ProtoMessage() {}
+
+func (x *GetRaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v2_racing_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRaceRequest.ProtoReflect.Descriptor instead.
+func (*GetRaceRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v2_racing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRaceRequest) GetRaceId() int64 {
+	if x != nil {
+		return x.RaceId
+	}
+	return 0
+}
+
+// UpdateRaceStatusRequest represents a request for the UpdateRaceStatus call.
+type UpdateRaceStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the race to update.
+	RaceId int64 `protobuf:"varint,1,opt,name=race_id,json=raceId,proto3" json:"race_id,omitempty"`
+	// Status is the new status of the race.
+	Status Race_Status `protobuf:"varint,2,opt,name=status,proto3,enum=racing.v2.Race_Status" json:"status,omitempty"`
+	// Etag is the etag of the race the update is based on. The update fails if
+	// the race has been changed since. If it is not set, the etag is taken from
+	// the "if-match" metadata of the request.
+	Etag          string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRaceStatusRequest) Reset() {
+	*x = UpdateRaceStatusRequest{}
+	mi := &file_api_racing_v2_racing_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRaceStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRaceStatusRequest) ProtoMessage() {}
+
+func (x *UpdateRaceStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v2_racing_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRaceStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRaceStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_racing_v2_racing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateRaceStatusRequest) GetRaceId() int64 {
+	if x != nil {
+		return x.RaceId
+	}
+	return 0
+}
+
+func (x *UpdateRaceStatusRequest) GetStatus() Race_Status {
+	if x != nil {
+		return x.Status
+	}
+	return Race_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateRaceStatusRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// A race resource.
+type Race struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID represents a unique identifier for the race.
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// MeetingID represents a unique identifier for the races meeting.
+	MeetingId int64 `protobuf:"varint,2,opt,name=meeting_id,json=meetingId,proto3" json:"meeting_id,omitempty"`
+	// Name is the official name given to the race.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Number represents the number of the race.
+	Number int64 `protobuf:"varint,4,opt,name=number,proto3" json:"number,omitempty"`
+	// Visible represents whether or not the race is visible. It reflects the
+	// visibility overridden for the tenant of the request, if any.
+	Visible bool `protobuf:"varint,5,opt,name=visible,proto3" json:"visible,omitempty"`
+	// AdvertisedStartTime is the time the race is advertised to run.
+	AdvertisedStartTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=advertised_start_time,json=advertisedStartTime,proto3" json:"advertised_start_time,omitempty"`
+	// Status is the stored status of the race.
+	Status Race_Status `protobuf:"varint,7,opt,name=status,proto3,enum=racing.v2.Race_Status" json:"status,omitempty"`
+	// Etag is an opaque identifier of the current version of the race. It
+	// changes every time the race is changed, except when it is closed once its
+	// advertised start time has passed.
+	Etag          string `protobuf:"bytes,8,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Race) Reset() {
+	*x = Race{}
+	mi := &file_api_racing_v2_racing_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Race) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Race) ProtoMessage() {}
+
+func (x *Race) ProtoReflect() protoreflect.Message {
+	mi := &file_api_racing_v2_racing_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Race.ProtoReflect.Descriptor instead.
+func (*Race) Descriptor() ([]byte, []int) {
+	return file_api_racing_v2_racing_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Race) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Race) GetMeetingId() int64 {
+	if x != nil {
+		return x.MeetingId
+	}
+	return 0
+}
+
+func (x *Race) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Race) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Race) GetVisible() bool {
+	if x != nil {
+		return x.Visible
+	}
+	return false
+}
+
+func (x *Race) GetAdvertisedStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AdvertisedStartTime
+	}
+	return nil
+}
+
+func (x *Race) GetStatus() Race_Status {
+	if x != nil {
+		return x.Status
+	}
+	return Race_STATUS_UNSPECIFIED
+}
+
+func (x *Race) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+var File_api_racing_v2_racing_proto protoreflect.FileDescriptor
+
+const file_api_racing_v2_racing_proto_rawDesc = "" +
+	"\n" +
+	"\x1aapi/racing/v2/racing.proto\x12\tracing.v2\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1bbuf/validate/validate.proto\"\xef\x01\n" +
+	"\x10ListRacesRequest\x12-\n" +
+	"\n" +
+	"meeting_id\x18\x01 \x03(\x03B\x0e\xbaH\v\x92\x01\b\x18\x01\"\x04\"\x02 \x00R\tmeetingId\x12!\n" +
+	"\fvisible_only\x18\x02 \x01(\bR\vvisibleOnly\x12A\n" +
+	"\x06status\x18\x03 \x03(\x0e2\x16.racing.v2.Race.StatusB\x11\xbaH\x0e\x92\x01\v\x18\x01\"\a\x82\x01\x04\x10\x01 \x00R\x06status\x12'\n" +
+	"\tpage_size\x18\x04 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\"b\n" +
+	"\x11ListRacesResponse\x12%\n" +
+	"\x05races\x18\x01 \x03(\v2\x0f.racing.v2.RaceR\x05races\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"2\n" +
+	"\x0eGetRaceRequest\x12 \n" +
+	"\arace_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06raceId\"\x8b\x01\n" +
+	"\x17UpdateRaceStatusRequest\x12 \n" +
+	"\arace_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\x06raceId\x12:\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x16.racing.v2.Race.StatusB\n" +
+	"\xbaH\a\x82\x01\x04\x10\x01 \x00R\x06status\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\"\xeb\x02\n" +
+	"\x04Race\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"meeting_id\x18\x02 \x01(\x03R\tmeetingId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06number\x18\x04 \x01(\x03R\x06number\x12\x18\n" +
+	"\avisible\x18\x05 \x01(\bR\avisible\x12N\n" +
+	"\x15advertised_start_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x13advertisedStartTime\x12.\n" +
+	"\x06status\x18\a \x01(\x0e2\x16.racing.v2.Race.StatusR\x06status\x12\x12\n" +
+	"\x04etag\x18\b \x01(\tR\x04etag\"Z\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vSTATUS_OPEN\x10\x01\x12\x11\n" +
+	"\rSTATUS_CLOSED\x10\x02\x12\x14\n" +
+	"\x10STATUS_ABANDONED\x10\x032\xad\x02\n" +
+	"\x06Racing\x12Y\n" +
+	"\tListRaces\x12\x1b.racing.v2.ListRacesRequest\x1a\x1c.racing.v2.ListRacesResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v2/races\x12R\n" +
+	"\aGetRace\x12\x19.racing.v2.GetRaceRequest\x1a\x0f.racing.v2.Race\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v2/races/{race_id}\x12t\n" +
+	"\x10UpdateRaceStatus\x12\".racing.v2.UpdateRaceStatusRequest\x1a\x0f.racing.v2.Race\"+\x82\xd3\xe4\x93\x02%:\x01*\" /v2/races/{race_id}:updateStatusB7Z5github.com/danilvpetrov/entain/api/racing/v2;racingv2b\x06proto3"
+
+var (
+	file_api_racing_v2_racing_proto_rawDescOnce sync.Once
+	file_api_racing_v2_racing_proto_rawDescData []byte
+)
+
+func file_api_racing_v2_racing_proto_rawDescGZIP() []byte {
+	file_api_racing_v2_racing_proto_rawDescOnce.Do(func() {
+		file_api_racing_v2_racing_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_racing_v2_racing_proto_rawDesc), len(file_api_racing_v2_racing_proto_rawDesc)))
+	})
+	return file_api_racing_v2_racing_proto_rawDescData
+}
+
+var file_api_racing_v2_racing_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_racing_v2_racing_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_api_racing_v2_racing_proto_goTypes = []any{
+	(Race_Status)(0),                // 0: racing.v2.Race.Status
+	(*ListRacesRequest)(nil),        // 1: racing.v2.ListRacesRequest
+	(*ListRacesResponse)(nil),       // 2: racing.v2.ListRacesResponse
+	(*GetRaceRequest)(nil),          // 3: racing.v2.GetRaceRequest
+	(*UpdateRaceStatusRequest)(nil), // 4: racing.v2.UpdateRaceStatusRequest
+	(*Race)(nil),                    // 5: racing.v2.Race
+	(*timestamppb.Timestamp)(nil),   // 6: google.protobuf.Timestamp
+}
+var file_api_racing_v2_racing_proto_depIdxs = []int32{
+	0, // 0: racing.v2.ListRacesRequest.status:type_name -> racing.v2.Race.Status
+	5, // 1: racing.v2.ListRacesResponse.races:type_name -> racing.v2.Race
+	0, // 2: racing.v2.UpdateRaceStatusRequest.status:type_name -> racing.v2.Race.Status
+	6, // 3: racing.v2.Race.advertised_start_time:type_name -> google.protobuf.Timestamp
+	0, // 4: racing.v2.Race.status:type_name -> racing.v2.Race.Status
+	1, // 5: racing.v2.Racing.ListRaces:input_type -> racing.v2.ListRacesRequest
+	3, // 6: racing.v2.Racing.GetRace:input_type -> racing.v2.GetRaceRequest
+	4, // 7: racing.v2.Racing.UpdateRaceStatus:input_type -> racing.v2.UpdateRaceStatusRequest
+	2, // 8: racing.v2.Racing.ListRaces:output_type -> racing.v2.ListRacesResponse
+	5, // 9: racing.v2.Racing.GetRace:output_type -> racing.v2.Race
+	5, // 10: racing.v2.Racing.UpdateRaceStatus:output_type -> racing.v2.Race
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_api_racing_v2_racing_proto_init() }
+func file_api_racing_v2_racing_proto_init() {
+	if File_api_racing_v2_racing_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_racing_v2_racing_proto_rawDesc), len(file_api_racing_v2_racing_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_racing_v2_racing_proto_goTypes,
+		DependencyIndexes: file_api_racing_v2_racing_proto_depIdxs,
+		EnumInfos:         file_api_racing_v2_racing_proto_enumTypes,
+		MessageInfos:      file_api_racing_v2_racing_proto_msgTypes,
+	}.Build()
+	File_api_racing_v2_racing_proto = out.File
+	file_api_racing_v2_racing_proto_goTypes = nil
+	file_api_racing_v2_racing_proto_depIdxs = nil
+}
@@ -1,12 +1,12 @@
 // Code generated by protoc-gen-grpc-gateway. DO NOT EDIT.
-// source: api/racing/racing.proto
+// source: api/racing/v2/racing.proto
 
 /*
-Package racing is a reverse proxy.
+Package racingv2 is a reverse proxy.
 
 It translates gRPC into RESTful JSON APIs.
 */
-package racing
+package racingv2
 
 import (
 	"context"
@@ -109,6 +109,51 @@ func local_request_Racing_GetRace_0(ctx context.Context, marshaler runtime.Marsh
 	return msg, metadata, err
 }
 
+func request_Racing_UpdateRaceStatus_0(ctx context.Context, marshaler runtime.Marshaler, client RacingClient, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq UpdateRaceStatusRequest
+		metadata runtime.ServerMetadata
+		err      error
+	)
+	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if req.Body != nil {
+		_, _ = io.Copy(io.Discard, req.Body)
+	}
+	val, ok := pathParams["race_id"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "race_id")
+	}
+	protoReq.RaceId, err = runtime.Int64(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "race_id", err)
+	}
+	msg, err := client.UpdateRaceStatus(ctx, &protoReq, grpc.Header(&metadata.HeaderMD), grpc.Trailer(&metadata.TrailerMD))
+	return msg, metadata, err
+}
+
+func local_request_Racing_UpdateRaceStatus_0(ctx context.Context, marshaler runtime.Marshaler, server RacingServer, req *http.Request, pathParams map[string]string) (proto.Message, runtime.ServerMetadata, error) {
+	var (
+		protoReq UpdateRaceStatusRequest
+		metadata runtime.ServerMetadata
+		err      error
+	)
+	if err := marshaler.NewDecoder(req.Body).Decode(&protoReq); err != nil && !errors.Is(err, io.EOF) {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	val, ok := pathParams["race_id"]
+	if !ok {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "missing parameter %s", "race_id")
+	}
+	protoReq.RaceId, err = runtime.Int64(val)
+	if err != nil {
+		return nil, metadata, status.Errorf(codes.InvalidArgument, "type mismatch, parameter: %s, error: %v", "race_id", err)
+	}
+	msg, err := server.UpdateRaceStatus(ctx, &protoReq)
+	return msg, metadata, err
+}
+
 // RegisterRacingHandlerServer registers the http handlers for service Racing to "mux".
 // UnaryRPC     :call RacingServer directly.
 // StreamingRPC :currently unsupported pending https://github.com/grpc/grpc-go/issues/906.
@@ -121,7 +166,7 @@ func RegisterRacingHandlerServer(ctx context.Context, mux *runtime.ServeMux, ser
 		var stream runtime.ServerTransportStream
 		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, mux, req, "/racing.Racing/ListRaces", runtime.WithHTTPPathPattern("/v1/races"))
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, mux, req, "/racing.v2.Racing/ListRaces", runtime.WithHTTPPathPattern("/v2/races"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
@@ -141,7 +186,7 @@ func RegisterRacingHandlerServer(ctx context.Context, mux *runtime.ServeMux, ser
 		var stream runtime.ServerTransportStream
 		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, mux, req, "/racing.Racing/GetRace", runtime.WithHTTPPathPattern("/v1/races/{race_id}"))
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, mux, req, "/racing.v2.Racing/GetRace", runtime.WithHTTPPathPattern("/v2/races/{race_id}"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
@@ -155,6 +200,26 @@ func RegisterRacingHandlerServer(ctx context.Context, mux *runtime.ServeMux, ser
 		}
 		forward_Racing_GetRace_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
+	mux.Handle(http.MethodPost, pattern_Racing_UpdateRaceStatus_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		var stream runtime.ServerTransportStream
+		ctx = grpc.NewContextWithServerTransportStream(ctx, &stream)
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		annotatedContext, err := runtime.AnnotateIncomingContext(ctx, mux, req, "/racing.v2.Racing/UpdateRaceStatus", runtime.WithHTTPPathPattern("/v2/races/{race_id}:updateStatus"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := local_request_Racing_UpdateRaceStatus_0(annotatedContext, inboundMarshaler, server, req, pathParams)
+		md.HeaderMD, md.TrailerMD = metadata.Join(md.HeaderMD, stream.Header()), metadata.Join(md.TrailerMD, stream.Trailer())
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		forward_Racing_UpdateRaceStatus_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+	})
 
 	return nil
 }
@@ -199,7 +264,7 @@ func RegisterRacingHandlerClient(ctx context.Context, mux *runtime.ServeMux, cli
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, "/racing.Racing/ListRaces", runtime.WithHTTPPathPattern("/v1/races"))
+		annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, "/racing.v2.Racing/ListRaces", runtime.WithHTTPPathPattern("/v2/races"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
@@ -216,7 +281,7 @@ func RegisterRacingHandlerClient(ctx context.Context, mux *runtime.ServeMux, cli
 		ctx, cancel := context.WithCancel(req.Context())
 		defer cancel()
 		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
-		annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, "/racing.Racing/GetRace", runtime.WithHTTPPathPattern("/v1/races/{race_id}"))
+		annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, "/racing.v2.Racing/GetRace", runtime.WithHTTPPathPattern("/v2/races/{race_id}"))
 		if err != nil {
 			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
 			return
@@ -229,15 +294,34 @@ func RegisterRacingHandlerClient(ctx context.Context, mux *runtime.ServeMux, cli
 		}
 		forward_Racing_GetRace_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
 	})
+	mux.Handle(http.MethodPost, pattern_Racing_UpdateRaceStatus_0, func(w http.ResponseWriter, req *http.Request, pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		inboundMarshaler, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		annotatedContext, err := runtime.AnnotateContext(ctx, mux, req, "/racing.v2.Racing/UpdateRaceStatus", runtime.WithHTTPPathPattern("/v2/races/{race_id}:updateStatus"))
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		resp, md, err := request_Racing_UpdateRaceStatus_0(annotatedContext, inboundMarshaler, client, req, pathParams)
+		annotatedContext = runtime.NewServerMetadataContext(annotatedContext, md)
+		if err != nil {
+			runtime.HTTPError(annotatedContext, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		forward_Racing_UpdateRaceStatus_0(annotatedContext, mux, outboundMarshaler, w, req, resp, mux.GetForwardResponseOptions()...)
+	})
 	return nil
 }
 
 var (
-	pattern_Racing_ListRaces_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"v1", "races"}, ""))
-	pattern_Racing_GetRace_0   = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2}, []string{"v1", "races", "race_id"}, ""))
+	pattern_Racing_ListRaces_0        = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1}, []string{"v2", "races"}, ""))
+	pattern_Racing_GetRace_0          = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2}, []string{"v2", "races", "race_id"}, ""))
+	pattern_Racing_UpdateRaceStatus_0 = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 2, 1, 1, 0, 4, 1, 5, 2}, []string{"v2", "races", "race_id"}, "updateStatus"))
 )
 
 var (
-	forward_Racing_ListRaces_0 = runtime.ForwardResponseMessage
-	forward_Racing_GetRace_0   = runtime.ForwardResponseMessage
+	forward_Racing_ListRaces_0        = runtime.ForwardResponseMessage
+	forward_Racing_GetRace_0          = runtime.ForwardResponseMessage
+	forward_Racing_UpdateRaceStatus_0 = runtime.ForwardResponseMessage
 )
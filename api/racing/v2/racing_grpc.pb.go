@@ -2,9 +2,9 @@
 // versions:
 // - protoc-gen-go-grpc v1.5.1
 // - protoc             v6.32.1
-// source: api/racing/racing.proto
+// source: api/racing/v2/racing.proto
 
-package racing
+package racingv2
 
 import (
 	context "context"
@@ -19,20 +19,29 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Racing_ListRaces_FullMethodName = "/racing.Racing/ListRaces"
-	Racing_GetRace_FullMethodName   = "/racing.Racing/GetRace"
+	Racing_ListRaces_FullMethodName        = "/racing.v2.Racing/ListRaces"
+	Racing_GetRace_FullMethodName          = "/racing.v2.Racing/GetRace"
+	Racing_UpdateRaceStatus_FullMethodName = "/racing.v2.Racing/UpdateRaceStatus"
 )
 
 // RacingClient is the client API for Racing service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
-// Racing service provides operations for managing horse racing events.
+// Racing service provides operations for managing horse racing events. It is
+// version 2 of the service, served alongside version 1 from the same storage.
 type RacingClient interface {
-	// ListRaces returns a list of all races.
+	// ListRaces returns a page of races in the order of their advertised start
+	// times. The races of the meetings that cannot be offered in the
+	// jurisdiction of the request are not listed.
 	ListRaces(ctx context.Context, in *ListRacesRequest, opts ...grpc.CallOption) (*ListRacesResponse, error)
-	// GetRace returns a specific race by its ID.
+	// GetRace returns a specific race by its ID. It fails with the
+	// PERMISSION_DENIED status and the RESTRICTED_IN_JURISDICTION reason if the
+	// meeting of the race cannot be offered in the jurisdiction of the request.
 	GetRace(ctx context.Context, in *GetRaceRequest, opts ...grpc.CallOption) (*Race, error)
+	// UpdateRaceStatus sets the status of a race, for example to abandon it.
+	// Only admins can update the statuses of races.
+	UpdateRaceStatus(ctx context.Context, in *UpdateRaceStatusRequest, opts ...grpc.CallOption) (*Race, error)
 }
 
 type racingClient struct {
@@ -63,16 +72,34 @@ func (c *racingClient) GetRace(ctx context.Context, in *GetRaceRequest, opts ...
 	return out, nil
 }
 
+func (c *racingClient) UpdateRaceStatus(ctx context.Context, in *UpdateRaceStatusRequest, opts ...grpc.CallOption) (*Race, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Race)
+	err := c.cc.Invoke(ctx, Racing_UpdateRaceStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RacingServer is the server API for Racing service.
 // All implementations should embed UnimplementedRacingServer
 // for forward compatibility.
 //
-// Racing service provides operations for managing horse racing events.
+// Racing service provides operations for managing horse racing events. It is
+// version 2 of the service, served alongside version 1 from the same storage.
 type RacingServer interface {
-	// ListRaces returns a list of all races.
+	// ListRaces returns a page of races in the order of their advertised start
+	// times. The races of the meetings that cannot be offered in the
+	// jurisdiction of the request are not listed.
 	ListRaces(context.Context, *ListRacesRequest) (*ListRacesResponse, error)
-	// GetRace returns a specific race by its ID.
+	// GetRace returns a specific race by its ID. It fails with the
+	// PERMISSION_DENIED status and the RESTRICTED_IN_JURISDICTION reason if the
+	// meeting of the race cannot be offered in the jurisdiction of the request.
 	GetRace(context.Context, *GetRaceRequest) (*Race, error)
+	// UpdateRaceStatus sets the status of a race, for example to abandon it.
+	// Only admins can update the statuses of races.
+	UpdateRaceStatus(context.Context, *UpdateRaceStatusRequest) (*Race, error)
 }
 
 // UnimplementedRacingServer should be embedded to have
@@ -88,6 +115,9 @@ func (UnimplementedRacingServer) ListRaces(context.Context, *ListRacesRequest) (
 func (UnimplementedRacingServer) GetRace(context.Context, *GetRaceRequest) (*Race, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRace not implemented")
 }
+func (UnimplementedRacingServer) UpdateRaceStatus(context.Context, *UpdateRaceStatusRequest) (*Race, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRaceStatus not implemented")
+}
 func (UnimplementedRacingServer) testEmbeddedByValue() {}
 
 // UnsafeRacingServer may be embedded to opt out of forward compatibility for this service.
@@ -144,11 +174,29 @@ func _Racing_GetRace_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Racing_UpdateRaceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRaceStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RacingServer).UpdateRaceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Racing_UpdateRaceStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RacingServer).UpdateRaceStatus(ctx, req.(*UpdateRaceStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Racing_ServiceDesc is the grpc.ServiceDesc for Racing service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var Racing_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "racing.Racing",
+	ServiceName: "racing.v2.Racing",
 	HandlerType: (*RacingServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
@@ -159,7 +207,11 @@ var Racing_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRace",
 			Handler:    _Racing_GetRace_Handler,
 		},
+		{
+			MethodName: "UpdateRaceStatus",
+			Handler:    _Racing_UpdateRaceStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
-	Metadata: "api/racing/racing.proto",
+	Metadata: "api/racing/v2/racing.proto",
 }
@@ -7,9 +7,13 @@
 package sports
 
 import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -242,7 +246,7 @@ func (x Event_Category) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Event_Category.Descriptor instead.
 func (Event_Category) EnumDescriptor() ([]byte, []int) {
-	return file_api_sports_sports_proto_rawDescGZIP(), []int{3, 0}
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{6, 0}
 }
 
 // Status represents the current status of the event.
@@ -294,7 +298,189 @@ func (x Event_Status) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Event_Status.Descriptor instead.
 func (Event_Status) EnumDescriptor() ([]byte, []int) {
-	return file_api_sports_sports_proto_rawDescGZIP(), []int{3, 1}
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{6, 1}
+}
+
+// MatchState represents the state of the match of an event.
+type Event_MatchState int32
+
+const (
+	// UNSPECIFIED_MATCH_STATE indicates no specific match state.
+	Event_UNSPECIFIED_MATCH_STATE Event_MatchState = 0
+	// PRE_MATCH indicates the match has not started yet.
+	Event_PRE_MATCH Event_MatchState = 1
+	// IN_PLAY indicates the match is being played.
+	Event_IN_PLAY Event_MatchState = 2
+	// SUSPENDED indicates the match has been interrupted, for example at
+	// half-time or due to weather.
+	Event_SUSPENDED Event_MatchState = 3
+	// FINISHED indicates the match is over.
+	Event_FINISHED Event_MatchState = 4
+	// POSTPONED indicates the match has been moved to a later time.
+	Event_POSTPONED Event_MatchState = 5
+	// CANCELLED indicates the match will not be played.
+	Event_CANCELLED Event_MatchState = 6
+)
+
+// Enum value maps for Event_MatchState.
+var (
+	Event_MatchState_name = map[int32]string{
+		0: "UNSPECIFIED_MATCH_STATE",
+		1: "PRE_MATCH",
+		2: "IN_PLAY",
+		3: "SUSPENDED",
+		4: "FINISHED",
+		5: "POSTPONED",
+		6: "CANCELLED",
+	}
+	Event_MatchState_value = map[string]int32{
+		"UNSPECIFIED_MATCH_STATE": 0,
+		"PRE_MATCH":               1,
+		"IN_PLAY":                 2,
+		"SUSPENDED":               3,
+		"FINISHED":                4,
+		"POSTPONED":               5,
+		"CANCELLED":               6,
+	}
+)
+
+func (x Event_MatchState) Enum() *Event_MatchState {
+	p := new(Event_MatchState)
+	*p = x
+	return p
+}
+
+func (x Event_MatchState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Event_MatchState) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_sports_sports_proto_enumTypes[3].Descriptor()
+}
+
+func (Event_MatchState) Type() protoreflect.EnumType {
+	return &file_api_sports_sports_proto_enumTypes[3]
+}
+
+func (x Event_MatchState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Event_MatchState.Descriptor instead.
+func (Event_MatchState) EnumDescriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{6, 2}
+}
+
+// Operation represents the kind of a change.
+type Change_Operation int32
+
+const (
+	Change_UNSPECIFIED Change_Operation = 0
+	// CREATED indicates the event was created.
+	Change_CREATED Change_Operation = 1
+	// UPDATED indicates the event, its scores or its match state were
+	// updated, including its archival.
+	Change_UPDATED Change_Operation = 2
+	// DELETED indicates the event was deleted or purged by the retention
+	// policy.
+	Change_DELETED Change_Operation = 3
+)
+
+// Enum value maps for Change_Operation.
+var (
+	Change_Operation_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "CREATED",
+		2: "UPDATED",
+		3: "DELETED",
+	}
+	Change_Operation_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"CREATED":     1,
+		"UPDATED":     2,
+		"DELETED":     3,
+	}
+)
+
+func (x Change_Operation) Enum() *Change_Operation {
+	p := new(Change_Operation)
+	*p = x
+	return p
+}
+
+func (x Change_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Change_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_sports_sports_proto_enumTypes[4].Descriptor()
+}
+
+func (Change_Operation) Type() protoreflect.EnumType {
+	return &file_api_sports_sports_proto_enumTypes[4]
+}
+
+func (x Change_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Change_Operation.Descriptor instead.
+func (Change_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{25, 0}
+}
+
+// Visibility represents the visibility of a sports event for a tenant.
+type TenantOverride_Visibility int32
+
+const (
+	// UNSPECIFIED indicates the event is as visible for the tenant as it is
+	// for other clients.
+	TenantOverride_UNSPECIFIED TenantOverride_Visibility = 0
+	// VISIBLE indicates the event is visible for the tenant.
+	TenantOverride_VISIBLE TenantOverride_Visibility = 1
+	// HIDDEN indicates the event is not visible for the tenant.
+	TenantOverride_HIDDEN TenantOverride_Visibility = 2
+)
+
+// Enum value maps for TenantOverride_Visibility.
+var (
+	TenantOverride_Visibility_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "VISIBLE",
+		2: "HIDDEN",
+	}
+	TenantOverride_Visibility_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"VISIBLE":     1,
+		"HIDDEN":      2,
+	}
+)
+
+func (x TenantOverride_Visibility) Enum() *TenantOverride_Visibility {
+	p := new(TenantOverride_Visibility)
+	*p = x
+	return p
+}
+
+func (x TenantOverride_Visibility) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TenantOverride_Visibility) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_sports_sports_proto_enumTypes[5].Descriptor()
+}
+
+func (TenantOverride_Visibility) Type() protoreflect.EnumType {
+	return &file_api_sports_sports_proto_enumTypes[5]
+}
+
+func (x TenantOverride_Visibility) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TenantOverride_Visibility.Descriptor instead.
+func (TenantOverride_Visibility) EnumDescriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{30, 0}
 }
 
 // ListEventsRequest represents a request for the ListEvents call.
@@ -302,12 +488,34 @@ type ListEventsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Category is an optional list of event categories to filter the events.
 	Category []Event_Category `protobuf:"varint,1,rep,packed,name=category,proto3,enum=sports.Event_Category" json:"category,omitempty"`
-	// VisibleOnly indicates whether to return only visible events.
+	// VisibleOnly indicates whether to return only visible events. The events
+	// are visible as overridden for the tenant of the request, if any.
 	VisibleOnly bool `protobuf:"varint,2,opt,name=visible_only,json=visibleOnly,proto3" json:"visible_only,omitempty"`
-	// OrderBy specifies the ordering of the returned events.
-	OrderBy       []ListEventsRequest_OrderBy `protobuf:"varint,3,rep,packed,name=order_by,json=orderBy,proto3,enum=sports.ListEventsRequest_OrderBy" json:"order_by,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// OrderBy specifies the ordering of the returned events. The events
+	// positioned for the tenant of the request, if any, are returned first.
+	OrderBy []ListEventsRequest_OrderBy `protobuf:"varint,3,rep,packed,name=order_by,json=orderBy,proto3,enum=sports.ListEventsRequest_OrderBy" json:"order_by,omitempty"`
+	// ReadMask is an optional list of fields of the returned events to read.
+	// If it is not set, all fields are read. In HTTP requests it is passed as
+	// the "fields" query parameter, for example "fields=id,name".
+	ReadMask *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=read_mask,json=fields,proto3" json:"read_mask,omitempty"`
+	// CompetitionId is an optional list of competition IDs to filter the events.
+	CompetitionId []int64 `protobuf:"varint,5,rep,packed,name=competition_id,json=competitionId,proto3" json:"competition_id,omitempty"`
+	// ParticipantId is an optional list of participant IDs to filter the
+	// events. An event matches if any of its participants is in the list.
+	ParticipantId []int64 `protobuf:"varint,6,rep,packed,name=participant_id,json=participantId,proto3" json:"participant_id,omitempty"`
+	// LocalDate is an optional date in the YYYY-MM-DD format to filter the
+	// events. An event matches if it is advertised to start on that date in the
+	// timezone of its competition's venue.
+	LocalDate string `protobuf:"bytes,7,opt,name=local_date,json=localDate,proto3" json:"local_date,omitempty"`
+	// AsOf is an optional moment to compute the statuses of the returned events
+	// at, to see them as customers saw them at that moment. It can only be set
+	// by admins.
+	AsOf *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	// IncludeArchived indicates whether to return archived events as well.
+	// Archived events are not returned by default.
+	IncludeArchived bool `protobuf:"varint,9,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ListEventsRequest) Reset() {
@@ -361,6 +569,48 @@ func (x *ListEventsRequest) GetOrderBy() []ListEventsRequest_OrderBy {
 	return nil
 }
 
+func (x *ListEventsRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetCompetitionId() []int64 {
+	if x != nil {
+		return x.CompetitionId
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetParticipantId() []int64 {
+	if x != nil {
+		return x.ParticipantId
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetLocalDate() string {
+	if x != nil {
+		return x.LocalDate
+	}
+	return ""
+}
+
+func (x *ListEventsRequest) GetAsOf() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AsOf
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
 // ListEventsResponse represents a response to the ListEvents call.
 type ListEventsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -411,9 +661,16 @@ func (x *ListEventsResponse) GetEvents() []*Event {
 type GetEventRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The ID of the sports event to retrieve.
-	EventId       int64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	EventId int64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// ReadMask is an optional list of fields of the returned event to read.
+	// If it is not set, all fields are read. In HTTP requests it is passed as
+	// the "fields" query parameter, for example "fields=id,name".
+	ReadMask *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=fields,proto3" json:"read_mask,omitempty"`
+	// IncludeArchived indicates whether to return the event if it is archived.
+	// Archived events are not returned by default.
+	IncludeArchived bool `protobuf:"varint,3,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetEventRequest) Reset() {
@@ -453,6 +710,212 @@ func (x *GetEventRequest) GetEventId() int64 {
 	return 0
 }
 
+func (x *GetEventRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *GetEventRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+// GetEventByExternalIdRequest represents a request for the
+// GetEventByExternalId call.
+type GetEventByExternalIdRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Provider is the name of the provider the sports event was ingested from.
+	Provider string `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`
+	// ExternalId is the ID assigned to the sports event by the provider.
+	ExternalId string `protobuf:"bytes,2,opt,name=external_id,json=externalId,proto3" json:"external_id,omitempty"`
+	// ReadMask is an optional list of fields of the returned event to read.
+	// If it is not set, all fields are read. In HTTP requests it is passed as
+	// the "fields" query parameter, for example "fields=id,name".
+	ReadMask *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=read_mask,json=fields,proto3" json:"read_mask,omitempty"`
+	// IncludeArchived indicates whether to return the event if it is archived.
+	// Archived events are not returned by default.
+	IncludeArchived bool `protobuf:"varint,4,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetEventByExternalIdRequest) Reset() {
+	*x = GetEventByExternalIdRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventByExternalIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventByExternalIdRequest) ProtoMessage() {}
+
+func (x *GetEventByExternalIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventByExternalIdRequest.ProtoReflect.Descriptor instead.
+func (*GetEventByExternalIdRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetEventByExternalIdRequest) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *GetEventByExternalIdRequest) GetExternalId() string {
+	if x != nil {
+		return x.ExternalId
+	}
+	return ""
+}
+
+func (x *GetEventByExternalIdRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+func (x *GetEventByExternalIdRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+// BatchGetEventsRequest represents a request for the BatchGetEvents call.
+type BatchGetEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// EventId is a list of IDs of the sports events to retrieve. The maximum
+	// number of IDs is limited by the service configuration.
+	EventId []int64 `protobuf:"varint,1,rep,packed,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// IncludeArchived indicates whether to return archived events as well.
+	// Archived events are not returned by default.
+	IncludeArchived bool `protobuf:"varint,2,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BatchGetEventsRequest) Reset() {
+	*x = BatchGetEventsRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetEventsRequest) ProtoMessage() {}
+
+func (x *BatchGetEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetEventsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchGetEventsRequest) GetEventId() []int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return nil
+}
+
+func (x *BatchGetEventsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+// BatchGetEventsResponse represents a response to the BatchGetEvents call.
+type BatchGetEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Events is a list of the found sports events, in the order of the
+	// requested IDs.
+	Events []*Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// MissingEventId is a list of the requested IDs for which no sports event
+	// was found, including the events that cannot be offered in the
+	// jurisdiction of the request.
+	MissingEventId []int64 `protobuf:"varint,2,rep,packed,name=missing_event_id,json=missingEventId,proto3" json:"missing_event_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BatchGetEventsResponse) Reset() {
+	*x = BatchGetEventsResponse{}
+	mi := &file_api_sports_sports_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetEventsResponse) ProtoMessage() {}
+
+func (x *BatchGetEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetEventsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetEventsResponse) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchGetEventsResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *BatchGetEventsResponse) GetMissingEventId() []int64 {
+	if x != nil {
+		return x.MissingEventId
+	}
+	return nil
+}
+
 // Event represents a sports event.
 type Event struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -461,21 +924,51 @@ type Event struct {
 	// Name is the official name given to the event.
 	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
 	// Category represents the category of the event.
-	Category    Event_Category `protobuf:"varint,4,opt,name=category,proto3,enum=sports.Event_Category" json:"category,omitempty"`
-	Competition string         `protobuf:"bytes,5,opt,name=competition,proto3" json:"competition,omitempty"`
-	// Visible represents whether or not the event is visible.
+	Category Event_Category `protobuf:"varint,4,opt,name=category,proto3,enum=sports.Event_Category" json:"category,omitempty"`
+	// Competition is the name of the competition the event is part of.
+	Competition string `protobuf:"bytes,5,opt,name=competition,proto3" json:"competition,omitempty"`
+	// Visible represents whether or not the event is visible. It reflects the
+	// visibility overridden for the tenant of the request, if any.
 	Visible bool `protobuf:"varint,6,opt,name=visible,proto3" json:"visible,omitempty"`
 	// AdvertisedStartTime is the time the event is advertised to run.
 	AdvertisedStartTime *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=advertised_start_time,json=advertisedStartTime,proto3" json:"advertised_start_time,omitempty"`
 	// Status represents the current status of the event.
-	Status        Event_Status `protobuf:"varint,8,opt,name=status,proto3,enum=sports.Event_Status" json:"status,omitempty"`
+	Status Event_Status `protobuf:"varint,8,opt,name=status,proto3,enum=sports.Event_Status" json:"status,omitempty"`
+	// CompetitionId is the ID of the competition the event is part of. It is
+	// zero if the competition is not known.
+	CompetitionId int64 `protobuf:"varint,9,opt,name=competition_id,json=competitionId,proto3" json:"competition_id,omitempty"`
+	// HomeParticipantId is the ID of the participant listed first in the event
+	// name. It is zero if the participants of the event are not known.
+	HomeParticipantId int64 `protobuf:"varint,10,opt,name=home_participant_id,json=homeParticipantId,proto3" json:"home_participant_id,omitempty"`
+	// AwayParticipantId is the ID of the participant listed second in the event
+	// name. It is zero if the participants of the event are not known.
+	AwayParticipantId int64 `protobuf:"varint,11,opt,name=away_participant_id,json=awayParticipantId,proto3" json:"away_participant_id,omitempty"`
+	// MatchState represents the current state of the match.
+	MatchState Event_MatchState `protobuf:"varint,12,opt,name=match_state,json=matchState,proto3,enum=sports.Event_MatchState" json:"match_state,omitempty"`
+	// Scores is a list of scores of the match per period, ordered by period.
+	Scores []*PeriodScore `protobuf:"bytes,13,rep,name=scores,proto3" json:"scores,omitempty"`
+	// Timezone is the IANA timezone of the venue of the competition, for
+	// example "Australia/Melbourne". It is "UTC" if the timezone of the venue is
+	// not known.
+	Timezone string `protobuf:"bytes,14,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// LocalAdvertisedStartTime is the time the event is advertised to run in
+	// the timezone of the venue, formatted as RFC 3339 with the local UTC
+	// offset, for example "2025-09-27T14:30:00+10:00".
+	LocalAdvertisedStartTime string `protobuf:"bytes,15,opt,name=local_advertised_start_time,json=localAdvertisedStartTime,proto3" json:"local_advertised_start_time,omitempty"`
+	// ArchiveTime is the time the event was archived by the retention policy.
+	// It is not set if the event is not archived.
+	ArchiveTime *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=archive_time,json=archiveTime,proto3" json:"archive_time,omitempty"`
+	// Etag is an opaque identifier of the current version of the event. It
+	// changes every time the event, its scores or its match state are changed,
+	// and must be passed to the requests updating or deleting the event.
+	Etag          string `protobuf:"bytes,17,opt,name=etag,proto3" json:"etag,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Event) Reset() {
 	*x = Event{}
-	mi := &file_api_sports_sports_proto_msgTypes[3]
+	mi := &file_api_sports_sports_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -487,7 +980,7 @@ func (x *Event) String() string {
 func (*Event) ProtoMessage() {}
 
 func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_api_sports_sports_proto_msgTypes[3]
+	mi := &file_api_sports_sports_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -500,7 +993,7 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Event.ProtoReflect.Descriptor instead.
 func (*Event) Descriptor() ([]byte, []int) {
-	return file_api_sports_sports_proto_rawDescGZIP(), []int{3}
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Event) GetId() int64 {
@@ -552,35 +1045,1944 @@ func (x *Event) GetStatus() Event_Status {
 	return Event_UNSPECIFIED_STATUS
 }
 
-var File_api_sports_sports_proto protoreflect.FileDescriptor
+func (x *Event) GetCompetitionId() int64 {
+	if x != nil {
+		return x.CompetitionId
+	}
+	return 0
+}
 
-const file_api_sports_sports_proto_rawDesc = "" +
-	"\n" +
-	"\x17api/sports/sports.proto\x12\x06sports\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\"\xcc\x02\n" +
-	"\x11ListEventsRequest\x122\n" +
-	"\bcategory\x18\x01 \x03(\x0e2\x16.sports.Event.CategoryR\bcategory\x12!\n" +
-	"\fvisible_only\x18\x02 \x01(\bR\vvisibleOnly\x12<\n" +
-	"\border_by\x18\x03 \x03(\x0e2!.sports.ListEventsRequest.OrderByR\aorderBy\"\xa1\x01\n" +
-	"\aOrderBy\x12\x0f\n" +
-	"\vUNSPECIFIED\x10\x00\x12\x1d\n" +
-	"\x19ADVERTISED_START_TIME_ASC\x10\x01\x12\x1e\n" +
-	"\x1aADVERTISED_START_TIME_DESC\x10\x02\x12\f\n" +
-	"\bNAME_ASC\x10\x03\x12\r\n" +
-	"\tNAME_DESC\x10\x04\x12\x13\n" +
-	"\x0fCOMPETITION_ASC\x10\x05\x12\x14\n" +
-	"\x10COMPETITION_DESC\x10\x06\";\n" +
-	"\x12ListEventsResponse\x12%\n" +
-	"\x06events\x18\x01 \x03(\v2\r.sports.EventR\x06events\",\n" +
-	"\x0fGetEventRequest\x12\x19\n" +
-	"\bevent_id\x18\x01 \x01(\x03R\aeventId\"\x90\x06\n" +
-	"\x05Event\x12\x0e\n" +
+func (x *Event) GetHomeParticipantId() int64 {
+	if x != nil {
+		return x.HomeParticipantId
+	}
+	return 0
+}
+
+func (x *Event) GetAwayParticipantId() int64 {
+	if x != nil {
+		return x.AwayParticipantId
+	}
+	return 0
+}
+
+func (x *Event) GetMatchState() Event_MatchState {
+	if x != nil {
+		return x.MatchState
+	}
+	return Event_UNSPECIFIED_MATCH_STATE
+}
+
+func (x *Event) GetScores() []*PeriodScore {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *Event) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Event) GetLocalAdvertisedStartTime() string {
+	if x != nil {
+		return x.LocalAdvertisedStartTime
+	}
+	return ""
+}
+
+func (x *Event) GetArchiveTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchiveTime
+	}
+	return nil
+}
+
+func (x *Event) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// UpdateEventRequest represents a request for the UpdateEvent call.
+type UpdateEventRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Event is the sports event to update, identified by its ID. Only the fields
+	// listed in the update mask are updated.
+	Event *Event `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	// UpdateMask is a list of the fields of the event to update. The name,
+	// category, visible and advertised_start_time fields can be updated. If the
+	// mask is not set, all of them are updated.
+	UpdateMask *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// Etag is the etag of the event the update is based on. The update fails if
+	// the event has been changed since. If it is not set, the etag is taken from
+	// the "if-match" metadata of the request.
+	Etag          string `protobuf:"bytes,3,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateEventRequest) Reset() {
+	*x = UpdateEventRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateEventRequest) ProtoMessage() {}
+
+func (x *UpdateEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateEventRequest.ProtoReflect.Descriptor instead.
+func (*UpdateEventRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateEventRequest) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *UpdateEventRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateEventRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// DeleteEventRequest represents a request for the DeleteEvent call.
+type DeleteEventRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the sports event to delete.
+	EventId int64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Etag is the etag of the event the deletion is based on. The deletion fails
+	// if the event has been changed since. If it is not set, the etag is taken
+	// from the "if-match" metadata of the request.
+	Etag          string `protobuf:"bytes,2,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteEventRequest) Reset() {
+	*x = DeleteEventRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteEventRequest) ProtoMessage() {}
+
+func (x *DeleteEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteEventRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEventRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteEventRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *DeleteEventRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// UpdateScoreRequest represents a request for the UpdateScore call.
+type UpdateScoreRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the sports event to update.
+	EventId int64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// MatchState is the new state of the match. If it is not set, the state is
+	// left unchanged.
+	MatchState Event_MatchState `protobuf:"varint,2,opt,name=match_state,json=matchState,proto3,enum=sports.Event_MatchState" json:"match_state,omitempty"`
+	// Scores is a list of scores of the periods to update. The scores of the
+	// periods that are not listed are left unchanged.
+	Scores []*PeriodScore `protobuf:"bytes,3,rep,name=scores,proto3" json:"scores,omitempty"`
+	// Etag is the etag of the event the update is based on. The update fails if
+	// the event has been changed since. If it is not set, the etag is taken from
+	// the "if-match" metadata of the request.
+	Etag          string `protobuf:"bytes,4,opt,name=etag,proto3" json:"etag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateScoreRequest) Reset() {
+	*x = UpdateScoreRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateScoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScoreRequest) ProtoMessage() {}
+
+func (x *UpdateScoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateScoreRequest.ProtoReflect.Descriptor instead.
+func (*UpdateScoreRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateScoreRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *UpdateScoreRequest) GetMatchState() Event_MatchState {
+	if x != nil {
+		return x.MatchState
+	}
+	return Event_UNSPECIFIED_MATCH_STATE
+}
+
+func (x *UpdateScoreRequest) GetScores() []*PeriodScore {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *UpdateScoreRequest) GetEtag() string {
+	if x != nil {
+		return x.Etag
+	}
+	return ""
+}
+
+// WatchEventRequest represents a request for the WatchEvent call.
+type WatchEventRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The ID of the sports event to watch.
+	EventId       int64 `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchEventRequest) Reset() {
+	*x = WatchEventRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventRequest) ProtoMessage() {}
+
+func (x *WatchEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchEventRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+// PeriodScore represents the score of a single period of a match, for example
+// a half, a quarter or a set.
+type PeriodScore struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Period is the number of the period, starting from 1.
+	Period int32 `protobuf:"varint,1,opt,name=period,proto3" json:"period,omitempty"`
+	// Home is the score of the home participant in the period.
+	Home int32 `protobuf:"varint,2,opt,name=home,proto3" json:"home,omitempty"`
+	// Away is the score of the away participant in the period.
+	Away          int32 `protobuf:"varint,3,opt,name=away,proto3" json:"away,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeriodScore) Reset() {
+	*x = PeriodScore{}
+	mi := &file_api_sports_sports_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeriodScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeriodScore) ProtoMessage() {}
+
+func (x *PeriodScore) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeriodScore.ProtoReflect.Descriptor instead.
+func (*PeriodScore) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PeriodScore) GetPeriod() int32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+func (x *PeriodScore) GetHome() int32 {
+	if x != nil {
+		return x.Home
+	}
+	return 0
+}
+
+func (x *PeriodScore) GetAway() int32 {
+	if x != nil {
+		return x.Away
+	}
+	return 0
+}
+
+// ListCompetitionsRequest represents a request for the ListCompetitions call.
+type ListCompetitionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Category is an optional list of categories to filter the competitions.
+	Category      []Event_Category `protobuf:"varint,1,rep,packed,name=category,proto3,enum=sports.Event_Category" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCompetitionsRequest) Reset() {
+	*x = ListCompetitionsRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCompetitionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCompetitionsRequest) ProtoMessage() {}
+
+func (x *ListCompetitionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCompetitionsRequest.ProtoReflect.Descriptor instead.
+func (*ListCompetitionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListCompetitionsRequest) GetCategory() []Event_Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+// ListCompetitionsResponse represents a response to the ListCompetitions call.
+type ListCompetitionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Competitions is a list of competitions, ordered by name.
+	Competitions  []*Competition `protobuf:"bytes,1,rep,name=competitions,proto3" json:"competitions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCompetitionsResponse) Reset() {
+	*x = ListCompetitionsResponse{}
+	mi := &file_api_sports_sports_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCompetitionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCompetitionsResponse) ProtoMessage() {}
+
+func (x *ListCompetitionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCompetitionsResponse.ProtoReflect.Descriptor instead.
+func (*ListCompetitionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListCompetitionsResponse) GetCompetitions() []*Competition {
+	if x != nil {
+		return x.Competitions
+	}
+	return nil
+}
+
+// ListParticipantsRequest represents a request for the ListParticipants call.
+type ListParticipantsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Category is an optional list of categories to filter the participants.
+	Category []Event_Category `protobuf:"varint,1,rep,packed,name=category,proto3,enum=sports.Event_Category" json:"category,omitempty"`
+	// CompetitionId is an optional list of competition IDs to filter the
+	// participants. A participant matches if it takes part in any event of the
+	// listed competitions.
+	CompetitionId []int64 `protobuf:"varint,2,rep,packed,name=competition_id,json=competitionId,proto3" json:"competition_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListParticipantsRequest) Reset() {
+	*x = ListParticipantsRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListParticipantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListParticipantsRequest) ProtoMessage() {}
+
+func (x *ListParticipantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListParticipantsRequest.ProtoReflect.Descriptor instead.
+func (*ListParticipantsRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListParticipantsRequest) GetCategory() []Event_Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *ListParticipantsRequest) GetCompetitionId() []int64 {
+	if x != nil {
+		return x.CompetitionId
+	}
+	return nil
+}
+
+// ListParticipantsResponse represents a response to the ListParticipants call.
+type ListParticipantsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Participants is a list of participants, ordered by name.
+	Participants  []*Participant `protobuf:"bytes,1,rep,name=participants,proto3" json:"participants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListParticipantsResponse) Reset() {
+	*x = ListParticipantsResponse{}
+	mi := &file_api_sports_sports_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListParticipantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListParticipantsResponse) ProtoMessage() {}
+
+func (x *ListParticipantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListParticipantsResponse.ProtoReflect.Descriptor instead.
+func (*ListParticipantsResponse) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListParticipantsResponse) GetParticipants() []*Participant {
+	if x != nil {
+		return x.Participants
+	}
+	return nil
+}
+
+// Competition represents a competition sports events are part of, for example
+// a league or a tournament.
+type Competition struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID represents a unique identifier for the competition.
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name is the official name of the competition.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Category represents the category of the competition.
+	Category Event_Category `protobuf:"varint,3,opt,name=category,proto3,enum=sports.Event_Category" json:"category,omitempty"`
+	// Timezone is the IANA timezone of the venue of the competition, for
+	// example "Australia/Melbourne". It is empty if it is not known.
+	Timezone      string `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Competition) Reset() {
+	*x = Competition{}
+	mi := &file_api_sports_sports_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Competition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Competition) ProtoMessage() {}
+
+func (x *Competition) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Competition.ProtoReflect.Descriptor instead.
+func (*Competition) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Competition) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Competition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Competition) GetCategory() Event_Category {
+	if x != nil {
+		return x.Category
+	}
+	return Event_UNSPECIFIED_CATEGORY
+}
+
+func (x *Competition) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// Participant represents a team or an individual taking part in sports events.
+type Participant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID represents a unique identifier for the participant.
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name is the name of the participant.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Category represents the category the participant competes in.
+	Category      Event_Category `protobuf:"varint,3,opt,name=category,proto3,enum=sports.Event_Category" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Participant) Reset() {
+	*x = Participant{}
+	mi := &file_api_sports_sports_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Participant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Participant) ProtoMessage() {}
+
+func (x *Participant) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Participant.ProtoReflect.Descriptor instead.
+func (*Participant) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Participant) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Participant) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Participant) GetCategory() Event_Category {
+	if x != nil {
+		return x.Category
+	}
+	return Event_UNSPECIFIED_CATEGORY
+}
+
+// ImportEventsRequest represents a message of the ImportEvents stream.
+type ImportEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Event is the sports event to import. An event without an ID is created,
+	// otherwise the event with the ID is updated. The name, category,
+	// competition, visible and advertised_start_time fields are imported, and
+	// the other fields are ignored. If the etag is set, the event is only
+	// updated if it has not been changed since.
+	Event *Event `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	// Options are the options of the import. Only the options of the first
+	// message of the stream are used.
+	Options       *ImportOptions `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportEventsRequest) Reset() {
+	*x = ImportEventsRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportEventsRequest) ProtoMessage() {}
+
+func (x *ImportEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportEventsRequest.ProtoReflect.Descriptor instead.
+func (*ImportEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ImportEventsRequest) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *ImportEventsRequest) GetOptions() *ImportOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+// ImportOptions represents the options of an import.
+type ImportOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// DryRun indicates whether to only validate the imported rows. The response
+	// reports the outcome the import would have, without changing anything.
+	DryRun bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// AllOrNothing indicates whether to import no rows at all if any row is
+	// invalid. Otherwise, the valid rows are imported and the invalid ones are
+	// skipped.
+	AllOrNothing  bool `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportOptions) Reset() {
+	*x = ImportOptions{}
+	mi := &file_api_sports_sports_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportOptions) ProtoMessage() {}
+
+func (x *ImportOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportOptions.ProtoReflect.Descriptor instead.
+func (*ImportOptions) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ImportOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportOptions) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+// ImportEventsResponse represents a response to the ImportEvents call.
+type ImportEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Created is the number of created events.
+	Created int64 `protobuf:"varint,1,opt,name=created,proto3" json:"created,omitempty"`
+	// Updated is the number of updated events.
+	Updated int64 `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	// Unchanged is the number of existing events imported as they were.
+	Unchanged int64 `protobuf:"varint,3,opt,name=unchanged,proto3" json:"unchanged,omitempty"`
+	// Errors is a list of the errors of the invalid rows, which are not
+	// imported.
+	Errors []*ImportError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	// Committed indicates whether the import has been committed. It is false
+	// for dry runs, and for all-or-nothing imports with invalid rows.
+	Committed     bool `protobuf:"varint,5,opt,name=committed,proto3" json:"committed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportEventsResponse) Reset() {
+	*x = ImportEventsResponse{}
+	mi := &file_api_sports_sports_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportEventsResponse) ProtoMessage() {}
+
+func (x *ImportEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportEventsResponse.ProtoReflect.Descriptor instead.
+func (*ImportEventsResponse) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ImportEventsResponse) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportEventsResponse) GetUpdated() int64 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *ImportEventsResponse) GetUnchanged() int64 {
+	if x != nil {
+		return x.Unchanged
+	}
+	return 0
+}
+
+func (x *ImportEventsResponse) GetErrors() []*ImportError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportEventsResponse) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+// ImportError represents an error of an invalid row of an import.
+type ImportError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Row is the 1-based position of the invalid row in the stream, not
+	// counting the messages without a row.
+	Row int64 `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	// Field is the name of the invalid field of the row, if the error is
+	// specific to a field, for example "category".
+	Field string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	// Description is a human-readable description of the error.
+	Description   string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportError) Reset() {
+	*x = ImportError{}
+	mi := &file_api_sports_sports_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportError) ProtoMessage() {}
+
+func (x *ImportError) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportError.ProtoReflect.Descriptor instead.
+func (*ImportError) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ImportError) GetRow() int64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ImportError) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// ExportEventsRequest represents a request for the ExportEvents call.
+type ExportEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Category is an optional list of event categories to filter the events.
+	Category []Event_Category `protobuf:"varint,1,rep,packed,name=category,proto3,enum=sports.Event_Category" json:"category,omitempty"`
+	// VisibleOnly indicates whether to export only visible events.
+	VisibleOnly bool `protobuf:"varint,2,opt,name=visible_only,json=visibleOnly,proto3" json:"visible_only,omitempty"`
+	// CompetitionId is an optional list of competition IDs to filter the events.
+	CompetitionId []int64 `protobuf:"varint,3,rep,packed,name=competition_id,json=competitionId,proto3" json:"competition_id,omitempty"`
+	// ParticipantId is an optional list of participant IDs to filter the
+	// events. An event matches if any of its participants is in the list.
+	ParticipantId []int64 `protobuf:"varint,4,rep,packed,name=participant_id,json=participantId,proto3" json:"participant_id,omitempty"`
+	// LocalDate is an optional date in the YYYY-MM-DD format to filter the
+	// events. An event matches if it is advertised to start on that date in the
+	// timezone of its competition's venue.
+	LocalDate string `protobuf:"bytes,5,opt,name=local_date,json=localDate,proto3" json:"local_date,omitempty"`
+	// IncludeArchived indicates whether to export archived events as well.
+	IncludeArchived bool `protobuf:"varint,6,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ExportEventsRequest) Reset() {
+	*x = ExportEventsRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportEventsRequest) ProtoMessage() {}
+
+func (x *ExportEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportEventsRequest.ProtoReflect.Descriptor instead.
+func (*ExportEventsRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ExportEventsRequest) GetCategory() []Event_Category {
+	if x != nil {
+		return x.Category
+	}
+	return nil
+}
+
+func (x *ExportEventsRequest) GetVisibleOnly() bool {
+	if x != nil {
+		return x.VisibleOnly
+	}
+	return false
+}
+
+func (x *ExportEventsRequest) GetCompetitionId() []int64 {
+	if x != nil {
+		return x.CompetitionId
+	}
+	return nil
+}
+
+func (x *ExportEventsRequest) GetParticipantId() []int64 {
+	if x != nil {
+		return x.ParticipantId
+	}
+	return nil
+}
+
+func (x *ExportEventsRequest) GetLocalDate() string {
+	if x != nil {
+		return x.LocalDate
+	}
+	return ""
+}
+
+func (x *ExportEventsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+// ListChangesRequest represents a request for the ListChanges call.
+type ListChangesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Cursor is an optional position in the change log to list the changes
+	// after, as returned in the next_cursor field of a previous response. If it
+	// is not set, the changes are listed from the beginning of the change log.
+	Cursor string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// PageSize is the maximum number of changes to return. If it is not set,
+	// at most 100 changes are returned.
+	PageSize      int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChangesRequest) Reset() {
+	*x = ListChangesRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChangesRequest) ProtoMessage() {}
+
+func (x *ListChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListChangesRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListChangesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListChangesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListChangesResponse represents a response to the ListChanges call.
+type ListChangesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Changes is a list of changes of sports events in the order they were
+	// made.
+	Changes []*Change `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	// NextCursor is the cursor to list the changes made after the returned
+	// ones. It is set even if there are no more changes yet, so that the
+	// changes can be polled for.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChangesResponse) Reset() {
+	*x = ListChangesResponse{}
+	mi := &file_api_sports_sports_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChangesResponse) ProtoMessage() {}
+
+func (x *ListChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChangesResponse.ProtoReflect.Descriptor instead.
+func (*ListChangesResponse) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListChangesResponse) GetChanges() []*Change {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ListChangesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// Change represents a change of a sports event.
+type Change struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sequence is the position of the change in the change log. Changes made
+	// later have greater sequence numbers.
+	Sequence int64 `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// Operation is the kind of the change.
+	Operation Change_Operation `protobuf:"varint,2,opt,name=operation,proto3,enum=sports.Change_Operation" json:"operation,omitempty"`
+	// ChangeTime is the time the change was made.
+	ChangeTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=change_time,json=changeTime,proto3" json:"change_time,omitempty"`
+	// Event is the sports event right after the change, or right before it was
+	// deleted. Its status is not set, as it depends on the time the event is
+	// read at rather than on the change, and neither is its etag.
+	Event         *Event `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Change) Reset() {
+	*x = Change{}
+	mi := &file_api_sports_sports_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Change) ProtoMessage() {}
+
+func (x *Change) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Change.ProtoReflect.Descriptor instead.
+func (*Change) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Change) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Change) GetOperation() Change_Operation {
+	if x != nil {
+		return x.Operation
+	}
+	return Change_UNSPECIFIED
+}
+
+func (x *Change) GetChangeTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangeTime
+	}
+	return nil
+}
+
+func (x *Change) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+// ListAuditEntriesRequest represents a request for the ListAuditEntries call.
+type ListAuditEntriesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// EntityType is an optional filter to list only the audit entries of
+	// entities of the given type.
+	EntityType string `protobuf:"bytes,1,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	// EntityId is an optional filter to list only the audit entries of the
+	// entity with the given ID.
+	EntityId int64 `protobuf:"varint,2,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// Actor is an optional filter to list only the audit entries of the changes
+	// made by the admin with the given name.
+	Actor string `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	// StartTime is an optional filter to list only the audit entries of the
+	// changes made at or after the given time.
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// EndTime is an optional filter to list only the audit entries of the
+	// changes made before the given time.
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// Cursor is an optional position in the audit log to list the entries
+	// after, as returned in the next_cursor field of a previous response. If it
+	// is not set, the entries are listed from the beginning of the audit log.
+	Cursor string `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// PageSize is the maximum number of entries to return. If it is not set,
+	// at most 100 entries are returned.
+	PageSize      int32 `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEntriesRequest) Reset() {
+	*x = ListAuditEntriesRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEntriesRequest) ProtoMessage() {}
+
+func (x *ListAuditEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListAuditEntriesRequest) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *ListAuditEntriesRequest) GetEntityId() int64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *ListAuditEntriesRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ListAuditEntriesRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *ListAuditEntriesRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *ListAuditEntriesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListAuditEntriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ListAuditEntriesResponse represents a response to the ListAuditEntries
+// call.
+type ListAuditEntriesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Entries is a list of audit entries in the order the changes were made.
+	Entries []*AuditEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// NextCursor is the cursor to list the entries after the returned ones. It
+	// is set even if there are no more entries yet.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEntriesResponse) Reset() {
+	*x = ListAuditEntriesResponse{}
+	mi := &file_api_sports_sports_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEntriesResponse) ProtoMessage() {}
+
+func (x *ListAuditEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListAuditEntriesResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListAuditEntriesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// AuditEntry represents a change made to an entity through the API.
+type AuditEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Id is the position of the entry in the audit log. Changes made later
+	// have greater IDs.
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Actor is the name of the admin who made the change, or empty if the
+	// change was made by a client that is not an admin.
+	Actor string `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	// Method is the full name of the RPC the change was made by, such as
+	// "/sports.Sports/UpdateScore".
+	Method string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	// EntityType is the type of the changed entity, such as "event".
+	EntityType string `protobuf:"bytes,4,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	// EntityId is the ID of the changed entity.
+	EntityId int64 `protobuf:"varint,5,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	// EntryTime is the time the change was made.
+	EntryTime *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=entry_time,json=entryTime,proto3" json:"entry_time,omitempty"`
+	// Changes is a list of the changed fields of the entity, ordered by their
+	// names.
+	Changes       []*FieldChange `protobuf:"bytes,7,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_api_sports_sports_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AuditEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetEntityId() int64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetEntryTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EntryTime
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// FieldChange represents a change of a single field of an entity.
+type FieldChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Field is the name of the field, as it is encoded in JSON.
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// Before is the value of the field before the change. It is not set if the
+	// field was not set.
+	Before *structpb.Value `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	// After is the value of the field after the change. It is not set if the
+	// field is not set anymore.
+	After         *structpb.Value `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_api_sports_sports_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetBefore() *structpb.Value {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *FieldChange) GetAfter() *structpb.Value {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+// TenantOverride represents the override of the visibility and the ordering
+// of a sports event for a tenant.
+type TenantOverride struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tenant is the name of the tenant the override applies to.
+	Tenant string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// EventId is the ID of the sports event the override applies to.
+	EventId int64 `protobuf:"varint,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// Visibility overrides the visible field of the event for the tenant.
+	Visibility TenantOverride_Visibility `protobuf:"varint,3,opt,name=visibility,proto3,enum=sports.TenantOverride_Visibility" json:"visibility,omitempty"`
+	// Position is the position of the event in the lists of events of the
+	// tenant, starting at 1. Events with a position are listed before the
+	// others, in the order of their positions. If it is not set, the event is
+	// listed in the requested order.
+	Position      int32 `protobuf:"varint,4,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TenantOverride) Reset() {
+	*x = TenantOverride{}
+	mi := &file_api_sports_sports_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TenantOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantOverride) ProtoMessage() {}
+
+func (x *TenantOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantOverride.ProtoReflect.Descriptor instead.
+func (*TenantOverride) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TenantOverride) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *TenantOverride) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *TenantOverride) GetVisibility() TenantOverride_Visibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return TenantOverride_UNSPECIFIED
+}
+
+func (x *TenantOverride) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+// ListTenantOverridesRequest represents a request for the ListTenantOverrides
+// call.
+type ListTenantOverridesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tenant is the name of the tenant to list the overrides of.
+	Tenant        string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantOverridesRequest) Reset() {
+	*x = ListTenantOverridesRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantOverridesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantOverridesRequest) ProtoMessage() {}
+
+func (x *ListTenantOverridesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantOverridesRequest.ProtoReflect.Descriptor instead.
+func (*ListTenantOverridesRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListTenantOverridesRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+// ListTenantOverridesResponse represents a response to the
+// ListTenantOverrides call.
+type ListTenantOverridesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Overrides is a list of the overrides of the tenant.
+	Overrides     []*TenantOverride `protobuf:"bytes,1,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTenantOverridesResponse) Reset() {
+	*x = ListTenantOverridesResponse{}
+	mi := &file_api_sports_sports_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTenantOverridesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTenantOverridesResponse) ProtoMessage() {}
+
+func (x *ListTenantOverridesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTenantOverridesResponse.ProtoReflect.Descriptor instead.
+func (*ListTenantOverridesResponse) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListTenantOverridesResponse) GetOverrides() []*TenantOverride {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
+// SetTenantOverrideRequest represents a request for the SetTenantOverride
+// call.
+type SetTenantOverrideRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Override is the override to set. At least one of its visibility and
+	// position must be set.
+	Override      *TenantOverride `protobuf:"bytes,1,opt,name=override,proto3" json:"override,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTenantOverrideRequest) Reset() {
+	*x = SetTenantOverrideRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTenantOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTenantOverrideRequest) ProtoMessage() {}
+
+func (x *SetTenantOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTenantOverrideRequest.ProtoReflect.Descriptor instead.
+func (*SetTenantOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SetTenantOverrideRequest) GetOverride() *TenantOverride {
+	if x != nil {
+		return x.Override
+	}
+	return nil
+}
+
+// DeleteTenantOverrideRequest represents a request for the
+// DeleteTenantOverride call.
+type DeleteTenantOverrideRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tenant is the name of the tenant to delete the override of.
+	Tenant string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	// EventId is the ID of the sports event to delete the override of.
+	EventId       int64 `protobuf:"varint,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTenantOverrideRequest) Reset() {
+	*x = DeleteTenantOverrideRequest{}
+	mi := &file_api_sports_sports_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTenantOverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTenantOverrideRequest) ProtoMessage() {}
+
+func (x *DeleteTenantOverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_sports_sports_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTenantOverrideRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTenantOverrideRequest) Descriptor() ([]byte, []int) {
+	return file_api_sports_sports_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteTenantOverrideRequest) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *DeleteTenantOverrideRequest) GetEventId() int64 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+var File_api_sports_sports_proto protoreflect.FileDescriptor
+
+const file_api_sports_sports_proto_rawDesc = "" +
+	"\n" +
+	"\x17api/sports/sports.proto\x12\x06sports\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a\x1bbuf/validate/validate.proto\"\xba\x05\n" +
+	"\x11ListEventsRequest\x12E\n" +
+	"\bcategory\x18\x01 \x03(\x0e2\x16.sports.Event.CategoryB\x11\xbaH\x0e\x92\x01\v\x18\x01\"\a\x82\x01\x04\x10\x01 \x00R\bcategory\x12!\n" +
+	"\fvisible_only\x18\x02 \x01(\bR\vvisibleOnly\x12O\n" +
+	"\border_by\x18\x03 \x03(\x0e2!.sports.ListEventsRequest.OrderByB\x11\xbaH\x0e\x92\x01\v\x18\x01\"\a\x82\x01\x04\x10\x01 \x00R\aorderBy\x125\n" +
+	"\tread_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\x06fields\x125\n" +
+	"\x0ecompetition_id\x18\x05 \x03(\x03B\x0e\xbaH\v\x92\x01\b\x18\x01\"\x04\"\x02 \x00R\rcompetitionId\x125\n" +
+	"\x0eparticipant_id\x18\x06 \x03(\x03B\x0e\xbaH\v\x92\x01\b\x18\x01\"\x04\"\x02 \x00R\rparticipantId\x12E\n" +
+	"\n" +
+	"local_date\x18\a \x01(\tB&\xbaH#\xd8\x01\x01r\x1e2\x1c^[0-9]{4}-[0-9]{2}-[0-9]{2}$R\tlocalDate\x12/\n" +
+	"\x05as_of\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x04asOf\x12)\n" +
+	"\x10include_archived\x18\t \x01(\bR\x0fincludeArchived\"\xa1\x01\n" +
+	"\aOrderBy\x12\x0f\n" +
+	"\vUNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19ADVERTISED_START_TIME_ASC\x10\x01\x12\x1e\n" +
+	"\x1aADVERTISED_START_TIME_DESC\x10\x02\x12\f\n" +
+	"\bNAME_ASC\x10\x03\x12\r\n" +
+	"\tNAME_DESC\x10\x04\x12\x13\n" +
+	"\x0fCOMPETITION_ASC\x10\x05\x12\x14\n" +
+	"\x10COMPETITION_DESC\x10\x06\";\n" +
+	"\x12ListEventsResponse\x12%\n" +
+	"\x06events\x18\x01 \x03(\v2\r.sports.EventR\x06events\"\x97\x01\n" +
+	"\x0fGetEventRequest\x12\"\n" +
+	"\bevent_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\aeventId\x125\n" +
+	"\tread_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\x06fields\x12)\n" +
+	"\x10include_archived\x18\x03 \x01(\bR\x0fincludeArchived\"\xce\x01\n" +
+	"\x1bGetEventByExternalIdRequest\x12#\n" +
+	"\bprovider\x18\x01 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\bprovider\x12(\n" +
+	"\vexternal_id\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x10\x01R\n" +
+	"externalId\x125\n" +
+	"\tread_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\x06fields\x12)\n" +
+	"\x10include_archived\x18\x04 \x01(\bR\x0fincludeArchived\"o\n" +
+	"\x15BatchGetEventsRequest\x12+\n" +
+	"\bevent_id\x18\x01 \x03(\x03B\x10\xbaH\r\x92\x01\n" +
+	"\b\x01\x18\x01\"\x04\"\x02 \x00R\aeventId\x12)\n" +
+	"\x10include_archived\x18\x02 \x01(\bR\x0fincludeArchived\"i\n" +
+	"\x16BatchGetEventsResponse\x12%\n" +
+	"\x06events\x18\x01 \x03(\v2\r.sports.EventR\x06events\x12(\n" +
+	"\x10missing_event_id\x18\x02 \x03(\x03R\x0emissingEventId\"\xb0\n" +
+	"\n" +
+	"\x05Event\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x122\n" +
 	"\bcategory\x18\x04 \x01(\x0e2\x16.sports.Event.CategoryR\bcategory\x12 \n" +
 	"\vcompetition\x18\x05 \x01(\tR\vcompetition\x12\x18\n" +
 	"\avisible\x18\x06 \x01(\bR\avisible\x12N\n" +
 	"\x15advertised_start_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x13advertisedStartTime\x12,\n" +
-	"\x06status\x18\b \x01(\x0e2\x14.sports.Event.StatusR\x06status\"\xbc\x03\n" +
+	"\x06status\x18\b \x01(\x0e2\x14.sports.Event.StatusR\x06status\x12%\n" +
+	"\x0ecompetition_id\x18\t \x01(\x03R\rcompetitionId\x12.\n" +
+	"\x13home_participant_id\x18\n" +
+	" \x01(\x03R\x11homeParticipantId\x12.\n" +
+	"\x13away_participant_id\x18\v \x01(\x03R\x11awayParticipantId\x129\n" +
+	"\vmatch_state\x18\f \x01(\x0e2\x18.sports.Event.MatchStateR\n" +
+	"matchState\x12+\n" +
+	"\x06scores\x18\r \x03(\v2\x13.sports.PeriodScoreR\x06scores\x12\x1a\n" +
+	"\btimezone\x18\x0e \x01(\tR\btimezone\x12=\n" +
+	"\x1blocal_advertised_start_time\x18\x0f \x01(\tR\x18localAdvertisedStartTime\x12=\n" +
+	"\farchive_time\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\varchiveTime\x12\x12\n" +
+	"\x04etag\x18\x11 \x01(\tR\x04etag\"\xbc\x03\n" +
 	"\bCategory\x12\x18\n" +
 	"\x14UNSPECIFIED_CATEGORY\x10\x00\x12\x15\n" +
 	"\x11AMERICAN_FOOTBALL\x10\x01\x12\x14\n" +
@@ -621,12 +3023,170 @@ const file_api_sports_sports_proto_rawDesc = "" +
 	"\x12UNSPECIFIED_STATUS\x10\x00\x12\b\n" +
 	"\x04OPEN\x10\x01\x12\n" +
 	"\n" +
-	"\x06CLOSED\x10\x022\xb4\x01\n" +
+	"\x06CLOSED\x10\x02\"\x80\x01\n" +
+	"\n" +
+	"MatchState\x12\x1b\n" +
+	"\x17UNSPECIFIED_MATCH_STATE\x10\x00\x12\r\n" +
+	"\tPRE_MATCH\x10\x01\x12\v\n" +
+	"\aIN_PLAY\x10\x02\x12\r\n" +
+	"\tSUSPENDED\x10\x03\x12\f\n" +
+	"\bFINISHED\x10\x04\x12\r\n" +
+	"\tPOSTPONED\x10\x05\x12\r\n" +
+	"\tCANCELLED\x10\x06\"\x92\x01\n" +
+	"\x12UpdateEventRequest\x12+\n" +
+	"\x05event\x18\x01 \x01(\v2\r.sports.EventB\x06\xbaH\x03\xc8\x01\x01R\x05event\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x12\n" +
+	"\x04etag\x18\x03 \x01(\tR\x04etag\"L\n" +
+	"\x12DeleteEventRequest\x12\"\n" +
+	"\bevent_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\aeventId\x12\x12\n" +
+	"\x04etag\x18\x02 \x01(\tR\x04etag\"\xbe\x01\n" +
+	"\x12UpdateScoreRequest\x12\"\n" +
+	"\bevent_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\aeventId\x12C\n" +
+	"\vmatch_state\x18\x02 \x01(\x0e2\x18.sports.Event.MatchStateB\b\xbaH\x05\x82\x01\x02\x10\x01R\n" +
+	"matchState\x12+\n" +
+	"\x06scores\x18\x03 \x03(\v2\x13.sports.PeriodScoreR\x06scores\x12\x12\n" +
+	"\x04etag\x18\x04 \x01(\tR\x04etag\"7\n" +
+	"\x11WatchEventRequest\x12\"\n" +
+	"\bevent_id\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\aeventId\"h\n" +
+	"\vPeriodScore\x12\x1f\n" +
+	"\x06period\x18\x01 \x01(\x05B\a\xbaH\x04\x1a\x02 \x00R\x06period\x12\x1b\n" +
+	"\x04home\x18\x02 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x04home\x12\x1b\n" +
+	"\x04away\x18\x03 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\x04away\"`\n" +
+	"\x17ListCompetitionsRequest\x12E\n" +
+	"\bcategory\x18\x01 \x03(\x0e2\x16.sports.Event.CategoryB\x11\xbaH\x0e\x92\x01\v\x18\x01\"\a\x82\x01\x04\x10\x01 \x00R\bcategory\"S\n" +
+	"\x18ListCompetitionsResponse\x127\n" +
+	"\fcompetitions\x18\x01 \x03(\v2\x13.sports.CompetitionR\fcompetitions\"\x97\x01\n" +
+	"\x17ListParticipantsRequest\x12E\n" +
+	"\bcategory\x18\x01 \x03(\x0e2\x16.sports.Event.CategoryB\x11\xbaH\x0e\x92\x01\v\x18\x01\"\a\x82\x01\x04\x10\x01 \x00R\bcategory\x125\n" +
+	"\x0ecompetition_id\x18\x02 \x03(\x03B\x0e\xbaH\v\x92\x01\b\x18\x01\"\x04\"\x02 \x00R\rcompetitionId\"S\n" +
+	"\x18ListParticipantsResponse\x127\n" +
+	"\fparticipants\x18\x01 \x03(\v2\x13.sports.ParticipantR\fparticipants\"\x81\x01\n" +
+	"\vCompetition\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x122\n" +
+	"\bcategory\x18\x03 \x01(\x0e2\x16.sports.Event.CategoryR\bcategory\x12\x1a\n" +
+	"\btimezone\x18\x04 \x01(\tR\btimezone\"e\n" +
+	"\vParticipant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x122\n" +
+	"\bcategory\x18\x03 \x01(\x0e2\x16.sports.Event.CategoryR\bcategory\"k\n" +
+	"\x13ImportEventsRequest\x12#\n" +
+	"\x05event\x18\x01 \x01(\v2\r.sports.EventR\x05event\x12/\n" +
+	"\aoptions\x18\x02 \x01(\v2\x15.sports.ImportOptionsR\aoptions\"N\n" +
+	"\rImportOptions\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12$\n" +
+	"\x0eall_or_nothing\x18\x02 \x01(\bR\fallOrNothing\"\xb3\x01\n" +
+	"\x14ImportEventsResponse\x12\x18\n" +
+	"\acreated\x18\x01 \x01(\x03R\acreated\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\x03R\aupdated\x12\x1c\n" +
+	"\tunchanged\x18\x03 \x01(\x03R\tunchanged\x12+\n" +
+	"\x06errors\x18\x04 \x03(\v2\x13.sports.ImportErrorR\x06errors\x12\x1c\n" +
+	"\tcommitted\x18\x05 \x01(\bR\tcommitted\"W\n" +
+	"\vImportError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x03R\x03row\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"\xdf\x02\n" +
+	"\x13ExportEventsRequest\x12E\n" +
+	"\bcategory\x18\x01 \x03(\x0e2\x16.sports.Event.CategoryB\x11\xbaH\x0e\x92\x01\v\x18\x01\"\a\x82\x01\x04\x10\x01 \x00R\bcategory\x12!\n" +
+	"\fvisible_only\x18\x02 \x01(\bR\vvisibleOnly\x125\n" +
+	"\x0ecompetition_id\x18\x03 \x03(\x03B\x0e\xbaH\v\x92\x01\b\x18\x01\"\x04\"\x02 \x00R\rcompetitionId\x125\n" +
+	"\x0eparticipant_id\x18\x04 \x03(\x03B\x0e\xbaH\v\x92\x01\b\x18\x01\"\x04\"\x02 \x00R\rparticipantId\x12E\n" +
+	"\n" +
+	"local_date\x18\x05 \x01(\tB&\xbaH#\xd8\x01\x01r\x1e2\x1c^[0-9]{4}-[0-9]{2}-[0-9]{2}$R\tlocalDate\x12)\n" +
+	"\x10include_archived\x18\x06 \x01(\bR\x0fincludeArchived\"U\n" +
+	"\x12ListChangesRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12'\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x00R\bpageSize\"`\n" +
+	"\x13ListChangesResponse\x12(\n" +
+	"\achanges\x18\x01 \x03(\v2\x0e.sports.ChangeR\achanges\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\x83\x02\n" +
+	"\x06Change\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x03R\bsequence\x126\n" +
+	"\toperation\x18\x02 \x01(\x0e2\x18.sports.Change.OperationR\toperation\x12;\n" +
+	"\vchange_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"changeTime\x12#\n" +
+	"\x05event\x18\x04 \x01(\v2\r.sports.EventR\x05event\"C\n" +
+	"\tOperation\x12\x0f\n" +
+	"\vUNSPECIFIED\x10\x00\x12\v\n" +
+	"\aCREATED\x10\x01\x12\v\n" +
+	"\aUPDATED\x10\x02\x12\v\n" +
+	"\aDELETED\x10\x03\"\xb9\x02\n" +
+	"\x17ListAuditEntriesRequest\x12/\n" +
+	"\ventity_type\x18\x01 \x01(\tB\x0e\xbaH\vr\tR\x00R\x05eventR\n" +
+	"entityType\x12$\n" +
+	"\tentity_id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\bentityId\x12\x14\n" +
+	"\x05actor\x18\x03 \x01(\tR\x05actor\x129\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bend_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendTime\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\x12'\n" +
+	"\tpage_size\x18\a \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x00R\bpageSize\"i\n" +
+	"\x18ListAuditEntriesResponse\x12,\n" +
+	"\aentries\x18\x01 \x03(\v2\x12.sports.AuditEntryR\aentries\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"\xf2\x01\n" +
+	"\n" +
+	"AuditEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\x12\x1f\n" +
+	"\ventity_type\x18\x04 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x05 \x01(\x03R\bentityId\x129\n" +
+	"\n" +
+	"entry_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tentryTime\x12-\n" +
+	"\achanges\x18\a \x03(\v2\x13.sports.FieldChangeR\achanges\"\x81\x01\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12.\n" +
+	"\x06before\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x06before\x12,\n" +
+	"\x05after\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x05after\"\x98\x02\n" +
+	"\x0eTenantOverride\x128\n" +
+	"\x06tenant\x18\x01 \x01(\tB \xbaH\x1dr\x1b2\x19^[a-z0-9][a-z0-9-]{0,62}$R\x06tenant\x12\"\n" +
+	"\bevent_id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\aeventId\x12K\n" +
+	"\n" +
+	"visibility\x18\x03 \x01(\x0e2!.sports.TenantOverride.VisibilityB\b\xbaH\x05\x82\x01\x02\x10\x01R\n" +
+	"visibility\x12#\n" +
+	"\bposition\x18\x04 \x01(\x05B\a\xbaH\x04\x1a\x02(\x00R\bposition\"6\n" +
+	"\n" +
+	"Visibility\x12\x0f\n" +
+	"\vUNSPECIFIED\x10\x00\x12\v\n" +
+	"\aVISIBLE\x10\x01\x12\n" +
+	"\n" +
+	"\x06HIDDEN\x10\x02\"V\n" +
+	"\x1aListTenantOverridesRequest\x128\n" +
+	"\x06tenant\x18\x01 \x01(\tB \xbaH\x1dr\x1b2\x19^[a-z0-9][a-z0-9-]{0,62}$R\x06tenant\"S\n" +
+	"\x1bListTenantOverridesResponse\x124\n" +
+	"\toverrides\x18\x01 \x03(\v2\x16.sports.TenantOverrideR\toverrides\"V\n" +
+	"\x18SetTenantOverrideRequest\x12:\n" +
+	"\boverride\x18\x01 \x01(\v2\x16.sports.TenantOverrideB\x06\xbaH\x03\xc8\x01\x01R\boverride\"{\n" +
+	"\x1bDeleteTenantOverrideRequest\x128\n" +
+	"\x06tenant\x18\x01 \x01(\tB \xbaH\x1dr\x1b2\x19^[a-z0-9][a-z0-9-]{0,62}$R\x06tenant\x12\"\n" +
+	"\bevent_id\x18\x02 \x01(\x03B\a\xbaH\x04\"\x02 \x00R\aeventId2\xbb\x0e\n" +
 	"\x06Sports\x12W\n" +
 	"\n" +
 	"ListEvents\x12\x19.sports.ListEventsRequest\x1a\x1a.sports.ListEventsResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
 	"/v1/sports\x12Q\n" +
-	"\bGetEvent\x12\x17.sports.GetEventRequest\x1a\r.sports.Event\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/sports/{event_id}B+Z)github.com/danilvpetrov/entain/api/sportsb\x06proto3"
+	"\bGetEvent\x12\x17.sports.GetEventRequest\x1a\r.sports.Event\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/v1/sports/{event_id}\x12k\n" +
+	"\x14GetEventByExternalId\x12#.sports.GetEventByExternalIdRequest\x1a\r.sports.Event\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/v1/sports:byExternalId\x12l\n" +
+	"\x0eBatchGetEvents\x12\x1d.sports.BatchGetEventsRequest\x1a\x1e.sports.BatchGetEventsResponse\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/sports:batchGet\x12^\n" +
+	"\vUpdateEvent\x12\x1a.sports.UpdateEventRequest\x1a\r.sports.Event\"$\x82\xd3\xe4\x93\x02\x1e:\x05event2\x15/v1/sports/{event.id}\x12`\n" +
+	"\vDeleteEvent\x12\x1a.sports.DeleteEventRequest\x1a\x16.google.protobuf.Empty\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/v1/sports/{event_id}\x12f\n" +
+	"\vUpdateScore\x12\x1a.sports.UpdateScoreRequest\x1a\r.sports.Event\",\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/sports/{event_id}:updateScore\x12]\n" +
+	"\n" +
+	"WatchEvent\x12\x19.sports.WatchEventRequest\x1a\r.sports.Event\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/sports/{event_id}:watch0\x01\x12o\n" +
+	"\x10ListCompetitions\x12\x1f.sports.ListCompetitionsRequest\x1a .sports.ListCompetitionsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/competitions\x12o\n" +
+	"\x10ListParticipants\x12\x1f.sports.ListParticipantsRequest\x1a .sports.ListParticipantsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/participants\x12i\n" +
+	"\fImportEvents\x12\x1b.sports.ImportEventsRequest\x1a\x1c.sports.ImportEventsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/v1/sports:import(\x01\x12W\n" +
+	"\fExportEvents\x12\x1b.sports.ExportEventsRequest\x1a\r.sports.Event\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/sports:export0\x01\x12b\n" +
+	"\vListChanges\x12\x1a.sports.ListChangesRequest\x1a\x1b.sports.ListChangesResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/sports:changes\x12o\n" +
+	"\x10ListAuditEntries\x12\x1f.sports.ListAuditEntriesRequest\x1a .sports.ListAuditEntriesResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/sports:audit\x12\x83\x01\n" +
+	"\x13ListTenantOverrides\x12\".sports.ListTenantOverridesRequest\x1a#.sports.ListTenantOverridesResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/tenants/{tenant}/sports\x12\x99\x01\n" +
+	"\x11SetTenantOverride\x12 .sports.SetTenantOverrideRequest\x1a\x16.sports.TenantOverride\"J\x82\xd3\xe4\x93\x02D:\boverride\x1a8/v1/tenants/{override.tenant}/sports/{override.event_id}\x12\x83\x01\n" +
+	"\x14DeleteTenantOverride\x12#.sports.DeleteTenantOverrideRequest\x1a\x16.google.protobuf.Empty\".\x82\xd3\xe4\x93\x02(*&/v1/tenants/{tenant}/sports/{event_id}B+Z)github.com/danilvpetrov/entain/api/sportsb\x06proto3"
 
 var (
 	file_api_sports_sports_proto_rawDescOnce sync.Once
@@ -640,34 +3200,137 @@ func file_api_sports_sports_proto_rawDescGZIP() []byte {
 	return file_api_sports_sports_proto_rawDescData
 }
 
-var file_api_sports_sports_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_api_sports_sports_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_sports_sports_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_api_sports_sports_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_api_sports_sports_proto_goTypes = []any{
-	(ListEventsRequest_OrderBy)(0), // 0: sports.ListEventsRequest.OrderBy
-	(Event_Category)(0),            // 1: sports.Event.Category
-	(Event_Status)(0),              // 2: sports.Event.Status
-	(*ListEventsRequest)(nil),      // 3: sports.ListEventsRequest
-	(*ListEventsResponse)(nil),     // 4: sports.ListEventsResponse
-	(*GetEventRequest)(nil),        // 5: sports.GetEventRequest
-	(*Event)(nil),                  // 6: sports.Event
-	(*timestamppb.Timestamp)(nil),  // 7: google.protobuf.Timestamp
+	(ListEventsRequest_OrderBy)(0),      // 0: sports.ListEventsRequest.OrderBy
+	(Event_Category)(0),                 // 1: sports.Event.Category
+	(Event_Status)(0),                   // 2: sports.Event.Status
+	(Event_MatchState)(0),               // 3: sports.Event.MatchState
+	(Change_Operation)(0),               // 4: sports.Change.Operation
+	(TenantOverride_Visibility)(0),      // 5: sports.TenantOverride.Visibility
+	(*ListEventsRequest)(nil),           // 6: sports.ListEventsRequest
+	(*ListEventsResponse)(nil),          // 7: sports.ListEventsResponse
+	(*GetEventRequest)(nil),             // 8: sports.GetEventRequest
+	(*GetEventByExternalIdRequest)(nil), // 9: sports.GetEventByExternalIdRequest
+	(*BatchGetEventsRequest)(nil),       // 10: sports.BatchGetEventsRequest
+	(*BatchGetEventsResponse)(nil),      // 11: sports.BatchGetEventsResponse
+	(*Event)(nil),                       // 12: sports.Event
+	(*UpdateEventRequest)(nil),          // 13: sports.UpdateEventRequest
+	(*DeleteEventRequest)(nil),          // 14: sports.DeleteEventRequest
+	(*UpdateScoreRequest)(nil),          // 15: sports.UpdateScoreRequest
+	(*WatchEventRequest)(nil),           // 16: sports.WatchEventRequest
+	(*PeriodScore)(nil),                 // 17: sports.PeriodScore
+	(*ListCompetitionsRequest)(nil),     // 18: sports.ListCompetitionsRequest
+	(*ListCompetitionsResponse)(nil),    // 19: sports.ListCompetitionsResponse
+	(*ListParticipantsRequest)(nil),     // 20: sports.ListParticipantsRequest
+	(*ListParticipantsResponse)(nil),    // 21: sports.ListParticipantsResponse
+	(*Competition)(nil),                 // 22: sports.Competition
+	(*Participant)(nil),                 // 23: sports.Participant
+	(*ImportEventsRequest)(nil),         // 24: sports.ImportEventsRequest
+	(*ImportOptions)(nil),               // 25: sports.ImportOptions
+	(*ImportEventsResponse)(nil),        // 26: sports.ImportEventsResponse
+	(*ImportError)(nil),                 // 27: sports.ImportError
+	(*ExportEventsRequest)(nil),         // 28: sports.ExportEventsRequest
+	(*ListChangesRequest)(nil),          // 29: sports.ListChangesRequest
+	(*ListChangesResponse)(nil),         // 30: sports.ListChangesResponse
+	(*Change)(nil),                      // 31: sports.Change
+	(*ListAuditEntriesRequest)(nil),     // 32: sports.ListAuditEntriesRequest
+	(*ListAuditEntriesResponse)(nil),    // 33: sports.ListAuditEntriesResponse
+	(*AuditEntry)(nil),                  // 34: sports.AuditEntry
+	(*FieldChange)(nil),                 // 35: sports.FieldChange
+	(*TenantOverride)(nil),              // 36: sports.TenantOverride
+	(*ListTenantOverridesRequest)(nil),  // 37: sports.ListTenantOverridesRequest
+	(*ListTenantOverridesResponse)(nil), // 38: sports.ListTenantOverridesResponse
+	(*SetTenantOverrideRequest)(nil),    // 39: sports.SetTenantOverrideRequest
+	(*DeleteTenantOverrideRequest)(nil), // 40: sports.DeleteTenantOverrideRequest
+	(*fieldmaskpb.FieldMask)(nil),       // 41: google.protobuf.FieldMask
+	(*timestamppb.Timestamp)(nil),       // 42: google.protobuf.Timestamp
+	(*structpb.Value)(nil),              // 43: google.protobuf.Value
+	(*emptypb.Empty)(nil),               // 44: google.protobuf.Empty
 }
 var file_api_sports_sports_proto_depIdxs = []int32{
-	1, // 0: sports.ListEventsRequest.category:type_name -> sports.Event.Category
-	0, // 1: sports.ListEventsRequest.order_by:type_name -> sports.ListEventsRequest.OrderBy
-	6, // 2: sports.ListEventsResponse.events:type_name -> sports.Event
-	1, // 3: sports.Event.category:type_name -> sports.Event.Category
-	7, // 4: sports.Event.advertised_start_time:type_name -> google.protobuf.Timestamp
-	2, // 5: sports.Event.status:type_name -> sports.Event.Status
-	3, // 6: sports.Sports.ListEvents:input_type -> sports.ListEventsRequest
-	5, // 7: sports.Sports.GetEvent:input_type -> sports.GetEventRequest
-	4, // 8: sports.Sports.ListEvents:output_type -> sports.ListEventsResponse
-	6, // 9: sports.Sports.GetEvent:output_type -> sports.Event
-	8, // [8:10] is the sub-list for method output_type
-	6, // [6:8] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	1,  // 0: sports.ListEventsRequest.category:type_name -> sports.Event.Category
+	0,  // 1: sports.ListEventsRequest.order_by:type_name -> sports.ListEventsRequest.OrderBy
+	41, // 2: sports.ListEventsRequest.read_mask:type_name -> google.protobuf.FieldMask
+	42, // 3: sports.ListEventsRequest.as_of:type_name -> google.protobuf.Timestamp
+	12, // 4: sports.ListEventsResponse.events:type_name -> sports.Event
+	41, // 5: sports.GetEventRequest.read_mask:type_name -> google.protobuf.FieldMask
+	41, // 6: sports.GetEventByExternalIdRequest.read_mask:type_name -> google.protobuf.FieldMask
+	12, // 7: sports.BatchGetEventsResponse.events:type_name -> sports.Event
+	1,  // 8: sports.Event.category:type_name -> sports.Event.Category
+	42, // 9: sports.Event.advertised_start_time:type_name -> google.protobuf.Timestamp
+	2,  // 10: sports.Event.status:type_name -> sports.Event.Status
+	3,  // 11: sports.Event.match_state:type_name -> sports.Event.MatchState
+	17, // 12: sports.Event.scores:type_name -> sports.PeriodScore
+	42, // 13: sports.Event.archive_time:type_name -> google.protobuf.Timestamp
+	12, // 14: sports.UpdateEventRequest.event:type_name -> sports.Event
+	41, // 15: sports.UpdateEventRequest.update_mask:type_name -> google.protobuf.FieldMask
+	3,  // 16: sports.UpdateScoreRequest.match_state:type_name -> sports.Event.MatchState
+	17, // 17: sports.UpdateScoreRequest.scores:type_name -> sports.PeriodScore
+	1,  // 18: sports.ListCompetitionsRequest.category:type_name -> sports.Event.Category
+	22, // 19: sports.ListCompetitionsResponse.competitions:type_name -> sports.Competition
+	1,  // 20: sports.ListParticipantsRequest.category:type_name -> sports.Event.Category
+	23, // 21: sports.ListParticipantsResponse.participants:type_name -> sports.Participant
+	1,  // 22: sports.Competition.category:type_name -> sports.Event.Category
+	1,  // 23: sports.Participant.category:type_name -> sports.Event.Category
+	12, // 24: sports.ImportEventsRequest.event:type_name -> sports.Event
+	25, // 25: sports.ImportEventsRequest.options:type_name -> sports.ImportOptions
+	27, // 26: sports.ImportEventsResponse.errors:type_name -> sports.ImportError
+	1,  // 27: sports.ExportEventsRequest.category:type_name -> sports.Event.Category
+	31, // 28: sports.ListChangesResponse.changes:type_name -> sports.Change
+	4,  // 29: sports.Change.operation:type_name -> sports.Change.Operation
+	42, // 30: sports.Change.change_time:type_name -> google.protobuf.Timestamp
+	12, // 31: sports.Change.event:type_name -> sports.Event
+	42, // 32: sports.ListAuditEntriesRequest.start_time:type_name -> google.protobuf.Timestamp
+	42, // 33: sports.ListAuditEntriesRequest.end_time:type_name -> google.protobuf.Timestamp
+	34, // 34: sports.ListAuditEntriesResponse.entries:type_name -> sports.AuditEntry
+	42, // 35: sports.AuditEntry.entry_time:type_name -> google.protobuf.Timestamp
+	35, // 36: sports.AuditEntry.changes:type_name -> sports.FieldChange
+	43, // 37: sports.FieldChange.before:type_name -> google.protobuf.Value
+	43, // 38: sports.FieldChange.after:type_name -> google.protobuf.Value
+	5,  // 39: sports.TenantOverride.visibility:type_name -> sports.TenantOverride.Visibility
+	36, // 40: sports.ListTenantOverridesResponse.overrides:type_name -> sports.TenantOverride
+	36, // 41: sports.SetTenantOverrideRequest.override:type_name -> sports.TenantOverride
+	6,  // 42: sports.Sports.ListEvents:input_type -> sports.ListEventsRequest
+	8,  // 43: sports.Sports.GetEvent:input_type -> sports.GetEventRequest
+	9,  // 44: sports.Sports.GetEventByExternalId:input_type -> sports.GetEventByExternalIdRequest
+	10, // 45: sports.Sports.BatchGetEvents:input_type -> sports.BatchGetEventsRequest
+	13, // 46: sports.Sports.UpdateEvent:input_type -> sports.UpdateEventRequest
+	14, // 47: sports.Sports.DeleteEvent:input_type -> sports.DeleteEventRequest
+	15, // 48: sports.Sports.UpdateScore:input_type -> sports.UpdateScoreRequest
+	16, // 49: sports.Sports.WatchEvent:input_type -> sports.WatchEventRequest
+	18, // 50: sports.Sports.ListCompetitions:input_type -> sports.ListCompetitionsRequest
+	20, // 51: sports.Sports.ListParticipants:input_type -> sports.ListParticipantsRequest
+	24, // 52: sports.Sports.ImportEvents:input_type -> sports.ImportEventsRequest
+	28, // 53: sports.Sports.ExportEvents:input_type -> sports.ExportEventsRequest
+	29, // 54: sports.Sports.ListChanges:input_type -> sports.ListChangesRequest
+	32, // 55: sports.Sports.ListAuditEntries:input_type -> sports.ListAuditEntriesRequest
+	37, // 56: sports.Sports.ListTenantOverrides:input_type -> sports.ListTenantOverridesRequest
+	39, // 57: sports.Sports.SetTenantOverride:input_type -> sports.SetTenantOverrideRequest
+	40, // 58: sports.Sports.DeleteTenantOverride:input_type -> sports.DeleteTenantOverrideRequest
+	7,  // 59: sports.Sports.ListEvents:output_type -> sports.ListEventsResponse
+	12, // 60: sports.Sports.GetEvent:output_type -> sports.Event
+	12, // 61: sports.Sports.GetEventByExternalId:output_type -> sports.Event
+	11, // 62: sports.Sports.BatchGetEvents:output_type -> sports.BatchGetEventsResponse
+	12, // 63: sports.Sports.UpdateEvent:output_type -> sports.Event
+	44, // 64: sports.Sports.DeleteEvent:output_type -> google.protobuf.Empty
+	12, // 65: sports.Sports.UpdateScore:output_type -> sports.Event
+	12, // 66: sports.Sports.WatchEvent:output_type -> sports.Event
+	19, // 67: sports.Sports.ListCompetitions:output_type -> sports.ListCompetitionsResponse
+	21, // 68: sports.Sports.ListParticipants:output_type -> sports.ListParticipantsResponse
+	26, // 69: sports.Sports.ImportEvents:output_type -> sports.ImportEventsResponse
+	12, // 70: sports.Sports.ExportEvents:output_type -> sports.Event
+	30, // 71: sports.Sports.ListChanges:output_type -> sports.ListChangesResponse
+	33, // 72: sports.Sports.ListAuditEntries:output_type -> sports.ListAuditEntriesResponse
+	38, // 73: sports.Sports.ListTenantOverrides:output_type -> sports.ListTenantOverridesResponse
+	36, // 74: sports.Sports.SetTenantOverride:output_type -> sports.TenantOverride
+	44, // 75: sports.Sports.DeleteTenantOverride:output_type -> google.protobuf.Empty
+	59, // [59:76] is the sub-list for method output_type
+	42, // [42:59] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_api_sports_sports_proto_init() }
@@ -680,8 +3343,8 @@ func file_api_sports_sports_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_sports_sports_proto_rawDesc), len(file_api_sports_sports_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   4,
+			NumEnums:      6,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -19,8 +20,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Sports_ListEvents_FullMethodName = "/sports.Sports/ListEvents"
-	Sports_GetEvent_FullMethodName   = "/sports.Sports/GetEvent"
+	Sports_ListEvents_FullMethodName           = "/sports.Sports/ListEvents"
+	Sports_GetEvent_FullMethodName             = "/sports.Sports/GetEvent"
+	Sports_GetEventByExternalId_FullMethodName = "/sports.Sports/GetEventByExternalId"
+	Sports_BatchGetEvents_FullMethodName       = "/sports.Sports/BatchGetEvents"
+	Sports_UpdateEvent_FullMethodName          = "/sports.Sports/UpdateEvent"
+	Sports_DeleteEvent_FullMethodName          = "/sports.Sports/DeleteEvent"
+	Sports_UpdateScore_FullMethodName          = "/sports.Sports/UpdateScore"
+	Sports_WatchEvent_FullMethodName           = "/sports.Sports/WatchEvent"
+	Sports_ListCompetitions_FullMethodName     = "/sports.Sports/ListCompetitions"
+	Sports_ListParticipants_FullMethodName     = "/sports.Sports/ListParticipants"
+	Sports_ImportEvents_FullMethodName         = "/sports.Sports/ImportEvents"
+	Sports_ExportEvents_FullMethodName         = "/sports.Sports/ExportEvents"
+	Sports_ListChanges_FullMethodName          = "/sports.Sports/ListChanges"
+	Sports_ListAuditEntries_FullMethodName     = "/sports.Sports/ListAuditEntries"
+	Sports_ListTenantOverrides_FullMethodName  = "/sports.Sports/ListTenantOverrides"
+	Sports_SetTenantOverride_FullMethodName    = "/sports.Sports/SetTenantOverride"
+	Sports_DeleteTenantOverride_FullMethodName = "/sports.Sports/DeleteTenantOverride"
 )
 
 // SportsClient is the client API for Sports service.
@@ -29,10 +45,64 @@ const (
 //
 // Sports service provides operations for managing sports events.
 type SportsClient interface {
-	// ListEvents returns a list of all sports events.
+	// ListEvents returns a list of all sports events. The events of the
+	// categories that cannot be offered in the jurisdiction of the request are
+	// not listed.
 	ListEvents(ctx context.Context, in *ListEventsRequest, opts ...grpc.CallOption) (*ListEventsResponse, error)
-	// GetEvent returns a specific sport event by its ID.
+	// GetEvent returns a specific sport event by its ID. It fails with the
+	// PERMISSION_DENIED status and the RESTRICTED_IN_JURISDICTION reason if the
+	// category of the event cannot be offered in the jurisdiction of the
+	// request.
 	GetEvent(ctx context.Context, in *GetEventRequest, opts ...grpc.CallOption) (*Event, error)
+	// GetEventByExternalId returns a specific sport event by the ID assigned to
+	// it by an external provider. It is restricted in jurisdictions as GetEvent.
+	GetEventByExternalId(ctx context.Context, in *GetEventByExternalIdRequest, opts ...grpc.CallOption) (*Event, error)
+	// BatchGetEvents returns multiple sport events by their IDs.
+	BatchGetEvents(ctx context.Context, in *BatchGetEventsRequest, opts ...grpc.CallOption) (*BatchGetEventsResponse, error)
+	// UpdateEvent updates the fields of a sports event listed in the update
+	// mask. Only admins can update sports events.
+	UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*Event, error)
+	// DeleteEvent deletes a sports event. Only admins can delete sports events.
+	DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UpdateScore updates the scores and the match state of a sports event. Only
+	// admins can update scores.
+	UpdateScore(ctx context.Context, in *UpdateScoreRequest, opts ...grpc.CallOption) (*Event, error)
+	// WatchEvent streams a sports event, sending its current snapshot followed
+	// by a new snapshot every time its scores or match state change. The stream
+	// ends once the match is finished or cancelled. It is restricted in
+	// jurisdictions as GetEvent, and ends with the same error if the category of
+	// the event changes to one that cannot be offered in the jurisdiction.
+	WatchEvent(ctx context.Context, in *WatchEventRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	// ListCompetitions returns a list of competitions sports events are part of.
+	ListCompetitions(ctx context.Context, in *ListCompetitionsRequest, opts ...grpc.CallOption) (*ListCompetitionsResponse, error)
+	// ListParticipants returns a list of participants of sports events.
+	ListParticipants(ctx context.Context, in *ListParticipantsRequest, opts ...grpc.CallOption) (*ListParticipantsResponse, error)
+	// ImportEvents creates and updates the sports events streamed by the
+	// client, and returns the outcome of the import once the stream is closed.
+	// Only admins can import sports events.
+	ImportEvents(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportEventsRequest, ImportEventsResponse], error)
+	// ExportEvents streams all sports events matching the request in the order
+	// of their IDs.
+	ExportEvents(ctx context.Context, in *ExportEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	// ListChanges returns the changes of sports events in the order they were
+	// made.
+	ListChanges(ctx context.Context, in *ListChangesRequest, opts ...grpc.CallOption) (*ListChangesResponse, error)
+	// ListAuditEntries returns the audit entries of the changes made to
+	// sports events through the API, in the order they were made. Only admins can
+	// list the audit entries.
+	ListAuditEntries(ctx context.Context, in *ListAuditEntriesRequest, opts ...grpc.CallOption) (*ListAuditEntriesResponse, error)
+	// ListTenantOverrides returns the overrides of the visibility and the
+	// ordering of sports events for a tenant, in the order of the IDs of the
+	// events. Only admins can list the overrides.
+	ListTenantOverrides(ctx context.Context, in *ListTenantOverridesRequest, opts ...grpc.CallOption) (*ListTenantOverridesResponse, error)
+	// SetTenantOverride creates or replaces the override of the visibility and
+	// the ordering of a sports event for a tenant. Only admins can set the
+	// overrides.
+	SetTenantOverride(ctx context.Context, in *SetTenantOverrideRequest, opts ...grpc.CallOption) (*TenantOverride, error)
+	// DeleteTenantOverride deletes the override of a sports event for a tenant,
+	// so that the event is seen by the tenant as by any other client. Only
+	// admins can delete the overrides.
+	DeleteTenantOverride(ctx context.Context, in *DeleteTenantOverrideRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type sportsClient struct {
@@ -63,16 +133,241 @@ func (c *sportsClient) GetEvent(ctx context.Context, in *GetEventRequest, opts .
 	return out, nil
 }
 
+func (c *sportsClient) GetEventByExternalId(ctx context.Context, in *GetEventByExternalIdRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, Sports_GetEventByExternalId_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsClient) BatchGetEvents(ctx context.Context, in *BatchGetEventsRequest, opts ...grpc.CallOption) (*BatchGetEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetEventsResponse)
+	err := c.cc.Invoke(ctx, Sports_BatchGetEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsClient) UpdateEvent(ctx context.Context, in *UpdateEventRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, Sports_UpdateEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsClient) DeleteEvent(ctx context.Context, in *DeleteEventRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Sports_DeleteEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsClient) UpdateScore(ctx context.Context, in *UpdateScoreRequest, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, Sports_UpdateScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsClient) WatchEvent(ctx context.Context, in *WatchEventRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Sports_ServiceDesc.Streams[0], Sports_WatchEvent_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchEventRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Sports_WatchEventClient = grpc.ServerStreamingClient[Event]
+
+func (c *sportsClient) ListCompetitions(ctx context.Context, in *ListCompetitionsRequest, opts ...grpc.CallOption) (*ListCompetitionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCompetitionsResponse)
+	err := c.cc.Invoke(ctx, Sports_ListCompetitions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsClient) ListParticipants(ctx context.Context, in *ListParticipantsRequest, opts ...grpc.CallOption) (*ListParticipantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListParticipantsResponse)
+	err := c.cc.Invoke(ctx, Sports_ListParticipants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsClient) ImportEvents(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportEventsRequest, ImportEventsResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Sports_ServiceDesc.Streams[1], Sports_ImportEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportEventsRequest, ImportEventsResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Sports_ImportEventsClient = grpc.ClientStreamingClient[ImportEventsRequest, ImportEventsResponse]
+
+func (c *sportsClient) ExportEvents(ctx context.Context, in *ExportEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Sports_ServiceDesc.Streams[2], Sports_ExportEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportEventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Sports_ExportEventsClient = grpc.ServerStreamingClient[Event]
+
+func (c *sportsClient) ListChanges(ctx context.Context, in *ListChangesRequest, opts ...grpc.CallOption) (*ListChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChangesResponse)
+	err := c.cc.Invoke(ctx, Sports_ListChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsClient) ListAuditEntries(ctx context.Context, in *ListAuditEntriesRequest, opts ...grpc.CallOption) (*ListAuditEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEntriesResponse)
+	err := c.cc.Invoke(ctx, Sports_ListAuditEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsClient) ListTenantOverrides(ctx context.Context, in *ListTenantOverridesRequest, opts ...grpc.CallOption) (*ListTenantOverridesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTenantOverridesResponse)
+	err := c.cc.Invoke(ctx, Sports_ListTenantOverrides_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsClient) SetTenantOverride(ctx context.Context, in *SetTenantOverrideRequest, opts ...grpc.CallOption) (*TenantOverride, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TenantOverride)
+	err := c.cc.Invoke(ctx, Sports_SetTenantOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sportsClient) DeleteTenantOverride(ctx context.Context, in *DeleteTenantOverrideRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Sports_DeleteTenantOverride_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SportsServer is the server API for Sports service.
 // All implementations should embed UnimplementedSportsServer
 // for forward compatibility.
 //
 // Sports service provides operations for managing sports events.
 type SportsServer interface {
-	// ListEvents returns a list of all sports events.
+	// ListEvents returns a list of all sports events. The events of the
+	// categories that cannot be offered in the jurisdiction of the request are
+	// not listed.
 	ListEvents(context.Context, *ListEventsRequest) (*ListEventsResponse, error)
-	// GetEvent returns a specific sport event by its ID.
+	// GetEvent returns a specific sport event by its ID. It fails with the
+	// PERMISSION_DENIED status and the RESTRICTED_IN_JURISDICTION reason if the
+	// category of the event cannot be offered in the jurisdiction of the
+	// request.
 	GetEvent(context.Context, *GetEventRequest) (*Event, error)
+	// GetEventByExternalId returns a specific sport event by the ID assigned to
+	// it by an external provider. It is restricted in jurisdictions as GetEvent.
+	GetEventByExternalId(context.Context, *GetEventByExternalIdRequest) (*Event, error)
+	// BatchGetEvents returns multiple sport events by their IDs.
+	BatchGetEvents(context.Context, *BatchGetEventsRequest) (*BatchGetEventsResponse, error)
+	// UpdateEvent updates the fields of a sports event listed in the update
+	// mask. Only admins can update sports events.
+	UpdateEvent(context.Context, *UpdateEventRequest) (*Event, error)
+	// DeleteEvent deletes a sports event. Only admins can delete sports events.
+	DeleteEvent(context.Context, *DeleteEventRequest) (*emptypb.Empty, error)
+	// UpdateScore updates the scores and the match state of a sports event. Only
+	// admins can update scores.
+	UpdateScore(context.Context, *UpdateScoreRequest) (*Event, error)
+	// WatchEvent streams a sports event, sending its current snapshot followed
+	// by a new snapshot every time its scores or match state change. The stream
+	// ends once the match is finished or cancelled. It is restricted in
+	// jurisdictions as GetEvent, and ends with the same error if the category of
+	// the event changes to one that cannot be offered in the jurisdiction.
+	WatchEvent(*WatchEventRequest, grpc.ServerStreamingServer[Event]) error
+	// ListCompetitions returns a list of competitions sports events are part of.
+	ListCompetitions(context.Context, *ListCompetitionsRequest) (*ListCompetitionsResponse, error)
+	// ListParticipants returns a list of participants of sports events.
+	ListParticipants(context.Context, *ListParticipantsRequest) (*ListParticipantsResponse, error)
+	// ImportEvents creates and updates the sports events streamed by the
+	// client, and returns the outcome of the import once the stream is closed.
+	// Only admins can import sports events.
+	ImportEvents(grpc.ClientStreamingServer[ImportEventsRequest, ImportEventsResponse]) error
+	// ExportEvents streams all sports events matching the request in the order
+	// of their IDs.
+	ExportEvents(*ExportEventsRequest, grpc.ServerStreamingServer[Event]) error
+	// ListChanges returns the changes of sports events in the order they were
+	// made.
+	ListChanges(context.Context, *ListChangesRequest) (*ListChangesResponse, error)
+	// ListAuditEntries returns the audit entries of the changes made to
+	// sports events through the API, in the order they were made. Only admins can
+	// list the audit entries.
+	ListAuditEntries(context.Context, *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error)
+	// ListTenantOverrides returns the overrides of the visibility and the
+	// ordering of sports events for a tenant, in the order of the IDs of the
+	// events. Only admins can list the overrides.
+	ListTenantOverrides(context.Context, *ListTenantOverridesRequest) (*ListTenantOverridesResponse, error)
+	// SetTenantOverride creates or replaces the override of the visibility and
+	// the ordering of a sports event for a tenant. Only admins can set the
+	// overrides.
+	SetTenantOverride(context.Context, *SetTenantOverrideRequest) (*TenantOverride, error)
+	// DeleteTenantOverride deletes the override of a sports event for a tenant,
+	// so that the event is seen by the tenant as by any other client. Only
+	// admins can delete the overrides.
+	DeleteTenantOverride(context.Context, *DeleteTenantOverrideRequest) (*emptypb.Empty, error)
 }
 
 // UnimplementedSportsServer should be embedded to have
@@ -88,6 +383,51 @@ func (UnimplementedSportsServer) ListEvents(context.Context, *ListEventsRequest)
 func (UnimplementedSportsServer) GetEvent(context.Context, *GetEventRequest) (*Event, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetEvent not implemented")
 }
+func (UnimplementedSportsServer) GetEventByExternalId(context.Context, *GetEventByExternalIdRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEventByExternalId not implemented")
+}
+func (UnimplementedSportsServer) BatchGetEvents(context.Context, *BatchGetEventsRequest) (*BatchGetEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetEvents not implemented")
+}
+func (UnimplementedSportsServer) UpdateEvent(context.Context, *UpdateEventRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateEvent not implemented")
+}
+func (UnimplementedSportsServer) DeleteEvent(context.Context, *DeleteEventRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteEvent not implemented")
+}
+func (UnimplementedSportsServer) UpdateScore(context.Context, *UpdateScoreRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateScore not implemented")
+}
+func (UnimplementedSportsServer) WatchEvent(*WatchEventRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvent not implemented")
+}
+func (UnimplementedSportsServer) ListCompetitions(context.Context, *ListCompetitionsRequest) (*ListCompetitionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCompetitions not implemented")
+}
+func (UnimplementedSportsServer) ListParticipants(context.Context, *ListParticipantsRequest) (*ListParticipantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListParticipants not implemented")
+}
+func (UnimplementedSportsServer) ImportEvents(grpc.ClientStreamingServer[ImportEventsRequest, ImportEventsResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportEvents not implemented")
+}
+func (UnimplementedSportsServer) ExportEvents(*ExportEventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportEvents not implemented")
+}
+func (UnimplementedSportsServer) ListChanges(context.Context, *ListChangesRequest) (*ListChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChanges not implemented")
+}
+func (UnimplementedSportsServer) ListAuditEntries(context.Context, *ListAuditEntriesRequest) (*ListAuditEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEntries not implemented")
+}
+func (UnimplementedSportsServer) ListTenantOverrides(context.Context, *ListTenantOverridesRequest) (*ListTenantOverridesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTenantOverrides not implemented")
+}
+func (UnimplementedSportsServer) SetTenantOverride(context.Context, *SetTenantOverrideRequest) (*TenantOverride, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTenantOverride not implemented")
+}
+func (UnimplementedSportsServer) DeleteTenantOverride(context.Context, *DeleteTenantOverrideRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTenantOverride not implemented")
+}
 func (UnimplementedSportsServer) testEmbeddedByValue() {}
 
 // UnsafeSportsServer may be embedded to opt out of forward compatibility for this service.
@@ -144,6 +484,251 @@ func _Sports_GetEvent_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Sports_GetEventByExternalId_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEventByExternalIdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).GetEventByExternalId(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sports_GetEventByExternalId_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).GetEventByExternalId(ctx, req.(*GetEventByExternalIdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_BatchGetEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).BatchGetEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sports_BatchGetEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).BatchGetEvents(ctx, req.(*BatchGetEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_UpdateEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).UpdateEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sports_UpdateEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).UpdateEvent(ctx, req.(*UpdateEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_DeleteEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).DeleteEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sports_DeleteEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).DeleteEvent(ctx, req.(*DeleteEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_UpdateScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).UpdateScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sports_UpdateScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).UpdateScore(ctx, req.(*UpdateScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_WatchEvent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SportsServer).WatchEvent(m, &grpc.GenericServerStream[WatchEventRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Sports_WatchEventServer = grpc.ServerStreamingServer[Event]
+
+func _Sports_ListCompetitions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCompetitionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).ListCompetitions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sports_ListCompetitions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).ListCompetitions(ctx, req.(*ListCompetitionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_ListParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListParticipantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).ListParticipants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sports_ListParticipants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).ListParticipants(ctx, req.(*ListParticipantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_ImportEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SportsServer).ImportEvents(&grpc.GenericServerStream[ImportEventsRequest, ImportEventsResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Sports_ImportEventsServer = grpc.ClientStreamingServer[ImportEventsRequest, ImportEventsResponse]
+
+func _Sports_ExportEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SportsServer).ExportEvents(m, &grpc.GenericServerStream[ExportEventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Sports_ExportEventsServer = grpc.ServerStreamingServer[Event]
+
+func _Sports_ListChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).ListChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sports_ListChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).ListChanges(ctx, req.(*ListChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_ListAuditEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).ListAuditEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sports_ListAuditEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).ListAuditEntries(ctx, req.(*ListAuditEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_ListTenantOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTenantOverridesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).ListTenantOverrides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sports_ListTenantOverrides_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).ListTenantOverrides(ctx, req.(*ListTenantOverridesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_SetTenantOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTenantOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).SetTenantOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sports_SetTenantOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).SetTenantOverride(ctx, req.(*SetTenantOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Sports_DeleteTenantOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTenantOverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SportsServer).DeleteTenantOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Sports_DeleteTenantOverride_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SportsServer).DeleteTenantOverride(ctx, req.(*DeleteTenantOverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Sports_ServiceDesc is the grpc.ServiceDesc for Sports service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -159,7 +744,71 @@ var Sports_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetEvent",
 			Handler:    _Sports_GetEvent_Handler,
 		},
+		{
+			MethodName: "GetEventByExternalId",
+			Handler:    _Sports_GetEventByExternalId_Handler,
+		},
+		{
+			MethodName: "BatchGetEvents",
+			Handler:    _Sports_BatchGetEvents_Handler,
+		},
+		{
+			MethodName: "UpdateEvent",
+			Handler:    _Sports_UpdateEvent_Handler,
+		},
+		{
+			MethodName: "DeleteEvent",
+			Handler:    _Sports_DeleteEvent_Handler,
+		},
+		{
+			MethodName: "UpdateScore",
+			Handler:    _Sports_UpdateScore_Handler,
+		},
+		{
+			MethodName: "ListCompetitions",
+			Handler:    _Sports_ListCompetitions_Handler,
+		},
+		{
+			MethodName: "ListParticipants",
+			Handler:    _Sports_ListParticipants_Handler,
+		},
+		{
+			MethodName: "ListChanges",
+			Handler:    _Sports_ListChanges_Handler,
+		},
+		{
+			MethodName: "ListAuditEntries",
+			Handler:    _Sports_ListAuditEntries_Handler,
+		},
+		{
+			MethodName: "ListTenantOverrides",
+			Handler:    _Sports_ListTenantOverrides_Handler,
+		},
+		{
+			MethodName: "SetTenantOverride",
+			Handler:    _Sports_SetTenantOverride_Handler,
+		},
+		{
+			MethodName: "DeleteTenantOverride",
+			Handler:    _Sports_DeleteTenantOverride_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvent",
+			Handler:       _Sports_WatchEvent_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportEvents",
+			Handler:       _Sports_ImportEvents_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportEvents",
+			Handler:       _Sports_ExportEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/sports/sports.proto",
 }
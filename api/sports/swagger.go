@@ -0,0 +1,9 @@
+package sports
+
+import _ "embed"
+
+// SwaggerYAML is the OpenAPI v2 (Swagger) specification of the HTTP routes of
+// the sports service, generated from sports.proto.
+//
+//go:embed sports.swagger.yaml
+var SwaggerYAML []byte
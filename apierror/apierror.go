@@ -0,0 +1,193 @@
+package apierror
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// RequestIDHeader is the name of the gRPC metadata key (and HTTP header) that
+// carries the ID of a request.
+const RequestIDHeader = "x-request-id"
+
+// Reasons of errors reported in ErrorInfo details.
+const (
+	// ReasonInvalidArgument indicates that the request is malformed.
+	ReasonInvalidArgument = "INVALID_ARGUMENT"
+	// ReasonInternal indicates an unexpected failure on the server side.
+	ReasonInternal = "INTERNAL"
+)
+
+// FieldViolation describes a single invalid field of a request.
+type FieldViolation struct {
+	// Field is a path to the invalid field, for example "order_by".
+	Field string
+	// Description explains why the field is invalid.
+	Description string
+}
+
+// InvalidArgument returns an error with codes.InvalidArgument code describing
+// the fields of the request that are invalid.
+func InvalidArgument(
+	ctx context.Context,
+	msg string,
+	violations ...FieldViolation,
+) error {
+	br := &errdetails.BadRequest{}
+	for _, v := range violations {
+		br.FieldViolations = append(
+			br.FieldViolations,
+			&errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			},
+		)
+	}
+
+	return newError(
+		ctx,
+		codes.InvalidArgument,
+		ReasonInvalidArgument,
+		msg,
+		br,
+	)
+}
+
+// NotFound returns an error with codes.NotFound code. The reason is a
+// machine-readable UPPER_SNAKE_CASE identifier of the error, for example
+// "RACE_NOT_FOUND".
+func NotFound(ctx context.Context, reason, msg string) error {
+	return newError(ctx, codes.NotFound, reason, msg)
+}
+
+// FailedPrecondition returns an error with codes.FailedPrecondition code,
+// indicating that the request cannot be served in the current state of the
+// resource. The reason is a machine-readable UPPER_SNAKE_CASE identifier of
+// the error, for example "INVALID_MATCH_STATE_TRANSITION".
+func FailedPrecondition(ctx context.Context, reason, msg string) error {
+	return newError(ctx, codes.FailedPrecondition, reason, msg)
+}
+
+// Aborted returns an error with codes.Aborted code, indicating that the
+// request conflicts with a concurrent change and can be retried from a fresh
+// read. The reason is a machine-readable UPPER_SNAKE_CASE identifier of the
+// error, for example "ETAG_MISMATCH".
+func Aborted(ctx context.Context, reason, msg string) error {
+	return newError(ctx, codes.Aborted, reason, msg)
+}
+
+// PermissionDenied returns an error with codes.PermissionDenied code,
+// indicating that the caller is not allowed to make the request. The reason is
+// a machine-readable UPPER_SNAKE_CASE identifier of the error, for example
+// "ADMIN_ONLY".
+func PermissionDenied(ctx context.Context, reason, msg string) error {
+	return newError(ctx, codes.PermissionDenied, reason, msg)
+}
+
+// Internal logs the given error and returns an error with codes.Internal code.
+// The returned error does not expose the details of the original error to the
+// client, it only carries the request ID to correlate it with the server logs.
+func Internal(ctx context.Context, err error) error {
+	// Make sure the same request ID is logged and returned to the client.
+	ctx = withRequestID(ctx)
+
+	slog.ErrorContext(
+		ctx,
+		"internal error",
+		slog.String("request_id", RequestID(ctx)),
+		slog.String("method", method(ctx)),
+		slog.Any("error", err),
+	)
+
+	return newError(ctx, codes.Internal, ReasonInternal, "internal error")
+}
+
+// newError returns an error with the given code and message, attaching
+// ErrorInfo, RequestInfo and any additional details.
+func newError(
+	ctx context.Context,
+	code codes.Code,
+	reason string,
+	msg string,
+	details ...protoadapt.MessageV1,
+) error {
+	details = append(
+		[]protoadapt.MessageV1{
+			&errdetails.ErrorInfo{
+				Reason: reason,
+				Domain: domain(ctx),
+			},
+			&errdetails.RequestInfo{
+				RequestId: RequestID(ctx),
+			},
+		},
+		details...,
+	)
+
+	st, err := status.New(code, msg).WithDetails(details...)
+	if err != nil {
+		// This is only possible if a detail cannot be marshalled, fallback to
+		// the plain status.
+		return status.Error(code, msg)
+	}
+
+	return st.Err()
+}
+
+// RequestID returns the ID of the request being served. The ID is taken from
+// the incoming gRPC metadata. If the metadata does not carry an ID, a new one
+// is generated.
+func RequestID(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(RequestIDHeader); len(ids) > 0 && ids[0] != "" {
+		return ids[0]
+	}
+
+	return NewRequestID()
+}
+
+// withRequestID returns a context whose incoming gRPC metadata carries a request
+// ID, generating a new one if necessary.
+func withRequestID(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if ids := md.Get(RequestIDHeader); len(ids) > 0 && ids[0] != "" {
+		return ctx
+	}
+
+	md = md.Copy()
+	md.Set(RequestIDHeader, NewRequestID())
+
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+// NewRequestID generates a new random request ID.
+func NewRequestID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:]) // never returns an error
+	return hex.EncodeToString(b[:])
+}
+
+// domain returns the ErrorInfo domain for the request being served, which is
+// the fully-qualified name of the gRPC service, for example "racing.v1.Racing".
+func domain(ctx context.Context) string {
+	m := strings.TrimPrefix(method(ctx), "/")
+	if i := strings.LastIndex(m, "/"); i >= 0 {
+		return m[:i]
+	}
+	return m
+}
+
+// method returns the full name of the gRPC method being served.
+func method(ctx context.Context) string {
+	m, _ := grpc.Method(ctx)
+	return m
+}
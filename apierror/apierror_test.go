@@ -0,0 +1,167 @@
+package apierror_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/danilvpetrov/entain/apierror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrors(t *testing.T) {
+	ctx := metadata.NewIncomingContext(
+		context.Background(),
+		metadata.Pairs(RequestIDHeader, "<request-id>"),
+	)
+
+	cases := []struct {
+		assertion func(t *testing.T, st *status.Status)
+		err       error
+		name      string
+	}{
+		{
+			name: "invalid argument",
+			err: InvalidArgument(
+				ctx,
+				"invalid request",
+				FieldViolation{Field: "<field>", Description: "<description>"},
+			),
+			assertion: func(t *testing.T, st *status.Status) {
+				if st.Code() != codes.InvalidArgument {
+					t.Fatalf("expected code %v, got %v", codes.InvalidArgument, st.Code())
+				}
+
+				br := findDetail[*errdetails.BadRequest](t, st)
+				if len(br.GetFieldViolations()) != 1 ||
+					br.GetFieldViolations()[0].GetField() != "<field>" ||
+					br.GetFieldViolations()[0].GetDescription() != "<description>" {
+					t.Fatalf("unexpected field violations %v", br.GetFieldViolations())
+				}
+			},
+		},
+		{
+			name: "not found",
+			err:  NotFound(ctx, "<REASON>", "<message>"),
+			assertion: func(t *testing.T, st *status.Status) {
+				if st.Code() != codes.NotFound {
+					t.Fatalf("expected code %v, got %v", codes.NotFound, st.Code())
+				}
+
+				info := findDetail[*errdetails.ErrorInfo](t, st)
+				if info.GetReason() != "<REASON>" {
+					t.Fatalf("expected reason %q, got %q", "<REASON>", info.GetReason())
+				}
+			},
+		},
+		{
+			name: "failed precondition",
+			err:  FailedPrecondition(ctx, "<REASON>", "<message>"),
+			assertion: func(t *testing.T, st *status.Status) {
+				if st.Code() != codes.FailedPrecondition {
+					t.Fatalf(
+						"expected code %v, got %v",
+						codes.FailedPrecondition,
+						st.Code(),
+					)
+				}
+
+				info := findDetail[*errdetails.ErrorInfo](t, st)
+				if info.GetReason() != "<REASON>" {
+					t.Fatalf("expected reason %q, got %q", "<REASON>", info.GetReason())
+				}
+			},
+		},
+		{
+			name: "permission denied",
+			err:  PermissionDenied(ctx, "<REASON>", "<message>"),
+			assertion: func(t *testing.T, st *status.Status) {
+				if st.Code() != codes.PermissionDenied {
+					t.Fatalf(
+						"expected code %v, got %v",
+						codes.PermissionDenied,
+						st.Code(),
+					)
+				}
+
+				info := findDetail[*errdetails.ErrorInfo](t, st)
+				if info.GetReason() != "<REASON>" {
+					t.Fatalf("expected reason %q, got %q", "<REASON>", info.GetReason())
+				}
+			},
+		},
+		{
+			name: "aborted",
+			err:  Aborted(ctx, "<REASON>", "<message>"),
+			assertion: func(t *testing.T, st *status.Status) {
+				if st.Code() != codes.Aborted {
+					t.Fatalf("expected code %v, got %v", codes.Aborted, st.Code())
+				}
+
+				info := findDetail[*errdetails.ErrorInfo](t, st)
+				if info.GetReason() != "<REASON>" {
+					t.Fatalf("expected reason %q, got %q", "<REASON>", info.GetReason())
+				}
+			},
+		},
+		{
+			name: "internal error is sanitised",
+			err:  Internal(ctx, errors.New("<sensitive>")),
+			assertion: func(t *testing.T, st *status.Status) {
+				if st.Code() != codes.Internal {
+					t.Fatalf("expected code %v, got %v", codes.Internal, st.Code())
+				}
+
+				if st.Message() != "internal error" {
+					t.Fatalf("expected sanitised message, got %q", st.Message())
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			st := status.Convert(c.err)
+
+			info := findDetail[*errdetails.RequestInfo](t, st)
+			if info.GetRequestId() != "<request-id>" {
+				t.Fatalf(
+					"expected request ID %q, got %q",
+					"<request-id>",
+					info.GetRequestId(),
+				)
+			}
+
+			c.assertion(t, st)
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	t.Run("generates a new ID if none is provided", func(t *testing.T) {
+		a := RequestID(context.Background())
+		b := RequestID(context.Background())
+
+		if a == "" || a == b {
+			t.Fatalf("expected unique non-empty IDs, got %q and %q", a, b)
+		}
+	})
+}
+
+// findDetail returns the first detail of the given type attached to the status.
+func findDetail[T any](t *testing.T, st *status.Status) T {
+	t.Helper()
+
+	for _, d := range st.Details() {
+		if v, ok := d.(T); ok {
+			return v
+		}
+	}
+
+	var zero T
+	t.Fatalf("expected %T detail in %v", zero, st.Details())
+	return zero
+}
@@ -0,0 +1,6 @@
+// Package apierror provides a unified error model for the gRPC services.
+//
+// Errors are represented as gRPC statuses carrying google.rpc error details:
+// ErrorInfo with a machine-readable reason, RequestInfo with the ID of the
+// failed request and, for invalid requests, BadRequest with field violations.
+package apierror
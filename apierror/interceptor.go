@@ -0,0 +1,60 @@
+package apierror
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor returns a gRPC server interceptor that assigns an ID
+// to every request that does not carry one already. The ID is sent back to the
+// client in the response header metadata.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		ctx = withRequestID(ctx)
+
+		// Failing to send the header must not fail the request itself.
+		_ = grpc.SetHeader(
+			ctx,
+			metadata.Pairs(RequestIDHeader, RequestID(ctx)),
+		)
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC server interceptor that assigns an ID
+// to every streaming call that does not carry one already. The ID is sent back
+// to the client in the response header metadata.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		_ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx := withRequestID(ss.Context())
+
+		// Failing to send the header must not fail the call itself.
+		_ = ss.SetHeader(metadata.Pairs(RequestIDHeader, RequestID(ctx)))
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// serverStream is a grpc.ServerStream with a replaced context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"maps"
+	"slices"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// FieldChange is a change of a single field of an entity.
+type FieldChange struct {
+	// Before is the value of the field before the change, or nil if the field
+	// was not set.
+	Before *structpb.Value
+	// After is the value of the field after the change, or nil if the field
+	// is not set anymore.
+	After *structpb.Value
+	// Field is the name of the field, as it is encoded in JSON.
+	Field string
+}
+
+// Diff returns the changes of the top-level fields of an entity between its
+// states before and after a change, ordered by the field names. Either state
+// can be nil if the entity was created or deleted by the change.
+func Diff(before, after proto.Message) ([]FieldChange, error) {
+	b, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	names := slices.Sorted(maps.Keys(b))
+	for name := range a {
+		if _, ok := b[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var changes []FieldChange
+	for _, name := range names {
+		if proto.Equal(b[name], a[name]) {
+			continue
+		}
+
+		changes = append(changes, FieldChange{
+			Field:  name,
+			Before: b[name],
+			After:  a[name],
+		})
+	}
+
+	return changes, nil
+}
+
+// fields returns the JSON values of the set fields of a message, keyed by the
+// names of the fields.
+func fields(m proto.Message) (map[string]*structpb.Value, error) {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return nil, nil
+	}
+
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var s structpb.Struct
+	if err := protojson.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+
+	return s.GetFields(), nil
+}
@@ -0,0 +1,93 @@
+package audit_test
+
+import (
+	"testing"
+
+	. "github.com/danilvpetrov/entain/audit"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestDiff(t *testing.T) {
+	cases := []struct {
+		before   proto.Message
+		after    proto.Message
+		name     string
+		expected []FieldChange
+	}{
+		{
+			name:   "changed, added and removed fields",
+			before: mustStruct(t, map[string]any{"a": 1, "b": "x", "c": true}),
+			after:  mustStruct(t, map[string]any{"a": 1, "b": "y", "d": 2}),
+			expected: []FieldChange{
+				{
+					Field:  "b",
+					Before: structpb.NewStringValue("x"),
+					After:  structpb.NewStringValue("y"),
+				},
+				{
+					Field:  "c",
+					Before: structpb.NewBoolValue(true),
+				},
+				{
+					Field: "d",
+					After: structpb.NewNumberValue(2),
+				},
+			},
+		},
+		{
+			name:   "created entity",
+			before: (*structpb.Struct)(nil),
+			after:  mustStruct(t, map[string]any{"a": 1}),
+			expected: []FieldChange{
+				{Field: "a", After: structpb.NewNumberValue(1)},
+			},
+		},
+		{
+			name:   "deleted entity",
+			before: mustStruct(t, map[string]any{"a": 1}),
+			expected: []FieldChange{
+				{Field: "a", Before: structpb.NewNumberValue(1)},
+			},
+		},
+		{
+			name:   "unchanged entity",
+			before: mustStruct(t, map[string]any{"a": []any{1, "x"}}),
+			after:  mustStruct(t, map[string]any{"a": []any{1, "x"}}),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := Diff(c.before, c.after)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if len(actual) != len(c.expected) {
+				t.Fatalf("expected %d changes, got %v", len(c.expected), actual)
+			}
+
+			for i, expected := range c.expected {
+				a := actual[i]
+				if a.Field != expected.Field ||
+					!proto.Equal(a.Before, expected.Before) ||
+					!proto.Equal(a.After, expected.After) {
+					t.Fatalf("expected change %v, got %v", expected, a)
+				}
+			}
+		})
+	}
+}
+
+// mustStruct is a test helper that returns a struct with the given fields.
+func mustStruct(t *testing.T, fields map[string]any) *structpb.Struct {
+	t.Helper()
+
+	s, err := structpb.NewStruct(fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
@@ -0,0 +1,9 @@
+// Package audit describes the changes made to races and sports events for the
+// audit logs of the services.
+//
+// The services record an audit entry for every change made through their
+// APIs, within the same transaction as the change itself. An entry identifies
+// the admin who made the change, the RPC they called, the changed entity and
+// the fields of the entity that were changed, with their values before and
+// after the change.
+package audit
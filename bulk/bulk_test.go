@@ -0,0 +1,314 @@
+package bulk_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	. "github.com/danilvpetrov/entain/bulk"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRoundTrip(t *testing.T) {
+	start := time.Date(2026, 10, 19, 14, 30, 0, 0, time.UTC)
+
+	races := []proto.Message{
+		&racingapi.Race{
+			Id:                  1,
+			MeetingId:           2,
+			Name:                "Race 1",
+			Number:              3,
+			Visible:             true,
+			AdvertisedStartTime: timestamppb.New(start),
+			Status:              racingapi.Race_OPEN,
+			Timezone:            "Australia/Melbourne",
+			Etag:                "4",
+		},
+		&racingapi.Race{
+			Name: "Race, \"quoted\"\nwith a new line",
+		},
+	}
+
+	events := []proto.Message{
+		&sportsapi.Event{
+			Id:                  1,
+			Name:                "Home vs Away",
+			Category:            sportsapi.Event_SOCCER,
+			Competition:         "League",
+			AdvertisedStartTime: timestamppb.New(start),
+			MatchState:          sportsapi.Event_IN_PLAY,
+			ArchiveTime:         timestamppb.New(start.Add(time.Hour)),
+		},
+	}
+
+	for _, f := range []Format{CSV, JSONLines, Parquet} {
+		t.Run(string(f), func(t *testing.T) {
+			assertRoundTrip(t, f, races)
+			assertRoundTrip(t, f, events)
+			assertRoundTrip(t, f, []proto.Message{&racingapi.Race{}})
+		})
+	}
+}
+
+func TestReaderRead(t *testing.T) {
+	cases := []struct {
+		name     string
+		format   Format
+		data     string
+		expected []string
+	}{
+		{
+			name:   "CSV",
+			format: CSV,
+			data: "id,name,visible,status,advertisedStartTime\n" +
+				"1,Race 1,true,OPEN,2026-10-19T14:30:00Z\n" +
+				"x,Race 2,true,OPEN,\n" +
+				"3,Race 3,yes,OPEN,\n" +
+				"4,Race 4,false,PENDING,\n" +
+				"5,Race 5,false,CLOSED,19/10/2026\n" +
+				"6,Race 6\n" +
+				"7,Race 7,false,2,\n",
+			expected: []string{
+				"",
+				`row 2: column "id": value "x" is not a valid int64`,
+				`row 3: column "visible": value "yes" is not a valid bool`,
+				`row 4: column "status": unknown Status value "PENDING"`,
+				`row 5: column "advertised_start_time": value must be a ` +
+					`time in the RFC 3339 format`,
+				"row 6: wrong number of fields",
+				"",
+			},
+		},
+		{
+			name:   "JSON Lines",
+			format: JSONLines,
+			data: `{"id":"1","name":"Race 1"}` + "\n" +
+				"\n" +
+				`{"id":"x"}` + "\n" +
+				`{"name":"Race 3"}` + "\n",
+			expected: []string{
+				"",
+				"row 2: ",
+				"",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r, err := NewReader(
+				c.format,
+				strings.NewReader(c.data),
+				(&racingapi.Race{}).ProtoReflect().Descriptor(),
+			)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			for i, expected := range c.expected {
+				err := r.Read(&racingapi.Race{})
+
+				var rowErr *RowError
+				switch {
+				case expected == "" && err != nil:
+					t.Fatalf("expected row %d to be read, got %v", i+1, err)
+				case expected == "":
+				case !errors.As(err, &rowErr):
+					t.Fatalf("expected row %d to fail, got %v", i+1, err)
+				case !strings.HasPrefix(err.Error(), expected):
+					t.Fatalf("expected %q error, got %q", expected, err)
+				}
+			}
+
+			if err := r.Read(&racingapi.Race{}); !errors.Is(err, io.EOF) {
+				t.Fatalf("expected %v error, got %v", io.EOF, err)
+			}
+		})
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	cases := []struct {
+		name   string
+		format Format
+		data   string
+	}{
+		{
+			name:   "empty CSV",
+			format: CSV,
+		},
+		{
+			name:   "unknown CSV column",
+			format: CSV,
+			data:   "id,colour\n1,red\n",
+		},
+		{
+			name:   "duplicate CSV column",
+			format: CSV,
+			data:   "id,name,meetingId,meeting_id\n",
+		},
+		{
+			name:   "repeated field",
+			format: CSV,
+			data:   "id,scores\n",
+		},
+		{
+			name:   "malformed Parquet",
+			format: Parquet,
+			data:   "id,name\n1,Race 1\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := NewReader(
+				c.format,
+				strings.NewReader(c.data),
+				(&sportsapi.Event{}).ProtoReflect().Descriptor(),
+			)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func FuzzReader(f *testing.F) {
+	f.Add("csv", "id,name,visible,status,advertisedStartTime\n"+
+		"1,Race 1,true,OPEN,2026-10-19T14:30:00Z\n")
+	f.Add("csv", "id,name\n\"1\",\"Race, \"\"quoted\"\"\"\n2\n")
+	f.Add("csv", "id,scores\n")
+	f.Add("csv", "")
+	f.Add("jsonl", `{"id":"1","name":"Race 1"}`+"\n\n"+`{"id":"x"}`)
+	f.Add("jsonl", `{"advertisedStartTime":"2026-10-19T14:30:00Z"}`)
+	f.Add("jsonl", "{")
+	f.Add("parquet", "PAR1")
+
+	f.Fuzz(func(t *testing.T, format, data string) {
+		ff, err := ParseFormat(format)
+		if err != nil {
+			t.Skip()
+		}
+
+		for _, m := range []proto.Message{
+			&racingapi.Race{},
+			&sportsapi.Event{},
+		} {
+			r, err := NewReader(
+				ff,
+				strings.NewReader(data),
+				m.ProtoReflect().Descriptor(),
+			)
+			if err != nil {
+				continue
+			}
+
+			// The reader either reads the rows or reports them as
+			// malformed, until the end of the data or an error reading it.
+			// Every row of text formats takes at least a byte of the data,
+			// while Parquet tables are read as a whole by NewReader.
+			for i := 0; ; i++ {
+				if ff != Parquet && i > len(data) {
+					t.Fatal("expected the reader to reach the end of the data")
+				}
+
+				err := r.Read(m)
+
+				var rowErr *RowError
+				if err == nil || errors.As(err, &rowErr) {
+					continue
+				}
+				break
+			}
+		}
+	})
+}
+
+func TestFormatOf(t *testing.T) {
+	cases := []struct {
+		path     string
+		expected Format
+	}{
+		{path: "races.csv", expected: CSV},
+		{path: "/tmp/races.CSV", expected: CSV},
+		{path: "events.jsonl", expected: JSONLines},
+		{path: "events.ndjson", expected: JSONLines},
+		{path: "events.parquet", expected: Parquet},
+		{path: "events.xlsx"},
+		{path: "events"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			f, err := FormatOf(c.path)
+			if c.expected == "" {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", f)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if f != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, f)
+			}
+		})
+	}
+}
+
+// assertRoundTrip is a test helper that asserts that the given messages are
+// read as they are written in the given format.
+func assertRoundTrip(t *testing.T, f Format, messages []proto.Message) {
+	t.Helper()
+
+	desc := messages[0].ProtoReflect().Descriptor()
+	newMessage := func() proto.Message {
+		return messages[0].ProtoReflect().New().Interface()
+	}
+
+	var buf bytes.Buffer
+
+	w, err := NewWriter(f, &buf, desc)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, m := range messages {
+		if err := w.Write(m); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	r, err := NewReader(f, &buf, desc)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	for _, expected := range messages {
+		actual := newMessage()
+		if err := r.Read(actual); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if !proto.Equal(actual, expected) {
+			t.Fatalf("expected %v, got %v", expected, actual)
+		}
+	}
+
+	if err := r.Read(newMessage()); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected %v error, got %v", io.EOF, err)
+	}
+}
@@ -0,0 +1,123 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// csvReader reads messages from a CSV table.
+type csvReader struct {
+	r      *csv.Reader
+	fields []protoreflect.FieldDescriptor
+	row    int
+}
+
+// newCSVReader returns a reader of a CSV table, reading its header.
+func newCSVReader(
+	r io.Reader,
+	desc protoreflect.MessageDescriptor,
+) (*csvReader, error) {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("missing CSV header")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading CSV header: %w", err)
+	}
+
+	fields, err := fieldsByColumn(desc, header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &csvReader{r: cr, fields: fields}, nil
+}
+
+// Read reads the next row of the table into m.
+func (r *csvReader) Read(m proto.Message) error {
+	proto.Reset(m)
+
+	record, err := r.r.Read()
+	if errors.Is(err, io.EOF) {
+		return io.EOF
+	}
+	r.row++
+
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		// Records with a wrong number of fields are still returned, so that
+		// the rest of the table can be read.
+		return &RowError{Row: r.row, Err: parseErr.Err}
+	}
+	if err != nil {
+		return err
+	}
+
+	return setValues(m.ProtoReflect(), r.fields, record, r.row)
+}
+
+// csvWriter writes messages to a CSV table.
+type csvWriter struct {
+	w       *csv.Writer
+	fields  []protoreflect.FieldDescriptor
+	started bool
+}
+
+// newCSVWriter returns a writer of a CSV table.
+func newCSVWriter(
+	w io.Writer,
+	desc protoreflect.MessageDescriptor,
+) *csvWriter {
+	return &csvWriter{
+		w:      csv.NewWriter(w),
+		fields: columnFields(desc),
+	}
+}
+
+// Write writes m as the next row of the table, writing the header first if m
+// is the first row.
+func (w *csvWriter) Write(m proto.Message) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	record := make([]string, 0, len(w.fields))
+	for _, fd := range w.fields {
+		record = append(record, formatValue(m.ProtoReflect(), fd))
+	}
+
+	return w.w.Write(record)
+}
+
+// Close flushes the rows, writing the header if the table is empty.
+func (w *csvWriter) Close() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// writeHeader writes the header of the table unless it is already written.
+func (w *csvWriter) writeHeader() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	header := make([]string, 0, len(w.fields))
+	for _, fd := range w.fields {
+		header = append(header, string(fd.Name()))
+	}
+
+	return w.w.Write(header)
+}
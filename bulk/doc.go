@@ -0,0 +1,13 @@
+// Package bulk reads and writes tables of races and sports events in the file
+// formats used to exchange them with spreadsheets and data analysis tools,
+// that is CSV, JSON Lines and Apache Parquet.
+//
+// Each row of a table is a message, such as racing.v1.Race or sports.Event. In
+// CSV and Parquet files, each column holds a field of the messages that is not
+// repeated and is either a scalar, an enum or a google.protobuf.Timestamp.
+// Columns are named after the fields, as in "advertised_start_time", though the
+// JSON names of the fields, as in "advertisedStartTime", are also accepted on
+// read. Enums are written by name and timestamps in the RFC 3339 format, or as
+// microsecond timestamps in Parquet files. In JSON Lines files, each line is
+// the JSON representation of a message.
+package bulk
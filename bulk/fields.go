@@ -0,0 +1,263 @@
+package bulk
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// timestampName is the full name of the google.protobuf.Timestamp message.
+const timestampName = "google.protobuf.Timestamp"
+
+// columnFields returns the fields of the messages with the given descriptor
+// that are stored in the columns of CSV tables, in the order of the columns.
+func columnFields(
+	desc protoreflect.MessageDescriptor,
+) []protoreflect.FieldDescriptor {
+	var fields []protoreflect.FieldDescriptor
+
+	for i := range desc.Fields().Len() {
+		fd := desc.Fields().Get(i)
+
+		switch {
+		case fd.IsList(), fd.IsMap():
+		case fd.Kind() == protoreflect.BytesKind,
+			fd.Kind() == protoreflect.GroupKind:
+		case fd.Kind() == protoreflect.MessageKind && !isTimestamp(fd):
+		default:
+			fields = append(fields, fd)
+		}
+	}
+
+	return fields
+}
+
+// fieldsByColumn returns the fields of the messages with the given descriptor
+// that are stored in the columns with the given names.
+func fieldsByColumn(
+	desc protoreflect.MessageDescriptor,
+	names []string,
+) ([]protoreflect.FieldDescriptor, error) {
+	byName := map[string]protoreflect.FieldDescriptor{}
+	for _, fd := range columnFields(desc) {
+		byName[string(fd.Name())] = fd
+		byName[fd.JSONName()] = fd
+	}
+
+	fields := make([]protoreflect.FieldDescriptor, 0, len(names))
+	seen := map[protoreflect.FieldDescriptor]bool{}
+
+	for _, name := range names {
+		fd, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+
+		if seen[fd] {
+			return nil, fmt.Errorf("duplicate column %q", name)
+		}
+		seen[fd] = true
+
+		fields = append(fields, fd)
+	}
+
+	return fields, nil
+}
+
+// isTimestamp returns true if the field is a google.protobuf.Timestamp.
+func isTimestamp(fd protoreflect.FieldDescriptor) bool {
+	return fd.Kind() == protoreflect.MessageKind &&
+		fd.Message().FullName() == timestampName
+}
+
+// formatValue returns the text of the value of a field in CSV tables. Unset
+// timestamps are empty.
+func formatValue(
+	m protoreflect.Message,
+	fd protoreflect.FieldDescriptor,
+) string {
+	if isTimestamp(fd) && !m.Has(fd) {
+		return ""
+	}
+
+	v := m.Get(fd)
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return strconv.FormatBool(v.Bool())
+	case protoreflect.Int32Kind,
+		protoreflect.Sint32Kind,
+		protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind,
+		protoreflect.Sint64Kind,
+		protoreflect.Sfixed64Kind:
+		return strconv.FormatInt(v.Int(), 10)
+	case protoreflect.Uint32Kind,
+		protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind,
+		protoreflect.Fixed64Kind:
+		return strconv.FormatUint(v.Uint(), 10)
+	case protoreflect.FloatKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32)
+	case protoreflect.DoubleKind:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case protoreflect.EnumKind:
+		return enumName(fd, v.Enum())
+	case protoreflect.MessageKind:
+		return timeOf(v.Message()).Format(time.RFC3339Nano)
+	default:
+		return v.String()
+	}
+}
+
+// parseValue parses the text of the value of a field of m.
+func parseValue(
+	m protoreflect.Message,
+	fd protoreflect.FieldDescriptor,
+	s string,
+) (protoreflect.Value, error) {
+	v, err := parseText(m, fd, s)
+
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return v, fmt.Errorf("value %q is not a valid %s", s, fd.Kind())
+	}
+
+	return v, err
+}
+
+// parseText parses the text of the value of a field of m, returning the errors
+// of the strconv package as they are.
+func parseText(
+	m protoreflect.Message,
+	fd protoreflect.FieldDescriptor,
+	s string,
+) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(v), err
+	case protoreflect.Int32Kind,
+		protoreflect.Sint32Kind,
+		protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(v)), err
+	case protoreflect.Int64Kind,
+		protoreflect.Sint64Kind,
+		protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(v), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(v)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(v), err
+	case protoreflect.FloatKind:
+		v, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(v)), err
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(v), err
+	case protoreflect.EnumKind:
+		return parseEnum(fd, s)
+	case protoreflect.MessageKind:
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return protoreflect.Value{}, errors.New(
+				"value must be a time in the RFC 3339 format",
+			)
+		}
+		return timestampValue(m, fd, t), nil
+	default:
+		return protoreflect.ValueOfString(s), nil
+	}
+}
+
+// enumName returns the name of an enum value, or its number if the value is
+// not known.
+func enumName(
+	fd protoreflect.FieldDescriptor,
+	n protoreflect.EnumNumber,
+) string {
+	if ev := fd.Enum().Values().ByNumber(n); ev != nil {
+		return string(ev.Name())
+	}
+	return strconv.Itoa(int(n))
+}
+
+// parseEnum parses an enum value given by its name or number.
+func parseEnum(
+	fd protoreflect.FieldDescriptor,
+	s string,
+) (protoreflect.Value, error) {
+	values := fd.Enum().Values()
+
+	if ev := values.ByName(protoreflect.Name(s)); ev != nil {
+		return protoreflect.ValueOfEnum(ev.Number()), nil
+	}
+
+	if n, err := strconv.ParseInt(s, 10, 32); err == nil {
+		if ev := values.ByNumber(protoreflect.EnumNumber(n)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+	}
+
+	return protoreflect.Value{}, fmt.Errorf(
+		"unknown %s value %q",
+		fd.Enum().Name(),
+		s,
+	)
+}
+
+// timeOf returns the time of a google.protobuf.Timestamp message.
+func timeOf(m protoreflect.Message) time.Time {
+	fields := m.Descriptor().Fields()
+	return time.Unix(
+		m.Get(fields.ByName("seconds")).Int(),
+		m.Get(fields.ByName("nanos")).Int(),
+	).UTC()
+}
+
+// timestampValue returns a google.protobuf.Timestamp value of a field of m.
+func timestampValue(
+	m protoreflect.Message,
+	fd protoreflect.FieldDescriptor,
+	t time.Time,
+) protoreflect.Value {
+	ts := m.NewField(fd).Message()
+	fields := ts.Descriptor().Fields()
+
+	nanos := int32(t.Nanosecond()) //nolint:gosec // Less than a second.
+
+	ts.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(t.Unix()))
+	ts.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(nanos))
+	return protoreflect.ValueOfMessage(ts)
+}
+
+// setValues sets the fields of m to the given values, read from the given row
+// of a table. Empty values leave the fields unset.
+func setValues(
+	m protoreflect.Message,
+	fields []protoreflect.FieldDescriptor,
+	values []string,
+	row int,
+) error {
+	for i, fd := range fields {
+		if values[i] == "" {
+			continue
+		}
+
+		v, err := parseValue(m, fd, values[i])
+		if err != nil {
+			return &RowError{Row: row, Column: string(fd.Name()), Err: err}
+		}
+
+		m.Set(fd, v)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package bulk
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Format is a file format of tables of messages.
+type Format string
+
+const (
+	// CSV is the comma-separated values format, with a header row naming the
+	// columns.
+	CSV Format = "csv"
+	// JSONLines is the JSON Lines format, with a message per line.
+	JSONLines Format = "jsonl"
+	// Parquet is the Apache Parquet columnar format, with a flat column per
+	// field.
+	Parquet Format = "parquet"
+)
+
+// ParseFormat parses the name of a format.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case CSV, JSONLines, Parquet:
+		return f, nil
+	case "ndjson":
+		return JSONLines, nil
+	default:
+		return "", fmt.Errorf(
+			"unknown format %q, expected %q, %q or %q",
+			s,
+			CSV,
+			JSONLines,
+			Parquet,
+		)
+	}
+}
+
+// FormatOf returns the format of the file with the given path, based on its
+// extension.
+func FormatOf(path string) (Format, error) {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	if ext == "" {
+		return "", fmt.Errorf(
+			"cannot infer format of %q without extension",
+			path,
+		)
+	}
+
+	return ParseFormat(ext)
+}
+
+// Reader reads messages from a table.
+type Reader interface {
+	// Read reads the next row of the table into m, which is reset first. It
+	// returns io.EOF after the last row, and a *RowError if the row is
+	// malformed, in which case the following rows can still be read.
+	Read(m proto.Message) error
+}
+
+// Writer writes messages to a table.
+type Writer interface {
+	// Write writes m as the next row of the table.
+	Write(m proto.Message) error
+	// Close writes the rows that are buffered and the end of the table, if
+	// the format has any. It does not close the underlying writer.
+	Close() error
+}
+
+// RowError is the error returned when a row of a table is malformed.
+type RowError struct {
+	// Err is the error describing the problem.
+	Err error
+	// Column is the name of the malformed column, if known.
+	Column string
+	// Row is the position of the row in the table, starting at 1. The header
+	// of CSV files is not counted.
+	Row int
+}
+
+// Error returns the error message.
+func (e *RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+	}
+	return fmt.Sprintf("row %d: column %q: %v", e.Row, e.Column, e.Err)
+}
+
+// Unwrap returns the error describing the problem.
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// NewReader returns a reader of a table of messages of the given type in the
+// given format. Parquet files are read into memory as a whole.
+func NewReader(
+	f Format,
+	r io.Reader,
+	desc protoreflect.MessageDescriptor,
+) (Reader, error) {
+	switch f {
+	case CSV:
+		return newCSVReader(r, desc)
+	case JSONLines:
+		return newJSONLinesReader(r), nil
+	case Parquet:
+		return newParquetReader(r, desc)
+	default:
+		return nil, fmt.Errorf("unknown format %q", f)
+	}
+}
+
+// NewWriter returns a writer of a table of messages of the given type in the
+// given format.
+func NewWriter(
+	f Format,
+	w io.Writer,
+	desc protoreflect.MessageDescriptor,
+) (Writer, error) {
+	switch f {
+	case CSV:
+		return newCSVWriter(w, desc), nil
+	case JSONLines:
+		return newJSONLinesWriter(w), nil
+	case Parquet:
+		return newParquetWriter(w, desc)
+	default:
+		return nil, fmt.Errorf("unknown format %q", f)
+	}
+}
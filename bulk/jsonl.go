@@ -0,0 +1,88 @@
+package bulk
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxLineSize is the maximum size of a line of a JSON Lines table.
+const maxLineSize = 1 << 20
+
+// jsonLinesReader reads messages from a JSON Lines table.
+type jsonLinesReader struct {
+	s   *bufio.Scanner
+	row int
+}
+
+// newJSONLinesReader returns a reader of a JSON Lines table.
+func newJSONLinesReader(r io.Reader) *jsonLinesReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, maxLineSize)
+
+	return &jsonLinesReader{s: s}
+}
+
+// Read reads the next row of the table into m. Blank lines are skipped.
+func (r *jsonLinesReader) Read(m proto.Message) error {
+	proto.Reset(m)
+
+	for r.s.Scan() {
+		line := bytes.TrimSpace(r.s.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		r.row++
+
+		if err := protojson.Unmarshal(line, m); err != nil {
+			return &RowError{Row: r.row, Err: err}
+		}
+
+		return nil
+	}
+
+	if err := r.s.Err(); err != nil {
+		return err
+	}
+
+	return io.EOF
+}
+
+// jsonLinesWriter writes messages to a JSON Lines table.
+type jsonLinesWriter struct {
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+// newJSONLinesWriter returns a writer of a JSON Lines table.
+func newJSONLinesWriter(w io.Writer) *jsonLinesWriter {
+	return &jsonLinesWriter{w: w}
+}
+
+// Write writes m as the next line of the table.
+func (w *jsonLinesWriter) Write(m proto.Message) error {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	// The output of protojson is deliberately unstable, compacting it keeps
+	// each message on a single line.
+	w.buf.Reset()
+	if err := json.Compact(&w.buf, data); err != nil {
+		return err
+	}
+	w.buf.WriteByte('\n')
+
+	_, err = w.w.Write(w.buf.Bytes())
+	return err
+}
+
+// Close does nothing, as JSON Lines tables have no end.
+func (w *jsonLinesWriter) Close() error {
+	return nil
+}
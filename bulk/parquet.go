@@ -0,0 +1,255 @@
+package bulk
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/types"
+	"github.com/xitongsys/parquet-go/writer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// parquetReader reads messages from a Parquet table.
+type parquetReader struct {
+	fields []protoreflect.FieldDescriptor
+	// columns hold the text of the values of the columns of all rows.
+	columns [][]string
+	row     int
+}
+
+// newParquetReader returns a reader of a Parquet table, reading the whole
+// table into memory, as Parquet files can only be read from their end.
+func newParquetReader(
+	r io.Reader,
+	desc protoreflect.MessageDescriptor,
+) (_ *parquetReader, err error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Parquet library panics on some malformed files.
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("malformed Parquet file: %v", v)
+		}
+	}()
+
+	pr, err := reader.NewParquetColumnReader(
+		buffer.NewBufferFileFromBytesNoAlloc(data),
+		1,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("malformed Parquet file: %w", err)
+	}
+
+	sh := pr.SchemaHandler
+	rows := pr.GetNumRows()
+
+	names := make([]string, 0, len(sh.ValueColumns))
+	columns := make([][]string, 0, len(sh.ValueColumns))
+
+	for i, path := range sh.ValueColumns {
+		index := sh.MapIndex[path]
+		se := sh.SchemaElements[index]
+
+		// The paths of flat columns only hold the root and the column.
+		if strings.Count(path, common.PAR_GO_PATH_DELIMITER) > 1 ||
+			se.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED {
+			return nil, fmt.Errorf(
+				"column %q is nested or repeated, which is not supported",
+				sh.GetExName(int(index)),
+			)
+		}
+		names = append(names, sh.GetExName(int(index)))
+
+		values, _, _, err := pr.ReadColumnByIndex(int64(i), rows)
+		if err != nil {
+			return nil, err
+		}
+
+		column := make([]string, 0, len(values))
+		for _, v := range values {
+			column = append(column, parquetText(se, v))
+		}
+		columns = append(columns, column)
+	}
+
+	fields, err := fieldsByColumn(desc, names)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parquetReader{fields: fields, columns: columns}, nil
+}
+
+// Read reads the next row of the table into m.
+func (r *parquetReader) Read(m proto.Message) error {
+	proto.Reset(m)
+
+	if len(r.columns) == 0 || r.row >= len(r.columns[0]) {
+		return io.EOF
+	}
+
+	values := make([]string, 0, len(r.columns))
+	for _, c := range r.columns {
+		values = append(values, c[r.row])
+	}
+	r.row++
+
+	return setValues(m.ProtoReflect(), r.fields, values, r.row)
+}
+
+// parquetText returns the text of a value of the Parquet column with the given
+// schema, as it is parsed by parseValue. Null values are empty, and timestamps
+// are formatted in the RFC 3339 format.
+func parquetText(se *parquet.SchemaElement, v any) string {
+	switch v := v.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		if unit, ok := timestampUnit(se); ok {
+			return time.Unix(0, v*int64(unit)).UTC().Format(time.RFC3339Nano)
+		}
+		return strconv.FormatInt(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		if se.GetType() == parquet.Type_INT96 && len(v) == 12 {
+			return types.INT96ToTime(v).UTC().Format(time.RFC3339Nano)
+		}
+		return v
+	default:
+		return ""
+	}
+}
+
+// timestampUnit returns the unit of the values of the Parquet column with the
+// given schema, if it is a timestamp column.
+func timestampUnit(se *parquet.SchemaElement) (time.Duration, bool) {
+	if se.IsSetLogicalType() && se.GetLogicalType().IsSetTIMESTAMP() {
+		switch unit := se.GetLogicalType().GetTIMESTAMP().GetUnit(); {
+		case unit.IsSetMILLIS():
+			return time.Millisecond, true
+		case unit.IsSetMICROS():
+			return time.Microsecond, true
+		case unit.IsSetNANOS():
+			return time.Nanosecond, true
+		}
+	}
+
+	switch se.GetConvertedType() {
+	case parquet.ConvertedType_TIMESTAMP_MILLIS:
+		return time.Millisecond, true
+	case parquet.ConvertedType_TIMESTAMP_MICROS:
+		return time.Microsecond, true
+	default:
+		return 0, false
+	}
+}
+
+// parquetWriter writes messages to a Parquet table.
+type parquetWriter struct {
+	fields []protoreflect.FieldDescriptor
+	pw     *writer.CSVWriter
+}
+
+// newParquetWriter returns a writer of a Parquet table.
+func newParquetWriter(
+	w io.Writer,
+	desc protoreflect.MessageDescriptor,
+) (*parquetWriter, error) {
+	fields := columnFields(desc)
+
+	schema := make([]string, 0, len(fields))
+	for _, fd := range fields {
+		schema = append(schema, columnSchema(fd))
+	}
+
+	pw, err := writer.NewCSVWriterFromWriter(schema, w, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parquetWriter{fields: fields, pw: pw}, nil
+}
+
+// Write writes m as the next row of the table.
+func (w *parquetWriter) Write(m proto.Message) error {
+	row := make([]any, 0, len(w.fields))
+	for _, fd := range w.fields {
+		row = append(row, columnValue(m.ProtoReflect(), fd))
+	}
+
+	return w.pw.Write(row)
+}
+
+// Close writes the buffered rows and the metadata of the table.
+func (w *parquetWriter) Close() error {
+	return w.pw.WriteStop()
+}
+
+// columnSchema returns the schema of the Parquet column of a field, in the
+// form of the metadata of the writer.CSVWriter. Timestamps are optional, so
+// that unset ones are null.
+func columnSchema(fd protoreflect.FieldDescriptor) string {
+	var typ string
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		typ = "type=BOOLEAN"
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		typ = "type=DOUBLE"
+	case protoreflect.StringKind, protoreflect.EnumKind:
+		typ = "type=BYTE_ARRAY, convertedtype=UTF8"
+	case protoreflect.MessageKind:
+		typ = "type=INT64, convertedtype=TIMESTAMP_MICROS, " +
+			"repetitiontype=OPTIONAL"
+	default:
+		typ = "type=INT64"
+	}
+
+	return fmt.Sprintf("name=%s, %s", fd.Name(), typ)
+}
+
+// columnValue returns the value of a field in Parquet tables. Unset timestamps
+// are null.
+func columnValue(m protoreflect.Message, fd protoreflect.FieldDescriptor) any {
+	if isTimestamp(fd) && !m.Has(fd) {
+		return nil
+	}
+
+	v := m.Get(fd)
+
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return v.Bool()
+	case protoreflect.Uint32Kind,
+		protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind,
+		protoreflect.Fixed64Kind:
+		return int64(v.Uint()) //nolint:gosec // Parquet integers are signed.
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float()
+	case protoreflect.StringKind:
+		return v.String()
+	case protoreflect.EnumKind:
+		return enumName(fd, v.Enum())
+	case protoreflect.MessageKind:
+		return timeOf(v.Message()).UnixMicro()
+	default:
+		return v.Int()
+	}
+}
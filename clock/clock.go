@@ -0,0 +1,28 @@
+package clock
+
+import "time"
+
+// Clock is a source of the current time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// System is a clock reading the system time.
+var System Clock = systemClock{}
+
+// systemClock is a clock reading the system time.
+type systemClock struct{}
+
+// Now returns the current system time.
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Fixed is a clock stopped at the given time.
+type Fixed time.Time
+
+// Now returns the time the clock is stopped at.
+func (c Fixed) Now() time.Time {
+	return time.Time(c)
+}
@@ -0,0 +1,3 @@
+// Package clock provides the current time to the services, so that it can be
+// fixed in tests or moved to a past moment to replay it.
+package clock
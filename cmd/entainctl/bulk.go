@@ -0,0 +1,475 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/bulk"
+	"google.golang.org/protobuf/proto"
+)
+
+// importFlags are the flags of the import commands.
+type importFlags struct {
+	Format       fileFormat
+	DryRun       bool
+	AllOrNothing bool
+}
+
+// newImportFlags defines the flags of an import command on the given flag
+// set.
+func newImportFlags(fs *flag.FlagSet) *importFlags {
+	f := &importFlags{}
+
+	fs.Var(
+		&f.Format,
+		"format",
+		"`format` of the file: csv, jsonl or parquet, inferred from its "+
+			"extension by default",
+	)
+	fs.BoolVar(
+		&f.DryRun,
+		"dry-run",
+		false,
+		"validate the rows without importing them",
+	)
+	fs.BoolVar(
+		&f.AllOrNothing,
+		"all-or-nothing",
+		false,
+		"import nothing if any of the rows is invalid",
+	)
+
+	return f
+}
+
+// exportFlags are the flags of the export commands.
+type exportFlags struct {
+	Format          fileFormat
+	IncludeArchived bool
+}
+
+// newExportFlags defines the flags of an export command on the given flag
+// set.
+func newExportFlags(fs *flag.FlagSet) *exportFlags {
+	f := &exportFlags{}
+
+	fs.Var(
+		&f.Format,
+		"format",
+		"`format` of the file: csv, jsonl or parquet, inferred from its "+
+			"extension by default",
+	)
+	fs.BoolVar(
+		&f.IncludeArchived,
+		"include-archived",
+		false,
+		"export archived rows as well",
+	)
+
+	return f
+}
+
+// setupImportRaces sets up the "races import" command.
+func setupImportRaces(fs *flag.FlagSet) runFunc {
+	f := newImportFlags(fs)
+
+	return func(
+		ctx context.Context,
+		c client,
+		p *printer,
+		args []string,
+	) error {
+		rows, err := readRows[*racingapi.Race](args[0], f.Format)
+		if err != nil {
+			return err
+		}
+
+		reqs := make([]*racingapi.ImportRacesRequest, 0, len(rows.Messages))
+		for _, race := range rows.Messages {
+			reqs = append(reqs, &racingapi.ImportRacesRequest{Race: race})
+		}
+		reqs[0].Options = &racingapi.ImportOptions{
+			DryRun:       rows.dryRun(f),
+			AllOrNothing: f.AllOrNothing,
+		}
+
+		resp, err := c.ImportRaces(ctx, reqs)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range resp.GetErrors() {
+			e.Row = rows.fileRow(e.GetRow())
+		}
+
+		return printImport(p, resp, resp.GetErrors(), rows.Malformed)
+	}
+}
+
+// setupExportRaces sets up the "races export" command.
+func setupExportRaces(fs *flag.FlagSet) runFunc {
+	f := newExportFlags(fs)
+
+	return func(ctx context.Context, c client, _ *printer, args []string) error {
+		return writeRows[*racingapi.Race](
+			args[0],
+			f.Format,
+			func(write func(*racingapi.Race) error) error {
+				return c.ExportRaces(
+					ctx,
+					&racingapi.ExportRacesRequest{
+						IncludeArchived: f.IncludeArchived,
+					},
+					write,
+				)
+			},
+		)
+	}
+}
+
+// setupImportEvents sets up the "events import" command.
+func setupImportEvents(fs *flag.FlagSet) runFunc {
+	f := newImportFlags(fs)
+
+	return func(
+		ctx context.Context,
+		c client,
+		p *printer,
+		args []string,
+	) error {
+		rows, err := readRows[*sportsapi.Event](args[0], f.Format)
+		if err != nil {
+			return err
+		}
+
+		reqs := make([]*sportsapi.ImportEventsRequest, 0, len(rows.Messages))
+		for _, event := range rows.Messages {
+			reqs = append(reqs, &sportsapi.ImportEventsRequest{Event: event})
+		}
+		reqs[0].Options = &sportsapi.ImportOptions{
+			DryRun:       rows.dryRun(f),
+			AllOrNothing: f.AllOrNothing,
+		}
+
+		resp, err := c.ImportEvents(ctx, reqs)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range resp.GetErrors() {
+			e.Row = rows.fileRow(e.GetRow())
+		}
+
+		return printImport(p, resp, resp.GetErrors(), rows.Malformed)
+	}
+}
+
+// setupExportEvents sets up the "events export" command.
+func setupExportEvents(fs *flag.FlagSet) runFunc {
+	f := newExportFlags(fs)
+
+	return func(ctx context.Context, c client, _ *printer, args []string) error {
+		return writeRows[*sportsapi.Event](
+			args[0],
+			f.Format,
+			func(write func(*sportsapi.Event) error) error {
+				return c.ExportEvents(
+					ctx,
+					&sportsapi.ExportEventsRequest{
+						IncludeArchived: f.IncludeArchived,
+					},
+					write,
+				)
+			},
+		)
+	}
+}
+
+// fileRows are the well-formed rows read from a file.
+type fileRows[M proto.Message] struct {
+	// Messages are the well-formed rows.
+	Messages []M
+	// Positions are the positions of the well-formed rows in the file,
+	// starting at 1.
+	Positions []int
+	// Malformed is the number of malformed rows, which are not imported.
+	Malformed int
+}
+
+// dryRun returns true if the rows are to be only validated with the given
+// flags. All-or-nothing imports of files with malformed rows are dry runs, so
+// that all errors are reported at once.
+func (r fileRows[M]) dryRun(f *importFlags) bool {
+	return f.DryRun || (f.AllOrNothing && r.Malformed > 0)
+}
+
+// fileRow returns the position in the file of the row at the given position
+// in the import call.
+func (r fileRows[M]) fileRow(row int64) int64 {
+	if row > 0 && row <= int64(len(r.Positions)) {
+		return int64(r.Positions[row-1])
+	}
+	return row
+}
+
+// readRows reads the rows of the file with the given path in the given
+// format, or in the format inferred from the path if it is empty. Malformed
+// rows are reported to the standard error. It fails if there are no
+// well-formed rows.
+func readRows[M proto.Message](
+	path string,
+	ff fileFormat,
+) (fileRows[M], error) {
+	var rows fileRows[M]
+
+	format, err := ff.of(path)
+	if err != nil {
+		return rows, err
+	}
+
+	f, err := openFile(path)
+	if err != nil {
+		return rows, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "error closing %s: %v\n", path, err)
+		}
+	}()
+
+	var zero M
+	desc := zero.ProtoReflect().Descriptor()
+
+	r, err := bulk.NewReader(format, f, desc)
+	if err != nil {
+		return rows, fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	for {
+		m, _ := zero.ProtoReflect().New().Interface().(M)
+
+		err := r.Read(m)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var rowErr *bulk.RowError
+		if errors.As(err, &rowErr) {
+			fmt.Fprintln(os.Stderr, rowErr)
+			rows.Malformed++
+			continue
+		}
+		if err != nil {
+			return rows, fmt.Errorf("error reading %s: %w", path, err)
+		}
+
+		rows.Messages = append(rows.Messages, m)
+		rows.Positions = append(
+			rows.Positions,
+			len(rows.Messages)+rows.Malformed,
+		)
+	}
+
+	if len(rows.Messages) == 0 {
+		return rows, errors.New("no rows to import")
+	}
+
+	return rows, nil
+}
+
+// importResponse is the response of an import RPC.
+type importResponse interface {
+	proto.Message
+
+	GetCreated() int64
+	GetUpdated() int64
+	GetUnchanged() int64
+	GetCommitted() bool
+}
+
+// importError is an error of a row that could not be imported.
+type importError interface {
+	GetRow() int64
+	GetField() string
+	GetDescription() string
+}
+
+// printImport prints the outcome of an import, with the errors of the invalid
+// rows reported to the standard error in the table format. It fails if any of
+// the rows could not be imported.
+func printImport[E importError](
+	p *printer,
+	resp importResponse,
+	errs []E,
+	malformed int,
+) error {
+	if p.format == formatTable {
+		for _, e := range errs {
+			fmt.Fprintf(
+				os.Stderr,
+				"row %d: column %q: %s\n",
+				e.GetRow(),
+				e.GetField(),
+				e.GetDescription(),
+			)
+		}
+	}
+
+	failed := malformed + len(errs)
+
+	if err := p.Print(resp, table{
+		Header: []string{"CREATED", "UPDATED", "UNCHANGED", "FAILED", "COMMITTED"},
+		Rows: [][]string{{
+			strconv.FormatInt(resp.GetCreated(), 10),
+			strconv.FormatInt(resp.GetUpdated(), 10),
+			strconv.FormatInt(resp.GetUnchanged(), 10),
+			strconv.Itoa(failed),
+			strconv.FormatBool(resp.GetCommitted()),
+		}},
+	}); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d rows could not be imported", failed)
+	}
+
+	return nil
+}
+
+// writeRows writes the rows passed by export to write to the file with the
+// given path in the given format, or in the format inferred from the path if
+// it is empty. The number of exported rows is reported to the standard error.
+// Partially written files are removed.
+func writeRows[M proto.Message](
+	path string,
+	ff fileFormat,
+	export func(write func(M) error) error,
+) error {
+	format, err := ff.of(path)
+	if err != nil {
+		return err
+	}
+
+	f, err := createFile(path)
+	if err != nil {
+		return err
+	}
+
+	var (
+		zero M
+		n    int
+	)
+
+	err = func() error {
+		w, err := bulk.NewWriter(format, f, zero.ProtoReflect().Descriptor())
+		if err != nil {
+			return err
+		}
+
+		if err := export(func(m M) error {
+			n++
+			return w.Write(m)
+		}); err != nil {
+			return err
+		}
+
+		return w.Close()
+	}()
+
+	if err := errors.Join(err, f.Close()); err != nil {
+		if path != "-" {
+			// Partial exports are not left behind.
+			_ = os.Remove(path)
+		}
+		return fmt.Errorf("error exporting rows: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "exported: %d\n", n)
+
+	return nil
+}
+
+// openFile opens the file with the given path for reading, or returns the
+// standard input if the path is "-".
+func openFile(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	return os.Open(path) //nolint:gosec // The path is given by the user.
+}
+
+// createFile creates the file with the given path for writing, or returns the
+// standard output if the path is "-".
+func createFile(path string) (io.WriteCloser, error) {
+	if path == "-" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+
+	return os.Create(path) //nolint:gosec // The path is given by the user.
+}
+
+// nopWriteCloser is an io.WriteCloser whose Close method does nothing.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close does nothing.
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// fileFormat is the format of the files of the import and export commands. It
+// is inferred from the extension of the file if it is empty.
+type fileFormat bulk.Format
+
+// String returns the name of the format.
+func (f *fileFormat) String() string {
+	if f == nil {
+		return ""
+	}
+
+	return string(*f)
+}
+
+// Set sets the format to the one with the given name.
+func (f *fileFormat) Set(s string) error {
+	v, err := bulk.ParseFormat(s)
+	if err != nil {
+		return err
+	}
+
+	*f = fileFormat(v)
+	return nil
+}
+
+// Values returns the names of the formats.
+func (f *fileFormat) Values() []string {
+	return []string{
+		string(bulk.CSV),
+		string(bulk.JSONLines),
+		string(bulk.Parquet),
+	}
+}
+
+// of returns the format of the file with the given path, which is "-" for
+// the standard streams.
+func (f fileFormat) of(path string) (bulk.Format, error) {
+	if f != "" {
+		return bulk.Format(f), nil
+	}
+
+	if path == "-" {
+		return "", errors.New("--format is required to use standard streams")
+	}
+
+	return bulk.FormatOf(path)
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/tenant"
+	rpccode "google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+var (
+	racingServiceAddr        = os.Getenv("RACING_SERVICE_ADDR")
+	defaultRacingServiceAddr = "localhost:9000"
+
+	sportsServiceAddr        = os.Getenv("SPORTS_SERVICE_ADDR")
+	defaultSportsServiceAddr = "localhost:9010"
+
+	gatewayAddr        = os.Getenv("GATEWAY_ADDR")
+	defaultGatewayAddr = "localhost:8000"
+
+	adminToken  = os.Getenv("ADMIN_TOKEN")
+	tenantToken = os.Getenv("TENANT_TOKEN")
+)
+
+// client calls the racing and sports services.
+type client interface {
+	ListRaces(
+		ctx context.Context,
+		req *racingapi.ListRacesRequest,
+	) (*racingapi.ListRacesResponse, error)
+	GetRace(
+		ctx context.Context,
+		req *racingapi.GetRaceRequest,
+	) (*racingapi.Race, error)
+	ListEvents(
+		ctx context.Context,
+		req *sportsapi.ListEventsRequest,
+	) (*sportsapi.ListEventsResponse, error)
+	GetEvent(
+		ctx context.Context,
+		req *sportsapi.GetEventRequest,
+	) (*sportsapi.Event, error)
+	// WatchEvent calls fn with every snapshot of the watched sport event until
+	// the stream ends.
+	WatchEvent(
+		ctx context.Context,
+		req *sportsapi.WatchEventRequest,
+		fn func(*sportsapi.Event) error,
+	) error
+	// ImportRaces sends the given requests in a single ImportRaces stream.
+	ImportRaces(
+		ctx context.Context,
+		reqs []*racingapi.ImportRacesRequest,
+	) (*racingapi.ImportRacesResponse, error)
+	// ExportRaces calls fn with every exported race until the stream ends.
+	ExportRaces(
+		ctx context.Context,
+		req *racingapi.ExportRacesRequest,
+		fn func(*racingapi.Race) error,
+	) error
+	// ImportEvents sends the given requests in a single ImportEvents stream.
+	ImportEvents(
+		ctx context.Context,
+		reqs []*sportsapi.ImportEventsRequest,
+	) (*sportsapi.ImportEventsResponse, error)
+	// ExportEvents calls fn with every exported sport event until the stream
+	// ends.
+	ExportEvents(
+		ctx context.Context,
+		req *sportsapi.ExportEventsRequest,
+		fn func(*sportsapi.Event) error,
+	) error
+	Close() error
+}
+
+// setupClient returns a client calling the services with gRPC, or through the
+// API Gateway if gateway is true.
+func setupClient(gateway bool) (client, error) {
+	if gateway {
+		if gatewayAddr == "" {
+			gatewayAddr = defaultGatewayAddr
+		}
+
+		return newHTTPClient(gatewayAddr), nil
+	}
+
+	if racingServiceAddr == "" {
+		racingServiceAddr = defaultRacingServiceAddr
+	}
+
+	if sportsServiceAddr == "" {
+		sportsServiceAddr = defaultSportsServiceAddr
+	}
+
+	racingConn, err := setupConn(racingServiceAddr)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error connecting to %s: %w",
+			racingServiceAddr,
+			err,
+		)
+	}
+
+	sportsConn, err := setupConn(sportsServiceAddr)
+	if err != nil {
+		return nil, errors.Join(
+			fmt.Errorf("error connecting to %s: %w", sportsServiceAddr, err),
+			racingConn.Close(),
+		)
+	}
+
+	return &grpcClient{
+		Racing: racingapi.NewRacingClient(racingConn),
+		Sports: sportsapi.NewSportsClient(sportsConn),
+		conns:  []*grpc.ClientConn{racingConn, sportsConn},
+	}, nil
+}
+
+// setupConn returns a connection to the service with the given address.
+func setupConn(addr string) (*grpc.ClientConn, error) {
+	return grpc.NewClient(
+		addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+}
+
+// grpcClient is a client calling the services with gRPC.
+type grpcClient struct {
+	Racing racingapi.RacingClient
+	Sports sportsapi.SportsClient
+	conns  []*grpc.ClientConn
+}
+
+// ListRaces calls the ListRaces RPC.
+func (c *grpcClient) ListRaces(
+	ctx context.Context,
+	req *racingapi.ListRacesRequest,
+) (*racingapi.ListRacesResponse, error) {
+	return c.Racing.ListRaces(withMetadata(ctx), req)
+}
+
+// GetRace calls the GetRace RPC.
+func (c *grpcClient) GetRace(
+	ctx context.Context,
+	req *racingapi.GetRaceRequest,
+) (*racingapi.Race, error) {
+	return c.Racing.GetRace(withMetadata(ctx), req)
+}
+
+// ListEvents calls the ListEvents RPC.
+func (c *grpcClient) ListEvents(
+	ctx context.Context,
+	req *sportsapi.ListEventsRequest,
+) (*sportsapi.ListEventsResponse, error) {
+	return c.Sports.ListEvents(withMetadata(ctx), req)
+}
+
+// GetEvent calls the GetEvent RPC.
+func (c *grpcClient) GetEvent(
+	ctx context.Context,
+	req *sportsapi.GetEventRequest,
+) (*sportsapi.Event, error) {
+	return c.Sports.GetEvent(withMetadata(ctx), req)
+}
+
+// WatchEvent calls the WatchEvent RPC.
+func (c *grpcClient) WatchEvent(
+	ctx context.Context,
+	req *sportsapi.WatchEventRequest,
+	fn func(*sportsapi.Event) error,
+) error {
+	stream, err := c.Sports.WatchEvent(withMetadata(ctx), req)
+	if err != nil {
+		return err
+	}
+
+	return recvAll(stream, fn)
+}
+
+// ImportRaces calls the ImportRaces RPC.
+func (c *grpcClient) ImportRaces(
+	ctx context.Context,
+	reqs []*racingapi.ImportRacesRequest,
+) (*racingapi.ImportRacesResponse, error) {
+	stream, err := c.Racing.ImportRaces(withMetadata(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return sendAll(stream, reqs)
+}
+
+// ExportRaces calls the ExportRaces RPC.
+func (c *grpcClient) ExportRaces(
+	ctx context.Context,
+	req *racingapi.ExportRacesRequest,
+	fn func(*racingapi.Race) error,
+) error {
+	stream, err := c.Racing.ExportRaces(withMetadata(ctx), req)
+	if err != nil {
+		return err
+	}
+
+	return recvAll(stream, fn)
+}
+
+// ImportEvents calls the ImportEvents RPC.
+func (c *grpcClient) ImportEvents(
+	ctx context.Context,
+	reqs []*sportsapi.ImportEventsRequest,
+) (*sportsapi.ImportEventsResponse, error) {
+	stream, err := c.Sports.ImportEvents(withMetadata(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return sendAll(stream, reqs)
+}
+
+// ExportEvents calls the ExportEvents RPC.
+func (c *grpcClient) ExportEvents(
+	ctx context.Context,
+	req *sportsapi.ExportEventsRequest,
+	fn func(*sportsapi.Event) error,
+) error {
+	stream, err := c.Sports.ExportEvents(withMetadata(ctx), req)
+	if err != nil {
+		return err
+	}
+
+	return recvAll(stream, fn)
+}
+
+// Close closes the connections to the services.
+func (c *grpcClient) Close() error {
+	var errs []error
+	for _, conn := range c.conns {
+		errs = append(errs, conn.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// sendAll sends the given requests to a client stream and returns the
+// response.
+func sendAll[Req, Res any](
+	stream grpc.ClientStreamingClient[Req, Res],
+	reqs []*Req,
+) (*Res, error) {
+	for _, req := range reqs {
+		if err := stream.Send(req); err != nil {
+			// The actual error is returned by CloseAndRecv.
+			break
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// recvAll calls fn with every message received from a server stream until
+// the stream ends.
+func recvAll[Res any](
+	stream grpc.ServerStreamingClient[Res],
+	fn func(*Res) error,
+) error {
+	for {
+		m, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := fn(m); err != nil {
+			return err
+		}
+	}
+}
+
+// withMetadata returns a context that authenticates the calls made with it by
+// the token set in the ADMIN_TOKEN environment variable, if any, and makes
+// them on behalf of the tenant of the given context, if any.
+func withMetadata(ctx context.Context) context.Context {
+	if adminToken != "" {
+		ctx = metadata.AppendToOutgoingContext(
+			ctx,
+			"authorization",
+			"Bearer "+adminToken,
+		)
+	}
+
+	if name := tenant.Name(ctx); name != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, tenant.MetadataKey, name)
+	}
+
+	return ctx
+}
+
+// statusName returns the name of the given gRPC status code as it is returned
+// by the API Gateway, for example "NOT_FOUND".
+func statusName(c codes.Code) string {
+	return rpccode.Code(c).String() //nolint:gosec // Codes are small.
+}
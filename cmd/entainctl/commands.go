@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+)
+
+// setupListRaces sets up the "races list" command.
+func setupListRaces(fs *flag.FlagSet) runFunc {
+	req := &racingapi.ListRacesRequest{}
+
+	fs.Var(
+		idsFlag{&req.MeetingId},
+		"meeting",
+		"comma-separated `IDs` of the meetings of the races",
+	)
+	fs.BoolVar(&req.VisibleOnly, "visible", false, "list only visible races")
+	fs.Var(
+		orderFlag[racingapi.ListRacesRequest_OrderBy]{&req.OrderBy},
+		"order",
+		"comma-separated `fields` to order the races by: start, meeting, "+
+			"name or number, prefixed by \"-\" for descending order",
+	)
+	fs.StringVar(
+		&req.LocalDate,
+		"date",
+		"",
+		"list races starting on the `YYYY-MM-DD` date at their venue",
+	)
+	fs.Func(
+		"as-of",
+		"RFC 3339 `time` to see the races as of, for admins only",
+		timeFlag(&req.AsOf),
+	)
+	fs.Func(
+		"fields",
+		"comma-separated `fields` of the races to read",
+		fieldsFlag(&req.ReadMask),
+	)
+	fs.BoolVar(
+		&req.IncludeArchived,
+		"include-archived",
+		false,
+		"list archived races as well",
+	)
+
+	return func(ctx context.Context, c client, p *printer, _ []string) error {
+		resp, err := c.ListRaces(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		return p.Print(resp, raceTable(resp.GetRaces()...))
+	}
+}
+
+// setupGetRace sets up the "races get" command.
+func setupGetRace(fs *flag.FlagSet) runFunc {
+	req := &racingapi.GetRaceRequest{}
+
+	fs.Func(
+		"fields",
+		"comma-separated `fields` of the race to read",
+		fieldsFlag(&req.ReadMask),
+	)
+	fs.BoolVar(
+		&req.IncludeArchived,
+		"include-archived",
+		false,
+		"get the race even if it is archived",
+	)
+
+	return func(
+		ctx context.Context,
+		c client,
+		p *printer,
+		args []string,
+	) error {
+		id, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+		req.RaceId = id
+
+		race, err := c.GetRace(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		return p.Print(race, raceTable(race))
+	}
+}
+
+// setupListEvents sets up the "events list" command.
+func setupListEvents(fs *flag.FlagSet) runFunc {
+	req := &sportsapi.ListEventsRequest{}
+
+	fs.Var(
+		enumsFlag[sportsapi.Event_Category]{&req.Category},
+		"category",
+		"comma-separated `categories` of the sport events, "+
+			"for example soccer,ice-hockey",
+	)
+	fs.Var(
+		idsFlag{&req.CompetitionId},
+		"competition",
+		"comma-separated `IDs` of the competitions of the sport events",
+	)
+	fs.Var(
+		idsFlag{&req.ParticipantId},
+		"participant",
+		"comma-separated `IDs` of the participants of the sport events",
+	)
+	fs.BoolVar(
+		&req.VisibleOnly,
+		"visible",
+		false,
+		"list only visible sport events",
+	)
+	fs.Var(
+		orderFlag[sportsapi.ListEventsRequest_OrderBy]{&req.OrderBy},
+		"order",
+		"comma-separated `fields` to order the sport events by: start, name "+
+			"or competition, prefixed by \"-\" for descending order",
+	)
+	fs.StringVar(
+		&req.LocalDate,
+		"date",
+		"",
+		"list sport events starting on the `YYYY-MM-DD` date at their venue",
+	)
+	fs.Func(
+		"as-of",
+		"RFC 3339 `time` to see the sport events as of, for admins only",
+		timeFlag(&req.AsOf),
+	)
+	fs.Func(
+		"fields",
+		"comma-separated `fields` of the sport events to read",
+		fieldsFlag(&req.ReadMask),
+	)
+	fs.BoolVar(
+		&req.IncludeArchived,
+		"include-archived",
+		false,
+		"list archived sport events as well",
+	)
+
+	return func(ctx context.Context, c client, p *printer, _ []string) error {
+		resp, err := c.ListEvents(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		return p.Print(resp, eventTable(resp.GetEvents()...))
+	}
+}
+
+// setupGetEvent sets up the "events get" command.
+func setupGetEvent(fs *flag.FlagSet) runFunc {
+	req := &sportsapi.GetEventRequest{}
+
+	fs.Func(
+		"fields",
+		"comma-separated `fields` of the sport event to read",
+		fieldsFlag(&req.ReadMask),
+	)
+	fs.BoolVar(
+		&req.IncludeArchived,
+		"include-archived",
+		false,
+		"get the sport event even if it is archived",
+	)
+
+	return func(
+		ctx context.Context,
+		c client,
+		p *printer,
+		args []string,
+	) error {
+		id, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+		req.EventId = id
+
+		event, err := c.GetEvent(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		return p.Print(event, eventTable(event))
+	}
+}
+
+// setupWatchEvent sets up the "events watch" command.
+func setupWatchEvent(*flag.FlagSet) runFunc {
+	return func(
+		ctx context.Context,
+		c client,
+		p *printer,
+		args []string,
+	) error {
+		id, err := parseID(args[0])
+		if err != nil {
+			return err
+		}
+
+		return c.WatchEvent(
+			ctx,
+			&sportsapi.WatchEventRequest{EventId: id},
+			func(event *sportsapi.Event) error {
+				return p.Print(event, eventTable(event))
+			},
+		)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// completionUsage is the usage message of the completion command.
+const completionUsage = `Usage:
+  entainctl completion bash|zsh
+
+To load the completions in the current shell, run:
+  source <(entainctl completion bash)
+`
+
+// bashCompletion is the template of the bash completion script.
+var bashCompletion = template.Must(
+	template.New("bash").
+		Funcs(template.FuncMap{"join": strings.Join}).
+		Parse(`# bash completion for entainctl.
+_entainctl() {
+	local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}"
+	local words=""
+
+	case "$COMP_CWORD" in
+	1) words="{{join .Resources " "}} completion help" ;;
+	2)
+		case "${COMP_WORDS[1]}" in
+{{- range .Resources}}
+		{{.}}) words="{{index $.Names .}}" ;;
+{{- end}}
+		completion) words="bash zsh" ;;
+		esac
+		;;
+	*)
+		case "${COMP_WORDS[1]} ${COMP_WORDS[2]}" in
+{{- range .Commands}}
+		"{{.Path}}")
+			case "$prev" in
+{{- range .Values}}
+			{{.Flag}}) words="{{.Values}}" ;;
+{{- end}}
+			*) words="{{.Flags}}" ;;
+			esac
+			;;
+{{- end}}
+		esac
+		;;
+	esac
+
+	COMPREPLY=($(compgen -W "$words" -- "$cur"))
+}
+
+complete -F _entainctl entainctl
+`),
+)
+
+// completion is the data of the completion script templates.
+type completion struct {
+	// Names is a map of resources to the space-separated names of their
+	// commands.
+	Names map[string]string
+	// Resources is a list of the resources of the commands.
+	Resources []string
+	// Commands is a list of the commands.
+	Commands []completionCommand
+}
+
+// completionCommand is the data of a command in the completion scripts.
+type completionCommand struct {
+	// Path is the path of the command, for example "races list".
+	Path string
+	// Flags is a space-separated list of the flags of the command.
+	Flags string
+	// Values is a list of the values of the flags of the command that are
+	// not boolean.
+	Values []completionValues
+}
+
+// completionValues are the values of a flag in the completion scripts.
+type completionValues struct {
+	// Flag is a shell pattern matching the flag, for example "-o|--o".
+	Flag string
+	// Values is a space-separated list of the valid values of the flag, or
+	// empty if they cannot be completed.
+	Values string
+}
+
+// runCompletion runs the completion command with the given arguments.
+func runCompletion(args []string) error {
+	if len(args) != 1 {
+		return errors.New(completionUsage)
+	}
+
+	switch args[0] {
+	case "bash":
+		return bashCompletion.Execute(os.Stdout, newCompletion())
+	case "zsh":
+		// Zsh runs the bash completion script in the emulation mode.
+		if _, err := os.Stdout.WriteString(
+			"#compdef entainctl\n" +
+				"autoload -U +X bashcompinit && bashcompinit\n",
+		); err != nil {
+			return err
+		}
+
+		return bashCompletion.Execute(os.Stdout, newCompletion())
+	default:
+		return errors.New(completionUsage)
+	}
+}
+
+// newCompletion returns the data of the completion scripts of the commands.
+func newCompletion() completion {
+	c := completion{Names: map[string]string{}}
+
+	for _, cmd := range commands {
+		resource, name, _ := strings.Cut(cmd.Path, " ")
+		if !slices.Contains(c.Resources, resource) {
+			c.Resources = append(c.Resources, resource)
+		}
+		c.Names[resource] = strings.TrimSpace(c.Names[resource] + " " + name)
+
+		fs, _ := newFlagSet(cmd)
+		cmd.Setup(fs)
+
+		cc := completionCommand{Path: cmd.Path}
+
+		var flags []string
+		fs.VisitAll(func(f *flag.Flag) {
+			flags = append(flags, flagName(f))
+
+			if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok &&
+				b.IsBoolFlag() {
+				return
+			}
+
+			v := completionValues{Flag: "-" + f.Name + "|--" + f.Name}
+			if c, ok := f.Value.(completer); ok {
+				v.Values = strings.Join(c.Values(), " ")
+			}
+			cc.Values = append(cc.Values, v)
+		})
+		cc.Flags = strings.Join(flags, " ")
+
+		c.Commands = append(c.Commands, cc)
+	}
+
+	return c
+}
+
+// flagName returns the name of the given flag as it is completed, with a
+// single dash for one-letter flags and two dashes otherwise.
+func flagName(f *flag.Flag) string {
+	if len(f.Name) == 1 {
+		return "-" + f.Name
+	}
+
+	return "--" + f.Name
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// parseArgs parses the flags of the given arguments, which may be placed
+// before, between or after the positional arguments, for example
+// "races get 1 -o json". It returns the positional arguments.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// parseID parses the given ID of a race or a sport event.
+func parseID(s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%q is not a valid ID", s)
+	}
+
+	return id, nil
+}
+
+// completer is a flag that lists its valid values for shell completion.
+type completer interface {
+	flag.Value
+
+	// Values returns the valid values of the flag.
+	Values() []string
+}
+
+// outputFormat is the format of the results of the commands.
+type outputFormat string
+
+const (
+	// formatTable prints the results as a table with a header.
+	formatTable outputFormat = "table"
+	// formatJSON prints the results in the JSON format of the API Gateway.
+	formatJSON outputFormat = "json"
+	// formatYAML prints the results in YAML, with the same fields as JSON.
+	formatYAML outputFormat = "yaml"
+)
+
+// String returns the name of the format.
+func (f *outputFormat) String() string {
+	if f == nil {
+		return ""
+	}
+
+	return string(*f)
+}
+
+// Set sets the format to the one with the given name.
+func (f *outputFormat) Set(s string) error {
+	switch v := outputFormat(s); v {
+	case formatTable, formatJSON, formatYAML:
+		*f = v
+		return nil
+	default:
+		return fmt.Errorf("unknown format %q", s)
+	}
+}
+
+// Values returns the names of the formats.
+func (f *outputFormat) Values() []string {
+	return []string{
+		string(formatTable),
+		string(formatJSON),
+		string(formatYAML),
+	}
+}
+
+// idsFlag is a flag of comma-separated IDs, for example "1,2". The flag can be
+// given multiple times.
+type idsFlag struct {
+	IDs *[]int64
+}
+
+// String returns the IDs separated by commas.
+func (f idsFlag) String() string {
+	if f.IDs == nil {
+		return ""
+	}
+
+	values := make([]string, 0, len(*f.IDs))
+	for _, id := range *f.IDs {
+		values = append(values, strconv.FormatInt(id, 10))
+	}
+
+	return strings.Join(values, ",")
+}
+
+// Set appends the given comma-separated IDs.
+func (f idsFlag) Set(s string) error {
+	for v := range strings.SplitSeq(s, ",") {
+		id, err := parseID(strings.TrimSpace(v))
+		if err != nil {
+			return err
+		}
+
+		*f.IDs = append(*f.IDs, id)
+	}
+
+	return nil
+}
+
+// protoEnum is a generated protobuf enum type.
+type protoEnum interface {
+	~int32
+
+	Descriptor() protoreflect.EnumDescriptor
+}
+
+// enumsFlag is a flag of comma-separated enum values, matched by their names
+// regardless of case and with dashes in place of underscores, for example
+// "soccer,ice-hockey". The flag can be given multiple times.
+type enumsFlag[T protoEnum] struct {
+	Enums *[]T
+}
+
+// String returns the names of the enum values separated by commas.
+func (f enumsFlag[T]) String() string {
+	if f.Enums == nil {
+		return ""
+	}
+
+	values := make([]string, 0, len(*f.Enums))
+	for _, e := range *f.Enums {
+		values = append(values, enumFlagName(e.Descriptor(), e))
+	}
+
+	return strings.Join(values, ",")
+}
+
+// Set appends the enum values with the given comma-separated names.
+func (f enumsFlag[T]) Set(s string) error {
+	var zero T
+
+	for v := range strings.SplitSeq(s, ",") {
+		e, ok := parseEnum(zero.Descriptor(), strings.TrimSpace(v))
+		if !ok {
+			return fmt.Errorf("unknown value %q", v)
+		}
+
+		*f.Enums = append(*f.Enums, T(e))
+	}
+
+	return nil
+}
+
+// Values returns the names of the enum values.
+func (f enumsFlag[T]) Values() []string {
+	var zero T
+
+	var values []string
+	for _, e := range enumValues(zero.Descriptor()) {
+		values = append(values, enumFlagName(zero.Descriptor(), e))
+	}
+
+	return values
+}
+
+// orderFlag is a flag of comma-separated fields to order the results by, for
+// example "start,-name". Fields are ordered in ascending order, or in
+// descending order if prefixed by "-". The fields are the names of the values
+// of an OrderBy enum without the _ASC and _DESC suffixes, matched as in
+// enumsFlag. The flag can be given multiple times.
+type orderFlag[T protoEnum] struct {
+	OrderBy *[]T
+}
+
+// orderAliases is a map of short names of ordered fields to their names in
+// the OrderBy enums.
+var orderAliases = map[string]string{
+	"START":   "ADVERTISED_START_TIME",
+	"MEETING": "MEETING_ID",
+}
+
+// String returns the ordered fields separated by commas.
+func (f orderFlag[T]) String() string {
+	if f.OrderBy == nil {
+		return ""
+	}
+
+	values := make([]string, 0, len(*f.OrderBy))
+	for _, o := range *f.OrderBy {
+		name := string(o.Descriptor().Values().ByNumber(
+			protoreflect.EnumNumber(o),
+		).Name())
+
+		if field, ok := strings.CutSuffix(name, "_DESC"); ok {
+			values = append(values, "-"+orderFieldName(field))
+		} else {
+			field := strings.TrimSuffix(name, "_ASC")
+			values = append(values, orderFieldName(field))
+		}
+	}
+
+	return strings.Join(values, ",")
+}
+
+// Set appends the given comma-separated ordered fields.
+func (f orderFlag[T]) Set(s string) error {
+	var zero T
+
+	for v := range strings.SplitSeq(s, ",") {
+		v = strings.TrimSpace(v)
+
+		field, desc := strings.CutPrefix(v, "-")
+		field = enumName(field)
+		if alias, ok := orderAliases[field]; ok {
+			field = alias
+		}
+
+		if desc {
+			field += "_DESC"
+		} else {
+			field += "_ASC"
+		}
+
+		e, ok := parseEnum(zero.Descriptor(), field)
+		if !ok {
+			return fmt.Errorf("unknown field %q", v)
+		}
+
+		*f.OrderBy = append(*f.OrderBy, T(e))
+	}
+
+	return nil
+}
+
+// Values returns the fields to order by in ascending and descending order.
+func (f orderFlag[T]) Values() []string {
+	var zero T
+
+	var values []string
+	for _, e := range enumValues(zero.Descriptor()) {
+		name := string(zero.Descriptor().Values().ByNumber(e).Name())
+		if field, ok := strings.CutSuffix(name, "_ASC"); ok {
+			field = orderFieldName(field)
+			values = append(values, field, "-"+field)
+		}
+	}
+
+	return values
+}
+
+// orderFieldName returns the name of the given field of an OrderBy enum as it
+// is given in flags, for example "start" for ADVERTISED_START_TIME.
+func orderFieldName(field string) string {
+	for alias, f := range orderAliases {
+		if f == field {
+			field = alias
+		}
+	}
+
+	return strings.ToLower(strings.ReplaceAll(field, "_", "-"))
+}
+
+// enumValues returns the numbers of the values of the given enum except for
+// the unspecified one.
+func enumValues(ed protoreflect.EnumDescriptor) []protoreflect.EnumNumber {
+	var values []protoreflect.EnumNumber
+
+	for i := range ed.Values().Len() {
+		if n := ed.Values().Get(i).Number(); n != 0 {
+			values = append(values, n)
+		}
+	}
+
+	return values
+}
+
+// parseEnum returns the number of the value of the given enum with the given
+// name. The unspecified value cannot be parsed.
+func parseEnum(
+	ed protoreflect.EnumDescriptor,
+	name string,
+) (protoreflect.EnumNumber, bool) {
+	v := ed.Values().ByName(protoreflect.Name(enumName(name)))
+	if v == nil || v.Number() == 0 {
+		return 0, false
+	}
+
+	return v.Number(), true
+}
+
+// enumName converts the name of a flag value to the name of an enum value,
+// for example "ice-hockey" to "ICE_HOCKEY".
+func enumName(s string) string {
+	return strings.ToUpper(strings.ReplaceAll(s, "-", "_"))
+}
+
+// enumFlagName returns the name of the value of the given enum with the given
+// number as it is given in flags, for example "ice-hockey" for ICE_HOCKEY.
+func enumFlagName[T ~int32](ed protoreflect.EnumDescriptor, n T) string {
+	v := ed.Values().ByNumber(protoreflect.EnumNumber(n))
+	if v == nil {
+		return strconv.Itoa(int(n))
+	}
+
+	return strings.ToLower(strings.ReplaceAll(string(v.Name()), "_", "-"))
+}
+
+// timeFlag returns a function that sets the given timestamp to the time in
+// the RFC 3339 format passed to a flag.
+func timeFlag(t **timestamppb.Timestamp) func(string) error {
+	return func(s string) error {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid RFC 3339 time", s)
+		}
+
+		*t = timestamppb.New(v)
+		return nil
+	}
+}
+
+// fieldsFlag returns a function that sets the given field mask to the
+// comma-separated field names passed to a flag.
+func fieldsFlag(m **fieldmaskpb.FieldMask) func(string) error {
+	return func(s string) error {
+		*m = &fieldmaskpb.FieldMask{}
+
+		for p := range strings.SplitSeq(s, ",") {
+			(*m).Paths = append((*m).Paths, strings.TrimSpace(p))
+		}
+
+		return nil
+	}
+}
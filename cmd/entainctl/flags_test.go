@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"slices"
+	"testing"
+
+	racingv1 "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/api/sports"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSetupListRaces(t *testing.T) {
+	cases := []struct {
+		expected *racingv1.ListRacesRequest
+		name     string
+		args     []string
+		err      bool
+	}{
+		{
+			name:     "no flags",
+			expected: &racingv1.ListRacesRequest{},
+		},
+		{
+			name: "filters and ordering",
+			args: []string{"--meeting", "1,2", "--visible", "--order", "name"},
+			expected: &racingv1.ListRacesRequest{
+				MeetingId:   []int64{1, 2},
+				VisibleOnly: true,
+				OrderBy: []racingv1.ListRacesRequest_OrderBy{
+					racingv1.ListRacesRequest_NAME_ASC,
+				},
+			},
+		},
+		{
+			name: "repeated flags",
+			args: []string{
+				"-meeting", "1",
+				"-meeting", "3",
+				"-order", "start,-meeting",
+				"-order", "-number",
+			},
+			expected: &racingv1.ListRacesRequest{
+				MeetingId: []int64{1, 3},
+				OrderBy: []racingv1.ListRacesRequest_OrderBy{
+					racingv1.ListRacesRequest_ADVERTISED_START_TIME_ASC,
+					racingv1.ListRacesRequest_MEETING_ID_DESC,
+					racingv1.ListRacesRequest_NUMBER_DESC,
+				},
+			},
+		},
+		{
+			name: "order by enum names",
+			args: []string{"--order", "ADVERTISED_START_TIME,-meeting-id"},
+			expected: &racingv1.ListRacesRequest{
+				OrderBy: []racingv1.ListRacesRequest_OrderBy{
+					racingv1.ListRacesRequest_ADVERTISED_START_TIME_ASC,
+					racingv1.ListRacesRequest_MEETING_ID_DESC,
+				},
+			},
+		},
+		{
+			name: "invalid meeting ID",
+			args: []string{"--meeting", "1,x"},
+			err:  true,
+		},
+		{
+			name: "unknown order field",
+			args: []string{"--order", "competition"},
+			err:  true,
+		},
+		{
+			name: "invalid time",
+			args: []string{"--as-of", "yesterday"},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			run := setupListRaces(fs)
+
+			_, err := parseArgs(fs, tc.args)
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// The request is captured by running the command with a fake
+			// client.
+			c := &fakeClient{}
+			p := newPrinter(io.Discard, formatJSON, false)
+			if err := run(t.Context(), c, p, nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !proto.Equal(c.ListRacesRequest, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, c.ListRacesRequest)
+			}
+		})
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	output := formatTable
+	fs.Var(&output, "o", "")
+
+	args, err := parseArgs(fs, []string{"1", "-o", "yaml", "2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(args, []string{"1", "2"}) {
+		t.Fatalf("expected positional arguments [1 2], got %v", args)
+	}
+
+	if output != formatYAML {
+		t.Fatalf("expected yaml output, got %s", output)
+	}
+}
+
+func TestEnumsFlag(t *testing.T) {
+	var categories []sports.Event_Category
+	f := enumsFlag[sports.Event_Category]{&categories}
+
+	if err := f.Set("soccer,Ice-Hockey"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []sports.Event_Category{
+		sports.Event_SOCCER,
+		sports.Event_ICE_HOCKEY,
+	}
+	if !slices.Equal(categories, expected) {
+		t.Fatalf("expected %v, got %v", expected, categories)
+	}
+
+	if s := f.String(); s != "soccer,ice-hockey" {
+		t.Fatalf("expected soccer,ice-hockey, got %s", s)
+	}
+
+	for _, v := range []string{"unspecified-category", "curling"} {
+		if err := f.Set(v); err == nil {
+			t.Fatalf("expected an error for %q", v)
+		}
+	}
+
+	if !slices.Contains(f.Values(), "ice-hockey") {
+		t.Fatalf("expected ice-hockey in values, got %v", f.Values())
+	}
+}
+
+func TestOrderFlagValues(t *testing.T) {
+	var orderBy []sports.ListEventsRequest_OrderBy
+	f := orderFlag[sports.ListEventsRequest_OrderBy]{&orderBy}
+
+	expected := []string{
+		"start", "-start",
+		"name", "-name",
+		"competition", "-competition",
+	}
+	if !slices.Equal(f.Values(), expected) {
+		t.Fatalf("expected %v, got %v", expected, f.Values())
+	}
+
+	if err := f.Set("-start,competition"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if s := f.String(); s != "-start,competition" {
+		t.Fatalf("expected -start,competition, got %s", s)
+	}
+}
+
+// fakeClient is a client that records the requests of the commands.
+type fakeClient struct {
+	client
+
+	ListRacesRequest *racingv1.ListRacesRequest
+}
+
+func (c *fakeClient) ListRaces(
+	_ context.Context,
+	req *racingv1.ListRacesRequest,
+) (*racingv1.ListRacesResponse, error) {
+	c.ListRacesRequest = req
+	return &racingv1.ListRacesResponse{}, nil
+}
@@ -0,0 +1,458 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	rpccode "google.golang.org/genproto/googleapis/rpc/code"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// httpClient is a client calling the services through the API Gateway.
+type httpClient struct {
+	// BaseURL is the URL of the API Gateway, for example
+	// "http://localhost:8000".
+	BaseURL string
+	// HTTP is the HTTP client making the requests.
+	HTTP *http.Client
+}
+
+// newHTTPClient returns a client calling the services through the API Gateway
+// with the given address. The address is either a URL or a host and port, in
+// which case the gateway is called over plain HTTP.
+func newHTTPClient(addr string) *httpClient {
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+
+	return &httpClient{
+		BaseURL: strings.TrimSuffix(addr, "/"),
+		HTTP:    http.DefaultClient,
+	}
+}
+
+// ListRaces calls the ListRaces RPC with the GET /v1/races route.
+func (c *httpClient) ListRaces(
+	ctx context.Context,
+	req *racingapi.ListRacesRequest,
+) (*racingapi.ListRacesResponse, error) {
+	resp := &racingapi.ListRacesResponse{}
+	if err := c.call(ctx, "/v1/races", queryOf(req), resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetRace calls the GetRace RPC with the GET /v1/races/{race_id} route.
+func (c *httpClient) GetRace(
+	ctx context.Context,
+	req *racingapi.GetRaceRequest,
+) (*racingapi.Race, error) {
+	path := fmt.Sprintf("/v1/races/%d", req.GetRaceId())
+
+	resp := &racingapi.Race{}
+	if err := c.call(ctx, path, queryOf(req, "race_id"), resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ListEvents calls the ListEvents RPC with the GET /v1/sports route.
+func (c *httpClient) ListEvents(
+	ctx context.Context,
+	req *sportsapi.ListEventsRequest,
+) (*sportsapi.ListEventsResponse, error) {
+	resp := &sportsapi.ListEventsResponse{}
+	if err := c.call(ctx, "/v1/sports", queryOf(req), resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetEvent calls the GetEvent RPC with the GET /v1/sports/{event_id} route.
+func (c *httpClient) GetEvent(
+	ctx context.Context,
+	req *sportsapi.GetEventRequest,
+) (*sportsapi.Event, error) {
+	path := fmt.Sprintf("/v1/sports/%d", req.GetEventId())
+
+	resp := &sportsapi.Event{}
+	if err := c.call(ctx, path, queryOf(req, "event_id"), resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// WatchEvent calls the WatchEvent RPC with the GET
+// /v1/sports/{event_id}:watch route.
+func (c *httpClient) WatchEvent(
+	ctx context.Context,
+	req *sportsapi.WatchEventRequest,
+	fn func(*sportsapi.Event) error,
+) error {
+	path := fmt.Sprintf("/v1/sports/%d:watch", req.GetEventId())
+
+	resp, err := c.get(ctx, path, nil)
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	return readStream(resp, func() *sportsapi.Event {
+		return &sportsapi.Event{}
+	}, fn)
+}
+
+// ImportRaces calls the ImportRaces RPC with the POST /v1/races:import route.
+func (c *httpClient) ImportRaces(
+	ctx context.Context,
+	reqs []*racingapi.ImportRacesRequest,
+) (*racingapi.ImportRacesResponse, error) {
+	resp := &racingapi.ImportRacesResponse{}
+	if err := callStream(ctx, c, "/v1/races:import", reqs, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ExportRaces calls the ExportRaces RPC with the GET /v1/races:export route.
+func (c *httpClient) ExportRaces(
+	ctx context.Context,
+	req *racingapi.ExportRacesRequest,
+	fn func(*racingapi.Race) error,
+) error {
+	resp, err := c.get(ctx, "/v1/races:export", queryOf(req))
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	return readStream(resp, func() *racingapi.Race {
+		return &racingapi.Race{}
+	}, fn)
+}
+
+// ImportEvents calls the ImportEvents RPC with the POST /v1/sports:import
+// route.
+func (c *httpClient) ImportEvents(
+	ctx context.Context,
+	reqs []*sportsapi.ImportEventsRequest,
+) (*sportsapi.ImportEventsResponse, error) {
+	resp := &sportsapi.ImportEventsResponse{}
+	if err := callStream(ctx, c, "/v1/sports:import", reqs, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ExportEvents calls the ExportEvents RPC with the GET /v1/sports:export
+// route.
+func (c *httpClient) ExportEvents(
+	ctx context.Context,
+	req *sportsapi.ExportEventsRequest,
+	fn func(*sportsapi.Event) error,
+) error {
+	resp, err := c.get(ctx, "/v1/sports:export", queryOf(req))
+	if err != nil {
+		return err
+	}
+	defer closeBody(resp)
+
+	return readStream(resp, func() *sportsapi.Event {
+		return &sportsapi.Event{}
+	}, fn)
+}
+
+// Close closes the idle connections to the API Gateway.
+func (c *httpClient) Close() error {
+	c.HTTP.CloseIdleConnections()
+	return nil
+}
+
+// call sends a GET request to the given path with the given query, and
+// unmarshals the response into resp.
+func (c *httpClient) call(
+	ctx context.Context,
+	path string,
+	query url.Values,
+	resp proto.Message,
+) error {
+	r, err := c.get(ctx, path, query)
+	if err != nil {
+		return err
+	}
+	defer closeBody(r)
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+
+	return unmarshal(body, resp)
+}
+
+// get sends a GET request to the given path with the given query. Error
+// responses are converted to gRPC status errors.
+func (c *httpClient) get(
+	ctx context.Context,
+	path string,
+	query url.Values,
+) (*http.Response, error) {
+	u := c.BaseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	return c.send(ctx, http.MethodGet, u, http.NoBody)
+}
+
+// send sends a request with the given method and body to the given URL. Error
+// responses are converted to gRPC status errors.
+func (c *httpClient) send(
+	ctx context.Context,
+	method string,
+	u string,
+	body io.Reader,
+) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != http.NoBody {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// The API Gateway identifies tenants by their tokens only, and consumes
+	// the token, so a tenant token takes precedence over the admin token.
+	switch {
+	case tenantToken != "":
+		req.Header.Set("Authorization", "Bearer "+tenantToken)
+	case adminToken != "":
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer closeBody(resp)
+		return nil, errorOf(resp)
+	}
+
+	return resp, nil
+}
+
+// callStream sends the given requests of a client streaming RPC to the given
+// path as newline-delimited JSON objects, and unmarshals the response into
+// resp.
+func callStream[M proto.Message](
+	ctx context.Context,
+	c *httpClient,
+	path string,
+	reqs []M,
+	resp proto.Message,
+) error {
+	var body bytes.Buffer
+	for _, req := range reqs {
+		b, err := protojson.Marshal(req)
+		if err != nil {
+			return fmt.Errorf("error creating request: %w", err)
+		}
+
+		body.Write(b)
+		body.WriteByte('\n')
+	}
+
+	r, err := c.send(ctx, http.MethodPost, c.BaseURL+path, &body)
+	if err != nil {
+		return err
+	}
+	defer closeBody(r)
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+
+	return unmarshal(b, resp)
+}
+
+// readStream calls fn with every message streamed by a server streaming RPC
+// in the body of the given response, until the stream ends.
+func readStream[M proto.Message](
+	resp *http.Response,
+	newMessage func() M,
+	fn func(M) error,
+) error {
+	// The API Gateway streams the messages as newline-delimited JSON objects,
+	// each one holding either a message or the error ending the stream.
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var chunk struct {
+			Result json.RawMessage `json:"result"`
+			Error  json.RawMessage `json:"error"`
+		}
+
+		err := dec.Decode(&chunk)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error reading response: %w", err)
+		}
+
+		if chunk.Error != nil {
+			st := &spb.Status{}
+			if err := unmarshal(chunk.Error, st); err != nil {
+				return err
+			}
+
+			return status.ErrorProto(st)
+		}
+
+		m := newMessage()
+		if err := unmarshal(chunk.Result, m); err != nil {
+			return err
+		}
+
+		if err := fn(m); err != nil {
+			return err
+		}
+	}
+}
+
+// errorOf returns the error described by the body of the given error response
+// of the API Gateway.
+func errorOf(resp *http.Response) error {
+	var body struct {
+		Error struct {
+			Message string            `json:"message"`
+			Status  string            `json:"status"`
+			Details []json.RawMessage `json:"details"`
+		} `json:"error"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	code, ok := rpccode.Code_value[body.Error.Status]
+	if !ok {
+		code = int32(codes.Unknown)
+	}
+
+	st := &spb.Status{Code: code, Message: body.Error.Message}
+	for _, raw := range body.Error.Details {
+		// Details of unknown types are skipped.
+		d := &anypb.Any{}
+		if err := protojson.Unmarshal(raw, d); err == nil {
+			st.Details = append(st.Details, d)
+		}
+	}
+
+	return status.ErrorProto(st)
+}
+
+// unmarshal unmarshals the given JSON returned by the API Gateway into m.
+func unmarshal(b []byte, m proto.Message) error {
+	opts := protojson.UnmarshalOptions{DiscardUnknown: true}
+	if err := opts.Unmarshal(b, m); err != nil {
+		return fmt.Errorf("error reading response: %w", err)
+	}
+
+	return nil
+}
+
+// queryOf returns the query parameters of the GET route of the given request,
+// named after the JSON names of the populated fields of the request. The
+// fields bound to the path of the route are skipped.
+func queryOf(m proto.Message, path ...protoreflect.Name) url.Values {
+	query := url.Values{}
+
+	m.ProtoReflect().Range(
+		func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+			if slices.Contains(path, fd.Name()) {
+				return true
+			}
+
+			if !fd.IsList() {
+				query.Set(fd.JSONName(), queryValue(fd, v))
+				return true
+			}
+
+			for i := range v.List().Len() {
+				query.Add(fd.JSONName(), queryValue(fd, v.List().Get(i)))
+			}
+
+			return true
+		},
+	)
+
+	return query
+}
+
+// queryValue formats the given value of a field as a query parameter.
+func queryValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		return enumValueName(fd.Enum(), v.Enum())
+	case protoreflect.MessageKind:
+		switch m := v.Message().Interface().(type) {
+		case *timestamppb.Timestamp:
+			return m.AsTime().Format(time.RFC3339Nano)
+		case *fieldmaskpb.FieldMask:
+			return strings.Join(m.GetPaths(), ",")
+		}
+	}
+
+	return v.String()
+}
+
+// enumValueName returns the name of the value of the given enum with the given
+// number, or the number if the enum has no such value.
+func enumValueName(
+	ed protoreflect.EnumDescriptor,
+	n protoreflect.EnumNumber,
+) string {
+	if v := ed.Values().ByNumber(n); v != nil {
+		return string(v.Name())
+	}
+
+	return fmt.Sprint(n)
+}
+
+// closeBody closes the body of the given response.
+func closeBody(resp *http.Response) {
+	if err := resp.Body.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "error closing response body: %v\n", err)
+	}
+}
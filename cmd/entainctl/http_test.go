@@ -0,0 +1,293 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	racingv1 "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/api/sports"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestQueryOf(t *testing.T) {
+	cases := []struct {
+		req      proto.Message
+		expected url.Values
+		name     string
+		path     []protoreflect.Name
+	}{
+		{
+			name:     "empty request",
+			req:      &racingv1.ListRacesRequest{},
+			expected: url.Values{},
+		},
+		{
+			name: "list races",
+			req: &racingv1.ListRacesRequest{
+				MeetingId:   []int64{1, 2},
+				VisibleOnly: true,
+				OrderBy: []racingv1.ListRacesRequest_OrderBy{
+					racingv1.ListRacesRequest_NAME_ASC,
+					racingv1.ListRacesRequest_NUMBER_DESC,
+				},
+				ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "name"}},
+				AsOf: timestamppb.New(
+					time.Date(2025, 11, 4, 10, 0, 0, 0, time.UTC),
+				),
+			},
+			expected: url.Values{
+				"meetingId":   {"1", "2"},
+				"visibleOnly": {"true"},
+				"orderBy":     {"NAME_ASC", "NUMBER_DESC"},
+				"fields":      {"id,name"},
+				"asOf":        {"2025-11-04T10:00:00Z"},
+			},
+		},
+		{
+			name: "get sport event",
+			req: &sports.GetEventRequest{
+				EventId:         1,
+				IncludeArchived: true,
+			},
+			path: []protoreflect.Name{"event_id"},
+			expected: url.Values{
+				"includeArchived": {"true"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			query := queryOf(tc.req, tc.path...)
+
+			if query.Encode() != tc.expected.Encode() {
+				t.Fatalf(
+					"expected query %q, got %q",
+					tc.expected.Encode(),
+					query.Encode(),
+				)
+			}
+		})
+	}
+}
+
+func TestHTTPClientGetRace(t *testing.T) {
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v1/races/1":
+				fmt.Fprint(w, `{"id": "1", "name": "Race 1", "unknown": true}`)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error": {
+					"code": 404,
+					"status": "NOT_FOUND",
+					"message": "race not found",
+					"details": [{
+						"@type": "type.googleapis.com/google.rpc.RequestInfo",
+						"requestId": "abc"
+					}]
+				}}`)
+			}
+		}),
+	)
+	defer srv.Close()
+
+	c := newHTTPClient(srv.URL)
+
+	race, err := c.GetRace(t.Context(), &racingv1.GetRaceRequest{RaceId: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &racingv1.Race{Id: 1, Name: "Race 1"}
+	if !proto.Equal(race, expected) {
+		t.Fatalf("expected %v, got %v", expected, race)
+	}
+
+	_, err = c.GetRace(t.Context(), &racingv1.GetRaceRequest{RaceId: 2})
+
+	st := status.Convert(err)
+	if st.Code() != codes.NotFound || st.Message() != "race not found" {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	if len(st.Details()) != 1 {
+		t.Fatalf("expected 1 error detail, got %v", st.Details())
+	}
+
+	info, ok := st.Details()[0].(*errdetails.RequestInfo)
+	if !ok || info.GetRequestId() != "abc" {
+		t.Fatalf("expected request info detail, got %v", st.Details()[0])
+	}
+}
+
+func TestHTTPClientTenantToken(t *testing.T) {
+	var header string
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header.Get("Authorization")
+			fmt.Fprint(w, `{}`)
+		}),
+	)
+	defer srv.Close()
+
+	prevAdminToken, prevTenantToken := adminToken, tenantToken
+	t.Cleanup(func() {
+		adminToken, tenantToken = prevAdminToken, prevTenantToken
+	})
+	adminToken, tenantToken = "<admin-token>", "<tenant-token>"
+
+	c := newHTTPClient(srv.URL)
+
+	if _, err := c.ListRaces(
+		t.Context(),
+		&racingv1.ListRacesRequest{},
+	); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if header != "Bearer <tenant-token>" {
+		t.Fatalf("expected tenant token, got Authorization header %q", header)
+	}
+}
+
+func TestHTTPClientWatchEvent(t *testing.T) {
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			fmt.Fprintln(w, `{"result": {"id": "1", "matchState": "IN_PLAY"}}`)
+			fmt.Fprintln(w, `{"result": {"id": "1", "matchState": "FINISHED"}}`)
+			fmt.Fprintln(w, `{"error": {"code": 14, "message": "unavailable"}}`)
+		}),
+	)
+	defer srv.Close()
+
+	c := newHTTPClient(srv.URL)
+
+	var states []sports.Event_MatchState
+	err := c.WatchEvent(
+		t.Context(),
+		&sports.WatchEventRequest{EventId: 1},
+		func(e *sports.Event) error {
+			states = append(states, e.GetMatchState())
+			return nil
+		},
+	)
+
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected unavailable error, got %v", err)
+	}
+
+	if len(states) != 2 ||
+		states[0] != sports.Event_IN_PLAY ||
+		states[1] != sports.Event_FINISHED {
+		t.Fatalf("expected IN_PLAY and FINISHED snapshots, got %v", states)
+	}
+}
+
+func TestHTTPClientImportRaces(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.URL.Path != "/v1/races:import" {
+				http.NotFound(w, r)
+				return
+			}
+
+			b, err := io.ReadAll(r.Body)
+			if err != nil {
+				t.Error(err)
+			}
+			body = string(b)
+
+			fmt.Fprint(w, `{"created": "2", "committed": true}`)
+		}),
+	)
+	defer srv.Close()
+
+	c := newHTTPClient(srv.URL)
+
+	resp, err := c.ImportRaces(
+		t.Context(),
+		[]*racingv1.ImportRacesRequest{
+			{
+				Race:    &racingv1.Race{Name: "Race 1"},
+				Options: &racingv1.ImportOptions{DryRun: true},
+			},
+			{Race: &racingv1.Race{Name: "Race 2"}},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.GetCreated() != 2 || !resp.GetCommitted() {
+		t.Fatalf("unexpected response: %v", resp)
+	}
+
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 requests, got %q", body)
+	}
+
+	req := &racingv1.ImportRacesRequest{}
+	if err := protojson.Unmarshal([]byte(lines[0]), req); err != nil {
+		t.Fatal(err)
+	}
+
+	if req.GetRace().GetName() != "Race 1" || !req.GetOptions().GetDryRun() {
+		t.Fatalf("unexpected first request: %v", req)
+	}
+}
+
+func TestHTTPClientExportEvents(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v1/sports:export" {
+				http.NotFound(w, r)
+				return
+			}
+
+			query = r.URL.Query()
+			fmt.Fprintln(w, `{"result": {"id": "1"}}`)
+			fmt.Fprintln(w, `{"result": {"id": "2"}}`)
+		}),
+	)
+	defer srv.Close()
+
+	c := newHTTPClient(srv.URL)
+
+	var ids []int64
+	if err := c.ExportEvents(
+		t.Context(),
+		&sports.ExportEventsRequest{IncludeArchived: true},
+		func(e *sports.Event) error {
+			ids = append(ids, e.GetId())
+			return nil
+		},
+	); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("expected events 1 and 2, got %v", ids)
+	}
+
+	if query.Get("includeArchived") != "true" {
+		t.Fatalf("expected includeArchived query, got %v", query)
+	}
+}
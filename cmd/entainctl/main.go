@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/danilvpetrov/entain/tenant"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// usage is the usage message of the command.
+const usage = `Usage:
+  entainctl races list [flags]
+  entainctl races get [flags] ID
+  entainctl races import [flags] FILE
+  entainctl races export [flags] FILE
+  entainctl events list [flags]
+  entainctl events get [flags] ID
+  entainctl events watch [flags] ID
+  entainctl events import [flags] FILE
+  entainctl events export [flags] FILE
+  entainctl completion bash|zsh
+
+FILE is the path to a CSV (.csv), JSON Lines (.jsonl) or Parquet (.parquet)
+file, or "-" for the standard input or output, in which case the format must be
+given by the --format flag.
+
+Run "entainctl COMMAND -h" to see the flags of a command, for example
+"entainctl races list -h".
+`
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, describeError(err))
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ctx, cancel := signal.NotifyContext(
+		context.Background(),
+		os.Interrupt, os.Kill,
+	)
+	defer cancel()
+
+	args := os.Args[1:]
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+
+	switch args[0] {
+	case "completion":
+		return runCompletion(args[1:])
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+		return nil
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf("missing command for %q\n\n%s", args[0], usage)
+	}
+
+	path := args[0] + " " + args[1]
+	for _, cmd := range commands {
+		if cmd.Path == path {
+			err := runCommand(ctx, cmd, args[2:])
+			if ctx.Err() != nil {
+				// The command has been interrupted by the user, for example
+				// to stop watching a sport event.
+				return nil
+			}
+			return err
+		}
+	}
+
+	return fmt.Errorf("unknown command %q\n\n%s", path, usage)
+}
+
+// runCommand runs the given command with the given arguments.
+func runCommand(ctx context.Context, cmd command, args []string) error {
+	fs, opts := newFlagSet(cmd)
+	runFn := cmd.Setup(fs)
+
+	args, err := parseArgs(fs, args)
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(args) != len(strings.Fields(cmd.Args)) {
+		fs.Usage()
+		return fmt.Errorf("expected arguments: %q", cmd.Args)
+	}
+
+	if opts.Gateway && opts.Tenant != "" {
+		return errors.New(
+			"the --tenant flag cannot be used with --gateway, " +
+				"set TENANT_TOKEN instead",
+		)
+	}
+
+	c, err := setupClient(opts.Gateway)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "error closing client: %v\n", err)
+		}
+	}()
+
+	p := newPrinter(os.Stdout, opts.Output, cmd.Stream)
+	defer func() {
+		if err := p.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "error closing output: %v\n", err)
+		}
+	}()
+
+	return runFn(tenant.WithName(ctx, opts.Tenant), c, p, args)
+}
+
+// options are the options shared by all commands.
+type options struct {
+	// Output is the format of the results of the command.
+	Output outputFormat
+	// Tenant is the name of the tenant to make the calls on behalf of, if
+	// any.
+	Tenant string
+	// Gateway indicates whether to call the services through the API Gateway.
+	Gateway bool
+}
+
+// newFlagSet returns a flag set for the given command, with the flags of the
+// options shared by all commands defined.
+func newFlagSet(cmd command) (*flag.FlagSet, *options) {
+	fs := flag.NewFlagSet("entainctl "+cmd.Path, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(
+			fs.Output(),
+			"Usage:\n  %s\n\nFlags:\n",
+			strings.TrimSpace("entainctl "+cmd.Path+" [flags] "+cmd.Args),
+		)
+		fs.PrintDefaults()
+	}
+
+	opts := &options{Output: formatTable}
+	fs.Var(&opts.Output, "o", "output `format`: table, json or yaml")
+	fs.BoolVar(
+		&opts.Gateway,
+		"gateway",
+		false,
+		"call the services through the API Gateway instead of gRPC",
+	)
+	fs.Func(
+		"tenant",
+		"`name` of the tenant to see the races and sport events as",
+		func(s string) error {
+			if !tenant.IsValid(s) {
+				return fmt.Errorf("invalid tenant name %q", s)
+			}
+			opts.Tenant = s
+			return nil
+		},
+	)
+
+	return fs, opts
+}
+
+// describeError returns a description of the given error. Errors returned by
+// the services are described by their status code and message, followed by
+// the invalid fields of the request and the ID of the request, if any.
+func describeError(err error) string {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err.Error()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s", statusName(st.Code()), st.Message())
+
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.GetFieldViolations() {
+				fmt.Fprintf(&b, "\n  %s: %s", v.GetField(), v.GetDescription())
+			}
+		case *errdetails.RequestInfo:
+			fmt.Fprintf(&b, "\n  request ID: %s", d.GetRequestId())
+		}
+	}
+
+	return b.String()
+}
+
+// commands is a list of the commands of the CLI.
+var commands = []command{
+	{Path: "races list", Setup: setupListRaces},
+	{Path: "races get", Args: "ID", Setup: setupGetRace},
+	{Path: "races import", Args: "FILE", Setup: setupImportRaces},
+	{Path: "races export", Args: "FILE", Setup: setupExportRaces},
+	{Path: "events list", Setup: setupListEvents},
+	{Path: "events get", Args: "ID", Setup: setupGetEvent},
+	{Path: "events watch", Args: "ID", Setup: setupWatchEvent, Stream: true},
+	{Path: "events import", Args: "FILE", Setup: setupImportEvents},
+	{Path: "events export", Args: "FILE", Setup: setupExportEvents},
+}
+
+// command is a command of the CLI.
+type command struct {
+	// Setup defines the flags of the command on the given flag set, and
+	// returns the function running the command once the flags are parsed.
+	Setup func(fs *flag.FlagSet) runFunc
+	// Path is the name of the command preceded by the name of its resource,
+	// for example "races list".
+	Path string
+	// Args describes the positional arguments of the command, if any.
+	Args string
+	// Stream indicates whether the command prints results for as long as it
+	// runs.
+	Stream bool
+}
+
+// runFunc runs a command with the given positional arguments.
+type runFunc func(
+	ctx context.Context,
+	c client,
+	p *printer,
+	args []string,
+) error
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// printer prints the results of the commands in an output format.
+type printer struct {
+	w      io.Writer
+	yaml   *yaml.Encoder
+	format outputFormat
+	// widths are the widths of the columns of the table printed so far.
+	widths []int
+	// stream indicates whether the results are printed as they arrive, in
+	// which case JSON results are printed one per line.
+	stream bool
+}
+
+// columnPadding is the number of spaces between the columns of tables.
+const columnPadding = 2
+
+// table is a table of results with a header.
+type table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// newPrinter returns a printer writing to w in the given format.
+func newPrinter(w io.Writer, format outputFormat, stream bool) *printer {
+	p := &printer{w: w, format: format, stream: stream}
+
+	if format == formatYAML {
+		p.yaml = yaml.NewEncoder(w)
+		p.yaml.SetIndent(2) //nolint:mnd // Indent as in JSON.
+	}
+
+	return p
+}
+
+// Print prints the given result, which is printed as t in the table format.
+func (p *printer) Print(m proto.Message, t table) error {
+	switch p.format {
+	case formatJSON:
+		return p.printJSON(m)
+	case formatYAML:
+		return p.printYAML(m)
+	default:
+		return p.printTable(t)
+	}
+}
+
+// Close flushes the output.
+func (p *printer) Close() error {
+	if p.yaml != nil {
+		return p.yaml.Close()
+	}
+
+	return nil
+}
+
+// printJSON prints m in the JSON format of the API Gateway.
+func (p *printer) printJSON(m proto.Message) error {
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("error marshalling result: %w", err)
+	}
+
+	// The output of protojson is deliberately unstable, so it is reformatted.
+	var buf bytes.Buffer
+	if p.stream {
+		err = json.Compact(&buf, b)
+	} else {
+		err = json.Indent(&buf, b, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("error formatting result: %w", err)
+	}
+	buf.WriteByte('\n')
+
+	_, err = buf.WriteTo(p.w)
+	return err
+}
+
+// printYAML prints m in YAML with the same fields and values as in the JSON
+// format of the API Gateway. Multiple results are printed as separate
+// documents.
+func (p *printer) printYAML(m proto.Message) error {
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("error marshalling result: %w", err)
+	}
+
+	// JSON is valid YAML, so it is decoded into a node to keep the order of
+	// the fields, and re-encoded in the block style.
+	var n yaml.Node
+	if err := yaml.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("error converting result to YAML: %w", err)
+	}
+	resetStyle(&n)
+
+	return p.yaml.Encode(&n)
+}
+
+// resetStyle resets the style of the given node and its children to the
+// default block style. Strings that would read as other types are still quoted.
+func resetStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		resetStyle(c)
+	}
+}
+
+// printTable prints the rows of t aligned in columns. The header is printed
+// only once, before the first rows. The columns only widen, so that the rows
+// of the results printed as they arrive stay aligned with the previous ones.
+func (p *printer) printTable(t table) error {
+	rows := t.Rows
+	if p.widths == nil {
+		rows = append([][]string{t.Header}, rows...)
+		p.widths = make([]int, len(t.Header))
+	}
+
+	for _, row := range rows {
+		for i, cell := range row {
+			p.widths[i] = max(p.widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range rows {
+		for i, cell := range row {
+			b.WriteString(cell)
+
+			if i < len(row)-1 {
+				pad := p.widths[i] - utf8.RuneCountInString(cell)
+				b.WriteString(strings.Repeat(" ", pad+columnPadding))
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(p.w, b.String())
+	return err
+}
+
+// raceTable returns the table of the given races.
+func raceTable(races ...*racingapi.Race) table {
+	t := table{
+		Header: []string{
+			"ID", "MEETING", "NUMBER", "NAME", "START", "STATUS", "VISIBLE",
+		},
+	}
+
+	for _, r := range races {
+		t.Rows = append(t.Rows, []string{
+			strconv.FormatInt(r.GetId(), 10),
+			strconv.FormatInt(r.GetMeetingId(), 10),
+			strconv.FormatInt(r.GetNumber(), 10),
+			r.GetName(),
+			startTime(r),
+			enumString(r.GetStatus()),
+			strconv.FormatBool(r.GetVisible()),
+		})
+	}
+
+	return t
+}
+
+// eventTable returns the table of the given sport events.
+func eventTable(events ...*sportsapi.Event) table {
+	t := table{
+		Header: []string{
+			"ID", "CATEGORY", "COMPETITION", "NAME", "START", "STATUS",
+			"MATCH", "SCORE",
+		},
+	}
+
+	for _, e := range events {
+		t.Rows = append(t.Rows, []string{
+			strconv.FormatInt(e.GetId(), 10),
+			enumString(e.GetCategory()),
+			e.GetCompetition(),
+			e.GetName(),
+			startTime(e),
+			enumString(e.GetStatus()),
+			enumString(e.GetMatchState()),
+			score(e),
+		})
+	}
+
+	return t
+}
+
+// scheduled is a race or a sport event.
+type scheduled interface {
+	GetAdvertisedStartTime() *timestamppb.Timestamp
+	GetLocalAdvertisedStartTime() string
+}
+
+// startTime returns the advertised start time of a race or a sport event in
+// the timezone of its venue if it is known, or in UTC otherwise.
+func startTime(m scheduled) string {
+	if local := m.GetLocalAdvertisedStartTime(); local != "" {
+		return local
+	}
+
+	if m.GetAdvertisedStartTime() == nil {
+		return ""
+	}
+
+	return m.GetAdvertisedStartTime().AsTime().Format(time.RFC3339)
+}
+
+// enumString returns the name of the given enum value, or an empty string if
+// it is unspecified.
+func enumString(e protoreflect.Enum) string {
+	if e.Number() == 0 {
+		return ""
+	}
+
+	return enumValueName(e.Descriptor(), e.Number())
+}
+
+// score returns the total score of the given sport event in the "home-away"
+// format, or an empty string if the event has no scores.
+func score(e *sportsapi.Event) string {
+	if len(e.GetScores()) == 0 {
+		return ""
+	}
+
+	var home, away int32
+	for _, s := range e.GetScores() {
+		home += s.GetHome()
+		away += s.GetAway()
+	}
+
+	return fmt.Sprintf("%d-%d", home, away)
+}
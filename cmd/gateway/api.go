@@ -3,22 +3,84 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 )
 
 // setupAPI sets up the HTTP API gateway, routing requests to the appropriate
-// gRPC services.
-func setupAPI(ctx context.Context) (*runtime.ServeMux, error) {
-	m := runtime.NewServeMux()
+// gRPC services. The REST routes of the services are served alongside the
+// GraphQL, live updates, OpenAPI and Connect endpoints, behind the middlewares
+// shared by all of them.
+func setupAPI(ctx context.Context) (http.Handler, error) {
+	m := runtime.NewServeMux(
+		runtime.WithErrorHandler(handleError),
+		runtime.WithIncomingHeaderMatcher(matchIncomingHeader),
+		runtime.WithForwardResponseRewriter(rewriteSparseResponse),
+		runtime.WithForwardResponseOption(setETagHeader),
+	)
 
-	if err := setupRacingService(ctx, m); err != nil {
+	racingConn, racingV2Conn, err := setupRacingService(ctx, m)
+	if err != nil {
 		return nil, fmt.Errorf("error setting up racing service: %w", err)
 	}
 
-	if err := setupSportsService(ctx, m); err != nil {
+	sportsConn, err := setupSportsService(ctx, m)
+	if err != nil {
 		return nil, fmt.Errorf("error setting up sports service: %w", err)
 	}
 
-	return m, nil
+	c, err := setupStaleCache(m)
+	if err != nil {
+		return nil, fmt.Errorf("error setting up stale cache: %w", err)
+	}
+
+	g, err := setupGraphQL(m, racingConn, sportsConn)
+	if err != nil {
+		return nil, fmt.Errorf("error setting up GraphQL: %w", err)
+	}
+
+	l, err := setupLive(ctx, m, racingConn, sportsConn)
+	if err != nil {
+		return nil, fmt.Errorf("error setting up live updates: %w", err)
+	}
+
+	rpc := setupConnect(m, racingConn, racingV2Conn, sportsConn)
+
+	spec, docs, err := setupOpenAPI()
+	if err != nil {
+		return nil, fmt.Errorf("error setting up OpenAPI: %w", err)
+	}
+
+	routes := http.NewServeMux()
+	routes.Handle(graphqlPath, g)
+	routes.Handle(livePath, l)
+	routes.Handle(openAPIPath, spec)
+	routes.Handle(docsPath, docs)
+	for _, prefix := range connectPrefixes {
+		routes.Handle(prefix, rpc)
+	}
+	routes.Handle("/", c)
+
+	d, err := setupDeprecation(routes)
+	if err != nil {
+		return nil, fmt.Errorf("error setting up deprecation: %w", err)
+	}
+
+	h, err := setupTenants(d)
+	if err != nil {
+		return nil, fmt.Errorf("error setting up tenants: %w", err)
+	}
+
+	h, err = setupJurisdiction(h)
+	if err != nil {
+		return nil, fmt.Errorf("error setting up jurisdictions: %w", err)
+	}
+
+	h, err = setupCORS(withRequestID(withSparseFields(withStreaming(h))))
+	if err != nil {
+		return nil, fmt.Errorf("error setting up CORS: %w", err)
+	}
+
+	return h, nil
 }
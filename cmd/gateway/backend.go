@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+const (
+	// responseWriteHeadroom is the portion of the HTTP server write timeout
+	// reserved for marshalling and writing a response back to the HTTP client
+	// once a backend call returns.
+	responseWriteHeadroom = 1 * time.Second
+
+	// listDeadline is the deadline applied to backend List* calls. It is
+	// derived from the HTTP server write timeout, so the gateway gives up on a
+	// backend early enough to still respond to the HTTP client.
+	listDeadline = serverWriteTimeout - responseWriteHeadroom
+
+	// getDeadline is the deadline applied to backend Get* calls. Point lookups
+	// are expected to be much cheaper than listings, hence a shorter deadline.
+	getDeadline = listDeadline / 3
+)
+
+// backendMethods describes the RPCs of a backend gRPC service that are subject
+// to the gateway's retry policies.
+type backendMethods struct {
+	// Service is the fully-qualified name of the gRPC service, for example
+	// "racing.v1.Racing".
+	Service string
+	// List is a list of names of idempotent RPCs that return collections.
+	List []string
+	// Get is a list of names of idempotent RPCs that return a single entity.
+	Get []string
+}
+
+// setupBackendConn returns the gRPC target and dial options used by the
+// gateway to connect to a backend service. All calls to the backend are guarded
+// by a circuit breaker.
+//
+// addr is either a single gRPC target (for example "localhost:9000" or
+// "dns:///racing.internal:9000"), or a comma-separated list of "host:port"
+// addresses. In the latter case, the addresses are resolved statically and the
+// load is spread across all of them.
+func setupBackendConn(
+	addr string,
+	methods backendMethods,
+) (target string, _ []grpc.DialOption, _ error) {
+	cfg, err := backendServiceConfig(methods)
+	if err != nil {
+		return "", nil, err
+	}
+
+	breaker, err := setupCircuitBreaker(methods.Service)
+	if err != nil {
+		return "", nil, err
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(
+			insecure.NewCredentials(),
+		),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithDefaultServiceConfig(cfg),
+		grpc.WithChainUnaryInterceptor(breaker.unaryClientInterceptor()),
+	}
+
+	addrs := strings.Split(addr, ",")
+	if len(addrs) == 1 {
+		return strings.TrimSpace(addr), opts, nil
+	}
+
+	state := resolver.State{}
+	for _, a := range addrs {
+		a = strings.TrimSpace(a)
+		if a == "" {
+			return "", nil, fmt.Errorf("empty address in %q", addr)
+		}
+		state.Addresses = append(state.Addresses, resolver.Address{Addr: a})
+	}
+
+	// The scheme is unique per backend service, so that the resolvers of
+	// different backends do not clash with each other.
+	scheme := strings.ToLower(strings.ReplaceAll(methods.Service, ".", "-"))
+	r := manual.NewBuilderWithScheme(scheme)
+	r.InitialState(state)
+
+	opts = append(opts, grpc.WithResolvers(r))
+
+	return scheme + ":///" + methods.Service, opts, nil
+}
+
+// backendServiceConfig builds a gRPC service config in JSON format for a
+// backend service. The config enables round-robin load balancing across all
+// resolved backend addresses, retries List* and Get* calls on transient
+// failures, and applies per-method deadlines.
+//
+// See https://github.com/grpc/grpc/blob/master/doc/service_config.md for the
+// format of the service config.
+func backendServiceConfig(methods backendMethods) (string, error) {
+	type methodName struct {
+		Service string `json:"service"`
+		Method  string `json:"method"`
+	}
+
+	type retryPolicy struct {
+		InitialBackoff       string   `json:"initialBackoff"`
+		MaxBackoff           string   `json:"maxBackoff"`
+		RetryableStatusCodes []string `json:"retryableStatusCodes"`
+		MaxAttempts          int      `json:"maxAttempts"`
+		BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	}
+
+	type methodConfig struct {
+		RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+		Timeout     string       `json:"timeout"`
+		Name        []methodName `json:"name"`
+	}
+
+	names := func(methodNames []string) []methodName {
+		result := make([]methodName, 0, len(methodNames))
+		for _, m := range methodNames {
+			result = append(result, methodName{
+				Service: methods.Service,
+				Method:  m,
+			})
+		}
+		return result
+	}
+
+	var methodConfigs []methodConfig
+
+	if len(methods.List) > 0 {
+		// Listings are comparatively expensive for backends, so they are
+		// retried only once the previous attempt has failed.
+		methodConfigs = append(methodConfigs, methodConfig{
+			Name:    names(methods.List),
+			Timeout: formatDuration(listDeadline),
+			RetryPolicy: &retryPolicy{
+				MaxAttempts:          4,
+				InitialBackoff:       "0.1s",
+				MaxBackoff:           "1s",
+				BackoffMultiplier:    2,
+				RetryableStatusCodes: []string{"UNAVAILABLE"},
+			},
+		})
+	}
+
+	if len(methods.Get) > 0 {
+		// Point lookups are cheap for backends, so they are retried sooner,
+		// within their shorter deadline. grpc-go does not implement hedging
+		// policies, so they are not used.
+		methodConfigs = append(methodConfigs, methodConfig{
+			Name:    names(methods.Get),
+			Timeout: formatDuration(getDeadline),
+			RetryPolicy: &retryPolicy{
+				MaxAttempts:          3,
+				InitialBackoff:       "0.05s",
+				MaxBackoff:           "0.5s",
+				BackoffMultiplier:    2,
+				RetryableStatusCodes: []string{"UNAVAILABLE"},
+			},
+		})
+	}
+
+	cfg, err := json.Marshal(struct {
+		LoadBalancingConfig []map[string]struct{} `json:"loadBalancingConfig"`
+		MethodConfig        []methodConfig        `json:"methodConfig"`
+	}{
+		LoadBalancingConfig: []map[string]struct{}{
+			{"round_robin": {}},
+		},
+		MethodConfig: methodConfigs,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(cfg), nil
+}
+
+// formatDuration formats a duration in the format expected by the gRPC service
+// config, i.e. a number of seconds with an "s" suffix.
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%gs", d.Seconds())
+}
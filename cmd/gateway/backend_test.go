@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	racingv1 "github.com/danilvpetrov/entain/api/racing/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestBackendServiceConfig(t *testing.T) {
+	methods := backendMethods{
+		Service: racingv1.Racing_ServiceDesc.ServiceName,
+		List:    []string{"ListRaces"},
+		Get:     []string{"GetRace"},
+	}
+
+	t.Run("parses the service config", func(t *testing.T) {
+		cfg, err := backendServiceConfig(methods)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The default service config is parsed when the client is created.
+		conn, err := grpc.NewClient(
+			"localhost:0",
+			grpc.WithDefaultServiceConfig(cfg),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		if err != nil {
+			t.Fatalf("expected valid service config, got %v", err)
+		}
+
+		if err := conn.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("retries calls to a failing backend", func(t *testing.T) {
+		backend := &failingRacingServer{}
+		backend.failures.Store(2)
+		addr := startRacingBackend(t, backend)
+
+		target, opts, err := setupBackendConn(addr, methods)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		conn, err := grpc.NewClient(target, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = conn.Close()
+		})
+
+		client := racingv1.NewRacingClient(conn)
+
+		if _, err := client.GetRace(
+			t.Context(),
+			&racingv1.GetRaceRequest{RaceId: 1},
+		); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if n := backend.calls.Swap(0); n != 3 {
+			t.Fatalf("expected 3 attempts of GetRace, got %d", n)
+		}
+
+		backend.failures.Store(2)
+
+		if _, err := client.ListRaces(
+			t.Context(),
+			&racingv1.ListRacesRequest{},
+		); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if n := backend.calls.Swap(0); n != 3 {
+			t.Fatalf("expected 3 attempts of ListRaces, got %d", n)
+		}
+	})
+
+	t.Run("gives up after the maximum attempts", func(t *testing.T) {
+		backend := &failingRacingServer{}
+		backend.failures.Store(10)
+		addr := startRacingBackend(t, backend)
+
+		target, opts, err := setupBackendConn(addr, methods)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		conn, err := grpc.NewClient(target, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = conn.Close()
+		})
+
+		_, err = racingv1.NewRacingClient(conn).GetRace(
+			t.Context(),
+			&racingv1.GetRaceRequest{RaceId: 1},
+		)
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("expected %v error, got %v", codes.Unavailable, err)
+		}
+
+		if n := backend.calls.Load(); n != 3 {
+			t.Fatalf("expected 3 attempts, got %d", n)
+		}
+	})
+}
+
+// failingRacingServer is a racing service failing the first calls with the
+// Unavailable code.
+type failingRacingServer struct {
+	racingv1.UnimplementedRacingServer
+
+	// failures is the number of the next calls to fail.
+	failures atomic.Int64
+	// calls is the number of calls made.
+	calls atomic.Int64
+}
+
+// fail returns an error if the call should fail, counting the call.
+func (s *failingRacingServer) fail() error {
+	s.calls.Add(1)
+	if s.failures.Add(-1) >= 0 {
+		return status.Error(codes.Unavailable, "backend is unavailable")
+	}
+	return nil
+}
+
+// GetRace fails the first calls and returns an empty race afterwards.
+func (s *failingRacingServer) GetRace(
+	context.Context,
+	*racingv1.GetRaceRequest,
+) (*racingv1.Race, error) {
+	if err := s.fail(); err != nil {
+		return nil, err
+	}
+	return &racingv1.Race{Id: 1}, nil
+}
+
+// ListRaces fails the first calls and returns no races afterwards.
+func (s *failingRacingServer) ListRaces(
+	context.Context,
+	*racingv1.ListRacesRequest,
+) (*racingv1.ListRacesResponse, error) {
+	if err := s.fail(); err != nil {
+		return nil, err
+	}
+	return &racingv1.ListRacesResponse{}, nil
+}
+
+// startRacingBackend is a test helper that starts a gRPC server of the given
+// racing service, and returns its address.
+func startRacingBackend(t *testing.T, s racingv1.RacingServer) string {
+	t.Helper()
+
+	server := grpc.NewServer()
+	racingv1.RegisterRacingServer(server, s)
+
+	listenCfg := net.ListenConfig{}
+	// Listen on a random port.
+	listener, err := listenCfg.Listen(t.Context(), "tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(server.GracefulStop)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	return listener.Addr().String()
+}
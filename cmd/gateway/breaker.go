@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	breakerFailureThreshold        = os.Getenv("CIRCUIT_BREAKER_FAILURE_THRESHOLD")
+	defaultBreakerFailureThreshold = 5
+
+	breakerOpenTimeout        = os.Getenv("CIRCUIT_BREAKER_OPEN_TIMEOUT")
+	defaultBreakerOpenTimeout = 10 * time.Second
+
+	breakerHalfOpenProbes        = os.Getenv("CIRCUIT_BREAKER_HALF_OPEN_PROBES")
+	defaultBreakerHalfOpenProbes = 1
+)
+
+// breakerState is a state of a circuit breaker.
+type breakerState int
+
+const (
+	// breakerClosed is the state in which all calls are let through to the
+	// backend.
+	breakerClosed breakerState = iota
+	// breakerOpen is the state in which all calls fail fast without reaching
+	// the backend.
+	breakerOpen
+	// breakerHalfOpen is the state in which a limited number of probe calls
+	// are let through to the backend to check whether it has recovered.
+	breakerHalfOpen
+)
+
+// String returns a human-readable representation of the state.
+func (s breakerState) String() string {
+	switch s {
+	case breakerClosed:
+		return "closed"
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// circuitBreaker is a circuit breaker that guards calls to a single backend.
+//
+// The breaker opens after FailureThreshold consecutive failed calls. While it
+// is open, calls fail fast with codes.Unavailable. Once OpenTimeout elapses,
+// the breaker becomes half-open and lets up to HalfOpenProbes calls through.
+// If all probes succeed the breaker closes, if any of them fails the breaker
+// opens again.
+type circuitBreaker struct {
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+
+	openedAt time.Time
+	name     string
+
+	// FailureThreshold is the number of consecutive failures that opens the
+	// breaker.
+	FailureThreshold int
+	// OpenTimeout is the duration the breaker stays open before it lets probe
+	// calls through.
+	OpenTimeout time.Duration
+	// HalfOpenProbes is the number of probe calls let through while the
+	// breaker is half-open.
+	HalfOpenProbes int
+
+	failures       int
+	probesInFlight int
+	probesPassed   int
+	state          breakerState
+	mu             sync.Mutex
+}
+
+// setupCircuitBreaker creates a circuit breaker for a backend with the given
+// name, configured from the environment variables.
+func setupCircuitBreaker(name string) (*circuitBreaker, error) {
+	b := &circuitBreaker{
+		name:             name,
+		now:              time.Now,
+		FailureThreshold: defaultBreakerFailureThreshold,
+		OpenTimeout:      defaultBreakerOpenTimeout,
+		HalfOpenProbes:   defaultBreakerHalfOpenProbes,
+	}
+
+	var err error
+
+	if breakerFailureThreshold != "" {
+		b.FailureThreshold, err = strconv.Atoi(breakerFailureThreshold)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing CIRCUIT_BREAKER_FAILURE_THRESHOLD envvar: %w",
+				err,
+			)
+		}
+	}
+
+	if breakerOpenTimeout != "" {
+		b.OpenTimeout, err = time.ParseDuration(breakerOpenTimeout)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing CIRCUIT_BREAKER_OPEN_TIMEOUT envvar: %w",
+				err,
+			)
+		}
+	}
+
+	if breakerHalfOpenProbes != "" {
+		b.HalfOpenProbes, err = strconv.Atoi(breakerHalfOpenProbes)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing CIRCUIT_BREAKER_HALF_OPEN_PROBES envvar: %w",
+				err,
+			)
+		}
+	}
+
+	return b, nil
+}
+
+// allow reports whether a call may proceed to the backend. If it returns true,
+// the caller must report the outcome of the call by calling done.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerClosed:
+		return true
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.OpenTimeout {
+			return false
+		}
+		b.transition(breakerHalfOpen)
+		fallthrough
+	default: // breakerHalfOpen
+		if b.probesInFlight+b.probesPassed >= b.HalfOpenProbes {
+			return false
+		}
+		b.probesInFlight++
+		return true
+	}
+}
+
+// done records the outcome of a call previously allowed by allow.
+func (b *circuitBreaker) done(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+
+		b.failures++
+		if b.failures >= b.FailureThreshold {
+			b.transition(breakerOpen)
+		}
+	case breakerHalfOpen:
+		b.probesInFlight--
+
+		if failed {
+			b.transition(breakerOpen)
+			return
+		}
+
+		b.probesPassed++
+		if b.probesPassed >= b.HalfOpenProbes {
+			b.transition(breakerClosed)
+		}
+	case breakerOpen:
+		// The outcome of a call that was let through before the breaker has
+		// opened is irrelevant.
+	}
+}
+
+// transition moves the breaker to the given state. It must be called with the
+// mutex held.
+func (b *circuitBreaker) transition(to breakerState) {
+	slog.Info(
+		"circuit breaker state changed",
+		slog.String("backend", b.name),
+		slog.String("from", b.state.String()),
+		slog.String("to", to.String()),
+	)
+
+	b.state = to
+	b.failures = 0
+	b.probesInFlight = 0
+	b.probesPassed = 0
+
+	if to == breakerOpen {
+		b.openedAt = b.now()
+	}
+}
+
+// unaryClientInterceptor returns a gRPC client interceptor that guards all
+// unary calls with the breaker.
+func (b *circuitBreaker) unaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if !b.allow() {
+			return status.Errorf(
+				codes.Unavailable,
+				"circuit breaker for %s backend is open",
+				b.name,
+			)
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.done(isBackendFailure(err))
+
+		return err
+	}
+}
+
+// isBackendFailure reports whether the error returned by a call indicates that
+// the backend is unhealthy, as opposed to the call being merely rejected.
+func isBackendFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
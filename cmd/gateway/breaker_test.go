@@ -0,0 +1,84 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := &circuitBreaker{
+		name:             "test",
+		now:              func() time.Time { return now },
+		FailureThreshold: 3,
+		OpenTimeout:      10 * time.Second,
+		HalfOpenProbes:   2,
+	}
+
+	fail := func() {
+		t.Helper()
+		if !b.allow() {
+			t.Fatal("expected call to be allowed")
+		}
+		b.done(true)
+	}
+	succeed := func() {
+		t.Helper()
+		if !b.allow() {
+			t.Fatal("expected call to be allowed")
+		}
+		b.done(false)
+	}
+	expectState := func(expected breakerState) {
+		t.Helper()
+		if b.state != expected {
+			t.Fatalf("expected breaker to be %v, got %v", expected, b.state)
+		}
+	}
+
+	t.Run("opens after consecutive failures", func(t *testing.T) {
+		fail()
+		fail()
+		succeed() // resets the count of consecutive failures
+		fail()
+		fail()
+		expectState(breakerClosed)
+
+		fail()
+		expectState(breakerOpen)
+
+		if b.allow() {
+			t.Fatal("expected call to be rejected while the breaker is open")
+		}
+	})
+
+	t.Run("re-opens if a probe fails", func(t *testing.T) {
+		now = now.Add(b.OpenTimeout)
+
+		fail()
+		expectState(breakerOpen)
+
+		if b.allow() {
+			t.Fatal("expected call to be rejected while the breaker is open")
+		}
+	})
+
+	t.Run("limits the number of probes", func(t *testing.T) {
+		now = now.Add(b.OpenTimeout)
+
+		if !b.allow() || !b.allow() {
+			t.Fatal("expected probe calls to be allowed")
+		}
+		if b.allow() {
+			t.Fatal("expected call to be rejected while probes are in flight")
+		}
+		expectState(breakerHalfOpen)
+
+		b.done(false)
+		expectState(breakerHalfOpen)
+		b.done(false)
+		expectState(breakerClosed)
+
+		succeed()
+	})
+}
@@ -0,0 +1,351 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	racingv1 "github.com/danilvpetrov/entain/api/racing/v1"
+	racingv2 "github.com/danilvpetrov/entain/api/racing/v2"
+	"github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// connectPrefixes is a list of the path prefixes of the procedures served by
+// the handler returned by setupConnect.
+var connectPrefixes = []string{
+	"/" + racingv1.Racing_ServiceDesc.ServiceName + "/",
+	"/" + racingv2.Racing_ServiceDesc.ServiceName + "/",
+	"/" + sports.Sports_ServiceDesc.ServiceName + "/",
+}
+
+// setupConnect sets up the routes serving the RPCs of the racing and sports
+// services over the Connect, gRPC and gRPC-Web protocols, at the paths of
+// their procedures, for example "/racing.v2.Racing/ListRaces". The calls are
+// forwarded to the services over the given connections, with the same
+// metadata as the calls made by the gRPC gateway mux.
+func setupConnect(
+	mux *runtime.ServeMux,
+	racingConn, racingV2Conn, sportsConn grpc.ClientConnInterface,
+) *http.ServeMux {
+	routes := http.NewServeMux()
+
+	handle := func(procedure string, h *connect.Handler) {
+		routes.Handle(
+			procedure,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx, err := runtime.AnnotateContext(
+					r.Context(),
+					mux,
+					r,
+					procedure,
+				)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				h.ServeHTTP(w, r.WithContext(ctx))
+			}),
+		)
+	}
+
+	racing := racingv1.NewRacingClient(racingConn)
+	for procedure, h := range map[string]func(string) *connect.Handler{
+		racingv1.Racing_ListRaces_FullMethodName: unary(racing.ListRaces),
+		racingv1.Racing_GetRace_FullMethodName:   unary(racing.GetRace),
+		racingv1.Racing_GetRaceByExternalId_FullMethodName: unary(
+			racing.GetRaceByExternalId,
+		),
+		racingv1.Racing_BatchGetRaces_FullMethodName: unary(
+			racing.BatchGetRaces,
+		),
+		racingv1.Racing_UpdateRace_FullMethodName: unary(racing.UpdateRace),
+		racingv1.Racing_DeleteRace_FullMethodName: unary(racing.DeleteRace),
+		racingv1.Racing_ImportRaces_FullMethodName: clientStream(
+			racing.ImportRaces,
+		),
+		racingv1.Racing_ExportRaces_FullMethodName: serverStream(
+			racing.ExportRaces,
+		),
+		racingv1.Racing_ListChanges_FullMethodName: unary(racing.ListChanges),
+		racingv1.Racing_ListAuditEntries_FullMethodName: unary(
+			racing.ListAuditEntries,
+		),
+		racingv1.Racing_ListTenantOverrides_FullMethodName: unary(
+			racing.ListTenantOverrides,
+		),
+		racingv1.Racing_SetTenantOverride_FullMethodName: unary(
+			racing.SetTenantOverride,
+		),
+		racingv1.Racing_DeleteTenantOverride_FullMethodName: unary(
+			racing.DeleteTenantOverride,
+		),
+	} {
+		handle(procedure, h(procedure))
+	}
+
+	racingV2 := racingv2.NewRacingClient(racingV2Conn)
+	for procedure, h := range map[string]func(string) *connect.Handler{
+		racingv2.Racing_ListRaces_FullMethodName: unary(racingV2.ListRaces),
+		racingv2.Racing_GetRace_FullMethodName:   unary(racingV2.GetRace),
+		racingv2.Racing_UpdateRaceStatus_FullMethodName: unary(
+			racingV2.UpdateRaceStatus,
+		),
+	} {
+		handle(procedure, h(procedure))
+	}
+
+	s := sports.NewSportsClient(sportsConn)
+	for procedure, h := range map[string]func(string) *connect.Handler{
+		sports.Sports_ListEvents_FullMethodName: unary(s.ListEvents),
+		sports.Sports_GetEvent_FullMethodName:   unary(s.GetEvent),
+		sports.Sports_GetEventByExternalId_FullMethodName: unary(
+			s.GetEventByExternalId,
+		),
+		sports.Sports_BatchGetEvents_FullMethodName: unary(s.BatchGetEvents),
+		sports.Sports_UpdateEvent_FullMethodName:    unary(s.UpdateEvent),
+		sports.Sports_DeleteEvent_FullMethodName:    unary(s.DeleteEvent),
+		sports.Sports_UpdateScore_FullMethodName:    unary(s.UpdateScore),
+		sports.Sports_WatchEvent_FullMethodName:     serverStream(s.WatchEvent),
+		sports.Sports_ListCompetitions_FullMethodName: unary(
+			s.ListCompetitions,
+		),
+		sports.Sports_ListParticipants_FullMethodName: unary(
+			s.ListParticipants,
+		),
+		sports.Sports_ImportEvents_FullMethodName: clientStream(
+			s.ImportEvents,
+		),
+		sports.Sports_ExportEvents_FullMethodName: serverStream(
+			s.ExportEvents,
+		),
+		sports.Sports_ListChanges_FullMethodName: unary(s.ListChanges),
+		sports.Sports_ListAuditEntries_FullMethodName: unary(
+			s.ListAuditEntries,
+		),
+		sports.Sports_ListTenantOverrides_FullMethodName: unary(
+			s.ListTenantOverrides,
+		),
+		sports.Sports_SetTenantOverride_FullMethodName: unary(
+			s.SetTenantOverride,
+		),
+		sports.Sports_DeleteTenantOverride_FullMethodName: unary(
+			s.DeleteTenantOverride,
+		),
+	} {
+		handle(procedure, h(procedure))
+	}
+
+	return routes
+}
+
+// isStreamingProcedure reports whether the path is the path of a procedure
+// of a streaming RPC, for example "/racing.v1.Racing/ExportRaces".
+func isStreamingProcedure(path string) bool {
+	service, method, ok := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if !ok {
+		return false
+	}
+
+	d, err := protoregistry.GlobalFiles.FindDescriptorByName(
+		protoreflect.FullName(service),
+	)
+	if err != nil {
+		return false
+	}
+
+	s, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return false
+	}
+
+	m := s.Methods().ByName(protoreflect.Name(method))
+	return m != nil && (m.IsStreamingClient() || m.IsStreamingServer())
+}
+
+// unary returns a constructor of the handler of a unary procedure forwarding
+// the requests to the given gRPC client method.
+func unary[Req, Res any](
+	call func(context.Context, *Req, ...grpc.CallOption) (*Res, error),
+) func(string) *connect.Handler {
+	return func(procedure string) *connect.Handler {
+		return connect.NewUnaryHandler(
+			procedure,
+			func(
+				ctx context.Context,
+				req *connect.Request[Req],
+			) (*connect.Response[Res], error) {
+				var header, trailer metadata.MD
+
+				res, err := call(
+					ctx,
+					req.Msg,
+					grpc.Header(&header),
+					grpc.Trailer(&trailer),
+				)
+				if err != nil {
+					return nil, connectError(err)
+				}
+
+				resp := connect.NewResponse(res)
+				copyMetadata(resp.Header(), header)
+				copyMetadata(resp.Trailer(), trailer)
+
+				return resp, nil
+			},
+		)
+	}
+}
+
+// serverStream returns a constructor of the handler of a server streaming
+// procedure forwarding the requests to the given gRPC client method.
+func serverStream[Req, Res any](
+	call func(
+		context.Context,
+		*Req,
+		...grpc.CallOption,
+	) (grpc.ServerStreamingClient[Res], error),
+) func(string) *connect.Handler {
+	return func(procedure string) *connect.Handler {
+		return connect.NewServerStreamHandler(
+			procedure,
+			func(
+				ctx context.Context,
+				req *connect.Request[Req],
+				stream *connect.ServerStream[Res],
+			) error {
+				client, err := call(ctx, req.Msg)
+				if err != nil {
+					return connectError(err)
+				}
+
+				header, err := client.Header()
+				if err != nil {
+					return connectError(err)
+				}
+				copyMetadata(stream.ResponseHeader(), header)
+
+				for {
+					msg, err := client.Recv()
+					if errors.Is(err, io.EOF) {
+						break
+					}
+					if err != nil {
+						return connectError(err)
+					}
+
+					if err := stream.Send(msg); err != nil {
+						return err
+					}
+				}
+
+				copyMetadata(stream.ResponseTrailer(), client.Trailer())
+
+				return nil
+			},
+		)
+	}
+}
+
+// clientStream returns a constructor of the handler of a client streaming
+// procedure forwarding the requests to the given gRPC client method.
+func clientStream[Req, Res any](
+	call func(
+		context.Context,
+		...grpc.CallOption,
+	) (grpc.ClientStreamingClient[Req, Res], error),
+) func(string) *connect.Handler {
+	return func(procedure string) *connect.Handler {
+		return connect.NewClientStreamHandler(
+			procedure,
+			func(
+				ctx context.Context,
+				stream *connect.ClientStream[Req],
+			) (*connect.Response[Res], error) {
+				client, err := call(ctx)
+				if err != nil {
+					return nil, connectError(err)
+				}
+
+				for stream.Receive() {
+					// The service closes the stream early on failure, in
+					// which case the error is returned by CloseAndRecv.
+					if err := client.Send(stream.Msg()); errors.Is(
+						err,
+						io.EOF,
+					) {
+						break
+					} else if err != nil {
+						return nil, connectError(err)
+					}
+				}
+
+				if err := stream.Err(); err != nil {
+					return nil, err
+				}
+
+				res, err := client.CloseAndRecv()
+				if err != nil {
+					return nil, connectError(err)
+				}
+
+				header, err := client.Header()
+				if err != nil {
+					return nil, connectError(err)
+				}
+
+				resp := connect.NewResponse(res)
+				copyMetadata(resp.Header(), header)
+				copyMetadata(resp.Trailer(), client.Trailer())
+
+				return resp, nil
+			},
+		)
+	}
+}
+
+// connectError converts an error returned by a gRPC service to a Connect
+// error with the same code, message and details.
+func connectError(err error) error {
+	st := status.Convert(err)
+
+	cerr := connect.NewError(
+		connect.Code(st.Code()),
+		errors.New(st.Message()),
+	)
+	for _, d := range st.Proto().GetDetails() {
+		if detail, err := connect.NewErrorDetail(d); err == nil {
+			cerr.AddDetail(detail)
+		}
+	}
+
+	return cerr
+}
+
+// copyMetadata copies the gRPC metadata returned by a service to the headers
+// or the trailers of a Connect response. The metadata describing the gRPC
+// response itself is not copied, nor is the request ID, which is already set
+// by withRequestID.
+func copyMetadata(h http.Header, md metadata.MD) {
+	for k, v := range md {
+		if k == "content-type" ||
+			k == apierror.RequestIDHeader ||
+			strings.HasPrefix(k, "grpc-") {
+			continue
+		}
+
+		for _, s := range v {
+			h.Add(k, s)
+		}
+	}
+}
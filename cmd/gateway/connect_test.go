@@ -0,0 +1,282 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+
+	"connectrpc.com/connect"
+	racingv1 "github.com/danilvpetrov/entain/api/racing/v1"
+	racingv2 "github.com/danilvpetrov/entain/api/racing/v2"
+	"github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/racing"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	_ "github.com/mattn/go-sqlite3" // underscore import for the SQLite driver
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestConnect(t *testing.T) {
+	url := setupConnectTest(t)
+
+	// http1 is a client making requests over HTTP/1.1, as browsers may do.
+	http1 := &http.Client{}
+
+	// h2c is a client making requests over unencrypted HTTP/2, as gRPC
+	// requires.
+	var protocols http.Protocols
+	protocols.SetUnencryptedHTTP2(true)
+	h2c := &http.Client{
+		Transport: &http.Transport{Protocols: &protocols},
+	}
+
+	cases := []struct {
+		name   string
+		client *http.Client
+		opts   []connect.ClientOption
+	}{
+		{
+			name:   "Connect over HTTP/1.1",
+			client: http1,
+		},
+		{
+			name:   "Connect over HTTP/2",
+			client: h2c,
+		},
+		{
+			name:   "gRPC-Web over HTTP/1.1",
+			client: http1,
+			opts:   []connect.ClientOption{connect.WithGRPCWeb()},
+		},
+		{
+			name:   "gRPC over HTTP/2",
+			client: h2c,
+			opts:   []connect.ClientOption{connect.WithGRPC()},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			getRace := connect.NewClient[
+				racingv1.GetRaceRequest,
+				racingv1.Race,
+			](
+				tc.client,
+				url+racingv1.Racing_GetRace_FullMethodName,
+				tc.opts...,
+			)
+
+			getRaceV2 := connect.NewClient[
+				racingv2.GetRaceRequest,
+				racingv2.Race,
+			](
+				tc.client,
+				url+racingv2.Racing_GetRace_FullMethodName,
+				tc.opts...,
+			)
+
+			exportRaces := connect.NewClient[
+				racingv1.ExportRacesRequest,
+				racingv1.Race,
+			](
+				tc.client,
+				url+racingv1.Racing_ExportRaces_FullMethodName,
+				tc.opts...,
+			)
+
+			t.Run("gets a race", func(t *testing.T) {
+				resp, err := getRace.CallUnary(
+					t.Context(),
+					connect.NewRequest(&racingv1.GetRaceRequest{RaceId: 1}),
+				)
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if resp.Msg.GetId() != 1 || resp.Msg.GetName() == "" {
+					t.Fatalf("expected race 1, got %v", resp.Msg)
+				}
+
+				if resp.Header().Get("Deprecation") == "" {
+					t.Fatal("expected the response to be deprecated")
+				}
+
+				if ids := resp.Header().Values(requestIDHeader); len(ids) != 1 {
+					t.Fatalf("expected one request ID, got %v", ids)
+				}
+
+				v2, err := getRaceV2.CallUnary(
+					t.Context(),
+					connect.NewRequest(&racingv2.GetRaceRequest{RaceId: 1}),
+				)
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if v2.Msg.GetEtag() != resp.Msg.GetEtag() {
+					t.Fatalf(
+						"expected etag %q, got %q",
+						resp.Msg.GetEtag(),
+						v2.Msg.GetEtag(),
+					)
+				}
+
+				if v2.Header().Get("Deprecation") != "" {
+					t.Fatal("expected the response not to be deprecated")
+				}
+			})
+
+			t.Run("reports missing race as not found", func(t *testing.T) {
+				_, err := getRace.CallUnary(
+					t.Context(),
+					connect.NewRequest(&racingv1.GetRaceRequest{
+						RaceId: racing.NumberOfSeededRaces + 1,
+					}),
+				)
+				if connect.CodeOf(err) != connect.CodeNotFound {
+					t.Fatalf(
+						"expected %v error, got %v",
+						connect.CodeNotFound,
+						err,
+					)
+				}
+			})
+
+			t.Run("exports races", func(t *testing.T) {
+				stream, err := exportRaces.CallServerStream(
+					t.Context(),
+					connect.NewRequest(&racingv1.ExportRacesRequest{}),
+				)
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				defer stream.Close()
+
+				var n int
+				for stream.Receive() {
+					n++
+				}
+
+				if err := stream.Err(); err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if n != racing.NumberOfSeededRaces {
+					t.Fatalf(
+						"expected %d races, got %d",
+						racing.NumberOfSeededRaces,
+						n,
+					)
+				}
+			})
+		})
+	}
+}
+
+func TestSetupConnect(t *testing.T) {
+	var conn grpc.ClientConnInterface = &grpc.ClientConn{}
+	rpc := setupConnect(runtime.NewServeMux(), conn, conn, conn)
+
+	for _, s := range []protoreflect.ServiceDescriptor{
+		racingv1.File_api_racing_v1_racing_proto.Services().Get(0),
+		racingv2.File_api_racing_v2_racing_proto.Services().Get(0),
+		sports.File_api_sports_sports_proto.Services().Get(0),
+	} {
+		for i := range s.Methods().Len() {
+			procedure := "/" + string(s.FullName()) + "/" +
+				string(s.Methods().Get(i).Name())
+
+			r, err := http.NewRequest(http.MethodPost, procedure, http.NoBody)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, pattern := rpc.Handler(r); pattern == "" {
+				t.Errorf("expected procedure %s to be served", procedure)
+			}
+		}
+	}
+}
+
+// setupConnectTest is a test helper that sets up a racing service and the
+// gateway in front of it, and returns the URL of the gateway.
+func setupConnectTest(t *testing.T) string {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if err := racing.ApplySchema(t.Context(), db); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := racing.SeedTestData(t.Context(), db); err != nil {
+		t.Fatal(err)
+	}
+
+	service := &racing.Service{DB: db}
+	// The services echo the request IDs, as they do in production.
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(apierror.UnaryServerInterceptor()),
+		grpc.StreamInterceptor(apierror.StreamServerInterceptor()),
+	)
+	racingv1.RegisterRacingServer(server, service)
+	racingv2.RegisterRacingServer(server, &racing.ServiceV2{V1: service})
+
+	listenCfg := net.ListenConfig{}
+	// Listen on a random port.
+	listener, err := listenCfg.Listen(t.Context(), "tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(server.GracefulStop)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	prevRacingServiceAddr, prevServerAddr := racingServiceAddr, serverAddr
+	t.Cleanup(func() {
+		racingServiceAddr, serverAddr = prevRacingServiceAddr, prevServerAddr
+	})
+	racingServiceAddr = listener.Addr().String()
+	serverAddr = "localhost:0"
+
+	h, err := setupAPI(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svr, gatewayListener, err := setupServer(t.Context(), h)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := svr.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	go func() {
+		if err := svr.Serve(gatewayListener); !errors.Is(
+			err,
+			http.ErrServerClosed,
+		) {
+			t.Error(err)
+		}
+	}()
+
+	return "http://" + gatewayListener.Addr().String()
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+var corsAllowedOrigins = os.Getenv("CORS_ALLOWED_ORIGINS")
+
+// corsAllowedMethods is a list of HTTP methods browsers are allowed to use in
+// cross-origin requests.
+const corsAllowedMethods = "GET, POST, PUT, PATCH, DELETE"
+
+// corsExposedHeaders is a list of response headers browsers expose to the
+// scripts making cross-origin requests. The gRPC-Web status is sent in the
+// Grpc-* headers of the responses ending with no messages.
+const corsExposedHeaders = "Grpc-Status, Grpc-Message, " +
+	"Grpc-Status-Details-Bin, X-Request-Id, Etag, Deprecation, Link, " +
+	"Sunset, Warning"
+
+// corsMaxAge is the number of seconds browsers cache the responses to
+// preflight requests for.
+const corsMaxAge = "7200"
+
+// setupCORS wraps next into an HTTP middleware that allows browsers to make
+// cross-origin requests from the configured origins.
+//
+// The origins are configured by the CORS_ALLOWED_ORIGINS envvar in the
+// "<origin>,<origin>" form, for example "https://www.example.com". An origin
+// may be a pattern, as accepted by path.Match, for example
+// "https://*.example.com", and "*" allows requests from any origin. If
+// CORS_ALLOWED_ORIGINS is not set, cross-origin requests are not allowed.
+func setupCORS(next http.Handler) (http.Handler, error) {
+	var origins []string
+	for o := range strings.SplitSeq(corsAllowedOrigins, ",") {
+		o = strings.TrimSpace(o)
+		if o == "" {
+			continue
+		}
+
+		if _, err := path.Match(o, ""); err != nil {
+			return nil, fmt.Errorf(
+				"error parsing CORS_ALLOWED_ORIGINS envvar: %q: %w",
+				o,
+				err,
+			)
+		}
+
+		origins = append(origins, o)
+	}
+
+	if len(origins) == 0 {
+		return next, nil
+	}
+
+	return withCORS(origins, next), nil
+}
+
+// withCORS is an HTTP middleware that allows cross-origin requests from the
+// given origins. The responses to requests from these origins bear the
+// Access-Control-Allow-Origin header naming the origin, and preflight requests
+// are answered by the middleware itself. Other requests are passed to next as
+// they are.
+func withCORS(origins []string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Add("Vary", "Origin")
+
+		if !isAllowedOrigin(origins, origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h.Set("Access-Control-Allow-Origin", origin)
+
+		if r.Method == http.MethodOptions &&
+			r.Header.Get("Access-Control-Request-Method") != "" {
+			h.Add("Vary", "Access-Control-Request-Method")
+			h.Add("Vary", "Access-Control-Request-Headers")
+			h.Set("Access-Control-Allow-Methods", corsAllowedMethods)
+			if headers := r.Header.Get(
+				"Access-Control-Request-Headers",
+			); headers != "" {
+				h.Set("Access-Control-Allow-Headers", headers)
+			}
+			h.Set("Access-Control-Max-Age", corsMaxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		h.Set("Access-Control-Expose-Headers", corsExposedHeaders)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isAllowedOrigin reports whether the origin matches one of the given origins.
+func isAllowedOrigin(origins []string, origin string) bool {
+	for _, o := range origins {
+		if o == "*" {
+			return true
+		}
+
+		if ok, _ := path.Match(o, origin); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWithCORS(t *testing.T) {
+	h := withCORS(
+		[]string{"https://www.example.com", "https://*.example.org"},
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	cases := []struct {
+		name        string
+		method      string
+		header      http.Header
+		status      int
+		allowOrigin string
+	}{
+		{
+			name:   "same-origin request",
+			method: http.MethodPost,
+			status: http.StatusOK,
+		},
+		{
+			name:        "request from an allowed origin",
+			method:      http.MethodPost,
+			header:      http.Header{"Origin": {"https://www.example.com"}},
+			status:      http.StatusOK,
+			allowOrigin: "https://www.example.com",
+		},
+		{
+			name:        "request from an origin matching a pattern",
+			method:      http.MethodPost,
+			header:      http.Header{"Origin": {"https://app.example.org"}},
+			status:      http.StatusOK,
+			allowOrigin: "https://app.example.org",
+		},
+		{
+			name:   "request from another origin",
+			method: http.MethodPost,
+			header: http.Header{"Origin": {"https://www.example.net"}},
+			status: http.StatusOK,
+		},
+		{
+			name:   "preflight request from an allowed origin",
+			method: http.MethodOptions,
+			header: http.Header{
+				"Origin":                         {"https://www.example.com"},
+				"Access-Control-Request-Method":  {"POST"},
+				"Access-Control-Request-Headers": {"content-type"},
+			},
+			status:      http.StatusNoContent,
+			allowOrigin: "https://www.example.com",
+		},
+		{
+			name:   "preflight request from another origin",
+			method: http.MethodOptions,
+			header: http.Header{
+				"Origin":                        {"https://www.example.net"},
+				"Access-Control-Request-Method": {"POST"},
+			},
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(
+				tc.method,
+				"/racing.v2.Racing/ListRaces",
+				http.NoBody,
+			)
+			for k, v := range tc.header {
+				r.Header[k] = v
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, r)
+
+			if rec.Code != tc.status {
+				t.Fatalf("expected status %d, got %d", tc.status, rec.Code)
+			}
+
+			actual := rec.Header().Get("Access-Control-Allow-Origin")
+			if actual != tc.allowOrigin {
+				t.Fatalf(
+					"expected Access-Control-Allow-Origin header %q, got %q",
+					tc.allowOrigin,
+					actual,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	racingv1 "github.com/danilvpetrov/entain/api/racing/v1"
+)
+
+var racingV1Sunset = os.Getenv("RACING_V1_SUNSET")
+
+// racingV1DeprecationTime is the time version 1 of the Racing API was
+// deprecated in favour of version 2.
+var racingV1DeprecationTime = time.Date(
+	2026, time.October, 19,
+	0, 0, 0, 0,
+	time.UTC,
+)
+
+// racingV1Successor is the link to the routes of the version of the Racing API
+// that succeeds version 1.
+const racingV1Successor = `</v2/races>; rel="successor-version"`
+
+// isRacingV1Request reports whether a request is routed to version 1 of the
+// Racing API, for example "/v1/races/1", "/v1/tenants/acme/races" or
+// "/racing.v1.Racing/GetRace".
+func isRacingV1Request(r *http.Request) bool {
+	if strings.HasPrefix(
+		r.URL.Path,
+		"/"+racingv1.Racing_ServiceDesc.ServiceName+"/",
+	) {
+		return true
+	}
+
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if segments[0] != "v1" || len(segments) < 2 {
+		return false
+	}
+
+	switch {
+	case segments[1] == "races",
+		strings.HasPrefix(segments[1], "races:"):
+		return true
+	case segments[1] == "tenants":
+		return len(segments) > 3 && segments[3] == "races"
+	default:
+		return false
+	}
+}
+
+// setupDeprecation wraps the handler with a middleware marking the responses of
+// version 1 of the Racing API as deprecated. The responses bear the
+// Deprecation header defined in RFC 9745 and a link to their successor. If
+// RACING_V1_SUNSET is set, they also bear the Sunset header defined in RFC
+// 8594 with the time version 1 stops being served.
+func setupDeprecation(next http.Handler) (http.Handler, error) {
+	var sunset string
+	if racingV1Sunset != "" {
+		t, err := time.Parse(time.RFC3339, racingV1Sunset)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing RACING_V1_SUNSET envvar: %w",
+				err,
+			)
+		}
+		sunset = t.UTC().Format(http.TimeFormat)
+	}
+
+	deprecation := "@" + strconv.FormatInt(racingV1DeprecationTime.Unix(), 10)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isRacingV1Request(r) {
+			h := w.Header()
+			h.Set("Deprecation", deprecation)
+			h.Add("Link", racingV1Successor)
+			if sunset != "" {
+				h.Set("Sunset", sunset)
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	}), nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetupDeprecation(t *testing.T) {
+	h, err := setupDeprecation(
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		target     string
+		deprecated bool
+	}{
+		{
+			name:       "request to list races",
+			target:     "/v1/races",
+			deprecated: true,
+		},
+		{
+			name:       "request to get a race",
+			target:     "/v1/races/1",
+			deprecated: true,
+		},
+		{
+			name:       "request to a custom method of races",
+			target:     "/v1/races:batchGet",
+			deprecated: true,
+		},
+		{
+			name:       "request to tenant overrides of races",
+			target:     "/v1/tenants/acme/races/1",
+			deprecated: true,
+		},
+		{
+			name:       "call of a procedure of version 1 of races",
+			target:     "/racing.v1.Racing/GetRace",
+			deprecated: true,
+		},
+		{
+			name:       "request to version 2 of races",
+			target:     "/v2/races",
+			deprecated: false,
+		},
+		{
+			name:       "request to sports events",
+			target:     "/v1/sports/1",
+			deprecated: false,
+		},
+		{
+			name:       "request to tenant overrides of sports events",
+			target:     "/v1/tenants/races/sports/1",
+			deprecated: false,
+		},
+		{
+			name:       "call of a procedure of version 2 of races",
+			target:     "/racing.v2.Racing/GetRace",
+			deprecated: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.target, nil))
+
+			deprecation := w.Header().Get("Deprecation")
+			link := w.Header().Get("Link")
+
+			if !tc.deprecated {
+				if deprecation != "" || link != "" {
+					t.Fatalf(
+						"expected no deprecation headers, got %v",
+						w.Header(),
+					)
+				}
+				return
+			}
+
+			if deprecation != "@1792368000" {
+				t.Fatalf("unexpected Deprecation header %q", deprecation)
+			}
+
+			if link != `</v2/races>; rel="successor-version"` {
+				t.Fatalf("unexpected Link header %q", link)
+			}
+		})
+	}
+}
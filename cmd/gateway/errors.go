@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	rpccode "google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// errorBody is the JSON body of an HTTP error response. It follows the format
+// described in https://google.aip.dev/193#http11json-representation.
+type errorBody struct {
+	Error errorBodyStatus `json:"error"`
+}
+
+// errorBodyStatus is a JSON representation of a gRPC status.
+type errorBodyStatus struct {
+	// Message is a developer-facing error message.
+	Message string `json:"message"`
+	// Status is the name of the gRPC status code, for example "NOT_FOUND".
+	Status string `json:"status"`
+	// Details is a list of google.rpc error details, each one carrying the
+	// "@type" field with the type URL of the detail.
+	Details []json.RawMessage `json:"details"`
+	// Code is the HTTP status code of the response.
+	Code int `json:"code"`
+}
+
+// handleError is a grpc-gateway error handler that writes errors returned by
+// the backend services in a consistent JSON format, including all google.rpc
+// error details attached to the status. Requests failing because of a stale
+// etag are answered with 412 Precondition Failed, and requests for races or
+// sports events restricted in the jurisdiction of the client with 451
+// Unavailable For Legal Reasons.
+func handleError(
+	_ context.Context,
+	_ *runtime.ServeMux,
+	_ runtime.Marshaler,
+	w http.ResponseWriter,
+	_ *http.Request,
+	err error,
+) {
+	var httpStatus *runtime.HTTPStatusError
+	if errors.As(err, &httpStatus) {
+		err = httpStatus.Err
+	}
+
+	st := status.Convert(err)
+
+	code := runtime.HTTPStatusFromCode(st.Code())
+	if isETagMismatch(st) {
+		code = http.StatusPreconditionFailed
+	}
+	if isRestricted(st) {
+		code = http.StatusUnavailableForLegalReasons
+	}
+	if httpStatus != nil {
+		code = httpStatus.HTTPStatus
+	}
+
+	body := errorBody{
+		Error: errorBodyStatus{
+			Code:    code,
+			Message: st.Message(),
+			// google.rpc.Code names are in UPPER_SNAKE_CASE unlike the names
+			// returned by codes.Code.
+			Status:  rpccode.Code(st.Code()).String(), //nolint:gosec // Codes are small.
+			Details: marshalDetails(st.Proto().GetDetails()),
+		},
+	}
+
+	w.Header().Del("Trailer")
+	w.Header().Del("Transfer-Encoding")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("failed writing error response", slog.Any("error", err))
+	}
+}
+
+// marshalDetails converts google.rpc error details to JSON. Details of unknown
+// types are skipped.
+func marshalDetails(details []*anypb.Any) []json.RawMessage {
+	result := make([]json.RawMessage, 0, len(details))
+
+	for _, d := range details {
+		raw, err := protojson.Marshal(d)
+		if err != nil {
+			slog.Warn(
+				"failed marshalling error detail",
+				slog.String("type", d.GetTypeUrl()),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		result = append(result, raw)
+	}
+
+	return result
+}
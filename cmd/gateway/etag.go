@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/danilvpetrov/entain/etag"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// ifMatchHeader is the canonical name of the HTTP header carrying the etag
+	// a request is conditional on.
+	ifMatchHeader = "If-Match"
+
+	// etagHeader is the canonical name of the HTTP header carrying the etag
+	// of the returned entity.
+	etagHeader = "Etag"
+)
+
+// setETagHeader is a grpc-gateway response option that returns the etag of a
+// race or a sports event in the ETag header, so that it can be passed back in
+// the If-Match header of the requests updating or deleting it.
+func setETagHeader(
+	_ context.Context,
+	w http.ResponseWriter,
+	resp proto.Message,
+) error {
+	m, ok := resp.(interface{ GetEtag() string })
+	if !ok || m.GetEtag() == "" {
+		return nil
+	}
+
+	w.Header().Set(etagHeader, strconv.Quote(m.GetEtag()))
+
+	return nil
+}
+
+// isETagMismatch returns true if the status is returned because the etag of
+// the request does not match the current version of the entity.
+func isETagMismatch(st *status.Status) bool {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.GetReason() == etag.ReasonMismatch
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	racingv1 "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/etag"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSetETagHeader(t *testing.T) {
+	cases := []struct {
+		resp     proto.Message
+		name     string
+		expected string
+	}{
+		{
+			name:     "race with etag",
+			resp:     &racingv1.Race{Id: 1, Etag: "3"},
+			expected: `"3"`,
+		},
+		{
+			name: "race without etag",
+			resp: &racingv1.Race{Id: 1},
+		},
+		{
+			name: "message without etag field",
+			resp: &racingv1.ListRacesResponse{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := setETagHeader(t.Context(), w, tc.resp); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := w.Header().Get("ETag"); got != tc.expected {
+				t.Fatalf("expected ETag %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestMatchIncomingHeader(t *testing.T) {
+	key, ok := matchIncomingHeader("if-match")
+	if !ok || key != etag.MetadataKey {
+		t.Fatalf(
+			"expected header forwarded as %q, got %q",
+			etag.MetadataKey,
+			key,
+		)
+	}
+}
+
+func TestHandleErrorETagMismatch(t *testing.T) {
+	cases := []struct {
+		err      error
+		name     string
+		expected int
+	}{
+		{
+			name: "etag mismatch",
+			err: apierror.Aborted(
+				t.Context(),
+				etag.ReasonMismatch,
+				"stale etag",
+			),
+			expected: http.StatusPreconditionFailed,
+		},
+		{
+			name:     "other aborted error",
+			err:      apierror.Aborted(t.Context(), "OTHER", "aborted"),
+			expected: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handleError(
+				t.Context(),
+				nil,
+				nil,
+				w,
+				httptest.NewRequest(http.MethodPatch, "/v1/races/1", nil),
+				tc.err,
+			)
+
+			if w.Code != tc.expected {
+				t.Fatalf("expected status %d, got %d", tc.expected, w.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// fieldsParam is the name of the query parameter that carries the read mask of
+// a request.
+const fieldsParam = "fields"
+
+// sparseFieldsKey is the context key that marks requests asking for a subset
+// of fields.
+type sparseFieldsKey struct{}
+
+// withSparseFields is an HTTP middleware that marks requests carrying the
+// "fields" query parameter, so that their responses only contain the
+// requested fields.
+func withSparseFields(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has(fieldsParam) {
+			r = r.WithContext(
+				context.WithValue(r.Context(), sparseFieldsKey{}, true),
+			)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// rewriteSparseResponse is a grpc-gateway response rewriter that omits the
+// unpopulated fields from responses to requests marked by withSparseFields.
+//
+// By default, the gateway emits every field of a response, so the fields left
+// out by the read mask would otherwise be serialised with zero values.
+func rewriteSparseResponse(ctx context.Context, resp proto.Message) (any, error) {
+	if sparse, _ := ctx.Value(sparseFieldsKey{}).(bool); !sparse {
+		return resp, nil
+	}
+
+	b, err := protojson.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.RawMessage(b), nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	racingv1 "github.com/danilvpetrov/entain/api/racing/v1"
+)
+
+func TestRewriteSparseResponse(t *testing.T) {
+	resp := &racingv1.Race{Id: 1, Name: "Race 1"}
+
+	cases := []struct {
+		name   string
+		target string
+		sparse bool
+	}{
+		{
+			name:   "request without fields",
+			target: "/v1/races/1",
+			sparse: false,
+		},
+		{
+			name:   "request with fields",
+			target: "/v1/races/1?fields=id,name",
+			sparse: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var ctx context.Context
+			h := withSparseFields(
+				http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+					ctx = r.Context()
+				}),
+			)
+			h.ServeHTTP(
+				httptest.NewRecorder(),
+				httptest.NewRequest(http.MethodGet, tc.target, nil),
+			)
+
+			got, err := rewriteSparseResponse(ctx, resp)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			raw, ok := got.(json.RawMessage)
+			if ok != tc.sparse {
+				t.Fatalf("expected sparse response %t, got %T", tc.sparse, got)
+			}
+			if !tc.sparse {
+				return
+			}
+
+			var fields map[string]any
+			if err := json.Unmarshal(raw, &fields); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(fields) != 2 || fields["id"] != "1" || fields["name"] != "Race 1" {
+				t.Fatalf("expected only id and name fields, got %s", raw)
+			}
+		})
+	}
+}
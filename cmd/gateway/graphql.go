@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/danilvpetrov/entain/graphql"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+)
+
+// graphqlPath is the path of the GraphQL endpoint.
+const graphqlPath = "/graphql"
+
+var (
+	graphqlMaxDepth      = os.Getenv("GRAPHQL_MAX_DEPTH")
+	graphqlMaxComplexity = os.Getenv("GRAPHQL_MAX_COMPLEXITY")
+	graphqlMaxBatchSize  = os.Getenv("GRAPHQL_MAX_BATCH_SIZE")
+)
+
+// setupGraphQL sets up the GraphQL endpoint at graphqlPath, configured from the
+// environment variables, calling the racing and sports services over the given
+// connections. The calls carry the same metadata as the ones made by the gRPC
+// gateway mux.
+func setupGraphQL(
+	mux *runtime.ServeMux,
+	racingConn, sportsConn grpc.ClientConnInterface,
+) (*graphql.Handler, error) {
+	h := &graphql.Handler{
+		Racing: racingConn,
+		Sports: sportsConn,
+		Context: func(
+			ctx context.Context,
+			r *http.Request,
+		) (context.Context, error) {
+			return runtime.AnnotateContext(ctx, mux, r, graphqlPath)
+		},
+	}
+
+	for _, v := range []struct {
+		name  string
+		value string
+		limit *int
+	}{
+		{"GRAPHQL_MAX_DEPTH", graphqlMaxDepth, &h.MaxDepth},
+		{"GRAPHQL_MAX_COMPLEXITY", graphqlMaxComplexity, &h.MaxComplexity},
+		{"GRAPHQL_MAX_BATCH_SIZE", graphqlMaxBatchSize, &h.MaxBatchSize},
+	} {
+		if v.value == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(v.value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s envvar: %w", v.name, err)
+		}
+
+		*v.limit = n
+	}
+
+	return h, nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/textproto"
+	"os"
+	"strings"
+
+	"github.com/danilvpetrov/entain/jurisdiction"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	trustedJurisdictionHeader = os.Getenv("JURISDICTION_HEADER")
+	geoIPDBPath               = os.Getenv("GEOIP_DB_PATH")
+	defaultJurisdiction       = os.Getenv("DEFAULT_JURISDICTION")
+)
+
+// jurisdictionHeader is the canonical name of the HTTP header carrying the
+// jurisdiction a request is made from.
+var jurisdictionHeader = textproto.CanonicalMIMEHeaderKey(
+	jurisdiction.MetadataKey,
+)
+
+// setupJurisdiction wraps next into an HTTP middleware that resolves the
+// jurisdictions the requests are made from.
+//
+// The name of the header set by a trusted proxy in front of the gateway is
+// configured by the JURISDICTION_HEADER envvar, the path to the GeoIP
+// database by the GEOIP_DB_PATH envvar, and the jurisdiction of the requests
+// that cannot be resolved otherwise by the DEFAULT_JURISDICTION envvar.
+func setupJurisdiction(next http.Handler) (http.Handler, error) {
+	fallback := strings.ToUpper(strings.TrimSpace(defaultJurisdiction))
+	if fallback != "" && !jurisdiction.IsValid(fallback) {
+		return nil, fmt.Errorf(
+			"error parsing DEFAULT_JURISDICTION envvar: "+
+				"%q is not a valid jurisdiction code",
+			defaultJurisdiction,
+		)
+	}
+
+	var geo *jurisdiction.GeoIP
+	if geoIPDBPath != "" {
+		var err error
+		geo, err = jurisdiction.LoadGeoIP(geoIPDBPath)
+		if err != nil {
+			return nil, fmt.Errorf("error loading GeoIP database: %w", err)
+		}
+	}
+
+	return withJurisdiction(trustedJurisdictionHeader, geo, fallback, next), nil
+}
+
+// withJurisdiction is an HTTP middleware that sets the X-Jurisdiction header
+// of the requests to the jurisdiction they are made from, replacing the header
+// sent by the client, if any. The jurisdiction is taken from the trusted
+// header, if it is given and carries a valid jurisdiction code, or looked up
+// by the address of the client in the GeoIP database, if it is not nil.
+// Otherwise, the request is made from the fallback jurisdiction, if it is not
+// empty, or its jurisdiction is unknown and the services restrict it the most.
+func withJurisdiction(
+	trusted string,
+	geo *jurisdiction.GeoIP,
+	fallback string,
+	next http.Handler,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code, ok := jurisdictionOf(trusted, geo, r)
+		if !ok && fallback != "" {
+			code, ok = fallback, true
+		}
+
+		r.Header.Del(jurisdictionHeader)
+		if ok {
+			r.Header.Set(jurisdictionHeader, code)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// jurisdictionOf returns the code of the jurisdiction the given request is
+// made from, if it is known.
+func jurisdictionOf(
+	trusted string,
+	geo *jurisdiction.GeoIP,
+	r *http.Request,
+) (string, bool) {
+	if trusted != "" {
+		code := strings.ToUpper(strings.TrimSpace(r.Header.Get(trusted)))
+		if jurisdiction.IsValid(code) {
+			return code, true
+		}
+	}
+
+	if geo == nil {
+		return "", false
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return "", false
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return "", false
+	}
+
+	return geo.Lookup(addr)
+}
+
+// isRestricted returns true if the status is returned because the requested
+// entity cannot be offered in the jurisdiction of the request.
+func isRestricted(st *status.Status) bool {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.GetReason() == jurisdiction.ReasonRestricted
+		}
+	}
+	return false
+}
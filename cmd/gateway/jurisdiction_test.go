@@ -0,0 +1,170 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/jurisdiction"
+)
+
+func TestWithJurisdiction(t *testing.T) {
+	geo, err := jurisdiction.ParseGeoIP(
+		strings.NewReader("203.0.113.0/24,AU-NSW\n"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		header     http.Header
+		geo        *jurisdiction.GeoIP
+		name       string
+		trusted    string
+		fallback   string
+		remoteAddr string
+		expected   string
+	}{
+		{
+			name: "unknown jurisdiction",
+		},
+		{
+			name:    "trusted header",
+			trusted: "Cloudfront-Viewer-Country",
+			header:  http.Header{"Cloudfront-Viewer-Country": {"nz"}},
+			geo:     geo,
+			// The trusted header takes precedence over the GeoIP database.
+			remoteAddr: "203.0.113.1:1234",
+			expected:   "NZ",
+		},
+		{
+			name:     "trusted jurisdiction header",
+			trusted:  "X-Jurisdiction",
+			header:   http.Header{"X-Jurisdiction": {"AU-VIC"}},
+			expected: "AU-VIC",
+		},
+		{
+			name:   "header sent by the client",
+			header: http.Header{"X-Jurisdiction": {"AU-VIC"}},
+		},
+		{
+			name:       "invalid trusted header",
+			trusted:    "Cloudfront-Viewer-Country",
+			header:     http.Header{"Cloudfront-Viewer-Country": {"Australia"}},
+			geo:        geo,
+			remoteAddr: "203.0.113.1:1234",
+			expected:   "AU-NSW",
+		},
+		{
+			name:       "located client",
+			geo:        geo,
+			header:     http.Header{"X-Jurisdiction": {"AU-VIC"}},
+			remoteAddr: "203.0.113.1:1234",
+			expected:   "AU-NSW",
+		},
+		{
+			name:       "unknown client",
+			geo:        geo,
+			remoteAddr: "198.51.100.1:1234",
+		},
+		{
+			name:       "default jurisdiction",
+			geo:        geo,
+			fallback:   "AU",
+			remoteAddr: "198.51.100.1:1234",
+			expected:   "AU",
+		},
+		{
+			name:       "located client with default jurisdiction",
+			geo:        geo,
+			fallback:   "AU",
+			remoteAddr: "203.0.113.1:1234",
+			expected:   "AU-NSW",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual *http.Request
+			h := withJurisdiction(
+				tc.trusted,
+				tc.geo,
+				tc.fallback,
+				http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+					actual = r
+				}),
+			)
+
+			r := httptest.NewRequest(http.MethodGet, "/v1/sports", http.NoBody)
+			for k, v := range tc.header {
+				r.Header[k] = v
+			}
+			if tc.remoteAddr != "" {
+				r.RemoteAddr = tc.remoteAddr
+			}
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			code := actual.Header.Get(jurisdictionHeader)
+			if code != tc.expected {
+				t.Fatalf("expected jurisdiction %q, got %q", tc.expected, code)
+			}
+		})
+	}
+}
+
+func TestMatchIncomingJurisdictionHeader(t *testing.T) {
+	key, ok := matchIncomingHeader("x-jurisdiction")
+	if !ok || key != jurisdiction.MetadataKey {
+		t.Fatalf(
+			"expected header forwarded as %q, got %q",
+			jurisdiction.MetadataKey,
+			key,
+		)
+	}
+}
+
+func TestHandleErrorRestricted(t *testing.T) {
+	cases := []struct {
+		err      error
+		name     string
+		expected int
+	}{
+		{
+			name: "restricted in jurisdiction",
+			err: jurisdiction.Restricted(
+				t.Context(),
+				"event is not available in your jurisdiction",
+			),
+			expected: http.StatusUnavailableForLegalReasons,
+		},
+		{
+			name: "other permission denied error",
+			err: apierror.PermissionDenied(
+				t.Context(),
+				"ADMIN_ONLY",
+				"admins only",
+			),
+			expected: http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handleError(
+				t.Context(),
+				nil,
+				nil,
+				w,
+				httptest.NewRequest(http.MethodGet, "/v1/sports/1", nil),
+				tc.err,
+			)
+
+			if w.Code != tc.expected {
+				t.Fatalf("expected status %d, got %d", tc.expected, w.Code)
+			}
+		})
+	}
+}
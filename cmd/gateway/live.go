@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danilvpetrov/entain/live"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+)
+
+// livePath is the path of the WebSocket endpoint pushing live updates.
+const livePath = "/live"
+
+var (
+	livePollInterval   = os.Getenv("LIVE_POLL_INTERVAL")
+	livePingInterval   = os.Getenv("LIVE_PING_INTERVAL")
+	liveQueueSize      = os.Getenv("LIVE_QUEUE_SIZE")
+	liveAllowedOrigins = os.Getenv("LIVE_ALLOWED_ORIGINS")
+	liveAdminToken     = os.Getenv("LIVE_ADMIN_TOKEN")
+)
+
+// setupLive sets up the WebSocket endpoint at livePath pushing the updates of
+// races and sports events, configured from the environment variables. The
+// updates are loaded from the racing and sports services over the given
+// connections, with the same metadata as the calls made by the gRPC gateway
+// mux.
+//
+// The services are polled until the context is cancelled, at which point the
+// connections are closed.
+func setupLive(
+	ctx context.Context,
+	mux *runtime.ServeMux,
+	racingConn, sportsConn grpc.ClientConnInterface,
+) (*live.Handler, error) {
+	h := &live.Handler{
+		Racing:     racingConn,
+		Sports:     sportsConn,
+		AdminToken: liveAdminToken,
+		Context: func(
+			ctx context.Context,
+			r *http.Request,
+		) (context.Context, error) {
+			return runtime.AnnotateContext(ctx, mux, r, livePath)
+		},
+	}
+
+	var err error
+
+	if livePollInterval != "" {
+		h.PollInterval, err = time.ParseDuration(livePollInterval)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing LIVE_POLL_INTERVAL envvar: %w",
+				err,
+			)
+		}
+	}
+
+	if livePingInterval != "" {
+		h.PingInterval, err = time.ParseDuration(livePingInterval)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing LIVE_PING_INTERVAL envvar: %w",
+				err,
+			)
+		}
+	}
+
+	if liveQueueSize != "" {
+		h.QueueSize, err = strconv.Atoi(liveQueueSize)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing LIVE_QUEUE_SIZE envvar: %w",
+				err,
+			)
+		}
+	}
+
+	for o := range strings.SplitSeq(liveAllowedOrigins, ",") {
+		if o = strings.TrimSpace(o); o != "" {
+			h.OriginPatterns = append(h.OriginPatterns, o)
+		}
+	}
+
+	go func() {
+		// Run only returns once the context is cancelled.
+		_ = h.Run(ctx)
+	}()
+
+	return h, nil
+}
@@ -0,0 +1,402 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	racingv1 "github.com/danilvpetrov/entain/api/racing/v1"
+	racingv2 "github.com/danilvpetrov/entain/api/racing/v2"
+	"github.com/danilvpetrov/entain/api/sports"
+	swaggerfiles "github.com/swaggo/files/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	// openAPIPath is the path of the OpenAPI specification of the gateway.
+	openAPIPath = "/openapi.json"
+	// docsPath is the path of the interactive API documentation. Requests to
+	// "/docs" are redirected to it.
+	docsPath = "/docs/"
+	// docsInitializerPath is the path of the script configuring Swagger UI,
+	// relative to docsPath.
+	docsInitializerPath = "swagger-initializer.js"
+)
+
+// docsInitializer is the script configuring Swagger UI to render the
+// specification served at openAPIPath. It replaces the script of the Swagger
+// UI distribution, which renders an example specification.
+const docsInitializer = `window.onload = function () {
+  window.ui = SwaggerUIBundle({
+    url: "` + openAPIPath + `",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout",
+  });
+};
+`
+
+// parameterSchemaKeys is a list of keys of Swagger 2.0 non-body parameters
+// that describe their values. OpenAPI 3.0 moves them to the schemas of the
+// parameters.
+var parameterSchemaKeys = []string{
+	"type",
+	"format",
+	"items",
+	"enum",
+	"default",
+	"pattern",
+	"minimum",
+	"maximum",
+	"minLength",
+	"maxLength",
+	"minItems",
+	"maxItems",
+	"uniqueItems",
+}
+
+// setupOpenAPI sets up the handlers of the OpenAPI specification served at
+// openAPIPath and of the interactive API documentation served at docsPath. The
+// specification merges the generated Swagger 2.0 specifications of both
+// versions of the racing service and of the sports service into a single
+// OpenAPI 3.0 document. The documentation is
+// rendered by Swagger UI, embedded in the gateway so that it works offline.
+func setupOpenAPI() (spec, docs http.Handler, _ error) {
+	doc, err := mergeSwagger(map[string][]byte{
+		"racingv1": racingv1.SwaggerYAML,
+		"racingv2": racingv2.SwaggerYAML,
+		"sports":   sports.SwaggerYAML,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf(
+			"error marshalling OpenAPI specification: %w",
+			err,
+		)
+	}
+
+	ui := http.NewServeMux()
+	ui.Handle(
+		docsPath+docsInitializerPath,
+		serveContent("text/javascript; charset=utf-8", []byte(docsInitializer)),
+	)
+	ui.Handle(
+		docsPath,
+		onlyGet(http.StripPrefix(
+			docsPath,
+			http.FileServerFS(swaggerfiles.FS),
+		)),
+	)
+
+	return serveContent("application/json", data), ui, nil
+}
+
+// serveContent returns an HTTP handler serving a static content of the given
+// type.
+func serveContent(contentType string, content []byte) http.Handler {
+	// The content is embedded at build time, so it is modified when the
+	// gateway starts at the latest.
+	modTime := time.Now()
+
+	return onlyGet(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			http.ServeContent(w, r, "", modTime, bytes.NewReader(content))
+		}),
+	)
+}
+
+// onlyGet is an HTTP middleware that rejects the requests with methods other
+// than GET and HEAD.
+func onlyGet(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(
+				w,
+				http.StatusText(http.StatusMethodNotAllowed),
+				http.StatusMethodNotAllowed,
+			)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// mergeSwagger converts the given Swagger 2.0 specifications in YAML, keyed by
+// the names of their services, to OpenAPI 3.0, and merges them into a single
+// document. The schemas defined differently by several specifications under
+// the same name, such as those of nested messages, and the tags and the
+// operation IDs used by several specifications, such as those of the versions
+// of a service, are prefixed with the names of the services, for example
+// "racingv2.Racing". It fails if the specifications define the same operation.
+func mergeSwagger(specs map[string][]byte) (map[string]any, error) {
+	parsed := map[string]map[string]any{}
+	defined := map[string]any{}
+	conflicting := map[string]bool{}
+
+	// The number of specifications using each tag and operation ID.
+	tagUses := map[string]int{}
+	operationUses := map[string]int{}
+
+	for service, data := range specs {
+		var spec map[string]any
+		if err := yaml.Unmarshal(data, &spec); err != nil {
+			return nil, fmt.Errorf(
+				"error parsing Swagger specification of %s service: %w",
+				service,
+				err,
+			)
+		}
+		parsed[service] = spec
+
+		for name, schema := range asMap(spec["definitions"]) {
+			if s, ok := defined[name]; ok && !reflect.DeepEqual(s, schema) {
+				conflicting[name] = true
+			}
+			defined[name] = schema
+		}
+
+		for _, tag := range asSlice(spec["tags"]) {
+			name, _ := asMap(tag)["name"].(string)
+			tagUses[name]++
+		}
+
+		for _, item := range asMap(spec["paths"]) {
+			for _, op := range asMap(item) {
+				if id, ok := asMap(op)["operationId"].(string); ok {
+					operationUses[id]++
+				}
+			}
+		}
+	}
+
+	paths := map[string]any{}
+	schemas := map[string]any{}
+	tags := map[string]any{}
+
+	for _, service := range slices.Sorted(maps.Keys(parsed)) {
+		rename := func(name string) string {
+			if conflicting[name] {
+				return service + "." + name
+			}
+			return name
+		}
+
+		renameTag := func(name string) string {
+			if tagUses[name] > 1 {
+				return service + "." + name
+			}
+			return name
+		}
+
+		spec := rewriteRefs(parsed[service], rename).(map[string]any)
+
+		for path, item := range asMap(spec["paths"]) {
+			merged := asMap(paths[path])
+			if merged == nil {
+				merged = map[string]any{}
+				paths[path] = merged
+			}
+
+			for method, op := range asMap(item) {
+				if _, ok := merged[method]; ok {
+					return nil, fmt.Errorf(
+						"duplicate operation %s %s",
+						strings.ToUpper(method),
+						path,
+					)
+				}
+				op := convertOperation(asMap(op))
+				if id, ok := op["operationId"].(string); ok &&
+					operationUses[id] > 1 {
+					op["operationId"] = service + "." + id
+				}
+				opTags := asSlice(op["tags"])
+				for i, tag := range opTags {
+					if name, ok := tag.(string); ok {
+						opTags[i] = renameTag(name)
+					}
+				}
+				merged[method] = op
+			}
+		}
+
+		for name, schema := range asMap(spec["definitions"]) {
+			schemas[rename(name)] = schema
+		}
+
+		for _, tag := range asSlice(spec["tags"]) {
+			t := asMap(tag)
+			name, _ := t["name"].(string)
+			t["name"] = renameTag(name)
+			tags[renameTag(name)] = t
+		}
+	}
+
+	var tagList []any
+	for _, name := range slices.Sorted(maps.Keys(tags)) {
+		tagList = append(tagList, tags[name])
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title": "Entain API",
+			"description": "The HTTP API of the racing and sports services, " +
+				"served by the API Gateway.",
+			"version": "v1",
+		},
+		"tags":  tagList,
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+		},
+	}, nil
+}
+
+// convertOperation converts a Swagger 2.0 operation to OpenAPI 3.0.
+func convertOperation(op map[string]any) map[string]any {
+	out := map[string]any{}
+
+	for k, v := range op {
+		switch k {
+		case "parameters":
+			var params []any
+			for _, p := range asSlice(v) {
+				p := asMap(p)
+				if p["in"] == "body" {
+					out["requestBody"] = convertBody(p)
+					continue
+				}
+				params = append(params, convertParameter(p))
+			}
+			if len(params) > 0 {
+				out["parameters"] = params
+			}
+		case "responses":
+			responses := map[string]any{}
+			for code, r := range asMap(v) {
+				responses[code] = convertResponse(asMap(r))
+			}
+			out["responses"] = responses
+		case "consumes", "produces", "schemes":
+			// All the routes consume and produce JSON.
+		default:
+			out[k] = v
+		}
+	}
+
+	return out
+}
+
+// convertParameter converts a Swagger 2.0 non-body parameter to OpenAPI 3.0.
+func convertParameter(p map[string]any) map[string]any {
+	out := map[string]any{}
+	schema := map[string]any{}
+
+	for k, v := range p {
+		switch {
+		case slices.Contains(parameterSchemaKeys, k):
+			schema[k] = v
+		case k == "collectionFormat":
+			// Repeated query parameters, for example "?id=1&id=2", are the
+			// default of OpenAPI 3.0. Others are comma-separated.
+			out["explode"] = v == "multi"
+		default:
+			out[k] = v
+		}
+	}
+	out["schema"] = schema
+
+	return out
+}
+
+// convertBody converts a Swagger 2.0 body parameter to an OpenAPI 3.0 request
+// body.
+func convertBody(p map[string]any) map[string]any {
+	out := map[string]any{
+		"content": map[string]any{
+			"application/json": map[string]any{
+				"schema": p["schema"],
+			},
+		},
+	}
+
+	if d, ok := p["description"]; ok {
+		out["description"] = d
+	}
+
+	if r, ok := p["required"]; ok {
+		out["required"] = r
+	}
+
+	return out
+}
+
+// convertResponse converts a Swagger 2.0 response to OpenAPI 3.0.
+func convertResponse(r map[string]any) map[string]any {
+	out := map[string]any{}
+
+	for k, v := range r {
+		if k == "schema" {
+			out["content"] = map[string]any{
+				"application/json": map[string]any{
+					"schema": v,
+				},
+			}
+			continue
+		}
+		out[k] = v
+	}
+
+	return out
+}
+
+// rewriteRefs rewrites the references to the Swagger 2.0 definitions in the
+// given value to the OpenAPI 3.0 schema components, renamed by the given
+// function.
+func rewriteRefs(v any, rename func(string) string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			if s, ok := e.(string); ok && k == "$ref" {
+				if name, ok := strings.CutPrefix(s, "#/definitions/"); ok {
+					v[k] = "#/components/schemas/" + rename(name)
+				}
+				continue
+			}
+			v[k] = rewriteRefs(e, rename)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = rewriteRefs(e, rename)
+		}
+	}
+	return v
+}
+
+// asMap returns the value as a map, or nil if it is not a map.
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+// asSlice returns the value as a slice, or nil if it is not a slice.
+func asSlice(v any) []any {
+	s, _ := v.([]any)
+	return s
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	racingv1 "github.com/danilvpetrov/entain/api/racing/v1"
+	racingv2 "github.com/danilvpetrov/entain/api/racing/v2"
+	"github.com/danilvpetrov/entain/api/sports"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// pathVariable matches the variables of path templates, for example
+// "{race_id}" or "{race_id=*}".
+var pathVariable = regexp.MustCompile(`\{[^}]*\}`)
+
+func TestSetupOpenAPI(t *testing.T) {
+	spec, docs, err := setupOpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("serves the specification", func(t *testing.T) {
+		doc := getSpec(t, spec)
+
+		if v := doc["openapi"]; v != "3.0.3" {
+			t.Fatalf("expected OpenAPI version 3.0.3, got %v", v)
+		}
+
+		data, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		schemas := asMap(asMap(doc["components"])["schemas"])
+		for _, m := range regexp.MustCompile(`"\$ref":"([^"]*)"`).
+			FindAllStringSubmatch(string(data), -1) {
+			name, ok := strings.CutPrefix(m[1], "#/components/schemas/")
+			if !ok || schemas[name] == nil {
+				t.Fatalf("unresolved reference %s", m[1])
+			}
+		}
+
+		ids := map[string]bool{}
+		for _, item := range asMap(doc["paths"]) {
+			for _, op := range asMap(item) {
+				id, _ := asMap(op)["operationId"].(string)
+				if ids[id] {
+					t.Fatalf("duplicate operation ID %q", id)
+				}
+				ids[id] = true
+			}
+		}
+	})
+
+	t.Run("validates the specification", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		spec.ServeHTTP(
+			w,
+			httptest.NewRequest(http.MethodGet, openAPIPath, nil),
+		)
+
+		doc, err := openapi3.NewLoader().LoadFromData(w.Body.Bytes())
+		if err != nil {
+			t.Fatalf("expected the specification to load, got %v", err)
+		}
+
+		if err := doc.Validate(t.Context()); err != nil {
+			t.Fatalf("expected the specification to be valid, got %v", err)
+		}
+	})
+
+	t.Run("serves the documentation", func(t *testing.T) {
+		cases := []struct {
+			path        string
+			contentType string
+			contains    string
+		}{
+			{
+				path:        docsPath,
+				contentType: "text/html",
+				contains:    docsInitializerPath,
+			},
+			{
+				path:        docsPath + docsInitializerPath,
+				contentType: "text/javascript",
+				contains:    `url: "` + openAPIPath + `"`,
+			},
+			{
+				path:        docsPath + "swagger-ui-bundle.js",
+				contentType: "text/javascript",
+				contains:    "SwaggerUIBundle",
+			},
+		}
+
+		for _, tc := range cases {
+			w := httptest.NewRecorder()
+			docs.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf(
+					"expected status %d for %s, got %d",
+					http.StatusOK,
+					tc.path,
+					w.Code,
+				)
+			}
+
+			if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(
+				ct,
+				tc.contentType,
+			) {
+				t.Fatalf(
+					"expected %s for %s, got %q",
+					tc.contentType,
+					tc.path,
+					ct,
+				)
+			}
+
+			if !strings.Contains(w.Body.String(), tc.contains) {
+				t.Fatalf("expected %s to contain %q", tc.path, tc.contains)
+			}
+		}
+	})
+
+	t.Run("rejects other methods", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		spec.ServeHTTP(
+			w,
+			httptest.NewRequest(http.MethodPost, openAPIPath, nil),
+		)
+
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Fatalf(
+				"expected status %d, got %d",
+				http.StatusMethodNotAllowed,
+				w.Code,
+			)
+		}
+	})
+}
+
+// TestOpenAPIRoutes fails when the OpenAPI specification drifts from the
+// routes registered by the gateway, in which case the Swagger specifications
+// of the services must be regenerated.
+func TestOpenAPIRoutes(t *testing.T) {
+	spec, _, err := setupOpenAPI()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The routes of the specification, for example "GET /v1/races/{}".
+	documented := map[string]bool{}
+	for path, item := range asMap(getSpec(t, spec)["paths"]) {
+		for method := range asMap(item) {
+			documented[route(method, path)] = true
+		}
+	}
+
+	// The routes registered by the gateway are matched by a middleware that
+	// does not call the services.
+	var matched string
+	mux := runtime.NewServeMux(runtime.WithMiddlewares(
+		func(runtime.HandlerFunc) runtime.HandlerFunc {
+			return func(
+				_ http.ResponseWriter,
+				r *http.Request,
+				_ map[string]string,
+			) {
+				p, _ := runtime.HTTPPattern(r.Context())
+				matched = route(r.Method, p.String())
+			}
+		},
+	))
+
+	if _, _, err := setupRacingService(t.Context(), mux); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := setupSportsService(t.Context(), mux); err != nil {
+		t.Fatal(err)
+	}
+
+	match := func(r string) string {
+		method, path, _ := strings.Cut(r, " ")
+		matched = ""
+		path = pathVariable.ReplaceAllString(path, "1")
+		mux.ServeHTTP(
+			httptest.NewRecorder(),
+			httptest.NewRequest(method, path, nil),
+		)
+		return matched
+	}
+
+	for r := range documented {
+		if m := match(r); m != r {
+			t.Errorf("documented route %s is not registered", r)
+		}
+	}
+
+	// The gateway registers the routes of the HTTP rules of the RPCs.
+	for _, s := range []protoreflect.ServiceDescriptor{
+		racingv1.File_api_racing_v1_racing_proto.Services().Get(0),
+		racingv2.File_api_racing_v2_racing_proto.Services().Get(0),
+		sports.File_api_sports_sports_proto.Services().Get(0),
+	} {
+		methods := s.Methods()
+		for i := range methods.Len() {
+			m := methods.Get(i)
+			name := m.FullName()
+			rule, _ := proto.GetExtension(
+				m.Options(),
+				annotations.E_Http,
+			).(*annotations.HttpRule)
+
+			for _, rule := range append(
+				[]*annotations.HttpRule{rule},
+				rule.GetAdditionalBindings()...,
+			) {
+				r := httpRuleRoute(rule)
+				if r == "" {
+					continue
+				}
+
+				if match(r) != r {
+					t.Errorf("route %s of %s is not registered", r, name)
+				}
+
+				if !documented[r] {
+					t.Errorf("route %s of %s is not documented", r, name)
+				}
+			}
+		}
+	}
+}
+
+// getSpec is a test helper that returns the OpenAPI specification served by
+// the given handler.
+func getSpec(t *testing.T, spec http.Handler) map[string]any {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	spec.ServeHTTP(w, httptest.NewRequest(http.MethodGet, openAPIPath, nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("expected JSON, got %q", ct)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	return doc
+}
+
+// route returns the route of the given method and path template, with the
+// variables of the template elided, for example "GET /v1/races/{}".
+func route(method, path string) string {
+	return strings.ToUpper(method) + " " +
+		pathVariable.ReplaceAllString(path, "{}")
+}
+
+// httpRuleRoute returns the route of an HTTP rule, or an empty string if the
+// rule has no route.
+func httpRuleRoute(rule *annotations.HttpRule) string {
+	switch p := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return route(http.MethodGet, p.Get)
+	case *annotations.HttpRule_Post:
+		return route(http.MethodPost, p.Post)
+	case *annotations.HttpRule_Put:
+		return route(http.MethodPut, p.Put)
+	case *annotations.HttpRule_Patch:
+		return route(http.MethodPatch, p.Patch)
+	case *annotations.HttpRule_Delete:
+		return route(http.MethodDelete, p.Delete)
+	case *annotations.HttpRule_Custom:
+		return route(p.Custom.GetKind(), p.Custom.GetPath())
+	default:
+		return ""
+	}
+}
@@ -2,13 +2,13 @@ package main
 
 import (
 	"context"
+	"log/slog"
 	"os"
 
-	"github.com/danilvpetrov/entain/api/racing"
+	racingv1 "github.com/danilvpetrov/entain/api/racing/v1"
+	racingv2 "github.com/danilvpetrov/entain/api/racing/v2"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 var (
@@ -16,24 +16,89 @@ var (
 	defaultRacingServiceAddr = "localhost:9000"
 )
 
-// setupRacingService sets up the gRPC gateway for the Racing service, allowing
-// HTTP requests to be proxied to the gRPC server.
-func setupRacingService(ctx context.Context, mux *runtime.ServeMux) error {
+// setupRacingService sets up the gRPC gateway for both versions of the Racing
+// service, allowing HTTP requests to be proxied to the gRPC server. It returns
+// the connections to the version 1 and version 2 services, which are closed
+// when the context is done.
+func setupRacingService(
+	ctx context.Context,
+	mux *runtime.ServeMux,
+) (v1, v2 *grpc.ClientConn, err error) {
 	if racingServiceAddr == "" {
 		racingServiceAddr = defaultRacingServiceAddr
 	}
 
-	otelClientHdr := otelgrpc.NewClientHandler()
+	conn, err := dialRacingService(
+		ctx,
+		backendMethods{
+			Service: racingv1.Racing_ServiceDesc.ServiceName,
+			List: []string{
+				"ListRaces",
+				"ListChanges",
+				"ListAuditEntries",
+				"ListTenantOverrides",
+			},
+			Get: []string{
+				"GetRace",
+				"GetRaceByExternalId",
+				"BatchGetRaces",
+			},
+		},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return racing.RegisterRacingHandlerFromEndpoint(
+	if err := racingv1.RegisterRacingHandler(ctx, mux, conn); err != nil {
+		return nil, nil, err
+	}
+
+	// Each version has its own connection, with the retry policies and the
+	// circuit breaker of its RPCs.
+	connV2, err := dialRacingService(
 		ctx,
-		mux,
-		racingServiceAddr,
-		[]grpc.DialOption{
-			grpc.WithTransportCredentials(
-				insecure.NewCredentials(),
-			),
-			grpc.WithStatsHandler(otelClientHdr),
+		backendMethods{
+			Service: racingv2.Racing_ServiceDesc.ServiceName,
+			List:    []string{"ListRaces"},
+			Get:     []string{"GetRace"},
 		},
 	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := racingv2.RegisterRacingHandler(ctx, mux, connV2); err != nil {
+		return nil, nil, err
+	}
+
+	return conn, connV2, nil
+}
+
+// dialRacingService returns a connection to the given version of the Racing
+// service, which is closed when the context is done.
+func dialRacingService(
+	ctx context.Context,
+	methods backendMethods,
+) (*grpc.ClientConn, error) {
+	target, opts, err := setupBackendConn(racingServiceAddr, methods)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := conn.Close(); err != nil {
+			slog.Error(
+				"error closing racing service connection",
+				slog.Any("error", err),
+			)
+		}
+	}()
+
+	return conn, nil
 }
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"net/http"
+	"net/textproto"
+
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/etag"
+	"github.com/danilvpetrov/entain/jurisdiction"
+	"github.com/danilvpetrov/entain/tenant"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// requestIDHeader is the canonical name of the HTTP header carrying the ID of
+// a request.
+var requestIDHeader = textproto.CanonicalMIMEHeaderKey(apierror.RequestIDHeader)
+
+// withRequestID is an HTTP middleware that assigns an ID to every request that
+// does not carry one in the X-Request-Id header. The ID is forwarded to the
+// backend services and returned to the client in the same header.
+func withRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if id == "" {
+			id = apierror.NewRequestID()
+			r.Header.Set(requestIDHeader, id)
+		}
+
+		w.Header().Set(requestIDHeader, id)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// matchIncomingHeader is a grpc-gateway header matcher that forwards the
+// X-Request-Id, If-Match, X-Tenant-Id and X-Jurisdiction headers to the
+// backend services, in addition to the headers forwarded by default.
+func matchIncomingHeader(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case requestIDHeader:
+		return apierror.RequestIDHeader, true
+	case ifMatchHeader:
+		return etag.MetadataKey, true
+	case tenantHeader:
+		return tenant.MetadataKey, true
+	case jurisdictionHeader:
+		return jurisdiction.MetadataKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
@@ -13,9 +13,15 @@ var (
 	defaultServerAddr = "localhost:8000"
 )
 
+// serverWriteTimeout is the maximum duration before timing out writes of a
+// response by the HTTP server.
+const serverWriteTimeout = 10 * time.Second
+
 // setupServer creates and configures an HTTP server listening on the address
 // specified by the LISTEN_ADDR environment variable or defaulting to port 8000.
-// It returns the configured server and the listener for the server to use.
+// It returns the configured server and the listener for the server to use. The
+// server accepts both HTTP/1.1 and unencrypted HTTP/2 connections, as gRPC
+// clients require HTTP/2.
 func setupServer(
 	ctx context.Context,
 	handler http.Handler,
@@ -33,10 +39,15 @@ func setupServer(
 		return nil, nil, err
 	}
 
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+
 	return &http.Server{
 		Handler:           handler,
+		Protocols:         &protocols,
 		ReadHeaderTimeout: 10 * time.Second,
 		ReadTimeout:       10 * time.Second,
-		WriteTimeout:      10 * time.Second,
+		WriteTimeout:      serverWriteTimeout,
 	}, listener, nil
 }
@@ -2,13 +2,12 @@ package main
 
 import (
 	"context"
+	"log/slog"
 	"os"
 
 	"github.com/danilvpetrov/entain/api/sports"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 var (
@@ -17,23 +16,58 @@ var (
 )
 
 // setupSportsService sets up the gRPC gateway for the Sports service, allowing
-// HTTP requests to be proxied to the gRPC server.
-func setupSportsService(ctx context.Context, mux *runtime.ServeMux) error {
+// HTTP requests to be proxied to the gRPC server. It returns the connection to
+// the gRPC server, which is closed when the context is done.
+func setupSportsService(
+	ctx context.Context,
+	mux *runtime.ServeMux,
+) (*grpc.ClientConn, error) {
 	if sportsServiceAddr == "" {
 		sportsServiceAddr = defaultSportsServiceAddr
 	}
 
-	otelClientHdr := otelgrpc.NewClientHandler()
-
-	return sports.RegisterSportsHandlerFromEndpoint(
-		ctx,
-		mux,
+	target, opts, err := setupBackendConn(
 		sportsServiceAddr,
-		[]grpc.DialOption{
-			grpc.WithTransportCredentials(
-				insecure.NewCredentials(),
-			),
-			grpc.WithStatsHandler(otelClientHdr),
+		backendMethods{
+			Service: sports.Sports_ServiceDesc.ServiceName,
+			List: []string{
+				"ListEvents",
+				"ListCompetitions",
+				"ListParticipants",
+				"ListChanges",
+				"ListAuditEntries",
+				"ListTenantOverrides",
+			},
+			Get: []string{
+				"GetEvent",
+				"GetEventByExternalId",
+				"BatchGetEvents",
+			},
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sports.RegisterSportsHandler(ctx, mux, conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	go func() {
+		<-ctx.Done()
+		if err := conn.Close(); err != nil {
+			slog.Error(
+				"error closing sports service connection",
+				slog.Any("error", err),
+			)
+		}
+	}()
+
+	return conn, nil
 }
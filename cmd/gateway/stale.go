@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	staleCacheSize        = os.Getenv("STALE_CACHE_SIZE")
+	defaultStaleCacheSize = 1000
+)
+
+// staleWarning is the value of the Warning header attached to stale responses,
+// as defined in RFC 7234, section 5.5.1.
+const staleWarning = `110 - "Response is Stale"`
+
+// cachedResponse is a successful HTTP response kept to be served when the
+// backend is failing.
+type cachedResponse struct {
+	storedAt time.Time
+	header   http.Header
+	key      string
+	body     []byte
+	status   int
+}
+
+// staleCache is an HTTP middleware that remembers the last successful response
+// of every GET route and serves it in place of a failed response when the
+// backend is unavailable, i.e. stale-while-error.
+//
+// The cache holds at most a fixed number of responses, evicting the least
+// recently used ones.
+type staleCache struct {
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+
+	next    http.Handler
+	entries map[string]*list.Element
+	lru     *list.List
+	size    int
+	mu      sync.Mutex
+}
+
+// setupStaleCache wraps the handler with a stale-while-error cache configured
+// from the environment variables. The cache serves the last successful
+// responses in place of failed ones while a backend service is unavailable.
+func setupStaleCache(next http.Handler) (*staleCache, error) {
+	size := defaultStaleCacheSize
+
+	if staleCacheSize != "" {
+		var err error
+		size, err = strconv.Atoi(staleCacheSize)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing STALE_CACHE_SIZE envvar: %w",
+				err,
+			)
+		}
+	}
+
+	return &staleCache{
+		now:     time.Now,
+		next:    next,
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+		size:    size,
+	}, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (c *staleCache) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Streamed responses are never complete enough to be cached. Responses
+	// to authorized requests, such as the ones made by admins, must not be
+	// served to other clients.
+	if r.Method != http.MethodGet ||
+		c.size <= 0 ||
+		isStreamingRequest(r) ||
+		r.Header.Get("Authorization") != "" {
+		c.next.ServeHTTP(w, r)
+		return
+	}
+
+	// Tenants and jurisdictions see races and sports events differently, so
+	// their responses are cached separately.
+	key := r.URL.RequestURI()
+	if code := r.Header.Get(jurisdictionHeader); code != "" {
+		key = code + " " + key
+	}
+	if name := r.Header.Get(tenantHeader); name != "" {
+		key = name + " " + key
+	}
+
+	rec := &bufferedResponseWriter{
+		header: http.Header{},
+		status: http.StatusOK,
+	}
+	c.next.ServeHTTP(rec, r)
+
+	switch {
+	case rec.status >= 200 && rec.status < 300:
+		c.store(key, rec)
+	case isBackendUnavailable(rec.status):
+		if cached, ok := c.load(key); ok {
+			c.writeStale(w, cached)
+			return
+		}
+	}
+
+	rec.writeTo(w)
+}
+
+// store remembers a successful response under the given key.
+func (c *staleCache) store(key string, rec *bufferedResponseWriter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resp := &cachedResponse{
+		storedAt: c.now(),
+		header:   rec.header.Clone(),
+		key:      key,
+		body:     bytes.Clone(rec.body.Bytes()),
+		status:   rec.status,
+	}
+
+	if e, ok := c.entries[key]; ok {
+		e.Value = resp
+		c.lru.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.lru.PushFront(resp)
+
+	for c.lru.Len() > c.size {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedResponse).key)
+	}
+}
+
+// load returns the response stored under the given key, if any.
+func (c *staleCache) load(key string) (*cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+
+	return e.Value.(*cachedResponse), true
+}
+
+// writeStale writes a cached response, marking it as stale.
+func (c *staleCache) writeStale(w http.ResponseWriter, resp *cachedResponse) {
+	for k, v := range resp.header {
+		w.Header()[k] = v
+	}
+
+	age := int(c.now().Sub(resp.storedAt).Seconds())
+	w.Header().Set("Age", strconv.Itoa(age))
+	w.Header().Set("Warning", staleWarning)
+	w.WriteHeader(resp.status)
+	_, _ = w.Write(resp.body)
+}
+
+// isBackendUnavailable reports whether an HTTP status code returned by the
+// gateway indicates that the backend could not serve the request.
+func isBackendUnavailable(code int) bool {
+	return code == http.StatusServiceUnavailable ||
+		code == http.StatusGatewayTimeout
+}
+
+// bufferedResponseWriter is an http.ResponseWriter that keeps the whole
+// response in memory, so that it can be inspected before being sent.
+type bufferedResponseWriter struct {
+	header      http.Header
+	body        bytes.Buffer
+	status      int
+	wroteHeader bool
+}
+
+// Header implements http.ResponseWriter.
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// Write implements http.ResponseWriter.
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.body.Write(b)
+}
+
+// WriteHeader implements http.ResponseWriter.
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+}
+
+// writeTo sends the buffered response to the given writer.
+func (w *bufferedResponseWriter) writeTo(dst http.ResponseWriter) {
+	for k, v := range w.header {
+		dst.Header()[k] = v
+	}
+	dst.WriteHeader(w.status)
+	_, _ = dst.Write(w.body.Bytes())
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"container/list"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStaleCache(t *testing.T) {
+	now := time.Now()
+	backendStatus := http.StatusOK
+	backendBody := "fresh"
+
+	c := &staleCache{
+		now: func() time.Time { return now },
+		next: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(backendStatus)
+			_, _ = w.Write([]byte(backendBody))
+		}),
+		entries: map[string]*list.Element{},
+		lru:     list.New(),
+		size:    1,
+	}
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, http.NoBody))
+		return rec
+	}
+
+	if rec := get("/v1/races"); rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	now = now.Add(30 * time.Second)
+	backendStatus = http.StatusServiceUnavailable
+	backendBody = "unavailable"
+
+	rec := get("/v1/races")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if rec.Body.String() != "fresh" {
+		t.Fatalf("expected stale body %q, got %q", "fresh", rec.Body.String())
+	}
+	if rec.Header().Get("Warning") != staleWarning {
+		t.Fatalf(
+			"expected Warning header %q, got %q",
+			staleWarning,
+			rec.Header().Get("Warning"),
+		)
+	}
+	if rec.Header().Get("Age") != "30" {
+		t.Fatalf("expected Age header 30, got %q", rec.Header().Get("Age"))
+	}
+
+	rec = get("/v1/sports")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf(
+			"expected status %d for uncached route, got %d",
+			http.StatusServiceUnavailable,
+			rec.Code,
+		)
+	}
+
+	backendStatus = http.StatusOK
+	backendBody = "fresh"
+	get("/v1/sports") // evicts /v1/races as the cache holds one response
+
+	backendStatus = http.StatusServiceUnavailable
+	if rec := get("/v1/races"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf(
+			"expected status %d for evicted route, got %d",
+			http.StatusServiceUnavailable,
+			rec.Code,
+		)
+	}
+
+	backendStatus = http.StatusOK
+	get("/v1/sports/1:watch")
+
+	backendStatus = http.StatusServiceUnavailable
+	if rec := get("/v1/sports/1:watch"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf(
+			"expected status %d for streaming route, got %d",
+			http.StatusServiceUnavailable,
+			rec.Code,
+		)
+	}
+
+	backendStatus = http.StatusOK
+	authorized := httptest.NewRequest(http.MethodGet, "/v1/races", http.NoBody)
+	authorized.Header.Set("Authorization", "Bearer token")
+	c.ServeHTTP(httptest.NewRecorder(), authorized)
+
+	backendStatus = http.StatusServiceUnavailable
+	if rec := get("/v1/races"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf(
+			"expected status %d for route cached by authorized request, got %d",
+			http.StatusServiceUnavailable,
+			rec.Code,
+		)
+	}
+
+	getAsTenant := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+		r.Header.Set(tenantHeader, "brand-1")
+		c.ServeHTTP(rec, r)
+		return rec
+	}
+
+	backendStatus = http.StatusOK
+	getAsTenant("/v1/races")
+
+	backendStatus = http.StatusServiceUnavailable
+	if rec := get("/v1/races"); rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf(
+			"expected status %d for route cached for a tenant, got %d",
+			http.StatusServiceUnavailable,
+			rec.Code,
+		)
+	}
+	if rec := getAsTenant("/v1/races"); rec.Code != http.StatusOK {
+		t.Fatalf(
+			"expected status %d for route cached for the tenant, got %d",
+			http.StatusOK,
+			rec.Code,
+		)
+	}
+
+	getFrom := func(target, code string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, target, http.NoBody)
+		r.Header.Set(jurisdictionHeader, code)
+		c.ServeHTTP(rec, r)
+		return rec
+	}
+
+	backendStatus = http.StatusOK
+	getFrom("/v1/sports", "AU")
+
+	backendStatus = http.StatusServiceUnavailable
+	rec = getFrom("/v1/sports", "US")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf(
+			"expected status %d for route cached for a jurisdiction, got %d",
+			http.StatusServiceUnavailable,
+			rec.Code,
+		)
+	}
+	if rec := getFrom("/v1/sports", "AU"); rec.Code != http.StatusOK {
+		t.Fatalf(
+			"expected status %d for route cached for the jurisdiction, got %d",
+			http.StatusOK,
+			rec.Code,
+		)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danilvpetrov/entain/graphql"
+)
+
+// streamingVerbs is a list of custom method verbs of the routes backed by
+// streaming RPCs, for example "/v1/sports/1:watch".
+var streamingVerbs = []string{":watch", ":import", ":export"}
+
+// isStreamingRequest reports whether a request is routed to a streaming RPC,
+// whose request or response is sent in parts for as long as the stream lasts.
+// GraphQL requests accepting server-sent events are streamed too, as they may
+// carry subscriptions, and so are the WebSocket connections pushing live
+// updates and the calls of streaming procedures.
+func isStreamingRequest(r *http.Request) bool {
+	switch r.URL.Path {
+	case graphqlPath:
+		return graphql.AcceptsEventStream(r)
+	case livePath:
+		return true
+	}
+
+	for _, verb := range streamingVerbs {
+		if strings.HasSuffix(r.URL.Path, verb) {
+			return true
+		}
+	}
+	return isStreamingProcedure(r.URL.Path)
+}
+
+// withStreaming is an HTTP middleware that lifts the read and write timeouts
+// of the HTTP server for streaming requests, so that streams are not cut after
+// the read timeout or serverWriteTimeout. The responses of the streaming REST
+// routes are sent as newline-delimited JSON for as long as the stream lasts.
+func withStreaming(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isStreamingRequest(r) {
+			// An error means the writer does not support deadlines, in which
+			// case there is no timeout to lift.
+			rc := http.NewResponseController(w)
+			_ = rc.SetReadDeadline(time.Time{})
+			_ = rc.SetWriteDeadline(time.Time{})
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsStreamingRequest(t *testing.T) {
+	cases := []struct {
+		name      string
+		target    string
+		accept    string
+		streaming bool
+	}{
+		{
+			name:      "request to a non-streaming route",
+			target:    "/v1/races/1",
+			streaming: false,
+		},
+		{
+			name:      "request to a streaming route",
+			target:    "/v1/sports/1:watch",
+			streaming: true,
+		},
+		{
+			name:      "GraphQL request",
+			target:    "/graphql",
+			accept:    "application/json",
+			streaming: false,
+		},
+		{
+			name:      "GraphQL request accepting server-sent events",
+			target:    "/graphql",
+			accept:    "text/event-stream",
+			streaming: true,
+		},
+		{
+			name:      "request for live updates",
+			target:    "/live",
+			streaming: true,
+		},
+		{
+			name:      "call of a unary procedure",
+			target:    "/racing.v1.Racing/GetRace",
+			streaming: false,
+		},
+		{
+			name:      "call of a streaming procedure",
+			target:    "/sports.Sports/WatchEvent",
+			streaming: true,
+		},
+		{
+			name:      "call of an unknown procedure",
+			target:    "/sports.Sports/Unknown",
+			streaming: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, tc.target, nil)
+			if tc.accept != "" {
+				r.Header.Set("Accept", tc.accept)
+			}
+
+			if got := isStreamingRequest(r); got != tc.streaming {
+				t.Fatalf("expected streaming %t, got %t", tc.streaming, got)
+			}
+		})
+	}
+}
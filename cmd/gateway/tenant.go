@@ -0,0 +1,71 @@
+package main
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"os"
+	"strings"
+
+	"github.com/danilvpetrov/entain/tenant"
+)
+
+var tenantTokens = os.Getenv("TENANT_TOKENS")
+
+// tenantHeader is the canonical name of the HTTP header carrying the name of
+// the tenant a request is made on behalf of.
+var tenantHeader = textproto.CanonicalMIMEHeaderKey(tenant.MetadataKey)
+
+// setupTenants wraps next into an HTTP middleware that resolves the tenants
+// the requests are made on behalf of.
+//
+// The tenant tokens are configured by the TENANT_TOKENS envvar in the
+// "<tenant>:<token>,<tenant>:<token>" form.
+func setupTenants(next http.Handler) (http.Handler, error) {
+	tokens, err := tenant.ParseTokens(tenantTokens)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing TENANT_TOKENS envvar: %w", err)
+	}
+
+	return withTenant(tokens, next), nil
+}
+
+// withTenant is an HTTP middleware that sets the X-Tenant-Id header of the
+// requests bearing one of the given tenant tokens to the tenant it identifies.
+// The token itself is consumed by the gateway and not forwarded to the backend
+// services. The X-Tenant-Id header sent by the client is not trusted and is
+// always removed, so other requests are not made on behalf of any tenant.
+func withTenant(tokens tenant.Tokens, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Header.Del(tenantHeader)
+
+		if name, ok := tenantOf(tokens, r); ok {
+			r.Header.Set(tenantHeader, name)
+			r.Header.Del("Authorization")
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// tenantOf returns the name of the tenant identified by the bearer token of
+// the given request, if any.
+func tenantOf(tokens tenant.Tokens, r *http.Request) (string, bool) {
+	bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return "", false
+	}
+
+	for token, name := range tokens {
+		if token == "" {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) == 1 {
+			return name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danilvpetrov/entain/tenant"
+)
+
+func TestWithTenant(t *testing.T) {
+	tokens := tenant.Tokens{"<token>": "brand-1"}
+
+	cases := []struct {
+		header        http.Header
+		name          string
+		expected      string
+		authorization string
+	}{
+		{
+			name: "no tenant",
+		},
+		{
+			name:   "tenant header",
+			header: http.Header{"X-Tenant-Id": {"brand-2"}},
+		},
+		{
+			name:     "tenant token",
+			header:   http.Header{"Authorization": {"Bearer <token>"}},
+			expected: "brand-1",
+		},
+		{
+			name: "tenant token and header",
+			header: http.Header{
+				"Authorization": {"Bearer <token>"},
+				"X-Tenant-Id":   {"brand-2"},
+			},
+			expected: "brand-1",
+		},
+		{
+			name: "other token",
+			header: http.Header{
+				"Authorization": {"Bearer <admin-token>"},
+				"X-Tenant-Id":   {"brand-2"},
+			},
+			authorization: "Bearer <admin-token>",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual *http.Request
+			h := withTenant(
+				tokens,
+				http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+					actual = r
+				}),
+			)
+
+			r := httptest.NewRequest(http.MethodGet, "/v1/races", http.NoBody)
+			for k, v := range tc.header {
+				r.Header[k] = v
+			}
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			if name := actual.Header.Get(tenantHeader); name != tc.expected {
+				t.Fatalf("expected tenant %q, got %q", tc.expected, name)
+			}
+
+			auth := actual.Header.Get("Authorization")
+			if auth != tc.authorization {
+				t.Fatalf(
+					"expected Authorization header %q, got %q",
+					tc.authorization,
+					auth,
+				)
+			}
+		})
+	}
+}
+
+func TestMatchIncomingTenantHeader(t *testing.T) {
+	key, ok := matchIncomingHeader("x-tenant-id")
+	if !ok || key != tenant.MetadataKey {
+		t.Fatalf(
+			"expected header forwarded as %q, got %q",
+			tenant.MetadataKey,
+			key,
+		)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/danilvpetrov/entain/ingest"
+	"github.com/danilvpetrov/entain/racing"
+	"github.com/danilvpetrov/entain/sports"
+	_ "github.com/mattn/go-sqlite3" // underscore import for the SQLite driver
+)
+
+var (
+	racingDBPath        = os.Getenv("RACING_DB_PATH")
+	defaultRacingDBPath = "racing.db"
+
+	sportsDBPath        = os.Getenv("SPORTS_DB_PATH")
+	defaultSportsDBPath = "sports.db"
+
+	ingestDBPath        = os.Getenv("INGEST_DB_PATH")
+	defaultIngestDBPath = "ingest.db"
+)
+
+// databases holds the connections to the databases ingested entities and
+// ingestion runs are stored in.
+type databases struct {
+	racing *sql.DB
+	sports *sql.DB
+	ingest *sql.DB
+}
+
+// setupDBs initialises the connections to the racing and sports databases,
+// and to the database the ingestion runs are recorded in, applying their
+// schemas.
+func setupDBs(ctx context.Context) (_ *databases, err error) {
+	if racingDBPath == "" {
+		racingDBPath = defaultRacingDBPath
+	}
+	if sportsDBPath == "" {
+		sportsDBPath = defaultSportsDBPath
+	}
+	if ingestDBPath == "" {
+		ingestDBPath = defaultIngestDBPath
+	}
+
+	dbs := &databases{}
+	defer func() {
+		if err != nil {
+			_ = dbs.close()
+		}
+	}()
+
+	dbs.racing, err = setupDB(ctx, racingDBPath, racing.ApplySchema)
+	if err != nil {
+		return nil, err
+	}
+
+	dbs.sports, err = setupDB(ctx, sportsDBPath, sports.ApplySchema)
+	if err != nil {
+		return nil, err
+	}
+
+	dbs.ingest, err = setupDB(ctx, ingestDBPath, ingest.ApplySchema)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbs, nil
+}
+
+// setupDB initialises a database connection and applies the given schema.
+func setupDB(
+	ctx context.Context,
+	path string,
+	applySchema func(context.Context, *sql.DB) error,
+) (*sql.DB, error) {
+	// Make sure the directory exists.
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applySchema(ctx, db); err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+
+	return db, nil
+}
+
+// close closes all open database connections.
+func (d *databases) close() error {
+	var errs []error
+
+	for _, db := range []*sql.DB{d.racing, d.sports, d.ingest} {
+		if db != nil {
+			errs = append(errs, db.Close())
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	ctx, cancel := signal.NotifyContext(
+		context.Background(),
+		os.Interrupt, os.Kill,
+	)
+	defer cancel()
+
+	if err := setupLogger(); err != nil {
+		return fmt.Errorf("error setting up logger: %w", err)
+	}
+
+	dbs, err := setupDBs(ctx)
+	if err != nil {
+		return fmt.Errorf("error setting up databases: %w", err)
+	}
+	defer func() {
+		if err := dbs.close(); err != nil {
+			fmt.Fprintf(os.Stderr, "error closing databases: %v\n", err)
+		}
+	}()
+
+	scheduler, err := setupScheduler(dbs)
+	if err != nil {
+		return fmt.Errorf("error setting up scheduler: %w", err)
+	}
+
+	slog.Info(
+		"ingestion started",
+		slog.Int("providers", len(scheduler.Sources)),
+	)
+
+	if err := scheduler.Run(ctx); !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	slog.Info("ingestion stopped")
+
+	return nil
+}
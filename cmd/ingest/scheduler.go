@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/danilvpetrov/entain/ingest"
+	"github.com/danilvpetrov/entain/racing"
+	"github.com/danilvpetrov/entain/sports"
+)
+
+var (
+	fileProviders = os.Getenv("INGEST_FILE_PROVIDERS")
+	httpProviders = os.Getenv("INGEST_HTTP_PROVIDERS")
+
+	pollInterval        = os.Getenv("INGEST_INTERVAL")
+	defaultPollInterval = time.Minute
+)
+
+// setupScheduler creates a scheduler polling the providers configured by the
+// environment variables and storing the ingested entities in the racing and
+// sports databases.
+//
+// INGEST_FILE_PROVIDERS and INGEST_HTTP_PROVIDERS are comma-separated lists of
+// "name=path" and "name=url" pairs respectively.
+func setupScheduler(dbs *databases) (*ingest.Scheduler, error) {
+	interval := defaultPollInterval
+
+	if pollInterval != "" {
+		var err error
+		interval, err = time.ParseDuration(pollInterval)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing INGEST_INTERVAL envvar: %w",
+				err,
+			)
+		}
+	}
+
+	files, err := parseProviders(fileProviders)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error parsing INGEST_FILE_PROVIDERS envvar: %w",
+			err,
+		)
+	}
+
+	urls, err := parseProviders(httpProviders)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error parsing INGEST_HTTP_PROVIDERS envvar: %w",
+			err,
+		)
+	}
+
+	for name := range urls {
+		if _, ok := files[name]; ok {
+			return nil, fmt.Errorf("duplicate provider %q", name)
+		}
+	}
+
+	s := &ingest.Scheduler{
+		DB: dbs.ingest,
+		Sinks: []ingest.Sink{
+			&racing.IngestSink{DB: dbs.racing},
+			&sports.IngestSink{DB: dbs.sports},
+		},
+	}
+
+	for name, path := range files {
+		s.Sources = append(s.Sources, ingest.Source{
+			Provider: &ingest.FileProvider{ProviderName: name, Path: path},
+			Interval: interval,
+		})
+	}
+
+	for name, url := range urls {
+		s.Sources = append(s.Sources, ingest.Source{
+			Provider: &ingest.HTTPProvider{ProviderName: name, URL: url},
+			Interval: interval,
+		})
+	}
+
+	if len(s.Sources) == 0 {
+		return nil, errors.New("no providers configured")
+	}
+
+	return s, nil
+}
+
+// parseProviders parses a comma-separated list of "name=location" pairs.
+func parseProviders(list string) (map[string]string, error) {
+	providers := map[string]string{}
+
+	for pair := range strings.SplitSeq(list, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, location, ok := strings.Cut(pair, "=")
+		if !ok || name == "" || location == "" {
+			return nil, fmt.Errorf("invalid provider %q", pair)
+		}
+
+		if _, ok := providers[name]; ok {
+			return nil, fmt.Errorf("duplicate provider %q", name)
+		}
+
+		providers[name] = location
+	}
+
+	return providers, nil
+}
@@ -2,12 +2,20 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net"
 	"os"
 	"time"
 
-	racingapi "github.com/danilvpetrov/entain/api/racing"
+	"github.com/danilvpetrov/entain/admin"
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	racingv2 "github.com/danilvpetrov/entain/api/racing/v2"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/jurisdiction"
 	"github.com/danilvpetrov/entain/racing"
+	"github.com/danilvpetrov/entain/tenant"
+	"github.com/danilvpetrov/entain/validation"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 )
@@ -15,19 +23,53 @@ import (
 var (
 	serverAddr        = os.Getenv("LISTEN_ADDR")
 	defaultServerAddr = "localhost:9000"
+
+	adminToken  = os.Getenv("ADMIN_TOKEN")
+	adminTokens = os.Getenv("ADMIN_TOKENS")
 )
 
-// setupServer sets up and returns a gRPC server along with its listener.
+// setupServer sets up and returns a gRPC server along with its listener. The
+// server serves both versions of the Racing API.
 func setupServer(
 	ctx context.Context,
 	s *racing.Service,
 ) (*grpc.Server, net.Listener, error) {
 	otelServerHdr := otelgrpc.NewServerHandler()
 
+	validationInterceptor, err := validation.UnaryServerInterceptor()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := setupAdminTokens()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validationStreamInterceptor, err := validation.StreamServerInterceptor()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	server := grpc.NewServer(
 		grpc.StatsHandler(otelServerHdr),
+		grpc.ChainUnaryInterceptor(
+			apierror.UnaryServerInterceptor(),
+			admin.UnaryServerInterceptor(tokens),
+			tenant.UnaryServerInterceptor(),
+			jurisdiction.UnaryServerInterceptor(),
+			validationInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			apierror.StreamServerInterceptor(),
+			admin.StreamServerInterceptor(tokens),
+			tenant.StreamServerInterceptor(),
+			jurisdiction.StreamServerInterceptor(),
+			validationStreamInterceptor,
+		),
 	)
 	racingapi.RegisterRacingServer(server, s)
+	racingv2.RegisterRacingServer(server, &racing.ServiceV2{V1: s})
 
 	if serverAddr == "" {
 		serverAddr = defaultServerAddr
@@ -44,3 +86,23 @@ func setupServer(
 
 	return server, listener, nil
 }
+
+// setupAdminTokens returns the tokens identifying admins. The shared token
+// identifies the admin named admin.DefaultName.
+func setupAdminTokens() (admin.Tokens, error) {
+	tokens, err := admin.ParseTokens(adminTokens)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing ADMIN_TOKENS envvar: %w", err)
+	}
+
+	if adminToken != "" {
+		if _, ok := tokens[adminToken]; ok {
+			return nil, errors.New(
+				"ADMIN_TOKEN envvar must differ from the ADMIN_TOKENS tokens",
+			)
+		}
+		tokens[adminToken] = admin.DefaultName
+	}
+
+	return tokens, nil
+}
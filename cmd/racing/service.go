@@ -2,11 +2,30 @@ package main
 
 import (
 	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
 
+	"github.com/danilvpetrov/entain/jurisdiction"
 	"github.com/danilvpetrov/entain/racing"
 )
 
+var maxBatchSize = os.Getenv("MAX_BATCH_SIZE")
+
 // setupService initialises and returns a new instance of the racing service.
-func setupService(db *sql.DB) *racing.Service {
-	return &racing.Service{DB: db}
+func setupService(
+	db *sql.DB,
+	rules *jurisdiction.Rules,
+) (*racing.Service, error) {
+	s := &racing.Service{DB: db, Jurisdictions: rules}
+
+	if maxBatchSize != "" {
+		var err error
+		s.MaxBatchSize, err = strconv.Atoi(maxBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing MAX_BATCH_SIZE envvar: %w", err)
+		}
+	}
+
+	return s, nil
 }
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/danilvpetrov/entain/racing"
+)
+
+var statusInterval = os.Getenv("STATUS_INTERVAL")
+
+// setupStatusUpdater creates a worker keeping the stored statuses of the races
+// in the racing database up to date.
+func setupStatusUpdater(db *sql.DB) (*racing.StatusUpdater, error) {
+	u := &racing.StatusUpdater{DB: db}
+
+	if statusInterval != "" {
+		var err error
+		u.Interval, err = time.ParseDuration(statusInterval)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing STATUS_INTERVAL envvar: %w",
+				err,
+			)
+		}
+	}
+
+	return u, nil
+}
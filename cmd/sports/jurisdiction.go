@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/danilvpetrov/entain/jurisdiction"
+)
+
+var (
+	jurisdictionRulesPath     = os.Getenv("JURISDICTION_RULES_PATH")
+	jurisdictionRulesInterval = os.Getenv("JURISDICTION_RULES_INTERVAL")
+)
+
+// setupJurisdictionRules loads the rules restricting the content offered in
+// jurisdictions from the file configured by the environment variables. It
+// returns nil if JURISDICTION_RULES_PATH is not set.
+func setupJurisdictionRules() (*jurisdiction.Rules, error) {
+	if jurisdictionRulesPath == "" {
+		return nil, nil
+	}
+
+	r := &jurisdiction.Rules{Path: jurisdictionRulesPath}
+
+	if jurisdictionRulesInterval != "" {
+		var err error
+		r.Interval, err = time.ParseDuration(jurisdictionRulesInterval)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing JURISDICTION_RULES_INTERVAL envvar: %w",
+				err,
+			)
+		}
+	}
+
+	if _, err := r.Load(); err != nil {
+		return nil, fmt.Errorf("error loading jurisdiction rules: %w", err)
+	}
+
+	return r, nil
+}
@@ -49,7 +49,44 @@ func run() error {
 		}
 	}()
 
-	service := setupService(db)
+	rules, err := setupJurisdictionRules()
+	if err != nil {
+		return fmt.Errorf("error setting up jurisdiction rules: %w", err)
+	}
+
+	if rules != nil {
+		go func() {
+			_ = rules.Run(ctx)
+		}()
+	}
+
+	service, err := setupService(db, rules)
+	if err != nil {
+		return fmt.Errorf("error setting up service: %w", err)
+	}
+
+	relay, err := setupRelay(db)
+	if err != nil {
+		return fmt.Errorf("error setting up outbox relay: %w", err)
+	}
+
+	if relay != nil {
+		go func() {
+			_ = relay.Run(ctx)
+		}()
+	}
+
+	retention, err := setupRetention(db)
+	if err != nil {
+		return fmt.Errorf("error setting up retention: %w", err)
+	}
+
+	if retention != nil {
+		go func() {
+			_ = retention.Run(ctx)
+		}()
+	}
+
 	svr, listener, err := setupServer(ctx, service)
 	if err != nil {
 		return fmt.Errorf("error setting up server: %w", err)
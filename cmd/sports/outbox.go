@@ -0,0 +1,53 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/danilvpetrov/entain/outbox"
+	"github.com/danilvpetrov/entain/sports"
+)
+
+var (
+	outboxSink     = os.Getenv("OUTBOX_SINK")
+	outboxInterval = os.Getenv("OUTBOX_INTERVAL")
+)
+
+// setupRelay creates an outbox relay publishing the changes of the sports
+// database to the sink configured by the OUTBOX_SINK environment variable. It
+// returns nil if no sink is configured.
+//
+// The only supported sink is "stdout", which writes the changes to the
+// standard output as a local stand-in for a message broker.
+func setupRelay(db *sql.DB) (*outbox.Relay, error) {
+	r := &outbox.Relay{
+		Source: &sports.OutboxSource{DB: db},
+	}
+
+	switch outboxSink {
+	case "":
+		return nil, nil
+	case "stdout":
+		r.Sink = &outbox.WriterSink{W: os.Stdout}
+	default:
+		return nil, fmt.Errorf(
+			"error parsing OUTBOX_SINK envvar: unknown sink %q",
+			outboxSink,
+		)
+	}
+
+	if outboxInterval != "" {
+		var err error
+		r.Interval, err = time.ParseDuration(outboxInterval)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing OUTBOX_INTERVAL envvar: %w",
+				err,
+			)
+		}
+	}
+
+	return r, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/danilvpetrov/entain/sports"
+)
+
+var (
+	retentionArchiveAfter = os.Getenv("RETENTION_ARCHIVE_AFTER")
+	retentionPurgeAfter   = os.Getenv("RETENTION_PURGE_AFTER")
+	retentionInterval     = os.Getenv("RETENTION_INTERVAL")
+
+	retentionExportDir        = os.Getenv("RETENTION_EXPORT_DIR")
+	defaultRetentionExportDir = "exports"
+)
+
+// setupRetention creates a worker applying the retention policy configured by
+// the environment variables to the sports database. It returns nil if
+// RETENTION_ARCHIVE_AFTER is not set.
+func setupRetention(db *sql.DB) (*sports.Retention, error) {
+	if retentionArchiveAfter == "" {
+		return nil, nil
+	}
+
+	r := &sports.Retention{
+		DB:        db,
+		ExportDir: retentionExportDir,
+	}
+
+	if r.ExportDir == "" {
+		r.ExportDir = defaultRetentionExportDir
+	}
+
+	var err error
+	r.ArchiveAfter, err = time.ParseDuration(retentionArchiveAfter)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"error parsing RETENTION_ARCHIVE_AFTER envvar: %w",
+			err,
+		)
+	}
+
+	if retentionPurgeAfter != "" {
+		r.PurgeAfter, err = time.ParseDuration(retentionPurgeAfter)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing RETENTION_PURGE_AFTER envvar: %w",
+				err,
+			)
+		}
+	}
+
+	if retentionInterval != "" {
+		r.Interval, err = time.ParseDuration(retentionInterval)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"error parsing RETENTION_INTERVAL envvar: %w",
+				err,
+			)
+		}
+	}
+
+	return r, nil
+}
@@ -2,11 +2,30 @@ package main
 
 import (
 	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
 
+	"github.com/danilvpetrov/entain/jurisdiction"
 	"github.com/danilvpetrov/entain/sports"
 )
 
+var maxBatchSize = os.Getenv("MAX_BATCH_SIZE")
+
 // setupService initialises and returns a new instance of the sports service.
-func setupService(db *sql.DB) *sports.Service {
-	return &sports.Service{DB: db}
+func setupService(
+	db *sql.DB,
+	rules *jurisdiction.Rules,
+) (*sports.Service, error) {
+	s := &sports.Service{DB: db, Jurisdictions: rules}
+
+	if maxBatchSize != "" {
+		var err error
+		s.MaxBatchSize, err = strconv.Atoi(maxBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing MAX_BATCH_SIZE envvar: %w", err)
+		}
+	}
+
+	return s, nil
 }
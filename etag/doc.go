@@ -0,0 +1,11 @@
+// Package etag implements the optimistic concurrency control of the changes
+// made to races and sports events through the APIs.
+//
+// Every race and sports event has a version, which is incremented every time
+// the race or the event is changed. The version is exposed to clients as an
+// opaque etag. Requests changing a race or an event must bear the etag of the
+// version they were based on, either in their etag field or in the "if-match"
+// metadata, and fail with codes.Aborted if the race or the event has been
+// changed since. The API gateway forwards the If-Match header of HTTP requests
+// as such metadata.
+package etag
@@ -0,0 +1,89 @@
+package etag
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/danilvpetrov/entain/apierror"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey is the name of the gRPC metadata key that carries the etag a
+// request is conditional on, as an alternative to the etag field of the
+// request.
+const MetadataKey = "if-match"
+
+// Any is the etag that matches any version, as in the "If-Match: *" HTTP
+// header.
+const Any = "*"
+
+// ReasonMismatch is the reason of the errors returned when the etag of a
+// request does not match the current version of the entity.
+const ReasonMismatch = "ETAG_MISMATCH"
+
+// Format returns the etag of the given version of an entity.
+func Format(version int64) string {
+	return strconv.FormatInt(version, 10)
+}
+
+// Check returns an error if the etag of a request does not match the current
+// version of an entity. The etag is taken from the etag field of the request,
+// given as tag, or from the "if-match" metadata if the field is not set.
+//
+// It returns an error with codes.InvalidArgument code if the request bears no
+// etag, and codes.Aborted code if the etag does not match.
+func Check(ctx context.Context, tag string, version int64) error {
+	if tag == "" {
+		tag = fromMetadata(ctx)
+	}
+
+	if tag == "" {
+		return apierror.InvalidArgument(
+			ctx,
+			"missing etag",
+			apierror.FieldViolation{
+				Field:       "etag",
+				Description: "etag of the entity or If-Match header is required",
+			},
+		)
+	}
+
+	return match(ctx, tag, version)
+}
+
+// Matches returns true if the given etag matches the version of an entity.
+func Matches(tag string, version int64) bool {
+	return tag == Any || tag == Format(version)
+}
+
+// match returns an error with codes.Aborted code if the given etag does not
+// match the version.
+func match(ctx context.Context, tag string, version int64) error {
+	if Matches(tag, version) {
+		return nil
+	}
+
+	return apierror.Aborted(
+		ctx,
+		ReasonMismatch,
+		"etag does not match the current version, re-read and try again",
+	)
+}
+
+// fromMetadata returns the etag in the "if-match" metadata of the request of
+// the given context, without the quotes and the weakness indicator of the HTTP
+// If-Match header.
+func fromMetadata(ctx context.Context) string {
+	for _, v := range metadata.ValueFromIncomingContext(ctx, MetadataKey) {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		v = strings.TrimPrefix(v, "W/")
+		return strings.Trim(v, `"`)
+	}
+
+	return ""
+}
@@ -0,0 +1,76 @@
+package etag_test
+
+import (
+	"testing"
+
+	. "github.com/danilvpetrov/entain/etag"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestCheck(t *testing.T) {
+	cases := []struct {
+		md       metadata.MD
+		name     string
+		tag      string
+		expected codes.Code
+	}{
+		{
+			name:     "matching etag",
+			tag:      Format(3),
+			expected: codes.OK,
+		},
+		{
+			name:     "stale etag",
+			tag:      Format(2),
+			expected: codes.Aborted,
+		},
+		{
+			name:     "malformed etag",
+			tag:      "<etag>",
+			expected: codes.Aborted,
+		},
+		{
+			name:     "any etag",
+			tag:      Any,
+			expected: codes.OK,
+		},
+		{
+			name:     "missing etag",
+			expected: codes.InvalidArgument,
+		},
+		{
+			name:     "matching if-match metadata",
+			md:       metadata.Pairs(MetadataKey, `"3"`),
+			expected: codes.OK,
+		},
+		{
+			name:     "weak if-match metadata",
+			md:       metadata.Pairs(MetadataKey, `W/"3"`),
+			expected: codes.OK,
+		},
+		{
+			name:     "stale if-match metadata",
+			md:       metadata.Pairs(MetadataKey, `"2"`),
+			expected: codes.Aborted,
+		},
+		{
+			name:     "etag takes precedence over if-match metadata",
+			tag:      Format(2),
+			md:       metadata.Pairs(MetadataKey, `"3"`),
+			expected: codes.Aborted,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(t.Context(), c.md)
+
+			err := Check(ctx, c.tag, 3)
+			if status.Code(err) != c.expected {
+				t.Fatalf("expected %v, got %v", c.expected, err)
+			}
+		})
+	}
+}
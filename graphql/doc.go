@@ -0,0 +1,16 @@
+// Package graphql serves a GraphQL API over the racing and sports gRPC
+// services.
+//
+// The schema is derived from the descriptors of the services. The RPCs bound to
+// HTTP GET routes are exposed as the fields of the "racing" and "sports"
+// objects of the Query type, or of the Subscription type if they stream their
+// responses. The fields of the request messages are the arguments of the
+// fields, and the response messages are object types with the fields named as
+// in the JSON encoding of the messages. The schema also relates races to their
+// meetings, and audit entries and tenant overrides to the races and sports
+// events they refer to.
+//
+// The races and sports events looked up while executing a request are loaded
+// in batches by the BatchGetRaces and BatchGetEvents calls, so that the
+// requests do not make a call per race or sports event.
+package graphql
@@ -0,0 +1,71 @@
+package graphql
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	rpccode "google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// fieldError converts an error resolving a field to a GraphQL error. The code
+// of the gRPC status of the error is reported in the "code" extension, for
+// example "NOT_FOUND", and its google.rpc error details in the "details"
+// extension.
+func fieldError(err error, f *ast.Field, path ast.Path) *gqlerror.Error {
+	st := status.Convert(err)
+
+	return &gqlerror.Error{
+		Message:   st.Message(),
+		Path:      path,
+		Locations: locations(f.Position),
+		Extensions: map[string]any{
+			// google.rpc.Code names are in UPPER_SNAKE_CASE unlike the names
+			// returned by codes.Code. The codes are small enough not to
+			// overflow.
+			"code": rpccode.Code( //nolint:gosec // See above.
+				st.Code(),
+			).String(),
+			"details": marshalDetails(st.Proto().GetDetails()),
+		},
+	}
+}
+
+// requestError returns a GraphQL error of a request that cannot be executed.
+func requestError(msg string, pos *ast.Position) *gqlerror.Error {
+	return &gqlerror.Error{Message: msg, Locations: locations(pos)}
+}
+
+// locations returns the locations of GraphQL errors raised at the given
+// position of a query, if it is known.
+func locations(pos *ast.Position) []gqlerror.Location {
+	if pos == nil {
+		return nil
+	}
+	return []gqlerror.Location{{Line: pos.Line, Column: pos.Column}}
+}
+
+// marshalDetails converts google.rpc error details to JSON. Details of unknown
+// types are skipped.
+func marshalDetails(details []*anypb.Any) []json.RawMessage {
+	result := make([]json.RawMessage, 0, len(details))
+
+	for _, d := range details {
+		raw, err := protojson.Marshal(d)
+		if err != nil {
+			slog.Warn(
+				"failed marshalling error detail",
+				slog.String("type", d.GetTypeUrl()),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		result = append(result, raw)
+	}
+
+	return result
+}
@@ -0,0 +1,460 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// thunk returns the value of a field once it is resolved. The thunks of the
+// fields at the same depth of a request are called concurrently.
+type thunk func() (any, error)
+
+// resolver resolves a field of an object from the value of the object and the
+// arguments of the field.
+type resolver func(e *executor, parent, args map[string]any) thunk
+
+// executor executes the operations of a GraphQL request.
+//
+// The fields are resolved breadth-first: the fields at the same depth of all
+// operations are resolved concurrently before the fields nested in them, so
+// that the races and sports events they refer to are loaded in a single batch.
+type executor struct {
+	ctx     context.Context
+	schema  *schema
+	conns   map[string]grpc.ClientConnInterface
+	loaders *loaders
+
+	// pending are the fields to resolve at the next depth.
+	pending []*pendingField
+}
+
+// pendingField is a field waiting to be resolved.
+type pendingField struct {
+	resolve  thunk
+	complete func(any, error)
+	value    any
+	err      error
+}
+
+// newExecutor returns a new executor making the gRPC calls with the given
+// context.
+func newExecutor(
+	ctx context.Context,
+	s *schema,
+	conns map[string]grpc.ClientConnInterface,
+) *executor {
+	e := &executor{ctx: ctx, schema: s, conns: conns}
+	e.loaders = newLoaders(e)
+	return e
+}
+
+// operation is an operation of a GraphQL request.
+type operation struct {
+	def    *ast.OperationDefinition
+	vars   map[string]any
+	result *result
+}
+
+// result is the result of an operation.
+type result struct {
+	// Data is the data selected by the operation. It is nil if the operation
+	// is not executed because it is invalid.
+	Data *object `json:"data,omitempty"`
+	// Errors is a list of the errors raised while validating or executing the
+	// operation.
+	Errors gqlerror.List `json:"errors,omitempty"`
+}
+
+// start starts executing an operation, resolving its fields from the given
+// root value. The fields that cannot be resolved immediately are resolved by
+// run.
+func (e *executor) start(op *operation, root map[string]any) {
+	typ := e.schema.Query
+	if op.def.Operation == ast.Subscription {
+		typ = e.schema.Subscription
+	}
+
+	op.result.Data = e.executeSelectionSet(
+		op,
+		typ,
+		root,
+		op.def.SelectionSet,
+		nil,
+	)
+}
+
+// run resolves the pending fields of the started operations, depth by depth,
+// until there are no more fields to resolve.
+func (e *executor) run() {
+	for len(e.pending) > 0 {
+		level := e.pending
+		e.pending = nil
+
+		var wg sync.WaitGroup
+		for _, p := range level {
+			wg.Go(func() {
+				p.value, p.err = p.resolve()
+			})
+		}
+		wg.Wait()
+
+		for _, p := range level {
+			p.complete(p.value, p.err)
+		}
+	}
+}
+
+// rootValue returns the value of the root type of operations, which holds the
+// objects exposing the RPCs of the services.
+func (e *executor) rootValue(typ *ast.Definition) map[string]any {
+	root := map[string]any{}
+	for _, f := range typ.Fields {
+		if _, ok := e.schema.rpcs[f.Type.Name()]; ok {
+			root[f.Name] = map[string]any{}
+		}
+	}
+	return root
+}
+
+// executeSelectionSet selects the fields of an object of the given type.
+func (e *executor) executeSelectionSet(
+	op *operation,
+	typ *ast.Definition,
+	parent map[string]any,
+	sel ast.SelectionSet,
+	path ast.Path,
+) *object {
+	obj := &object{values: map[string]any{}}
+
+	for _, g := range collectFields(typ, sel, op.vars, nil) {
+		f := g.fields[0]
+		fieldPath := append(slices.Clip(path), ast.PathName(g.key))
+		obj.set(g.key, nil)
+
+		var value any
+		switch f.Name {
+		case "__typename":
+			obj.set(g.key, typ.Name)
+			continue
+		case "__schema":
+			value = e.schema.introspection.schema
+		case "__type":
+			name, _ := f.ArgumentMap(op.vars)["name"].(string)
+			if t, ok := e.schema.introspection.types[name]; ok {
+				value = t
+			}
+		default:
+			r := e.resolverOf(typ.Name, f.Name)
+			if r == nil {
+				value = parent[f.Name]
+				break
+			}
+
+			e.pending = append(e.pending, &pendingField{
+				resolve: r(e, parent, f.ArgumentMap(op.vars)),
+				complete: func(v any, err error) {
+					if err != nil {
+						op.fail(err, f, fieldPath)
+						return
+					}
+					obj.set(
+						g.key,
+						e.complete(op, f.Definition.Type, g, v, fieldPath),
+					)
+				},
+			})
+			continue
+		}
+
+		obj.set(g.key, e.complete(op, f.Definition.Type, g, value, fieldPath))
+	}
+
+	return obj
+}
+
+// resolverOf returns the resolver of a field of the given object type, or nil
+// if the field is selected from the value of the object.
+func (e *executor) resolverOf(typ, field string) resolver {
+	if r, ok := resolvers[typ][field]; ok {
+		return r
+	}
+
+	// The RPCs streaming their responses are only called by subscriptions,
+	// which select the fields from the streamed messages.
+	r, ok := e.schema.rpcs[typ][field]
+	if !ok || r.desc.IsStreamingServer() {
+		return nil
+	}
+
+	return func(e *executor, _, args map[string]any) thunk {
+		return func() (any, error) {
+			return e.invoke(r, args)
+		}
+	}
+}
+
+// complete converts the value of a field to the value of its type, selecting
+// the fields of objects.
+func (e *executor) complete(
+	op *operation,
+	typ *ast.Type,
+	g *fieldGroup,
+	value any,
+	path ast.Path,
+) any {
+	if value == nil {
+		return nil
+	}
+
+	if typ.Elem != nil {
+		items, _ := value.([]any)
+		result := make([]any, len(items))
+		for i, item := range items {
+			result[i] = e.complete(
+				op,
+				typ.Elem,
+				g,
+				item,
+				append(slices.Clip(path), ast.PathIndex(i)),
+			)
+		}
+		return result
+	}
+
+	def := e.schema.Types[typ.NamedType]
+	if def.Kind == ast.Object {
+		obj, _ := value.(map[string]any)
+		return e.executeSelectionSet(op, def, obj, g.selectionSet(), path)
+	}
+
+	if typ.NamedType == int64Scalar {
+		// 64-bit integers are serialised as strings, which is how they are
+		// encoded in JSON by protobuf, unless they are unsigned 32-bit ones.
+		if n, ok := value.(json.Number); ok {
+			return n.String()
+		}
+	}
+
+	return value
+}
+
+// fail records an error resolving a field of the operation.
+func (op *operation) fail(err error, f *ast.Field, path ast.Path) {
+	op.result.Errors = append(op.result.Errors, fieldError(err, f, path))
+}
+
+// invoke calls a unary RPC with the request built from the given arguments,
+// and returns its response decoded from its JSON encoding.
+func (e *executor) invoke(r *rpc, args map[string]any) (any, error) {
+	in, err := newRequest(r.desc.Input(), args)
+	if err != nil {
+		return nil, err
+	}
+
+	out := newMessage(r.desc.Output())
+	if err := e.conns[r.service].Invoke(e.ctx, r.method, in, out); err != nil {
+		return nil, err
+	}
+
+	return decodeMessage(out)
+}
+
+// newRequest returns a request message built from the arguments of a field,
+// which are named after the fields of the message as in its JSON encoding.
+func newRequest(
+	md protoreflect.MessageDescriptor,
+	args map[string]any,
+) (proto.Message, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"invalid arguments: %v",
+			err,
+		)
+	}
+
+	m := newMessage(md)
+	if err := protojson.Unmarshal(data, m); err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"invalid arguments: %v",
+			err,
+		)
+	}
+
+	return m, nil
+}
+
+// newMessage returns a new message of the given type.
+func newMessage(md protoreflect.MessageDescriptor) proto.Message {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName())
+	if err != nil {
+		return dynamicpb.NewMessage(md)
+	}
+	return mt.New().Interface()
+}
+
+// decodeMessage returns a message decoded from its JSON encoding, which
+// includes the fields that are not set. The numbers are decoded as
+// json.Number.
+func decodeMessage(m proto.Message) (any, error) {
+	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(m)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"error encoding %s: %v",
+			m.ProtoReflect().Descriptor().FullName(),
+			err,
+		)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"error decoding %s: %v",
+			m.ProtoReflect().Descriptor().FullName(),
+			err,
+		)
+	}
+
+	return v, nil
+}
+
+// fieldGroup is a group of the fields of a selection set with the same
+// response key, which are executed as a single field.
+type fieldGroup struct {
+	key    string
+	fields []*ast.Field
+}
+
+// selectionSet returns the selection sets of the fields of the group merged
+// together.
+func (g *fieldGroup) selectionSet() ast.SelectionSet {
+	var sel ast.SelectionSet
+	for _, f := range g.fields {
+		sel = append(sel, f.SelectionSet...)
+	}
+	return sel
+}
+
+// collectFields groups the fields selected from an object of the given type by
+// their response keys, in the order they are selected in. The fields of the
+// fragments matching the type are included, as are the fields that are not
+// skipped by the @skip and @include directives.
+func collectFields(
+	typ *ast.Definition,
+	sel ast.SelectionSet,
+	vars map[string]any,
+	groups []*fieldGroup,
+) []*fieldGroup {
+	for _, s := range sel {
+		switch s := s.(type) {
+		case *ast.Field:
+			if !included(s.Directives, vars) {
+				continue
+			}
+
+			i := slices.IndexFunc(groups, func(g *fieldGroup) bool {
+				return g.key == s.Alias
+			})
+			if i < 0 {
+				i = len(groups)
+				groups = append(groups, &fieldGroup{key: s.Alias})
+			}
+			groups[i].fields = append(groups[i].fields, s)
+
+		case *ast.InlineFragment:
+			if !included(s.Directives, vars) ||
+				s.TypeCondition != "" && s.TypeCondition != typ.Name {
+				continue
+			}
+			groups = collectFields(typ, s.SelectionSet, vars, groups)
+
+		case *ast.FragmentSpread:
+			if !included(s.Directives, vars) ||
+				s.Definition == nil ||
+				s.Definition.TypeCondition != typ.Name {
+				continue
+			}
+			groups = collectFields(typ, s.Definition.SelectionSet, vars, groups)
+		}
+	}
+
+	return groups
+}
+
+// included reports whether a selection with the given directives is included
+// in the result, as requested by the @skip and @include directives.
+func included(dirs ast.DirectiveList, vars map[string]any) bool {
+	if d := dirs.ForName("skip"); d != nil {
+		if skip, _ := d.ArgumentMap(vars)["if"].(bool); skip {
+			return false
+		}
+	}
+
+	if d := dirs.ForName("include"); d != nil {
+		if include, _ := d.ArgumentMap(vars)["if"].(bool); !include {
+			return false
+		}
+	}
+
+	return true
+}
+
+// object is a JSON object with the fields in the order they are selected in.
+type object struct {
+	keys   []string
+	values map[string]any
+}
+
+// set sets the value of a field of the object.
+func (o *object) set(key string, value any) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = value
+}
+
+// MarshalJSON encodes the object in JSON.
+func (o *object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteByte('{')
+	for i, k := range o.keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		b.WriteString(strconv.Quote(k))
+		b.WriteByte(':')
+
+		v, err := json.Marshal(o.values[k])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(v)
+	}
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
@@ -0,0 +1,504 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/validator"
+	"google.golang.org/grpc"
+)
+
+const (
+	// DefaultMaxBatchSize is the default maximum number of operations in a
+	// batched request.
+	DefaultMaxBatchSize = 10
+
+	// maxRequestSize is the maximum size of the body of a request.
+	maxRequestSize = 1 << 20
+)
+
+// Handler is an HTTP handler serving GraphQL requests.
+//
+// The operations are sent in POST requests with JSON bodies carrying the
+// "query", "operationName" and "variables" fields, or in GET requests with
+// such query parameters. A POST request may carry a JSON array of such bodies
+// to execute a batch of operations, which is answered with an array of their
+// results. The requests accepting text/event-stream are answered with
+// server-sent events following the GraphQL over SSE protocol, which is how
+// subscriptions are served.
+type Handler struct {
+	// Racing is a connection to the racing service.
+	Racing grpc.ClientConnInterface
+	// Sports is a connection to the sports service.
+	Sports grpc.ClientConnInterface
+
+	// Context returns the context of the gRPC calls made to serve a request,
+	// for example one carrying the metadata forwarded from the headers of the
+	// request. If it is nil, the context of the request is used.
+	Context func(context.Context, *http.Request) (context.Context, error)
+
+	// MaxDepth is the maximum depth of the operations. If it is zero,
+	// DefaultMaxDepth is used.
+	MaxDepth int
+	// MaxComplexity is the maximum complexity of the operations. If it is
+	// zero, DefaultMaxComplexity is used.
+	MaxComplexity int
+	// MaxBatchSize is the maximum number of operations in a batched request.
+	// If it is zero, DefaultMaxBatchSize is used.
+	MaxBatchSize int
+}
+
+// params are the parameters of an operation sent in a request.
+type params struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// loadSchema returns the schema derived from the descriptors of the racing and
+// sports services.
+var loadSchema = sync.OnceValues(func() (*schema, error) {
+	return newSchema(
+		relationsSDL,
+		service{
+			name: "racing",
+			desc: racingapi.File_api_racing_v1_racing_proto.Services().
+				ByName("Racing"),
+		},
+		service{
+			name: "sports",
+			desc: sportsapi.File_api_sports_sports_proto.Services().
+				ByName("Sports"),
+		},
+	)
+})
+
+// ServeHTTP serves a GraphQL request.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s, err := loadSchema()
+	if err != nil {
+		slog.Error("failed loading GraphQL schema", slog.Any("error", err))
+		writeRequestError(
+			w,
+			http.StatusInternalServerError,
+			"GraphQL schema is not available",
+		)
+		return
+	}
+
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		writeRequestError(
+			w,
+			http.StatusMethodNotAllowed,
+			"only GET and POST requests are supported",
+		)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if ct != "application/json" {
+			writeRequestError(
+				w,
+				http.StatusUnsupportedMediaType,
+				"POST requests must carry application/json bodies",
+			)
+			return
+		}
+	}
+
+	batch, batched, err := readParams(r)
+	if err != nil {
+		writeRequestError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(batch) > h.maxBatchSize() {
+		writeRequestError(
+			w,
+			http.StatusBadRequest,
+			fmt.Sprintf(
+				"batch of %d operations exceeds the maximum of %d",
+				len(batch),
+				h.maxBatchSize(),
+			),
+		)
+		return
+	}
+
+	ctx := r.Context()
+	if h.Context != nil {
+		ctx, err = h.Context(ctx, r)
+		if err != nil {
+			writeRequestError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	if AcceptsEventStream(r) {
+		if batched {
+			writeRequestError(
+				w,
+				http.StatusBadRequest,
+				"batched operations cannot be served as server-sent events",
+			)
+			return
+		}
+
+		h.serveEvents(ctx, w, s, batch[0])
+		return
+	}
+
+	e := newExecutor(ctx, s, h.conns())
+	results := make([]*result, len(batch))
+	for i, p := range batch {
+		op, res := h.prepare(s, p)
+		results[i] = res
+		if op == nil {
+			continue
+		}
+
+		if op.def.Operation == ast.Subscription {
+			res.Errors = append(res.Errors, requestError(
+				"subscriptions are only served as server-sent events "+
+					"to the requests accepting text/event-stream",
+				op.def.Position,
+			))
+			continue
+		}
+
+		e.start(op, e.rootValue(s.Query))
+	}
+	e.run()
+
+	var body any = results[0]
+	if batched {
+		body = results
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("failed writing GraphQL response", slog.Any("error", err))
+	}
+}
+
+// serveEvents serves an operation as server-sent events. A subscription sends
+// a result for every message streamed by its RPC, while any other operation
+// sends a single result. The events are completed once there are no more
+// results to send.
+func (h *Handler) serveEvents(
+	ctx context.Context,
+	w http.ResponseWriter,
+	s *schema,
+	p params,
+) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	rc := http.NewResponseController(w)
+	write := func(event string, data []byte) {
+		_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+		if err != nil {
+			return
+		}
+		// An error means the writer does not support flushing, in which case
+		// the events are sent once the response is complete.
+		_ = rc.Flush()
+	}
+
+	send := func(res *result) {
+		data, err := json.Marshal(res)
+		if err != nil {
+			slog.Error("failed encoding GraphQL result", slog.Any("error", err))
+			return
+		}
+		write("next", data)
+	}
+
+	op, res := h.prepare(s, p)
+	switch {
+	case op == nil:
+		send(res)
+	case op.def.Operation == ast.Subscription:
+		h.subscribe(ctx, s, op, send)
+	default:
+		e := newExecutor(ctx, s, h.conns())
+		e.start(op, e.rootValue(s.Query))
+		e.run()
+		send(res)
+	}
+
+	write("complete", nil)
+}
+
+// subscribe calls the streaming RPC selected by a subscription, and sends the
+// result of the subscription for every message streamed by the RPC, until the
+// stream ends. The fields of every result are resolved from scratch.
+func (h *Handler) subscribe(
+	ctx context.Context,
+	s *schema,
+	op *operation,
+	send func(*result),
+) {
+	ns, f, gqlErr := subscriptionField(s, op)
+	if gqlErr != nil {
+		send(&result{Errors: gqlerror.List{gqlErr}})
+		return
+	}
+
+	field := f.fields[0]
+	path := ast.Path{ast.PathName(ns.key), ast.PathName(f.key)}
+	r := s.rpcs[ns.fields[0].Definition.Type.Name()][field.Name]
+
+	fail := func(err error) {
+		send(&result{Errors: gqlerror.List{fieldError(err, field, path)}})
+	}
+
+	in, err := newRequest(r.desc.Input(), field.ArgumentMap(op.vars))
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	stream, err := h.conns()[r.service].NewStream(
+		ctx,
+		&grpc.StreamDesc{ServerStreams: true},
+		r.method,
+	)
+	if err != nil {
+		fail(err)
+		return
+	}
+
+	if err := stream.SendMsg(in); err != nil && !errors.Is(err, io.EOF) {
+		fail(err)
+		return
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		fail(err)
+		return
+	}
+
+	for {
+		out := newMessage(r.desc.Output())
+		if err := stream.RecvMsg(out); err != nil {
+			if !errors.Is(err, io.EOF) {
+				fail(err)
+			}
+			return
+		}
+
+		value, err := decodeMessage(out)
+		if err != nil {
+			fail(err)
+			return
+		}
+
+		e := newExecutor(ctx, s, h.conns())
+		res := &result{}
+		e.start(
+			&operation{def: op.def, vars: op.vars, result: res},
+			map[string]any{
+				ns.fields[0].Name: map[string]any{field.Name: value},
+			},
+		)
+		e.run()
+		send(res)
+	}
+}
+
+// subscriptionField returns the groups of the field of the Subscription type
+// selected by a subscription, and of the field exposing a streaming RPC
+// selected from it. Subscriptions must select a single streaming RPC.
+func subscriptionField(
+	s *schema,
+	op *operation,
+) (ns, f *fieldGroup, _ *gqlerror.Error) {
+	roots := collectFields(s.Subscription, op.def.SelectionSet, op.vars, nil)
+	if len(roots) != 1 || roots[0].fields[0].Name == "__typename" {
+		return nil, nil, requestError(
+			"subscriptions must select a single field of Subscription",
+			op.def.Position,
+		)
+	}
+	ns = roots[0]
+
+	typ := ns.fields[0].Definition.Type.Name()
+	fields := collectFields(s.Types[typ], ns.selectionSet(), op.vars, nil)
+	if len(fields) != 1 || fields[0].fields[0].Name == "__typename" {
+		return nil, nil, requestError(
+			"subscriptions must select a single field of "+typ,
+			ns.fields[0].Position,
+		)
+	}
+
+	return ns, fields[0], nil
+}
+
+// prepare parses and validates an operation sent in a request. It returns nil
+// if the operation cannot be executed, along with the result reporting the
+// errors.
+func (h *Handler) prepare(s *schema, p params) (*operation, *result) {
+	res := &result{}
+
+	if p.Query == "" {
+		res.Errors = gqlerror.List{requestError("missing query", nil)}
+		return nil, res
+	}
+
+	doc, errs := gqlparser.LoadQueryWithRules(s.Schema, p.Query, nil)
+	if len(errs) > 0 {
+		res.Errors = errs
+		return nil, res
+	}
+
+	def := doc.Operations.ForName(p.OperationName)
+	if def == nil {
+		res.Errors = gqlerror.List{requestError(
+			fmt.Sprintf("unknown operation %q", p.OperationName),
+			nil,
+		)}
+		return nil, res
+	}
+
+	if err := checkLimits(def, h.maxDepth(), h.maxComplexity()); err != nil {
+		res.Errors = gqlerror.List{err}
+		return nil, res
+	}
+
+	vars, err := validator.VariableValues(s.Schema, def, p.Variables)
+	if err != nil {
+		res.Errors = gqlerror.List{gqlerror.WrapIfUnwrapped(err)}
+		return nil, res
+	}
+
+	return &operation{def: def, vars: vars, result: res}, res
+}
+
+// conns returns the connections to the services, by the names of the services.
+func (h *Handler) conns() map[string]grpc.ClientConnInterface {
+	return map[string]grpc.ClientConnInterface{
+		"racing": h.Racing,
+		"sports": h.Sports,
+	}
+}
+
+// maxDepth returns the maximum depth of the operations.
+func (h *Handler) maxDepth() int {
+	if h.MaxDepth == 0 {
+		return DefaultMaxDepth
+	}
+	return h.MaxDepth
+}
+
+// maxComplexity returns the maximum complexity of the operations.
+func (h *Handler) maxComplexity() int {
+	if h.MaxComplexity == 0 {
+		return DefaultMaxComplexity
+	}
+	return h.MaxComplexity
+}
+
+// maxBatchSize returns the maximum number of operations in a batch.
+func (h *Handler) maxBatchSize() int {
+	if h.MaxBatchSize == 0 {
+		return DefaultMaxBatchSize
+	}
+	return h.MaxBatchSize
+}
+
+// AcceptsEventStream reports whether a request accepts server-sent events, in
+// which case it is answered with them.
+func AcceptsEventStream(r *http.Request) bool {
+	for _, v := range r.Header.Values("Accept") {
+		for mt := range strings.SplitSeq(v, ",") {
+			mt, _, _ = mime.ParseMediaType(mt)
+			if mt == "text/event-stream" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readParams reads the parameters of the operations sent in a request. It also
+// reports whether the operations are batched.
+func readParams(r *http.Request) ([]params, bool, error) {
+	if r.Method == http.MethodGet {
+		q := r.URL.Query()
+		p := params{
+			Query:         q.Get("query"),
+			OperationName: q.Get("operationName"),
+		}
+
+		if v := q.Get("variables"); v != "" {
+			if err := decodeJSON([]byte(v), &p.Variables); err != nil {
+				return nil, false, fmt.Errorf("invalid variables: %w", err)
+			}
+		}
+
+		return []params{p}, false, nil
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxRequestSize))
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading request body: %w", err)
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var batch []params
+		if err := decodeJSON(data, &batch); err != nil {
+			return nil, false, fmt.Errorf("invalid request body: %w", err)
+		}
+
+		if len(batch) == 0 {
+			return nil, false, errors.New("empty batch of operations")
+		}
+
+		return batch, true, nil
+	}
+
+	var p params
+	if err := decodeJSON(data, &p); err != nil {
+		return nil, false, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	return []params{p}, false, nil
+}
+
+// decodeJSON decodes a JSON value, decoding the numbers as json.Number so that
+// 64-bit integers keep their precision.
+func decodeJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// writeRequestError writes the response to a request that cannot be served.
+func writeRequestError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	body := result{Errors: gqlerror.List{requestError(msg, nil)}}
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("failed writing GraphQL response", slog.Any("error", err))
+	}
+}
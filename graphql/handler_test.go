@@ -0,0 +1,347 @@
+package graphql_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+)
+
+func TestHandler(t *testing.T) {
+	env := setupHandler(t)
+
+	t.Run("executes queries sent in POST requests", func(t *testing.T) {
+		resp := query(
+			t,
+			env.handler,
+			`query($id: Int64) {
+				racing { getRace(raceId: $id) { id name } }
+			}`,
+			map[string]any{"id": "1"},
+		)
+		mustSucceed(t, resp)
+
+		if id := at(t, resp.Data, "racing", "getRace", "id"); id != "1" {
+			t.Fatalf("expected race ID %q, got %v", "1", id)
+		}
+	})
+
+	t.Run("executes queries sent in GET requests", func(t *testing.T) {
+		q := url.Values{
+			"query":     {`query($id: Int64) { racing { getRace(raceId: $id) { id } } }`},
+			"variables": {`{"id": 2}`},
+		}
+
+		w := httptest.NewRecorder()
+		env.handler.ServeHTTP(w, httptest.NewRequestWithContext(
+			t.Context(),
+			http.MethodGet,
+			"/graphql?"+q.Encode(),
+			http.NoBody,
+		))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		var resp response
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		mustSucceed(t, resp)
+
+		if id := at(t, resp.Data, "racing", "getRace", "id"); id != "2" {
+			t.Fatalf("expected race ID %q, got %v", "2", id)
+		}
+	})
+
+	t.Run("selects fields in the order of the query", func(t *testing.T) {
+		w := post(
+			t,
+			env.handler,
+			`{"query": "{ racing { r: getRace(raceId: 1) { status id } } }"}`,
+		)
+
+		want := `{"data":{"racing":{"r":{"status":"`
+		if !strings.HasPrefix(w.Body.String(), want) {
+			t.Fatalf("expected body starting with %s, got %s", want, w.Body)
+		}
+	})
+
+	t.Run("executes batches of operations", func(t *testing.T) {
+		w := post(t, env.handler, `[
+			{"query": "{ racing { getRace(raceId: 1) { id } } }"},
+			{"query": "{ sports { getEvent(eventId: 1) { id } } }"},
+			{"query": "{ unknown }"}
+		]`)
+
+		var results []response
+		if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(results) != 3 {
+			t.Fatalf("expected 3 results, got %d", len(results))
+		}
+
+		mustSucceed(t, results[0])
+		mustSucceed(t, results[1])
+
+		if id := at(t, results[1].Data, "sports", "getEvent", "id"); id != "1" {
+			t.Fatalf("expected event ID %q, got %v", "1", id)
+		}
+
+		if len(results[2].Errors) == 0 || results[2].Data != nil {
+			t.Fatalf("expected invalid query to fail, got %+v", results[2])
+		}
+	})
+
+	t.Run("reports errors of the services", func(t *testing.T) {
+		resp := query(
+			t,
+			env.handler,
+			`{ racing { getRace(raceId: 1) { id } missing: getRace(raceId: 1000000) { id } } }`,
+			nil,
+		)
+
+		if len(resp.Errors) != 1 {
+			t.Fatalf("expected 1 error, got %+v", resp.Errors)
+		}
+
+		err := resp.Errors[0]
+		if code := err.Extensions["code"]; code != "NOT_FOUND" {
+			t.Fatalf("expected code %q, got %v", "NOT_FOUND", code)
+		}
+
+		if len(err.Path) != 2 || err.Path[1] != "missing" {
+			t.Fatalf("expected path to the missing race, got %v", err.Path)
+		}
+
+		if r := at(t, resp.Data, "racing", "missing"); r != nil {
+			t.Fatalf("expected missing race to be null, got %v", r)
+		}
+
+		if id := at(t, resp.Data, "racing", "getRace", "id"); id != "1" {
+			t.Fatalf("expected race ID %q, got %v", "1", id)
+		}
+	})
+
+	t.Run("reports invalid arguments", func(t *testing.T) {
+		resp := query(
+			t,
+			env.handler,
+			`{ racing { getRace(raceId: -1) { id } } }`,
+			nil,
+		)
+
+		if len(resp.Errors) != 1 ||
+			resp.Errors[0].Extensions["code"] != "INVALID_ARGUMENT" {
+			t.Fatalf("expected INVALID_ARGUMENT error, got %+v", resp.Errors)
+		}
+	})
+
+	t.Run("reports invalid queries", func(t *testing.T) {
+		resp := query(t, env.handler, `{ racing { unknown } }`, nil)
+
+		if len(resp.Errors) == 0 || resp.Data != nil {
+			t.Fatalf("expected invalid query to fail, got %+v", resp)
+		}
+	})
+
+	t.Run("rejects subscriptions without event streams", func(t *testing.T) {
+		resp := query(
+			t,
+			env.handler,
+			`subscription { racing { exportRaces { id } } }`,
+			nil,
+		)
+
+		if len(resp.Errors) != 1 || resp.Data != nil {
+			t.Fatalf("expected subscription to fail, got %+v", resp)
+		}
+	})
+
+	t.Run("rejects unsupported methods", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		env.handler.ServeHTTP(w, httptest.NewRequestWithContext(
+			t.Context(),
+			http.MethodPut,
+			"/graphql",
+			http.NoBody,
+		))
+
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Fatalf(
+				"expected status %d, got %d",
+				http.StatusMethodNotAllowed,
+				w.Code,
+			)
+		}
+	})
+
+	t.Run("rejects bodies of other media types", func(t *testing.T) {
+		w := post(
+			t,
+			env.handler,
+			`{"query": "{ racing { getRace(raceId: 1) { id } } }"}`,
+			"Content-Type", "text/plain",
+		)
+
+		if w.Code != http.StatusUnsupportedMediaType {
+			t.Fatalf(
+				"expected status %d, got %d",
+				http.StatusUnsupportedMediaType,
+				w.Code,
+			)
+		}
+	})
+
+	t.Run("rejects malformed bodies", func(t *testing.T) {
+		w := post(t, env.handler, `{"query":`)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf(
+				"expected status %d, got %d",
+				http.StatusBadRequest,
+				w.Code,
+			)
+		}
+	})
+}
+
+func TestHandlerEvents(t *testing.T) {
+	env := setupHandler(t)
+
+	t.Run("streams the results of subscriptions", func(t *testing.T) {
+		want, err := env.racing.ListRaces(
+			t.Context(),
+			&racingapi.ListRacesRequest{MeetingId: []int64{1}},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		events := stream(
+			t,
+			env.handler,
+			`subscription { racing { exportRaces(meetingId: [1]) { id meetingId } } }`,
+		)
+
+		if len(events) != len(want.GetRaces())+1 {
+			t.Fatalf(
+				"expected %d races and completion, got %d events",
+				len(want.GetRaces()),
+				len(events),
+			)
+		}
+
+		for _, e := range events[:len(events)-1] {
+			if e.name != "next" {
+				t.Fatalf("expected next event, got %q", e.name)
+			}
+
+			var resp response
+			if err := json.Unmarshal([]byte(e.data), &resp); err != nil {
+				t.Fatal(err)
+			}
+			mustSucceed(t, resp)
+
+			race := at(t, resp.Data, "racing", "exportRaces")
+			if id := at(t, race, "meetingId"); id != "1" {
+				t.Fatalf("expected meeting ID %q, got %v", "1", id)
+			}
+		}
+
+		if e := events[len(events)-1]; e.name != "complete" {
+			t.Fatalf("expected complete event, got %q", e.name)
+		}
+	})
+
+	t.Run("streams the results of queries", func(t *testing.T) {
+		events := stream(
+			t,
+			env.handler,
+			`{ racing { getRace(raceId: 1) { id } } }`,
+		)
+
+		if len(events) != 2 ||
+			events[0].name != "next" ||
+			events[1].name != "complete" {
+			t.Fatalf("expected next and complete events, got %+v", events)
+		}
+	})
+
+	t.Run("streams the errors of subscriptions", func(t *testing.T) {
+		events := stream(
+			t,
+			env.handler,
+			`subscription { sports { watchEvent(eventId: 1000000) { id } } }`,
+		)
+
+		if len(events) != 2 {
+			t.Fatalf("expected error and completion, got %+v", events)
+		}
+
+		var resp response
+		if err := json.Unmarshal([]byte(events[0].data), &resp); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(resp.Errors) != 1 ||
+			resp.Errors[0].Extensions["code"] != "NOT_FOUND" {
+			t.Fatalf("expected NOT_FOUND error, got %+v", resp.Errors)
+		}
+	})
+}
+
+// event is a server-sent event.
+type event struct {
+	name string
+	data string
+}
+
+// stream is a test helper that sends a GraphQL query in a request accepting
+// server-sent events, and returns the events it is answered with.
+func stream(t *testing.T, h http.Handler, q string) []event {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"query": q})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := post(t, h, string(body), "Accept", "text/event-stream")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected event stream, got %q", ct)
+	}
+
+	var (
+		events  []event
+		current event
+	)
+
+	s := bufio.NewScanner(w.Body)
+	for s.Scan() {
+		line := s.Text()
+		switch {
+		case line == "":
+			events = append(events, current)
+			current = event{}
+		case strings.HasPrefix(line, "event: "):
+			current.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			current.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+
+	return events
+}
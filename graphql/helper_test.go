@@ -0,0 +1,382 @@
+package graphql_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/danilvpetrov/entain/admin"
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/apierror"
+	. "github.com/danilvpetrov/entain/graphql"
+	"github.com/danilvpetrov/entain/jurisdiction"
+	"github.com/danilvpetrov/entain/racing"
+	"github.com/danilvpetrov/entain/sports"
+	"github.com/danilvpetrov/entain/tenant"
+	"github.com/danilvpetrov/entain/validation"
+	_ "github.com/mattn/go-sqlite3" // underscore import for the SQLite driver
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// testAdminToken is the token that identifies the admin in the test
+	// servers.
+	testAdminToken = "test-admin-token"
+
+	// testAdminName is the name of the admin identified by testAdminToken.
+	testAdminName = "test-admin"
+)
+
+// callCounter counts the calls made to the services by their full method
+// names.
+type callCounter struct {
+	m     sync.Mutex
+	calls map[string]int
+}
+
+// count returns the number of calls made to the given method.
+func (c *callCounter) count(method string) int {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.calls[method]
+}
+
+// reset forgets the counted calls.
+func (c *callCounter) reset() {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.calls = map[string]int{}
+}
+
+// add counts a call to the given method.
+func (c *callCounter) add(method string) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.calls[method]++
+}
+
+// testEnv is the environment of a test of the GraphQL handler.
+type testEnv struct {
+	// handler is the tested handler.
+	handler *Handler
+	// racing is a client of the racing service the handler calls.
+	racing racingapi.RacingClient
+	// sports is a client of the sports service the handler calls.
+	sports sportsapi.SportsClient
+	// calls counts the calls made by the handler.
+	calls *callCounter
+}
+
+// setupHandler is a test helper that sets up the racing and sports services
+// with seeded test data, and returns a GraphQL handler calling them. The
+// handler forwards the Authorization and X-Tenant-Id headers of the requests
+// to the services.
+func setupHandler(t *testing.T) *testEnv {
+	t.Helper()
+
+	calls := &callCounter{calls: map[string]int{}}
+
+	racingConn := setupServer(t, calls, func(s *grpc.Server) {
+		racingapi.RegisterRacingServer(s, &racing.Service{
+			DB: setupRacingDatabase(t),
+		})
+	})
+
+	sportsConn := setupServer(t, calls, func(s *grpc.Server) {
+		sportsapi.RegisterSportsServer(s, &sports.Service{
+			DB: setupSportsDatabase(t),
+		})
+	})
+
+	return &testEnv{
+		handler: &Handler{
+			Racing:  racingConn,
+			Sports:  sportsConn,
+			Context: forwardHeaders,
+		},
+		racing: racingapi.NewRacingClient(racingConn),
+		sports: sportsapi.NewSportsClient(sportsConn),
+		calls:  calls,
+	}
+}
+
+// forwardHeaders returns a context of the gRPC calls carrying the
+// Authorization and X-Tenant-Id headers of a request as metadata.
+func forwardHeaders(
+	ctx context.Context,
+	r *http.Request,
+) (context.Context, error) {
+	for _, h := range []string{"Authorization", tenant.MetadataKey} {
+		if v := r.Header.Get(h); v != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, h, v)
+		}
+	}
+	return ctx, nil
+}
+
+// setupRacingDatabase is a test helper that sets up a racing database seeded
+// with test data.
+func setupRacingDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := openDatabase(t)
+
+	if err := racing.ApplySchema(t.Context(), db); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := racing.SeedTestData(t.Context(), db); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+// setupSportsDatabase is a test helper that sets up a sports database seeded
+// with test data.
+func setupSportsDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := openDatabase(t)
+
+	if err := sports.ApplySchema(t.Context(), db); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sports.SeedTestData(
+		t.Context(),
+		db,
+		"../sports/testdata/testdata.json",
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+// openDatabase is a test helper that opens an in-memory database.
+func openDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	return db
+}
+
+// setupServer is a test helper that sets up a gRPC server with the services
+// registered by the given function, and returns a connection to it. The calls
+// made through the connection are counted by the given counter.
+func setupServer(
+	t *testing.T,
+	calls *callCounter,
+	register func(*grpc.Server),
+) *grpc.ClientConn {
+	t.Helper()
+
+	validationInterceptor, err := validation.UnaryServerInterceptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validationStreamInterceptor, err := validation.StreamServerInterceptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adminTokens := admin.Tokens{testAdminToken: testAdminName}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			apierror.UnaryServerInterceptor(),
+			admin.UnaryServerInterceptor(adminTokens),
+			tenant.UnaryServerInterceptor(),
+			jurisdiction.UnaryServerInterceptor(),
+			validationInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			apierror.StreamServerInterceptor(),
+			admin.StreamServerInterceptor(adminTokens),
+			tenant.StreamServerInterceptor(),
+			jurisdiction.StreamServerInterceptor(),
+			validationStreamInterceptor,
+		),
+	)
+	register(server)
+
+	listenCfg := net.ListenConfig{}
+	// Listen on a random port.
+	listener, err := listenCfg.Listen(t.Context(), "tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(server.GracefulStop)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient(
+		listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(func(
+			ctx context.Context,
+			method string,
+			req, reply any,
+			cc *grpc.ClientConn,
+			invoker grpc.UnaryInvoker,
+			opts ...grpc.CallOption,
+		) error {
+			calls.add(method)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(
+			ctx context.Context,
+			desc *grpc.StreamDesc,
+			cc *grpc.ClientConn,
+			method string,
+			streamer grpc.Streamer,
+			opts ...grpc.CallOption,
+		) (grpc.ClientStream, error) {
+			calls.add(method)
+			return streamer(ctx, desc, cc, method, opts...)
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+// response is the decoded body of a GraphQL response.
+type response struct {
+	Data   map[string]any  `json:"data"`
+	Errors []responseError `json:"errors"`
+}
+
+// responseError is a decoded GraphQL error.
+type responseError struct {
+	Message    string         `json:"message"`
+	Path       []any          `json:"path"`
+	Extensions map[string]any `json:"extensions"`
+}
+
+// post is a test helper that sends a POST request with the given JSON body to
+// the handler.
+func post(
+	t *testing.T,
+	h http.Handler,
+	body string,
+	headers ...string,
+) *httptest.ResponseRecorder {
+	t.Helper()
+
+	r := httptest.NewRequestWithContext(
+		t.Context(),
+		http.MethodPost,
+		"/graphql",
+		strings.NewReader(body),
+	)
+	r.Header.Set("Content-Type", "application/json")
+	for i := 0; i+1 < len(headers); i += 2 {
+		r.Header.Set(headers[i], headers[i+1])
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	return w
+}
+
+// query is a test helper that executes a GraphQL query with the given
+// variables, and returns the decoded response.
+func query(
+	t *testing.T,
+	h http.Handler,
+	q string,
+	vars map[string]any,
+	headers ...string,
+) response {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]any{"query": q, "variables": vars})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := post(t, h, string(body), headers...)
+	if w.Code != http.StatusOK {
+		t.Fatalf(
+			"expected status %d, got %d: %s",
+			http.StatusOK,
+			w.Code,
+			w.Body.String(),
+		)
+	}
+
+	var resp response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	return resp
+}
+
+// mustSucceed is a test helper that fails the test if the response carries
+// errors.
+func mustSucceed(t *testing.T, resp response) {
+	t.Helper()
+
+	if len(resp.Errors) > 0 {
+		t.Fatalf("expected no errors, got %+v", resp.Errors)
+	}
+}
+
+// at is a test helper that returns the value at the given path of a decoded
+// JSON value.
+func at(t *testing.T, v any, path ...any) any {
+	t.Helper()
+
+	for _, p := range path {
+		switch p := p.(type) {
+		case string:
+			m, ok := v.(map[string]any)
+			if !ok {
+				t.Fatalf("expected an object at %q, got %v", p, v)
+			}
+			v = m[p]
+		case int:
+			l, ok := v.([]any)
+			if !ok || p >= len(l) {
+				t.Fatalf("expected a list of over %d items, got %v", p, v)
+			}
+			v = l[p]
+		}
+	}
+
+	return v
+}
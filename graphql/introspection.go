@@ -0,0 +1,254 @@
+package graphql
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// introspection holds the values of the objects the introspection fields of a
+// schema resolve to. The fields of the objects are selected from the values
+// as the fields of any other object, and the values of the types refer to
+// each other, so that any depth of the types can be selected.
+type introspection struct {
+	// schema is the value of the __Schema object of the schema.
+	schema map[string]any
+	// types are the values of the __Type objects of the named types of the
+	// schema, by their names.
+	types map[string]map[string]any
+}
+
+// introspect returns the values of the introspection objects describing a
+// schema.
+func introspect(s *ast.Schema) *introspection {
+	in := &introspection{types: map[string]map[string]any{}}
+
+	names := slices.Sorted(maps.Keys(s.Types))
+	for _, n := range names {
+		in.types[n] = map[string]any{}
+	}
+
+	types := make([]any, 0, len(names))
+	for _, n := range names {
+		in.describeType(s, s.Types[n])
+		types = append(types, in.types[n])
+	}
+
+	directives := make([]any, 0, len(s.Directives))
+	for _, n := range slices.Sorted(maps.Keys(s.Directives)) {
+		directives = append(directives, in.directive(s.Directives[n]))
+	}
+
+	in.schema = map[string]any{
+		"description": description(s.Description),
+		"types":       types,
+		"queryType":   in.namedType(s.Query),
+		// The schema has no mutations, as the RPCs changing the races and
+		// sports events are not exposed.
+		"mutationType":     in.namedType(s.Mutation),
+		"subscriptionType": in.namedType(s.Subscription),
+		"directives":       directives,
+	}
+
+	return in
+}
+
+// describeType sets the fields of the __Type object of a named type.
+func (in *introspection) describeType(s *ast.Schema, def *ast.Definition) {
+	t := in.types[def.Name]
+	t["kind"] = string(def.Kind)
+	t["name"] = def.Name
+	t["description"] = description(def.Description)
+
+	switch def.Kind {
+	case ast.Object, ast.Interface:
+		fields := []any{}
+		for _, f := range def.Fields {
+			// The introspection fields of the Query type are not listed.
+			if strings.HasPrefix(f.Name, "__") {
+				continue
+			}
+			fields = append(fields, in.field(f))
+		}
+		t["fields"] = fields
+
+		interfaces := []any{}
+		for _, n := range def.Interfaces {
+			interfaces = append(interfaces, in.types[n])
+		}
+		t["interfaces"] = interfaces
+
+		if def.Kind == ast.Interface {
+			t["possibleTypes"] = in.possibleTypes(s, def)
+		}
+
+	case ast.Union:
+		t["possibleTypes"] = in.possibleTypes(s, def)
+
+	case ast.Enum:
+		values := []any{}
+		for _, v := range def.EnumValues {
+			deprecated, reason := deprecation(v.Directives)
+			values = append(values, map[string]any{
+				"name":              v.Name,
+				"description":       description(v.Description),
+				"isDeprecated":      deprecated,
+				"deprecationReason": reason,
+			})
+		}
+		t["enumValues"] = values
+
+	case ast.InputObject:
+		fields := []any{}
+		for _, f := range def.Fields {
+			fields = append(fields, in.inputValue(
+				f.Name,
+				f.Description,
+				f.Type,
+				f.DefaultValue,
+				f.Directives,
+			))
+		}
+		t["inputFields"] = fields
+		t["isOneOf"] = def.Directives.ForName("oneOf") != nil
+	}
+}
+
+// possibleTypes returns the values of the __Type objects of the possible types
+// of an abstract type.
+func (in *introspection) possibleTypes(
+	s *ast.Schema,
+	def *ast.Definition,
+) []any {
+	types := []any{}
+	for _, t := range s.PossibleTypes[def.Name] {
+		types = append(types, in.types[t.Name])
+	}
+	return types
+}
+
+// field returns the value of the __Field object of a field.
+func (in *introspection) field(f *ast.FieldDefinition) map[string]any {
+	args := []any{}
+	for _, a := range f.Arguments {
+		args = append(args, in.inputValue(
+			a.Name,
+			a.Description,
+			a.Type,
+			a.DefaultValue,
+			a.Directives,
+		))
+	}
+
+	deprecated, reason := deprecation(f.Directives)
+
+	return map[string]any{
+		"name":              f.Name,
+		"description":       description(f.Description),
+		"args":              args,
+		"type":              in.typeRef(f.Type),
+		"isDeprecated":      deprecated,
+		"deprecationReason": reason,
+	}
+}
+
+// inputValue returns the value of the __InputValue object of an argument or a
+// field of an input object.
+func (in *introspection) inputValue(
+	name, desc string,
+	typ *ast.Type,
+	defaultValue *ast.Value,
+	dirs ast.DirectiveList,
+) map[string]any {
+	var def any
+	if defaultValue != nil {
+		def = defaultValue.String()
+	}
+
+	deprecated, reason := deprecation(dirs)
+
+	return map[string]any{
+		"name":              name,
+		"description":       description(desc),
+		"type":              in.typeRef(typ),
+		"defaultValue":      def,
+		"isDeprecated":      deprecated,
+		"deprecationReason": reason,
+	}
+}
+
+// directive returns the value of the __Directive object of a directive.
+func (in *introspection) directive(d *ast.DirectiveDefinition) map[string]any {
+	locations := []any{}
+	for _, l := range d.Locations {
+		locations = append(locations, string(l))
+	}
+
+	args := []any{}
+	for _, a := range d.Arguments {
+		args = append(args, in.inputValue(
+			a.Name,
+			a.Description,
+			a.Type,
+			a.DefaultValue,
+			a.Directives,
+		))
+	}
+
+	return map[string]any{
+		"name":         d.Name,
+		"description":  description(d.Description),
+		"isRepeatable": d.IsRepeatable,
+		"locations":    locations,
+		"args":         args,
+	}
+}
+
+// typeRef returns the value of the __Type object of a type, wrapping the named
+// type into the LIST and NON_NULL types as needed.
+func (in *introspection) typeRef(t *ast.Type) map[string]any {
+	ref := in.types[t.NamedType]
+	if t.Elem != nil {
+		ref = map[string]any{"kind": "LIST", "ofType": in.typeRef(t.Elem)}
+	}
+
+	if t.NonNull {
+		ref = map[string]any{"kind": "NON_NULL", "ofType": ref}
+	}
+
+	return ref
+}
+
+// namedType returns the value of the __Type object of a type defined by the
+// given definition, or nil if it is not defined.
+func (in *introspection) namedType(def *ast.Definition) any {
+	if def == nil {
+		return nil
+	}
+	return in.types[def.Name]
+}
+
+// deprecation returns whether an element with the given directives is
+// deprecated, and the reason it is deprecated for.
+func deprecation(dirs ast.DirectiveList) (bool, any) {
+	d := dirs.ForName("deprecated")
+	if d == nil {
+		return false, nil
+	}
+
+	if a := d.Arguments.ForName("reason"); a != nil {
+		return true, a.Value.Raw
+	}
+	return true, "No longer supported"
+}
+
+// description returns the value of the description of an element, which is
+// null if it is empty.
+func description(d string) any {
+	if d == "" {
+		return nil
+	}
+	return d
+}
@@ -0,0 +1,93 @@
+package graphql_test
+
+import (
+	"testing"
+)
+
+func TestIntrospection(t *testing.T) {
+	env := setupHandler(t)
+
+	t.Run("describes the operation types", func(t *testing.T) {
+		resp := query(
+			t,
+			env.handler,
+			`{ __schema {
+				queryType { name }
+				mutationType { name }
+				subscriptionType { name }
+			} }`,
+			nil,
+		)
+		mustSucceed(t, resp)
+
+		n := at(t, resp.Data, "__schema", "queryType", "name")
+		if n != "Query" {
+			t.Fatalf("expected query type %q, got %v", "Query", n)
+		}
+
+		if m := at(t, resp.Data, "__schema", "mutationType"); m != nil {
+			t.Fatalf("expected no mutation type, got %v", m)
+		}
+
+		n = at(t, resp.Data, "__schema", "subscriptionType", "name")
+		if n != "Subscription" {
+			t.Fatalf("expected subscription type %q, got %v", "Subscription", n)
+		}
+	})
+
+	t.Run("describes the types", func(t *testing.T) {
+		resp := query(
+			t,
+			env.handler,
+			`query($name: String!) { __type(name: $name) {
+				kind
+				name
+				fields { name type { kind ofType { kind name } } }
+			} }`,
+			map[string]any{"name": "Race"},
+		)
+		mustSucceed(t, resp)
+
+		if k := at(t, resp.Data, "__type", "kind"); k != "OBJECT" {
+			t.Fatalf("expected kind %q, got %v", "OBJECT", k)
+		}
+
+		fields, _ := at(t, resp.Data, "__type", "fields").([]any)
+
+		types := map[string]any{}
+		for _, f := range fields {
+			types[at(t, f, "name").(string)] = at(t, f, "type")
+		}
+
+		id, ok := types["id"]
+		if !ok {
+			t.Fatalf("expected id field, got %v", fields)
+		}
+
+		if k := at(t, id, "kind"); k != "NON_NULL" {
+			t.Fatalf("expected kind %q, got %v", "NON_NULL", k)
+		}
+
+		if n := at(t, id, "ofType", "name"); n != "Int64" {
+			t.Fatalf("expected type %q, got %v", "Int64", n)
+		}
+
+		if _, ok := types["meeting"]; !ok {
+			t.Fatalf("expected meeting relation, got %v", fields)
+		}
+	})
+
+	t.Run("returns null for unknown types", func(t *testing.T) {
+		resp := query(
+			t,
+			env.handler,
+			`{ __type(name: "Unknown") { name } }`,
+			nil,
+		)
+		mustSucceed(t, resp)
+
+		if v := at(t, resp.Data, "__type"); v != nil {
+			t.Fatalf("expected null, got %v", v)
+		}
+	})
+}
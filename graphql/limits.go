@@ -0,0 +1,126 @@
+package graphql
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	// DefaultMaxDepth is the default maximum depth of the operations.
+	DefaultMaxDepth = 10
+
+	// DefaultMaxComplexity is the default maximum complexity of the
+	// operations.
+	DefaultMaxComplexity = 1000
+
+	// listComplexityFactor is the factor the complexity of the fields selected
+	// from the items of a list is multiplied by, as the number of the items
+	// is not known before the list is resolved.
+	listComplexityFactor = 10
+
+	// maxMeasure is the maximum complexity measured, so that the complexity of
+	// deeply nested lists does not overflow.
+	maxMeasure = math.MaxInt32
+)
+
+// checkLimits returns an error if the depth or the complexity of an operation
+// exceeds the given maximums.
+//
+// The depth of an operation is the maximum number of fields nested in each
+// other, with the fields of fragments counted as if they were selected in
+// place of the fragments. The complexity of an operation is the number of its
+// fields, with the fields selected from the items of lists counted
+// listComplexityFactor times. The introspection fields are not counted, so
+// that the schema can be introspected regardless of the limits.
+func checkLimits(
+	op *ast.OperationDefinition,
+	maxDepth, maxComplexity int,
+) *gqlerror.Error {
+	m := measurer{fragments: map[string]measure{}}
+	got := m.measure(op.SelectionSet)
+
+	if got.depth > maxDepth {
+		return requestError(
+			fmt.Sprintf(
+				"operation depth %d exceeds the maximum of %d",
+				got.depth,
+				maxDepth,
+			),
+			op.Position,
+		)
+	}
+
+	if got.complexity > maxComplexity {
+		return requestError(
+			fmt.Sprintf(
+				"operation complexity %d exceeds the maximum of %d",
+				got.complexity,
+				maxComplexity,
+			),
+			op.Position,
+		)
+	}
+
+	return nil
+}
+
+// measure is the depth and complexity of a selection set.
+type measure struct {
+	depth      int
+	complexity int
+}
+
+// measurer measures selection sets, memoising the measures of the fragments,
+// so that fragments spread many times are measured once.
+type measurer struct {
+	fragments map[string]measure
+}
+
+// measure returns the depth and complexity of a selection set.
+func (m *measurer) measure(sel ast.SelectionSet) measure {
+	var result measure
+
+	add := func(n measure) {
+		result.depth = max(result.depth, n.depth)
+		result.complexity = min(result.complexity+n.complexity, maxMeasure)
+	}
+
+	for _, s := range sel {
+		switch s := s.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+
+			n := m.measure(s.SelectionSet)
+			if s.Definition != nil && s.Definition.Type.Elem != nil {
+				n.complexity = min(
+					n.complexity*listComplexityFactor,
+					maxMeasure,
+				)
+			}
+			add(measure{depth: n.depth + 1, complexity: n.complexity + 1})
+
+		case *ast.InlineFragment:
+			add(m.measure(s.SelectionSet))
+
+		case *ast.FragmentSpread:
+			if s.Definition == nil {
+				continue
+			}
+
+			n, ok := m.fragments[s.Name]
+			if !ok {
+				n = m.measure(s.Definition.SelectionSet)
+				m.fragments[s.Name] = n
+			}
+			add(n)
+		}
+	}
+
+	return result
+}
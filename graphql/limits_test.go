@@ -0,0 +1,131 @@
+package graphql_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	. "github.com/danilvpetrov/entain/graphql"
+)
+
+func TestHandlerLimits(t *testing.T) {
+	env := setupHandler(t)
+
+	cases := []struct {
+		name    string
+		handler *Handler
+		query   string
+		want    string
+	}{
+		{
+			name: "rejects operations exceeding the maximum depth",
+			handler: &Handler{
+				Racing:   env.handler.Racing,
+				Sports:   env.handler.Sports,
+				MaxDepth: 3,
+			},
+			query: `{ racing { getRace(raceId: 1) { meeting { id } } } }`,
+			want:  "operation depth 4 exceeds the maximum of 3",
+		},
+		{
+			name: "counts the fields of fragments in the depth",
+			handler: &Handler{
+				Racing:   env.handler.Racing,
+				Sports:   env.handler.Sports,
+				MaxDepth: 3,
+			},
+			query: `{ racing { getRace(raceId: 1) { ...f } } }
+				fragment f on Race { meeting { id } }`,
+			want: "operation depth 4 exceeds the maximum of 3",
+		},
+		{
+			name: "rejects operations exceeding the maximum complexity",
+			handler: &Handler{
+				Racing:        env.handler.Racing,
+				Sports:        env.handler.Sports,
+				MaxComplexity: 50,
+			},
+			// The listed races count 10 times each of their fields.
+			query: `{ racing { listRaces { races {
+				id name meeting { id races { id } }
+			} } } }`,
+			want: "operation complexity",
+		},
+		{
+			name: "uses the default limits",
+			handler: &Handler{
+				Racing: env.handler.Racing,
+				Sports: env.handler.Sports,
+			},
+			query: `{ racing { listRaces { races { meeting { races {
+				meeting { races { meeting { races { id } } } }
+			} } } } } }`,
+			want: "operation complexity",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			env.calls.reset()
+
+			resp := query(t, c.handler, c.query, nil)
+
+			if len(resp.Errors) != 1 || resp.Data != nil {
+				t.Fatalf("expected operation to be rejected, got %+v", resp)
+			}
+
+			if msg := resp.Errors[0].Message; !strings.Contains(msg, c.want) {
+				t.Fatalf("expected error containing %q, got %q", c.want, msg)
+			}
+
+			for _, method := range []string{
+				racingapi.Racing_ListRaces_FullMethodName,
+				racingapi.Racing_GetRace_FullMethodName,
+				racingapi.Racing_BatchGetRaces_FullMethodName,
+			} {
+				if n := env.calls.count(method); n != 0 {
+					t.Fatalf("expected no calls to %s, got %d", method, n)
+				}
+			}
+		})
+	}
+
+	t.Run("does not count introspection fields", func(t *testing.T) {
+		h := &Handler{
+			Racing:        env.handler.Racing,
+			Sports:        env.handler.Sports,
+			MaxDepth:      1,
+			MaxComplexity: 1,
+		}
+
+		resp := query(
+			t,
+			h,
+			`{ __schema { types { name fields { name type { name } } } } }`,
+			nil,
+		)
+		mustSucceed(t, resp)
+	})
+
+	t.Run("rejects batches exceeding the maximum size", func(t *testing.T) {
+		h := &Handler{
+			Racing:       env.handler.Racing,
+			Sports:       env.handler.Sports,
+			MaxBatchSize: 1,
+		}
+
+		w := post(t, h, `[
+			{"query": "{ racing { getRace(raceId: 1) { id } } }"},
+			{"query": "{ racing { getRace(raceId: 2) { id } } }"}
+		]`)
+
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf(
+				"expected status %d, got %d",
+				http.StatusBadRequest,
+				w.Code,
+			)
+		}
+	})
+}
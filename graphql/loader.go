@@ -0,0 +1,223 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+)
+
+// loadBatchSize is the maximum number of races or sports events loaded by a
+// single BatchGetRaces or BatchGetEvents call. It is the default maximum batch
+// size of the services.
+const loadBatchSize = 100
+
+// loader loads values by their keys in batches.
+//
+// The keys loaded while the fields at one depth of a request are completed are
+// fetched together once the fields at the next depth are resolved. The loaded
+// values are cached for the duration of the request.
+type loader[K comparable, V any] struct {
+	// fetch fetches the values of the given keys. The keys without values are
+	// left out of the returned map.
+	fetch func(keys []K) (map[K]V, error)
+
+	m sync.Mutex
+	// batches are the batches the keys are fetched in, by the keys.
+	batches map[K]*batch[K, V]
+	// next is the batch the keys are added to until it is fetched.
+	next *batch[K, V]
+}
+
+// batch is a batch of keys fetched together.
+type batch[K comparable, V any] struct {
+	keys   []K
+	once   sync.Once
+	values map[K]V
+	err    error
+}
+
+// newLoader returns a new loader fetching the values with the given function.
+func newLoader[K comparable, V any](
+	fetch func(keys []K) (map[K]V, error),
+) *loader[K, V] {
+	return &loader[K, V]{fetch: fetch, batches: map[K]*batch[K, V]{}}
+}
+
+// load adds the given key to the next batch, unless it is already loaded, and
+// returns a function that returns its value once the batch is fetched. The
+// function also reports whether the key has a value.
+func (l *loader[K, V]) load(key K) func() (V, bool, error) {
+	l.m.Lock()
+	b, ok := l.batches[key]
+	if !ok {
+		if l.next == nil {
+			l.next = &batch[K, V]{}
+		}
+		b = l.next
+		b.keys = append(b.keys, key)
+		l.batches[key] = b
+	}
+	l.m.Unlock()
+
+	return func() (V, bool, error) {
+		b.once.Do(func() {
+			l.m.Lock()
+			if l.next == b {
+				l.next = nil
+			}
+			l.m.Unlock()
+
+			b.values, b.err = l.fetch(b.keys)
+		})
+
+		v, ok := b.values[key]
+		return v, ok, b.err
+	}
+}
+
+// idKey is the key of a race, sports event or race meeting loaded by its ID.
+type idKey struct {
+	id              int64
+	includeArchived bool
+}
+
+// loaders are the loaders of the races, sports events and race meetings of a
+// request.
+type loaders struct {
+	// races loads races by their IDs.
+	races *loader[idKey, map[string]any]
+	// events loads sports events by their IDs.
+	events *loader[idKey, map[string]any]
+	// meetings loads the lists of races of meetings by the IDs of the
+	// meetings.
+	meetings *loader[idKey, []any]
+}
+
+// newLoaders returns the loaders making their calls with the given executor.
+func newLoaders(e *executor) *loaders {
+	return &loaders{
+		races: newLoader(e.batchGet(
+			racingapi.Racing_BatchGetRaces_FullMethodName,
+			"raceId",
+			"races",
+		)),
+		events: newLoader(e.batchGet(
+			sportsapi.Sports_BatchGetEvents_FullMethodName,
+			"eventId",
+			"events",
+		)),
+		meetings: newLoader(e.listRacesByMeeting),
+	}
+}
+
+// batchGet returns a function fetching entities by their IDs with the given
+// batch RPC, which takes the IDs in the idsArg field of its request and returns
+// the entities in the listField field of its response.
+func (e *executor) batchGet(
+	method, idsArg, listField string,
+) func([]idKey) (map[idKey]map[string]any, error) {
+	return func(keys []idKey) (map[idKey]map[string]any, error) {
+		values := map[idKey]map[string]any{}
+
+		for includeArchived, ids := range groupIDs(keys) {
+			for chunk := range slices.Chunk(ids, loadBatchSize) {
+				resp, err := e.invoke(e.schema.methods[method], map[string]any{
+					idsArg:            chunk,
+					"includeArchived": includeArchived,
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				for _, v := range list(resp, listField) {
+					entity, _ := v.(map[string]any)
+					id, err := toInt64(entity["id"])
+					if err != nil {
+						return nil, err
+					}
+					values[idKey{id, includeArchived}] = entity
+				}
+			}
+		}
+
+		return values, nil
+	}
+}
+
+// listRacesByMeeting fetches the races of the meetings with the given IDs in a
+// single ListRaces call. Every meeting is given a list of races, which is
+// empty for unknown meetings.
+func (e *executor) listRacesByMeeting(
+	keys []idKey,
+) (map[idKey][]any, error) {
+	values := map[idKey][]any{}
+
+	for includeArchived, ids := range groupIDs(keys) {
+		for _, id := range ids {
+			values[idKey{id, includeArchived}] = []any{}
+		}
+
+		resp, err := e.invoke(
+			e.schema.methods[racingapi.Racing_ListRaces_FullMethodName],
+			map[string]any{
+				"meetingId":       ids,
+				"includeArchived": includeArchived,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range list(resp, "races") {
+			race, _ := v.(map[string]any)
+			id, err := toInt64(race["meetingId"])
+			if err != nil {
+				return nil, err
+			}
+			k := idKey{id, includeArchived}
+			values[k] = append(values[k], race)
+		}
+	}
+
+	return values, nil
+}
+
+// groupIDs groups the IDs of the given keys by whether archived entities are
+// included.
+func groupIDs(keys []idKey) map[bool][]int64 {
+	groups := map[bool][]int64{}
+	for _, k := range keys {
+		groups[k.includeArchived] = append(groups[k.includeArchived], k.id)
+	}
+	return groups
+}
+
+// list returns a list field of a decoded message.
+func list(msg any, field string) []any {
+	m, _ := msg.(map[string]any)
+	l, _ := m[field].([]any)
+	return l
+}
+
+// toInt64 converts a 64-bit integer argument or field to int64. Such integers
+// are decoded from JSON as strings, and are passed as strings or integers in
+// arguments.
+func toInt64(v any) (int64, error) {
+	switch v := v.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return v, nil
+	case json.Number:
+		return v.Int64()
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("invalid 64-bit integer %v", v)
+	}
+}
@@ -0,0 +1,249 @@
+package graphql
+
+import (
+	"strconv"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// relationsSDL defines the fields relating the types derived from the
+// descriptors of the services to each other.
+const relationsSDL = `
+"Meeting is a race meeting."
+type Meeting {
+	id: Int64!
+	"Races are the races of the meeting. They are empty if the meeting is not known."
+	races(includeArchived: Boolean): [Race!]
+}
+
+extend type RacingQuery {
+	"Meeting returns the race meeting with the given ID."
+	meeting(id: Int64!): Meeting
+}
+
+extend type Race {
+	"Meeting is the meeting of the race."
+	meeting: Meeting
+}
+
+extend type RacingAuditEntry {
+	"Race is the changed race, unless it has been deleted."
+	race: Race
+}
+
+extend type RacingTenantOverride {
+	"Race is the race the override applies to, unless it has been deleted."
+	race: Race
+}
+
+extend type SportsAuditEntry {
+	"Event is the changed sports event, unless it has been deleted."
+	event: Event
+}
+
+extend type SportsTenantOverride {
+	"Event is the sports event the override applies to, unless it has been deleted."
+	event: Event
+}
+`
+
+// resolvers are the resolvers of the fields that are not resolved by calling
+// an RPC or selected from the value of their object, by the names of the
+// object types and then of the fields.
+var resolvers = map[string]map[string]resolver{
+	"RacingQuery": {
+		"getRace": resolveGetRace,
+		"meeting": resolveMeeting,
+	},
+	"SportsQuery": {
+		"getEvent": resolveGetEvent,
+	},
+	"Meeting": {
+		"races": resolveMeetingRaces,
+	},
+	"Race": {
+		"meeting": resolveRaceMeeting,
+	},
+	"RacingAuditEntry": {
+		"race": resolveAuditEntryRace,
+	},
+	"RacingTenantOverride": {
+		"race": resolveTenantOverrideRace,
+	},
+	"SportsAuditEntry": {
+		"event": resolveAuditEntryEvent,
+	},
+	"SportsTenantOverride": {
+		"event": resolveTenantOverrideEvent,
+	},
+}
+
+// resolveGetRace resolves the getRace field by loading the race in a batch
+// with the other races of the request.
+func resolveGetRace(e *executor, _, args map[string]any) thunk {
+	return e.getByID(
+		e.loaders.races,
+		racingapi.Racing_GetRace_FullMethodName,
+		args["raceId"],
+		args,
+	)
+}
+
+// resolveGetEvent resolves the getEvent field by loading the sports event in a
+// batch with the other sports events of the request.
+func resolveGetEvent(e *executor, _, args map[string]any) thunk {
+	return e.getByID(
+		e.loaders.events,
+		sportsapi.Sports_GetEvent_FullMethodName,
+		args["eventId"],
+		args,
+	)
+}
+
+// getByID returns a thunk loading an entity by its ID with the given loader.
+//
+// The batch RPCs of the loaders do not tell the entities that are not found
+// from the ones restricted in the jurisdiction of the request, so the given
+// Get* RPC is called to report the error if the entity is missing from the
+// batch, or if the ID is not valid.
+func (e *executor) getByID(
+	l *loader[idKey, map[string]any],
+	method string,
+	id any,
+	args map[string]any,
+) thunk {
+	get := func() (any, error) {
+		return e.invoke(e.schema.methods[method], args)
+	}
+
+	n, err := toInt64(id)
+	if err != nil || n <= 0 {
+		return get
+	}
+
+	includeArchived, _ := args["includeArchived"].(bool)
+	load := l.load(idKey{n, includeArchived})
+
+	return func() (any, error) {
+		entity, ok, err := load()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return get()
+		}
+		return entity, nil
+	}
+}
+
+// resolveMeeting resolves the meeting field of RacingQuery.
+func resolveMeeting(_ *executor, _, args map[string]any) thunk {
+	return meeting(args["id"])
+}
+
+// resolveRaceMeeting resolves the meeting field of Race.
+func resolveRaceMeeting(_ *executor, parent, _ map[string]any) thunk {
+	return meeting(parent["meetingId"])
+}
+
+// meeting returns a thunk returning the meeting with the given ID.
+func meeting(id any) thunk {
+	return func() (any, error) {
+		n, err := toInt64(id)
+		if err != nil {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"invalid meeting ID: %v",
+				err,
+			)
+		}
+		return map[string]any{"id": strconv.FormatInt(n, 10)}, nil
+	}
+}
+
+// resolveMeetingRaces resolves the races field of Meeting by loading the races
+// in a batch with the races of the other meetings of the request.
+func resolveMeetingRaces(e *executor, parent, args map[string]any) thunk {
+	id, err := toInt64(parent["id"])
+	if err != nil {
+		return failed(err)
+	}
+
+	includeArchived, _ := args["includeArchived"].(bool)
+	load := e.loaders.meetings.load(idKey{id, includeArchived})
+
+	return func() (any, error) {
+		races, _, err := load()
+		return races, err
+	}
+}
+
+// resolveAuditEntryRace resolves the race field of RacingAuditEntry.
+func resolveAuditEntryRace(e *executor, parent, _ map[string]any) thunk {
+	if parent["entityType"] != "race" {
+		return resolved(nil)
+	}
+	return e.relatedByID(e.loaders.races, parent["entityId"])
+}
+
+// resolveTenantOverrideRace resolves the race field of RacingTenantOverride.
+func resolveTenantOverrideRace(e *executor, parent, _ map[string]any) thunk {
+	return e.relatedByID(e.loaders.races, parent["raceId"])
+}
+
+// resolveAuditEntryEvent resolves the event field of SportsAuditEntry.
+func resolveAuditEntryEvent(e *executor, parent, _ map[string]any) thunk {
+	if parent["entityType"] != "event" {
+		return resolved(nil)
+	}
+	return e.relatedByID(e.loaders.events, parent["entityId"])
+}
+
+// resolveTenantOverrideEvent resolves the event field of
+// SportsTenantOverride.
+func resolveTenantOverrideEvent(e *executor, parent, _ map[string]any) thunk {
+	return e.relatedByID(e.loaders.events, parent["eventId"])
+}
+
+// relatedByID returns a thunk loading the entity an object refers to by its ID
+// with the given loader. Archived entities are included, and the thunk returns
+// nil if the entity is not found.
+func (e *executor) relatedByID(
+	l *loader[idKey, map[string]any],
+	id any,
+) thunk {
+	n, err := toInt64(id)
+	if err != nil {
+		return failed(err)
+	}
+	if n <= 0 {
+		return resolved(nil)
+	}
+
+	load := l.load(idKey{n, true})
+
+	return func() (any, error) {
+		entity, ok, err := load()
+		if err != nil || !ok {
+			return nil, err
+		}
+		return entity, nil
+	}
+}
+
+// resolved returns a thunk returning the given value.
+func resolved(v any) thunk {
+	return func() (any, error) {
+		return v, nil
+	}
+}
+
+// failed returns a thunk returning the given error.
+func failed(err error) thunk {
+	return func() (any, error) {
+		return nil, err
+	}
+}
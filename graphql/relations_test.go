@@ -0,0 +1,212 @@
+package graphql_test
+
+import (
+	"testing"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRelations(t *testing.T) {
+	env := setupHandler(t)
+
+	t.Run("loads races by ID in a single batch", func(t *testing.T) {
+		env.calls.reset()
+
+		resp := query(
+			t,
+			env.handler,
+			`{ racing {
+				a: getRace(raceId: 1) { id }
+				b: getRace(raceId: 2) { id }
+				c: getRace(raceId: 3) { id }
+			} }`,
+			nil,
+		)
+		mustSucceed(t, resp)
+
+		for alias, id := range map[string]string{"a": "1", "b": "2", "c": "3"} {
+			if got := at(t, resp.Data, "racing", alias, "id"); got != id {
+				t.Fatalf("expected race ID %q for %s, got %v", id, alias, got)
+			}
+		}
+
+		assertCalls(t, env.calls, map[string]int{
+			racingapi.Racing_BatchGetRaces_FullMethodName: 1,
+			racingapi.Racing_GetRace_FullMethodName:       0,
+		})
+	})
+
+	t.Run("loads events by ID in a single batch", func(t *testing.T) {
+		env.calls.reset()
+
+		resp := query(
+			t,
+			env.handler,
+			`{ sports {
+				a: getEvent(eventId: 1) { id }
+				b: getEvent(eventId: 2) { id }
+			} }`,
+			nil,
+		)
+		mustSucceed(t, resp)
+
+		assertCalls(t, env.calls, map[string]int{
+			sportsapi.Sports_BatchGetEvents_FullMethodName: 1,
+			sportsapi.Sports_GetEvent_FullMethodName:       0,
+		})
+	})
+
+	t.Run("loads the meetings of races", func(t *testing.T) {
+		env.calls.reset()
+
+		resp := query(
+			t,
+			env.handler,
+			`{ racing { listRaces(meetingId: [1, 2]) { races {
+				meetingId
+				meeting { id races { id meetingId } }
+			} } } }`,
+			nil,
+		)
+		mustSucceed(t, resp)
+
+		races, _ := at(t, resp.Data, "racing", "listRaces", "races").([]any)
+		if len(races) == 0 {
+			t.Fatal("expected races to be listed")
+		}
+
+		for _, r := range races {
+			meetingID := at(t, r, "meetingId")
+			if id := at(t, r, "meeting", "id"); id != meetingID {
+				t.Fatalf("expected meeting ID %v, got %v", meetingID, id)
+			}
+
+			meetingRaces, _ := at(t, r, "meeting", "races").([]any)
+			if len(meetingRaces) == 0 {
+				t.Fatal("expected the races of the meeting to be listed")
+			}
+
+			for _, mr := range meetingRaces {
+				if id := at(t, mr, "meetingId"); id != meetingID {
+					t.Fatalf("expected meeting ID %v, got %v", meetingID, id)
+				}
+			}
+		}
+
+		// The races are listed once, and their meetings once for all the
+		// races.
+		assertCalls(t, env.calls, map[string]int{
+			racingapi.Racing_ListRaces_FullMethodName: 2,
+			racingapi.Racing_GetRace_FullMethodName:   0,
+		})
+	})
+
+	t.Run("loads the races of tenant overrides", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(
+			t.Context(),
+			"authorization",
+			"Bearer "+testAdminToken,
+		)
+
+		for id := range int64(3) {
+			if _, err := env.racing.SetTenantOverride(
+				ctx,
+				&racingapi.SetTenantOverrideRequest{
+					Override: &racingapi.TenantOverride{
+						Tenant:   "brand-1",
+						RaceId:   id + 1,
+						Position: int32(id + 1),
+					},
+				},
+			); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		env.calls.reset()
+
+		resp := query(
+			t,
+			env.handler,
+			`{ racing { listTenantOverrides(tenant: "brand-1") { overrides {
+				raceId
+				race { id }
+			} } } }`,
+			nil,
+			"Authorization", "Bearer "+testAdminToken,
+		)
+		mustSucceed(t, resp)
+
+		overrides, _ := at(
+			t,
+			resp.Data,
+			"racing", "listTenantOverrides", "overrides",
+		).([]any)
+		if len(overrides) != 3 {
+			t.Fatalf("expected 3 overrides, got %d", len(overrides))
+		}
+
+		for _, o := range overrides {
+			if id := at(t, o, "race", "id"); id != at(t, o, "raceId") {
+				t.Fatalf("expected race of the override, got %v", id)
+			}
+		}
+
+		assertCalls(t, env.calls, map[string]int{
+			racingapi.Racing_BatchGetRaces_FullMethodName: 1,
+			racingapi.Racing_GetRace_FullMethodName:       0,
+		})
+	})
+
+	t.Run("shares the loaders within batches", func(t *testing.T) {
+		env.calls.reset()
+
+		w := post(t, env.handler, `[
+			{"query": "{ racing { getRace(raceId: 1) { id } } }"},
+			{"query": "{ racing { getRace(raceId: 2) { id } } }"}
+		]`)
+
+		if w.Code != 200 {
+			t.Fatalf("expected status 200, got %d", w.Code)
+		}
+
+		assertCalls(t, env.calls, map[string]int{
+			racingapi.Racing_BatchGetRaces_FullMethodName: 1,
+		})
+	})
+
+	t.Run("falls back to getting missing races", func(t *testing.T) {
+		env.calls.reset()
+
+		resp := query(
+			t,
+			env.handler,
+			`{ racing { getRace(raceId: 1000000) { id } } }`,
+			nil,
+		)
+
+		if len(resp.Errors) != 1 ||
+			resp.Errors[0].Extensions["code"] != "NOT_FOUND" {
+			t.Fatalf("expected NOT_FOUND error, got %+v", resp.Errors)
+		}
+
+		assertCalls(t, env.calls, map[string]int{
+			racingapi.Racing_BatchGetRaces_FullMethodName: 1,
+			racingapi.Racing_GetRace_FullMethodName:       1,
+		})
+	})
+}
+
+// assertCalls is a test helper that fails the test if the numbers of the
+// calls made to the given methods are not as expected.
+func assertCalls(t *testing.T, calls *callCounter, want map[string]int) {
+	t.Helper()
+
+	for method, n := range want {
+		if got := calls.count(method); got != n {
+			t.Fatalf("expected %d calls to %s, got %d", n, method, got)
+		}
+	}
+}
@@ -0,0 +1,550 @@
+package graphql
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Names of the scalars of the schema that are not built into GraphQL.
+const (
+	// int64Scalar is a 64-bit integer. It is serialised as a string, as in the
+	// JSON encoding of protobuf messages, and accepts both strings and
+	// integers as input.
+	int64Scalar = "Int64"
+	// timestampScalar is a time formatted as RFC 3339, for example
+	// "2025-11-04T04:00:00Z".
+	timestampScalar = "Timestamp"
+	// jsonScalar is an arbitrary JSON value.
+	jsonScalar = "JSON"
+)
+
+// scalarsSDL defines the scalars of the schema that are not built into
+// GraphQL.
+const scalarsSDL = `"A 64-bit integer, serialised as a string."
+scalar Int64
+
+"A time formatted as RFC 3339."
+scalar Timestamp
+
+"An arbitrary JSON value."
+scalar JSON
+`
+
+// service is a gRPC service whose RPCs are exposed in the schema.
+type service struct {
+	// name is the name of the fields of the Query and Subscription types the
+	// RPCs of the service are exposed under, for example "racing".
+	name string
+	// desc is the descriptor of the service.
+	desc protoreflect.ServiceDescriptor
+}
+
+// rpc is an RPC exposed as a field of the schema.
+type rpc struct {
+	// service is the name of the service of the RPC, for example "racing".
+	service string
+	// method is the full name of the gRPC method, for example
+	// "/racing.v1.Racing/ListRaces".
+	method string
+	// desc is the descriptor of the RPC.
+	desc protoreflect.MethodDescriptor
+}
+
+// schema is a GraphQL schema derived from the descriptors of gRPC services.
+type schema struct {
+	*ast.Schema
+
+	// sdl is the schema in the GraphQL schema definition language.
+	sdl string
+	// rpcs are the RPCs exposed as the fields of the schema, by the names of
+	// the object types and then of the fields.
+	rpcs map[string]map[string]*rpc
+	// methods are the RPCs exposed as the fields of the schema, by the full
+	// names of their gRPC methods.
+	methods map[string]*rpc
+	// introspection holds the values of the introspection objects describing
+	// the schema.
+	introspection *introspection
+}
+
+// newSchema derives a GraphQL schema from the descriptors of the given
+// services. The types and fields defined or extended by the extensions SDL are
+// added to the derived ones.
+func newSchema(extensions string, services ...service) (*schema, error) {
+	b := &schemaBuilder{
+		outputs: map[protoreflect.FullName]protoreflect.MessageDescriptor{},
+		inputs:  map[protoreflect.FullName]protoreflect.MessageDescriptor{},
+		enums:   map[protoreflect.FullName]protoreflect.EnumDescriptor{},
+		names:   map[protoreflect.FullName]string{},
+		rpcs:    map[string]map[string]*rpc{},
+		methods: map[string]*rpc{},
+	}
+
+	for _, s := range services {
+		b.addService(s)
+	}
+	b.nameTypes()
+
+	sdl := b.write(services) + extensions
+
+	s, err := gqlparser.LoadSchema(&ast.Source{
+		Name:  "schema.graphql",
+		Input: sdl,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error loading GraphQL schema: %w", err)
+	}
+
+	return &schema{
+		Schema:        s,
+		sdl:           sdl,
+		rpcs:          b.rpcs,
+		methods:       b.methods,
+		introspection: introspect(s),
+	}, nil
+}
+
+// schemaBuilder derives the definitions of a GraphQL schema from the
+// descriptors of gRPC services.
+type schemaBuilder struct {
+	// outputs are the messages represented by object types, by their full
+	// names.
+	outputs map[protoreflect.FullName]protoreflect.MessageDescriptor
+	// inputs are the messages represented by input object types, by their
+	// full names.
+	inputs map[protoreflect.FullName]protoreflect.MessageDescriptor
+	// enums are the enums represented by enum types, by their full names.
+	enums map[protoreflect.FullName]protoreflect.EnumDescriptor
+	// names are the names of the types representing messages and enums, by
+	// the full names of the messages and enums. The names of input object
+	// types are suffixed with "Input".
+	names map[protoreflect.FullName]string
+	// rpcs are the RPCs exposed as the fields of the schema, by the names of
+	// the object types and then of the fields.
+	rpcs map[string]map[string]*rpc
+	// methods are the RPCs exposed as the fields of the schema, by the full
+	// names of their gRPC methods.
+	methods map[string]*rpc
+}
+
+// addService adds the RPCs of a service bound to HTTP GET routes to the schema,
+// along with the types of their requests and responses. The RPCs streaming
+// their requests are not exposed.
+func (b *schemaBuilder) addService(s service) {
+	methods := s.desc.Methods()
+	for i := range methods.Len() {
+		m := methods.Get(i)
+		if m.IsStreamingClient() || !isGet(m) {
+			continue
+		}
+
+		typ := namespaceType(s, m.IsStreamingServer())
+		if b.rpcs[typ] == nil {
+			b.rpcs[typ] = map[string]*rpc{}
+		}
+		r := &rpc{
+			service: s.name,
+			method:  fmt.Sprintf("/%s/%s", s.desc.FullName(), m.Name()),
+			desc:    m,
+		}
+		b.rpcs[typ][fieldName(m)] = r
+		b.methods[r.method] = r
+
+		b.collect(m.Input(), true)
+
+		if out := m.Output(); !isOpaque(out) {
+			b.outputs[out.FullName()] = out
+			b.collect(out, false)
+		}
+	}
+}
+
+// collect adds the messages and enums of the fields of a message to the
+// schema, recursively.
+func (b *schemaBuilder) collect(
+	md protoreflect.MessageDescriptor,
+	input bool,
+) {
+	fields := md.Fields()
+	for i := range fields.Len() {
+		f := fields.Get(i)
+		if f.IsMap() {
+			continue
+		}
+
+		if e := f.Enum(); e != nil {
+			b.enums[e.FullName()] = e
+		}
+
+		m := f.Message()
+		if m == nil || isOpaque(m) {
+			continue
+		}
+
+		set := b.outputs
+		if input {
+			set = b.inputs
+		}
+
+		if _, ok := set[m.FullName()]; ok {
+			continue
+		}
+		set[m.FullName()] = m
+		b.collect(m, input)
+	}
+}
+
+// nameTypes names the types representing the collected messages and enums.
+//
+// A type is named after its message or enum, prefixed with the names of the
+// messages it is nested in, for example "RaceStatus" for the Race.Status enum.
+// If the same name is given to the messages or enums of different packages,
+// they are also prefixed with the names of their packages without their
+// versions, for example "RacingChange" and "SportsChange" for the Change
+// messages of the racing.v1 and sports packages.
+func (b *schemaBuilder) nameTypes() {
+	var all []protoreflect.Descriptor
+	for _, m := range b.outputs {
+		all = append(all, m)
+	}
+	for _, m := range b.inputs {
+		if _, ok := b.outputs[m.FullName()]; !ok {
+			all = append(all, m)
+		}
+	}
+	for _, e := range b.enums {
+		all = append(all, e)
+	}
+
+	fullNames := map[string]map[protoreflect.FullName]struct{}{}
+	for _, d := range all {
+		n := baseName(d)
+		if fullNames[n] == nil {
+			fullNames[n] = map[protoreflect.FullName]struct{}{}
+		}
+		fullNames[n][d.FullName()] = struct{}{}
+	}
+
+	for _, d := range all {
+		n := baseName(d)
+		if len(fullNames[n]) > 1 {
+			n = exportedName(unversioned(d.ParentFile().Package())) + n
+		}
+		b.names[d.FullName()] = n
+	}
+}
+
+// write writes the collected definitions in the GraphQL schema definition
+// language.
+func (b *schemaBuilder) write(services []service) string {
+	var w strings.Builder
+
+	w.WriteString(scalarsSDL)
+
+	for _, streaming := range []bool{false, true} {
+		root := "Query"
+		if streaming {
+			root = "Subscription"
+		}
+
+		var fields []string
+		for _, s := range services {
+			typ := namespaceType(s, streaming)
+			if _, ok := b.rpcs[typ]; ok {
+				fields = append(fields, fmt.Sprintf("%s: %s!", s.name, typ))
+			}
+		}
+
+		if len(fields) > 0 {
+			writeType(&w, "type", root, fields)
+		}
+	}
+
+	for _, s := range services {
+		for _, streaming := range []bool{false, true} {
+			typ := namespaceType(s, streaming)
+			if rpcs, ok := b.rpcs[typ]; ok {
+				fmt.Fprintf(
+					&w,
+					"\n\"%s exposes the RPCs of the %s service.\"",
+					typ,
+					s.desc.FullName(),
+				)
+				writeType(&w, "type", typ, b.rpcFields(rpcs))
+			}
+		}
+	}
+
+	for _, m := range sortedByName(b.outputs, b.names) {
+		writeType(&w, "type", b.names[m.FullName()], b.fields(m, false))
+	}
+
+	for _, m := range sortedByName(b.inputs, b.names) {
+		writeType(&w, "input", b.names[m.FullName()]+"Input", b.fields(m, true))
+	}
+
+	for _, e := range sortedByName(b.enums, b.names) {
+		var values []string
+		for i := range e.Values().Len() {
+			values = append(values, string(e.Values().Get(i).Name()))
+		}
+		writeType(&w, "enum", b.names[e.FullName()], values)
+	}
+
+	return w.String()
+}
+
+// rpcFields returns the definitions of the fields exposing the given RPCs, in
+// the order the RPCs are defined in their service.
+func (b *schemaBuilder) rpcFields(rpcs map[string]*rpc) []string {
+	sorted := make([]*rpc, 0, len(rpcs))
+	for _, r := range rpcs {
+		sorted = append(sorted, r)
+	}
+	slices.SortFunc(sorted, func(x, y *rpc) int {
+		return x.desc.Index() - y.desc.Index()
+	})
+
+	var fields []string
+	for _, r := range sorted {
+		var args []string
+		in := r.desc.Input().Fields()
+		for i := range in.Len() {
+			f := in.Get(i)
+			if m := f.Message(); m != nil &&
+				m.FullName() == fieldMaskName {
+				// Read masks are left out, as the fields to read are
+				// selected by the query itself.
+				continue
+			}
+			args = append(args, f.JSONName()+": "+b.inputType(f))
+		}
+
+		def := fieldName(r.desc)
+		if len(args) > 0 {
+			def += "(" + strings.Join(args, ", ") + ")"
+		}
+		def += ": " + b.messageType(r.desc.Output(), false)
+
+		fields = append(fields, def)
+	}
+
+	return fields
+}
+
+// fields returns the definitions of the fields of an object or input object
+// type representing a message.
+func (b *schemaBuilder) fields(
+	md protoreflect.MessageDescriptor,
+	input bool,
+) []string {
+	var fields []string
+	for i := range md.Fields().Len() {
+		f := md.Fields().Get(i)
+
+		typ := b.outputType(f)
+		if input {
+			typ = b.inputType(f)
+		}
+
+		fields = append(fields, f.JSONName()+": "+typ)
+	}
+	return fields
+}
+
+// outputType returns the type of a field of an object type representing a
+// message field. Messages are nullable, as they may not be set, while other
+// fields always have a value.
+func (b *schemaBuilder) outputType(f protoreflect.FieldDescriptor) string {
+	if f.IsMap() {
+		return jsonScalar + "!"
+	}
+
+	typ := b.namedType(f, false)
+	switch {
+	case f.IsList():
+		return "[" + typ + "!]!"
+	case f.Message() != nil:
+		return typ
+	default:
+		return typ + "!"
+	}
+}
+
+// inputType returns the type of an argument or a field of an input object type
+// representing a message field. All such fields are optional, as are the
+// fields of protobuf messages.
+func (b *schemaBuilder) inputType(f protoreflect.FieldDescriptor) string {
+	if f.IsMap() {
+		return jsonScalar
+	}
+
+	typ := b.namedType(f, true)
+	if f.IsList() {
+		return "[" + typ + "!]"
+	}
+	return typ
+}
+
+// namedType returns the name of the type of the values of a message field.
+func (b *schemaBuilder) namedType(
+	f protoreflect.FieldDescriptor,
+	input bool,
+) string {
+	switch f.Kind() {
+	case protoreflect.BoolKind:
+		return "Boolean"
+	case protoreflect.Int32Kind,
+		protoreflect.Sint32Kind,
+		protoreflect.Sfixed32Kind:
+		return "Int"
+	case protoreflect.Uint32Kind,
+		protoreflect.Fixed32Kind,
+		protoreflect.Int64Kind,
+		protoreflect.Sint64Kind,
+		protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind,
+		protoreflect.Fixed64Kind:
+		return int64Scalar
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return "Float"
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return "String"
+	case protoreflect.EnumKind:
+		return b.names[f.Enum().FullName()]
+	default:
+		return b.messageType(f.Message(), input)
+	}
+}
+
+// messageType returns the name of the type representing a message.
+func (b *schemaBuilder) messageType(
+	md protoreflect.MessageDescriptor,
+	input bool,
+) string {
+	switch {
+	case md.FullName() == timestampName:
+		return timestampScalar
+	case isOpaque(md):
+		return jsonScalar
+	case input:
+		return b.names[md.FullName()] + "Input"
+	default:
+		return b.names[md.FullName()]
+	}
+}
+
+// Full names of the well-known messages with a special representation in the
+// schema.
+var (
+	timestampName = (&timestamppb.Timestamp{}).ProtoReflect().
+			Descriptor().FullName()
+	fieldMaskName = (&fieldmaskpb.FieldMask{}).ProtoReflect().
+			Descriptor().FullName()
+)
+
+// isOpaque reports whether a message is represented by a scalar rather than an
+// object type. Such are the well-known messages, which have special JSON
+// encodings, and the messages without fields, which cannot be represented by
+// GraphQL objects.
+func isOpaque(md protoreflect.MessageDescriptor) bool {
+	return md.ParentFile().Package() == "google.protobuf" ||
+		md.Fields().Len() == 0
+}
+
+// isGet reports whether an RPC is bound to an HTTP GET route.
+func isGet(m protoreflect.MethodDescriptor) bool {
+	rule, ok := proto.GetExtension(
+		m.Options(),
+		annotations.E_Http,
+	).(*annotations.HttpRule)
+
+	return ok && rule.GetGet() != ""
+}
+
+// namespaceType returns the name of the object type exposing the RPCs of a
+// service, for example "RacingQuery" or "RacingSubscription" for the RPCs
+// streaming their responses.
+func namespaceType(s service, streaming bool) string {
+	if streaming {
+		return exportedName(s.name) + "Subscription"
+	}
+	return exportedName(s.name) + "Query"
+}
+
+// fieldName returns the name of the field exposing an RPC, for example
+// "listRaces" for the ListRaces RPC.
+func fieldName(m protoreflect.MethodDescriptor) string {
+	n := string(m.Name())
+	return strings.ToLower(n[:1]) + n[1:]
+}
+
+// baseName returns the name of a message or enum prefixed with the names of
+// the messages it is nested in.
+func baseName(d protoreflect.Descriptor) string {
+	n := string(d.Name())
+	for p := d.Parent(); p != nil; p = p.Parent() {
+		if _, ok := p.(protoreflect.MessageDescriptor); !ok {
+			break
+		}
+		n = string(p.Name()) + n
+	}
+	return n
+}
+
+// unversioned returns the name of a protobuf package without its version, for
+// example "racing" for the racing.v1 package.
+func unversioned(pkg protoreflect.FullName) string {
+	if v := pkg.Name(); len(v) > 1 && v[0] == 'v' &&
+		strings.Trim(string(v[1:]), "0123456789") == "" {
+		return string(pkg.Parent())
+	}
+	return string(pkg)
+}
+
+// exportedName converts a dot-separated name, such as the name of a protobuf
+// package, to PascalCase.
+func exportedName(n string) string {
+	var w strings.Builder
+	for p := range strings.SplitSeq(n, ".") {
+		if p != "" {
+			w.WriteString(strings.ToUpper(p[:1]) + p[1:])
+		}
+	}
+	return w.String()
+}
+
+// sortedByName returns the given descriptors sorted by the names of the types
+// representing them.
+func sortedByName[D protoreflect.Descriptor](
+	descs map[protoreflect.FullName]D,
+	names map[protoreflect.FullName]string,
+) []D {
+	sorted := make([]D, 0, len(descs))
+	for _, d := range descs {
+		sorted = append(sorted, d)
+	}
+	slices.SortFunc(sorted, func(x, y D) int {
+		return strings.Compare(names[x.FullName()], names[y.FullName()])
+	})
+	return sorted
+}
+
+// writeType writes the definition of a type with the given members in the
+// GraphQL schema definition language.
+func writeType(w *strings.Builder, kind, name string, members []string) {
+	fmt.Fprintf(w, "\n%s %s {\n", kind, name)
+	for _, m := range members {
+		fmt.Fprintf(w, "\t%s\n", m)
+	}
+	w.WriteString("}\n")
+}
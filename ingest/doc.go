@@ -0,0 +1,9 @@
+// Package ingest ingests races, meetings and sports events from external feed
+// providers.
+//
+// A Provider fetches a snapshot of the entities offered by a feed. A Scheduler
+// polls the providers and hands the fetched entities to the Sinks, which store
+// them in the databases of the services. Entities are deduplicated by the name
+// of the provider and their external IDs, i.e. the IDs assigned by the
+// provider.
+package ingest
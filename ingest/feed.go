@@ -0,0 +1,89 @@
+package ingest
+
+import "time"
+
+// Feed is a snapshot of the entities offered by a provider.
+type Feed struct {
+	// Meetings is a list of race meetings.
+	Meetings []Meeting `json:"meetings"`
+	// Races is a list of races.
+	Races []Race `json:"races"`
+	// Events is a list of sports events.
+	Events []Event `json:"events"`
+}
+
+// Meeting is a race meeting, i.e. a set of races held at the same venue on
+// the same day.
+type Meeting struct {
+	// ExternalID is the ID of the meeting assigned by the provider.
+	ExternalID string `json:"external_id"`
+	// Name is the name of the meeting, usually the name of the venue.
+	Name string `json:"name"`
+	// Timezone is the IANA timezone of the venue, for example
+	// "Australia/Melbourne". It is optional.
+	Timezone string `json:"timezone"`
+}
+
+// Race is a single race of a meeting.
+type Race struct {
+	// AdvertisedStartTime is the time the race is advertised to run.
+	AdvertisedStartTime time.Time `json:"advertised_start_time"`
+	// ExternalID is the ID of the race assigned by the provider.
+	ExternalID string `json:"external_id"`
+	// MeetingExternalID is the ID of the meeting of the race assigned by the
+	// provider. The meeting must be either part of the same feed or ingested
+	// from the same provider before.
+	MeetingExternalID string `json:"meeting_external_id"`
+	// Name is the official name of the race.
+	Name string `json:"name"`
+	// Number is the number of the race within its meeting.
+	Number int64 `json:"number"`
+	// Visible indicates whether the race is visible to customers.
+	Visible bool `json:"visible"`
+}
+
+// Event is a sports event.
+type Event struct {
+	// AdvertisedStartTime is the time the event is advertised to run.
+	AdvertisedStartTime time.Time `json:"advertised_start_time"`
+	// ExternalID is the ID of the event assigned by the provider.
+	ExternalID string `json:"external_id"`
+	// Name is the official name of the event, for example "Team A vs Team B".
+	Name string `json:"name"`
+	// Category is the name of the category of the event as defined by the
+	// sportsapi.Event_Category enum, for example "SOCCER".
+	Category string `json:"category"`
+	// Competition is the name of the competition the event is part of.
+	Competition string `json:"competition"`
+	// CompetitionTimezone is the IANA timezone of the venue of the
+	// competition, for example "Australia/Melbourne". It is optional and
+	// ignored if the competition is not set.
+	CompetitionTimezone string `json:"competition_timezone"`
+	// Visible indicates whether the event is visible to customers.
+	Visible bool `json:"visible"`
+}
+
+// GetMeetings returns the meetings of the feed. It is safe to call on a nil
+// feed.
+func (f *Feed) GetMeetings() []Meeting {
+	if f == nil {
+		return nil
+	}
+	return f.Meetings
+}
+
+// GetRaces returns the races of the feed. It is safe to call on a nil feed.
+func (f *Feed) GetRaces() []Race {
+	if f == nil {
+		return nil
+	}
+	return f.Races
+}
+
+// GetEvents returns the events of the feed. It is safe to call on a nil feed.
+func (f *Feed) GetEvents() []Event {
+	if f == nil {
+		return nil
+	}
+	return f.Events
+}
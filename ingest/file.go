@@ -0,0 +1,40 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+)
+
+// FileProvider is a provider that reads feeds from a JSON file in the Feed
+// format. The file is read again on every fetch, so it can be updated in
+// place.
+type FileProvider struct {
+	// ProviderName is the name of the provider.
+	ProviderName string
+	// Path is the path to the JSON file.
+	Path string
+}
+
+// Make sure FileProvider implements the Provider interface.
+var _ Provider = (*FileProvider)(nil)
+
+// Name returns the name of the provider.
+func (p *FileProvider) Name() string {
+	return p.ProviderName
+}
+
+// Fetch reads the feed from the file.
+func (p *FileProvider) Fetch(context.Context) (*Feed, error) {
+	raw, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var feed Feed
+	if err := json.Unmarshal(raw, &feed); err != nil {
+		return nil, err
+	}
+
+	return &feed, nil
+}
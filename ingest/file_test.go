@@ -0,0 +1,48 @@
+package ingest_test
+
+import (
+	"testing"
+
+	. "github.com/danilvpetrov/entain/ingest"
+)
+
+func TestFileProvider(t *testing.T) {
+	t.Run("reads feed from file", func(t *testing.T) {
+		p := &FileProvider{
+			ProviderName: "<provider>",
+			Path:         "testdata/feed.json",
+		}
+
+		if p.Name() != "<provider>" {
+			t.Fatalf("expected name %q, got %q", "<provider>", p.Name())
+		}
+
+		feed, err := p.Fetch(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(feed.Meetings) != 2 || len(feed.Races) != 2 || len(feed.Events) != 1 {
+			t.Fatalf("unexpected feed %+v", feed)
+		}
+
+		race := feed.Races[0]
+		if race.ExternalID != "R1" ||
+			race.MeetingExternalID != "M1" ||
+			race.Number != 7 ||
+			race.AdvertisedStartTime.IsZero() {
+			t.Fatalf("unexpected race %+v", race)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		p := &FileProvider{
+			ProviderName: "<provider>",
+			Path:         "testdata/missing.json",
+		}
+
+		if _, err := p.Fetch(t.Context()); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
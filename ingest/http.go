@@ -0,0 +1,79 @@
+package ingest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+)
+
+// HTTPProvider is a provider that fetches feeds from an HTTP endpoint.
+type HTTPProvider struct {
+	// Client is the HTTP client used to fetch the feeds. If it is nil,
+	// http.DefaultClient is used.
+	Client *http.Client
+
+	// Decode decodes the body of a response into a feed. It allows adapting
+	// the provider to the format of a third-party feed. If it is nil, the body
+	// is decoded as JSON in the Feed format.
+	Decode func(r io.Reader) (*Feed, error)
+
+	// ProviderName is the name of the provider.
+	ProviderName string
+	// URL is the URL of the feed, fetched with a GET request.
+	URL string
+}
+
+// Make sure HTTPProvider implements the Provider interface.
+var _ Provider = (*HTTPProvider)(nil)
+
+// Name returns the name of the provider.
+func (p *HTTPProvider) Name() string {
+	return p.ProviderName
+}
+
+// Fetch fetches the feed from the URL.
+func (p *HTTPProvider) Fetch(ctx context.Context) (*Feed, error) {
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		p.URL,
+		http.NoBody,
+	)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Error("failed to close response body", slog.Any("error", err))
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status: %s", resp.Status)
+	}
+
+	if p.Decode != nil {
+		return p.Decode(resp.Body)
+	}
+
+	var feed Feed
+	if err := json.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, err
+	}
+
+	return &feed, nil
+}
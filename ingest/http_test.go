@@ -0,0 +1,119 @@
+package ingest_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	. "github.com/danilvpetrov/entain/ingest"
+)
+
+func TestHTTPProvider(t *testing.T) {
+	feed, err := os.ReadFile("testdata/feed.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /feed", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(feed)
+	})
+	mux.HandleFunc("GET /unavailable", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("GET /third-party", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"fixtures":[{"id":"X1","title":"A vs B"}]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	cases := []struct {
+		assertion func(t *testing.T, feed *Feed, err error)
+		provider  *HTTPProvider
+		name      string
+	}{
+		{
+			name: "fetches feed",
+			provider: &HTTPProvider{
+				ProviderName: "<provider>",
+				URL:          server.URL + "/feed",
+			},
+			assertion: func(t *testing.T, feed *Feed, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if len(feed.Meetings) != 2 || len(feed.Races) != 2 || len(feed.Events) != 1 {
+					t.Fatalf("unexpected feed %+v", feed)
+				}
+
+				if feed.Events[0].ExternalID != "E1" {
+					t.Fatalf("unexpected event %+v", feed.Events[0])
+				}
+			},
+		},
+		{
+			name: "unexpected response status",
+			provider: &HTTPProvider{
+				ProviderName: "<provider>",
+				URL:          server.URL + "/unavailable",
+			},
+			assertion: func(t *testing.T, _ *Feed, err error) {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+			},
+		},
+		{
+			name: "decodes third-party format",
+			provider: &HTTPProvider{
+				Client:       server.Client(),
+				ProviderName: "<provider>",
+				URL:          server.URL + "/third-party",
+				Decode: func(r io.Reader) (*Feed, error) {
+					var resp struct {
+						Fixtures []struct {
+							ID    string `json:"id"`
+							Title string `json:"title"`
+						} `json:"fixtures"`
+					}
+					if err := json.NewDecoder(r).Decode(&resp); err != nil {
+						return nil, err
+					}
+
+					feed := &Feed{}
+					for _, f := range resp.Fixtures {
+						feed.Events = append(feed.Events, Event{
+							ExternalID: f.ID,
+							Name:       f.Title,
+						})
+					}
+					return feed, nil
+				},
+			},
+			assertion: func(t *testing.T, feed *Feed, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if len(feed.Events) != 1 ||
+					feed.Events[0].ExternalID != "X1" ||
+					feed.Events[0].Name != "A vs B" {
+					t.Fatalf("unexpected feed %+v", feed)
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			feed, err := c.provider.Fetch(t.Context())
+			c.assertion(t, feed, err)
+		})
+	}
+}
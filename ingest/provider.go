@@ -0,0 +1,26 @@
+package ingest
+
+import "context"
+
+// Provider is a source of races, meetings and sports events.
+type Provider interface {
+	// Name returns the name of the provider. The name must be unique and must
+	// never change, as the ingested entities are identified by the name of
+	// their provider and their external IDs.
+	Name() string
+
+	// Fetch returns a snapshot of the entities currently offered by the
+	// provider.
+	Fetch(ctx context.Context) (*Feed, error)
+}
+
+// Sink stores ingested entities.
+type Sink interface {
+	// Upsert stores the entities of a feed fetched from the provider with the
+	// given name. Entities that have been ingested from the same provider
+	// before are updated, the other ones are inserted.
+	//
+	// A sink stores only the kinds of entities it is responsible for and
+	// ignores the other ones.
+	Upsert(ctx context.Context, provider string, feed *Feed) error
+}
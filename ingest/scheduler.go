@@ -0,0 +1,168 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Source is a provider polled by a Scheduler at a fixed interval.
+type Source struct {
+	// Provider is the provider to poll.
+	Provider Provider
+	// Interval is the interval between the polls.
+	Interval time.Duration
+}
+
+// Scheduler polls providers and stores the fetched entities in sinks. Every
+// poll is recorded as an ingestion run.
+type Scheduler struct {
+	// DB is a database connection pool used to record the ingestion runs. The
+	// schema of the database must be applied with ApplySchema.
+	DB *sql.DB
+
+	// Sources is a list of providers to poll.
+	Sources []Source
+	// Sinks is a list of sinks the fetched entities are stored in.
+	Sinks []Sink
+}
+
+// Run polls every source right away and then at its interval, until the
+// context is cancelled. Failed polls are logged and retried at the next
+// interval.
+func (s *Scheduler) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+
+	for _, src := range s.Sources {
+		wg.Go(func() {
+			s.poll(ctx, src)
+		})
+	}
+
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// poll polls a source until the context is cancelled.
+func (s *Scheduler) poll(ctx context.Context, src Source) {
+	ticker := time.NewTicker(src.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.RunOnce(ctx, src.Provider); err != nil {
+			slog.ErrorContext(
+				ctx,
+				"ingestion run failed",
+				slog.String("provider", src.Provider.Name()),
+				slog.Any("error", err),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce fetches the entities from a provider and stores them in all sinks,
+// recording the ingestion run.
+func (s *Scheduler) RunOnce(ctx context.Context, p Provider) error {
+	runID, err := s.startRun(ctx, p.Name())
+	if err != nil {
+		return fmt.Errorf("error recording ingestion run: %w", err)
+	}
+
+	feed, err := p.Fetch(ctx)
+	if err != nil {
+		err = fmt.Errorf("error fetching feed: %w", err)
+	} else {
+		err = s.upsert(ctx, p.Name(), feed)
+	}
+
+	// The run is recorded even if it has been interrupted by the context
+	// cancellation.
+	if ferr := s.finishRun(
+		context.WithoutCancel(ctx),
+		runID,
+		feed,
+		err,
+	); ferr != nil {
+		return errors.Join(
+			err,
+			fmt.Errorf("error recording ingestion run: %w", ferr),
+		)
+	}
+
+	return err
+}
+
+// upsert stores a feed in all sinks. A failure of one sink does not prevent
+// the feed from being stored in the other ones.
+func (s *Scheduler) upsert(
+	ctx context.Context,
+	provider string,
+	feed *Feed,
+) error {
+	var errs []error
+
+	for _, sink := range s.Sinks {
+		if err := sink.Upsert(ctx, provider, feed); err != nil {
+			errs = append(errs, fmt.Errorf("error storing feed: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// startRun records the start of an ingestion run and returns its ID.
+func (s *Scheduler) startRun(
+	ctx context.Context,
+	provider string,
+) (int64, error) {
+	res, err := s.DB.ExecContext(
+		ctx,
+		`INSERT INTO ingestion_runs (provider, started_at) VALUES (?, ?)`,
+		provider,
+		time.Now().UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.LastInsertId()
+}
+
+// finishRun records the outcome of an ingestion run.
+func (s *Scheduler) finishRun(
+	ctx context.Context,
+	runID int64,
+	feed *Feed,
+	runErr error,
+) error {
+	var errMsg sql.Null[string]
+	if runErr != nil {
+		errMsg.V, errMsg.Valid = runErr.Error(), true
+	}
+
+	_, err := s.DB.ExecContext(
+		ctx,
+		`UPDATE ingestion_runs
+		SET finished_at = ?, meetings = ?, races = ?, events = ?, error = ?
+		WHERE id = ?`,
+		time.Now().UTC().Format(time.RFC3339Nano),
+		len(feed.GetMeetings()),
+		len(feed.GetRaces()),
+		len(feed.GetEvents()),
+		errMsg,
+		runID,
+	)
+
+	return err
+}
@@ -0,0 +1,258 @@
+package ingest_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/danilvpetrov/entain/ingest"
+	_ "github.com/mattn/go-sqlite3" // underscore import for the SQLite driver
+)
+
+// stubProvider is a provider returning a fixed feed or error.
+type stubProvider struct {
+	feed *Feed
+	err  error
+	name string
+}
+
+func (p *stubProvider) Name() string {
+	return p.name
+}
+
+func (p *stubProvider) Fetch(context.Context) (*Feed, error) {
+	return p.feed, p.err
+}
+
+// recordingSink is a sink that records the feeds it receives.
+type recordingSink struct {
+	err       error
+	providers []string
+	mu        sync.Mutex
+}
+
+func (s *recordingSink) Upsert(_ context.Context, provider string, _ *Feed) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.providers = append(s.providers, provider)
+	return s.err
+}
+
+func (s *recordingSink) calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.providers)
+}
+
+// run is an ingestion run as recorded in the database.
+type run struct {
+	err      sql.Null[string]
+	provider string
+	finished bool
+	events   int
+}
+
+// setupDatabase is a test helper that sets up a database to record the
+// ingestion runs in.
+func setupDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every connection to an in-memory database opens a new database.
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if err := ApplySchema(t.Context(), db); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+// loadRuns is a test helper that returns the recorded ingestion runs.
+func loadRuns(t *testing.T, db *sql.DB) []run {
+	t.Helper()
+
+	rows, err := db.QueryContext(
+		t.Context(),
+		`SELECT provider, finished_at IS NOT NULL, events, error
+		FROM ingestion_runs
+		ORDER BY id`,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var runs []run
+	for rows.Next() {
+		var r run
+		if err := rows.Scan(&r.provider, &r.finished, &r.events, &r.err); err != nil {
+			t.Fatal(err)
+		}
+		runs = append(runs, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return runs
+}
+
+func TestSchedulerRunOnce(t *testing.T) {
+	feed := &Feed{
+		Events: []Event{{ExternalID: "E1"}, {ExternalID: "E2"}},
+	}
+
+	cases := []struct {
+		provider  *stubProvider
+		sinkErr   error
+		assertion func(t *testing.T, runs []run, sinks []*recordingSink, err error)
+		name      string
+	}{
+		{
+			name:     "stores feed in all sinks",
+			provider: &stubProvider{name: "<provider>", feed: feed},
+			assertion: func(t *testing.T, runs []run, sinks []*recordingSink, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				for _, s := range sinks {
+					if s.calls() != 1 {
+						t.Fatalf("expected 1 call to each sink, got %d", s.calls())
+					}
+				}
+
+				if len(runs) != 1 ||
+					runs[0].provider != "<provider>" ||
+					!runs[0].finished ||
+					runs[0].events != 2 ||
+					runs[0].err.Valid {
+					t.Fatalf("unexpected runs %+v", runs)
+				}
+			},
+		},
+		{
+			name: "failed fetch",
+			provider: &stubProvider{
+				name: "<provider>",
+				err:  errors.New("<error>"),
+			},
+			assertion: func(t *testing.T, runs []run, sinks []*recordingSink, err error) {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				for _, s := range sinks {
+					if s.calls() != 0 {
+						t.Fatalf("expected no calls to sinks, got %d", s.calls())
+					}
+				}
+
+				if len(runs) != 1 || !runs[0].finished || !runs[0].err.Valid {
+					t.Fatalf("expected failed run to be recorded, got %+v", runs)
+				}
+			},
+		},
+		{
+			name:     "failed sink",
+			provider: &stubProvider{name: "<provider>", feed: feed},
+			sinkErr:  errors.New("<error>"),
+			assertion: func(t *testing.T, runs []run, sinks []*recordingSink, err error) {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+
+				// A failing sink does not prevent the other sinks from
+				// receiving the feed.
+				if sinks[1].calls() != 1 {
+					t.Fatalf("expected 1 call to the second sink, got %d", sinks[1].calls())
+				}
+
+				if len(runs) != 1 || !runs[0].err.Valid {
+					t.Fatalf("expected failed run to be recorded, got %+v", runs)
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			db := setupDatabase(t)
+			sinks := []*recordingSink{{err: c.sinkErr}, {}}
+
+			s := &Scheduler{
+				DB:    db,
+				Sinks: []Sink{sinks[0], sinks[1]},
+			}
+
+			err := s.RunOnce(t.Context(), c.provider)
+			c.assertion(t, loadRuns(t, db), sinks, err)
+		})
+	}
+}
+
+func TestSchedulerRun(t *testing.T) {
+	db := setupDatabase(t)
+	sink := &recordingSink{}
+
+	s := &Scheduler{
+		DB: db,
+		Sources: []Source{
+			{
+				Provider: &stubProvider{name: "<provider-1>", feed: &Feed{}},
+				Interval: 10 * time.Millisecond,
+			},
+			{
+				Provider: &stubProvider{name: "<provider-2>", feed: &Feed{}},
+				Interval: time.Hour,
+			},
+		},
+		Sinks: []Sink{sink},
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() {
+		done <- s.Run(ctx)
+	}()
+
+	// The first provider is polled repeatedly, the second one only once
+	// right away.
+	deadline := time.After(5 * time.Second)
+	for sink.calls() < 4 {
+		select {
+		case <-deadline:
+			t.Fatalf("expected at least 4 polls, got %d", sink.calls())
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %v error, got %v", context.Canceled, err)
+	}
+
+	counts := map[string]int{}
+	for _, r := range loadRuns(t, db) {
+		counts[r.provider]++
+	}
+
+	if counts["<provider-1>"] < 3 || counts["<provider-2>"] != 1 {
+		t.Fatalf("unexpected number of runs per provider %v", counts)
+	}
+}
@@ -0,0 +1,20 @@
+package ingest
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+)
+
+//go:embed schema.sql
+var schema string
+
+// ApplySchema applies the schema of the database the ingestion runs are
+// recorded in.
+func ApplySchema(
+	ctx context.Context,
+	db *sql.DB,
+) error {
+	_, err := db.ExecContext(ctx, schema)
+	return err
+}
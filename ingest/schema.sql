@@ -0,0 +1,14 @@
+-- ingestion_runs table to record every poll of a provider
+CREATE TABLE IF NOT EXISTS ingestion_runs (
+    id INTEGER PRIMARY KEY,
+    provider TEXT NOT NULL,
+    started_at DATETIME NOT NULL,
+    finished_at DATETIME,
+    meetings INTEGER,
+    races INTEGER,
+    events INTEGER,
+    error TEXT
+);
+
+-- Add an index on provider to optimize query filtering by this column
+CREATE INDEX IF NOT EXISTS idx_ingestion_runs_provider ON ingestion_runs(provider);
@@ -0,0 +1,15 @@
+// Package jurisdiction restricts the races and sports events offered to the
+// clients in some states or countries.
+//
+// The jurisdiction of a request is an ISO 3166 country code, such as "AU", or
+// subdivision code, such as "AU-NSW". It is carried by the "x-jurisdiction"
+// metadata of gRPC requests. The API gateway sets such metadata from a header
+// set by a trusted proxy, or from the address of the client looked up in a
+// local GeoIP database.
+//
+// Rules loaded from a YAML file restrict the sports categories and the race
+// meetings that cannot be offered in jurisdictions. The requests made from
+// unknown jurisdictions are restricted by all rules, unless they are made by
+// admins, which are not restricted at all. The rules are reloaded whenever the
+// file changes.
+package jurisdiction
@@ -0,0 +1,107 @@
+package jurisdiction
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"strings"
+)
+
+// GeoIP maps IP networks to the jurisdictions they are located in.
+type GeoIP struct {
+	// networks maps the IP networks, keyed by their lengths in bits, to the
+	// codes of their jurisdictions.
+	networks map[int]map[netip.Prefix]string
+}
+
+// LoadGeoIP loads a GeoIP database from the CSV file at the given path. Each
+// record of the file holds an IP network in the CIDR notation and the code of
+// the jurisdiction it is located in, for example "203.0.113.0/24,AU-NSW". Any
+// further fields of the records are ignored. The file may start with a
+// "network" header, and lines starting with "#" are comments.
+func LoadGeoIP(path string) (*GeoIP, error) {
+	f, err := os.Open(path) //nolint:gosec // The path is configured.
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseGeoIP(f)
+}
+
+// ParseGeoIP parses a GeoIP database in the form read by LoadGeoIP.
+func ParseGeoIP(r io.Reader) (*GeoIP, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+
+	g := &GeoIP{networks: map[int]map[netip.Prefix]string{}}
+
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return g, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if first && strings.EqualFold(record[0], "network") {
+			continue
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		if len(record) < 2 { //nolint:mnd // Network and jurisdiction.
+			return nil, fmt.Errorf("line %d: jurisdiction is missing", line)
+		}
+
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		code := strings.TrimSpace(record[1])
+		if !IsValid(code) {
+			return nil, fmt.Errorf(
+				"line %d: jurisdiction %q is not valid",
+				line,
+				code,
+			)
+		}
+
+		prefix = prefix.Masked()
+		if g.networks[prefix.Bits()] == nil {
+			g.networks[prefix.Bits()] = map[netip.Prefix]string{}
+		}
+		g.networks[prefix.Bits()][prefix] = code
+	}
+}
+
+// Lookup returns the code of the jurisdiction the given IP address is located
+// in, as per the most specific network containing it. It returns false if no
+// network contains the address.
+func (g *GeoIP) Lookup(addr netip.Addr) (string, bool) {
+	addr = addr.Unmap()
+
+	for bits := addr.BitLen(); bits >= 0; bits-- {
+		networks, ok := g.networks[bits]
+		if !ok {
+			continue
+		}
+
+		prefix, err := addr.Prefix(bits)
+		if err != nil {
+			return "", false
+		}
+
+		if code, ok := networks[prefix]; ok {
+			return code, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,67 @@
+package jurisdiction_test
+
+import (
+	"net/netip"
+	"strings"
+	"testing"
+
+	. "github.com/danilvpetrov/entain/jurisdiction"
+)
+
+func TestGeoIP(t *testing.T) {
+	g, err := ParseGeoIP(strings.NewReader(
+		`network,jurisdiction
+# Documentation networks.
+203.0.113.0/24,AU
+203.0.113.128/25,AU-NSW,<ignored>
+2001:db8::/32,NZ
+`,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		addr     string
+		expected string
+	}{
+		{addr: "203.0.113.1", expected: "AU"},
+		{addr: "203.0.113.200", expected: "AU-NSW"},
+		{addr: "::ffff:203.0.113.1", expected: "AU"},
+		{addr: "2001:db8::1", expected: "NZ"},
+		{addr: "198.51.100.1"},
+		{addr: "2001:db9::1"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.addr, func(t *testing.T) {
+			actual, ok := g.Lookup(netip.MustParseAddr(c.addr))
+			if ok != (c.expected != "") {
+				t.Fatalf("expected found to be %v", c.expected != "")
+			}
+
+			if actual != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseGeoIPInvalid(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+	}{
+		{name: "missing jurisdiction", input: "203.0.113.0/24\n"},
+		{name: "invalid network", input: "203.0.113.0,AU\n"},
+		{name: "invalid jurisdiction", input: "203.0.113.0/24,Australia\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := ParseGeoIP(strings.NewReader(c.input)); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package jurisdiction
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/danilvpetrov/entain/admin"
+	"github.com/danilvpetrov/entain/apierror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey is the name of the gRPC metadata key that carries the
+// jurisdiction a request is made from.
+const MetadataKey = "x-jurisdiction"
+
+// ReasonRestricted is the reason of the errors returned when the requested
+// entity cannot be offered in the jurisdiction of the request.
+const ReasonRestricted = "RESTRICTED_IN_JURISDICTION"
+
+// Unrestricted is the code of the pseudo-jurisdiction no rules apply to. It is
+// not a valid jurisdiction code, so the requests cannot name it. The requests
+// made by admins without naming a jurisdiction are made from it, and the
+// services use it to read the entities regardless of the restrictions.
+const Unrestricted = "*"
+
+// pattern is the pattern of valid jurisdiction codes.
+var pattern = regexp.MustCompile(`^[A-Z]{2}(-[A-Z0-9]{1,3})?$`)
+
+// IsValid returns true if the given string is a valid jurisdiction code.
+// Jurisdiction codes are ISO 3166-1 alpha-2 country codes, such as "AU", or
+// ISO 3166-2 subdivision codes, such as "AU-NSW", in upper case.
+func IsValid(code string) bool {
+	return pattern.MatchString(code)
+}
+
+// Contains returns true if the jurisdiction with the given code is within the
+// jurisdiction with the code c. A country contains itself and all of its
+// subdivisions, a subdivision only contains itself.
+func Contains(c, code string) bool {
+	if c == code {
+		return true
+	}
+
+	country, _, ok := strings.Cut(code, "-")
+	return ok && c == country
+}
+
+// Restricted returns an error with codes.PermissionDenied code and the
+// ReasonRestricted reason, indicating that the requested entity cannot be
+// offered in the jurisdiction of the request.
+func Restricted(ctx context.Context, msg string) error {
+	return apierror.PermissionDenied(ctx, ReasonRestricted, msg)
+}
+
+// contextKey is the type of the key of the jurisdiction code in a context.
+type contextKey struct{}
+
+// Code returns the code of the jurisdiction the request of the given context
+// is made from, or an empty string if the jurisdiction is unknown.
+func Code(ctx context.Context) string {
+	code, _ := ctx.Value(contextKey{}).(string)
+	return code
+}
+
+// WithCode returns a copy of the given context with the request made from the
+// jurisdiction with the given code. If the code is empty, the jurisdiction of
+// the request is unknown, and if it is Unrestricted, no rules apply to it.
+func WithCode(ctx context.Context, code string) context.Context {
+	return context.WithValue(ctx, contextKey{}, code)
+}
+
+// UnaryServerInterceptor returns a gRPC server interceptor that marks the
+// requests bearing the "x-jurisdiction" metadata as made from the jurisdiction
+// it names. Requests naming an invalid jurisdiction fail with
+// codes.InvalidArgument. Requests made by admins without the metadata are
+// marked as Unrestricted, so the interceptor must follow the admin
+// interceptor.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		ctx, err := withJurisdiction(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC server interceptor that marks the
+// streaming calls bearing the "x-jurisdiction" metadata as made from the
+// jurisdiction it names. Calls naming an invalid jurisdiction fail with
+// codes.InvalidArgument. Calls made by admins without the metadata are marked
+// as Unrestricted, so the interceptor must follow the admin interceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		_ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := withJurisdiction(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// withJurisdiction returns a context marked as made from the jurisdiction
+// named by the incoming metadata of the given context, if any, or from the
+// Unrestricted jurisdiction if the request is made by an admin.
+func withJurisdiction(ctx context.Context) (context.Context, error) {
+	values := metadata.ValueFromIncomingContext(ctx, MetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		if admin.IsAdmin(ctx) {
+			return WithCode(ctx, Unrestricted), nil
+		}
+		return ctx, nil
+	}
+
+	code := values[0]
+	if len(values) > 1 || !IsValid(code) {
+		return nil, apierror.InvalidArgument(
+			ctx,
+			"invalid jurisdiction",
+			apierror.FieldViolation{
+				Field: MetadataKey,
+				Description: "a single ISO 3166 country or subdivision code " +
+					"is required",
+			},
+		)
+	}
+
+	return WithCode(ctx, code), nil
+}
+
+// serverStream is a grpc.ServerStream with a replaced context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
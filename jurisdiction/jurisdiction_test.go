@@ -0,0 +1,132 @@
+package jurisdiction_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/danilvpetrov/entain/admin"
+	. "github.com/danilvpetrov/entain/jurisdiction"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	cases := []struct {
+		md       metadata.MD
+		name     string
+		expected string
+		invalid  bool
+	}{
+		{
+			name:     "country",
+			md:       metadata.Pairs(MetadataKey, "AU"),
+			expected: "AU",
+		},
+		{
+			name:     "subdivision",
+			md:       metadata.Pairs(MetadataKey, "AU-NSW"),
+			expected: "AU-NSW",
+		},
+		{
+			name: "missing jurisdiction",
+			md:   metadata.MD{},
+		},
+		{
+			name: "empty jurisdiction",
+			md:   metadata.Pairs(MetadataKey, ""),
+		},
+		{
+			name:     "admin",
+			md:       metadata.Pairs("authorization", "Bearer <token>"),
+			expected: Unrestricted,
+		},
+		{
+			name: "admin in a jurisdiction",
+			md: metadata.Pairs(
+				"authorization", "Bearer <token>",
+				MetadataKey, "AU",
+			),
+			expected: "AU",
+		},
+		{
+			name:    "invalid jurisdiction",
+			md:      metadata.Pairs(MetadataKey, "au-nsw"),
+			invalid: true,
+		},
+		{
+			name: "multiple jurisdictions",
+			md: metadata.Pairs(
+				MetadataKey, "AU",
+				MetadataKey, "NZ",
+			),
+			invalid: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			interceptor := UnaryServerInterceptor()
+
+			var actual string
+			// The requests bearing the token are made by an admin.
+			_, err := admin.UnaryServerInterceptor(
+				admin.Tokens{"<token>": "<admin>"},
+			)(
+				metadata.NewIncomingContext(t.Context(), c.md),
+				nil,
+				&grpc.UnaryServerInfo{},
+				func(ctx context.Context, req any) (any, error) {
+					return interceptor(
+						ctx,
+						req,
+						&grpc.UnaryServerInfo{},
+						func(ctx context.Context, _ any) (any, error) {
+							actual = Code(ctx)
+							return nil, nil
+						},
+					)
+				},
+			)
+
+			if c.invalid {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("expected invalid argument error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if actual != c.expected {
+				t.Fatalf("expected jurisdiction %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	cases := []struct {
+		c        string
+		code     string
+		expected bool
+	}{
+		{c: "AU", code: "AU", expected: true},
+		{c: "AU", code: "AU-NSW", expected: true},
+		{c: "AU-NSW", code: "AU-NSW", expected: true},
+		{c: "AU-NSW", code: "AU", expected: false},
+		{c: "AU-NSW", code: "AU-VIC", expected: false},
+		{c: "AU", code: "NZ", expected: false},
+		{c: "A", code: "AU", expected: false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.c+" contains "+c.code, func(t *testing.T) {
+			if actual := Contains(c.c, c.code); actual != c.expected {
+				t.Fatalf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package jurisdiction
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"go.yaml.in/yaml/v3"
+)
+
+// DefaultReloadInterval is the default interval between checks of the rules
+// file for changes.
+const DefaultReloadInterval = 10 * time.Second
+
+// Rule restricts sports categories and race meetings in jurisdictions.
+type Rule struct {
+	// Jurisdictions is the list of the codes of the jurisdictions the rule
+	// applies to. A country code applies to all subdivisions of the country.
+	Jurisdictions []string `yaml:"jurisdictions"`
+
+	// Categories is the list of the names of the sports categories that
+	// cannot be offered in the jurisdictions, for example "POLITICS".
+	Categories []string `yaml:"categories"`
+
+	// Meetings is the list of the IDs of the race meetings that cannot be
+	// offered in the jurisdictions.
+	Meetings []int64 `yaml:"meetings"`
+}
+
+// ParseRules parses rules from a YAML document in the following form:
+//
+//	rules:
+//	  - jurisdictions: [US, AU-SA]
+//	    categories: [POLITICS, NOVELTY]
+//	  - jurisdictions: [NZ]
+//	    meetings: [5, 8]
+func ParseRules(data []byte) ([]Rule, error) {
+	var doc struct {
+		Rules []Rule `yaml:"rules"`
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	for i, r := range doc.Rules {
+		if err := validateRule(r); err != nil {
+			return nil, fmt.Errorf("rule #%d is not valid: %w", i+1, err)
+		}
+	}
+
+	return doc.Rules, nil
+}
+
+// validateRule returns an error if the given rule is not valid.
+func validateRule(r Rule) error {
+	if len(r.Jurisdictions) == 0 {
+		return errors.New("no jurisdictions")
+	}
+
+	if len(r.Categories) == 0 && len(r.Meetings) == 0 {
+		return errors.New("no categories or meetings")
+	}
+
+	for _, code := range r.Jurisdictions {
+		if !IsValid(code) {
+			return fmt.Errorf("jurisdiction %q is not valid", code)
+		}
+	}
+
+	for _, name := range r.Categories {
+		if v, ok := sportsapi.Event_Category_value[name]; !ok || v == 0 {
+			return fmt.Errorf("category %q is not valid", name)
+		}
+	}
+
+	for _, id := range r.Meetings {
+		if id <= 0 {
+			return fmt.Errorf("meeting ID %d is not valid", id)
+		}
+	}
+
+	return nil
+}
+
+// Rules is a set of rules loaded from a YAML file in the form accepted by
+// ParseRules. The rules are reloaded by Run whenever the file changes. A nil
+// *Rules restricts nothing.
+type Rules struct {
+	// Path is the path to the file the rules are loaded from.
+	Path string
+
+	// Interval is the interval between checks of the file for changes. If it
+	// is zero, DefaultReloadInterval is used.
+	Interval time.Duration
+
+	// m guards modTime and size.
+	m       sync.Mutex
+	modTime time.Time
+	size    int64
+
+	rules atomic.Pointer[[]Rule]
+}
+
+// Load loads the rules from the file, unless the file has not changed since
+// it was last loaded. It returns true if the rules have been loaded. The rules
+// are kept unchanged if it fails, and an invalid file is not loaded again
+// until it changes.
+func (r *Rules) Load() (bool, error) {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	info, err := os.Stat(r.Path)
+	if err != nil {
+		return false, err
+	}
+
+	if r.rules.Load() != nil &&
+		info.ModTime().Equal(r.modTime) &&
+		info.Size() == r.size {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(r.Path) //nolint:gosec // The path is configured.
+	if err != nil {
+		return false, err
+	}
+
+	r.modTime = info.ModTime()
+	r.size = info.Size()
+
+	rules, err := ParseRules(data)
+	if err != nil {
+		return false, err
+	}
+
+	r.rules.Store(&rules)
+
+	return true, nil
+}
+
+// Run reloads the rules whenever the file changes, until the context is
+// cancelled. Failed reloads are logged, and the previously loaded rules stay
+// in effect until the file is changed again.
+func (r *Rules) Run(ctx context.Context) error {
+	interval := r.Interval
+	if interval == 0 {
+		interval = DefaultReloadInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		loaded, err := r.Load()
+		if err != nil {
+			slog.ErrorContext(
+				ctx,
+				"failed reloading jurisdiction rules",
+				slog.String("path", r.Path),
+				slog.Any("error", err),
+			)
+			continue
+		}
+
+		if loaded {
+			slog.InfoContext(
+				ctx,
+				"reloaded jurisdiction rules",
+				slog.String("path", r.Path),
+			)
+		}
+	}
+}
+
+// Categories returns the names of the sports categories that cannot be
+// offered in the jurisdiction with the given code. If the code is empty, the
+// jurisdiction is unknown and the categories restricted in any jurisdiction
+// are returned.
+func (r *Rules) Categories(code string) []string {
+	var categories []string
+	for _, rule := range r.matching(code) {
+		categories = append(categories, rule.Categories...)
+	}
+	return categories
+}
+
+// Meetings returns the IDs of the race meetings that cannot be offered in the
+// jurisdiction with the given code. If the code is empty, the jurisdiction is
+// unknown and the meetings restricted in any jurisdiction are returned.
+func (r *Rules) Meetings(code string) []int64 {
+	var meetings []int64
+	for _, rule := range r.matching(code) {
+		meetings = append(meetings, rule.Meetings...)
+	}
+	return meetings
+}
+
+// matching returns the rules applying to the jurisdiction with the given
+// code. All rules apply if the code is empty, so that the requests made from
+// unknown jurisdictions are restricted the most, and no rules apply if it is
+// Unrestricted.
+func (r *Rules) matching(code string) []Rule {
+	if r == nil || code == Unrestricted {
+		return nil
+	}
+
+	rules := r.rules.Load()
+	if rules == nil {
+		return nil
+	}
+
+	if code == "" {
+		return *rules
+	}
+
+	var matching []Rule
+	for _, rule := range *rules {
+		for _, c := range rule.Jurisdictions {
+			if Contains(c, code) {
+				matching = append(matching, rule)
+				break
+			}
+		}
+	}
+
+	return matching
+}
@@ -0,0 +1,203 @@
+package jurisdiction_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	. "github.com/danilvpetrov/entain/jurisdiction"
+)
+
+func TestRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	writeRules(
+		t,
+		path,
+		`rules:
+  - jurisdictions: [US, AU-SA]
+    categories: [POLITICS, NOVELTY]
+  - jurisdictions: [AU]
+    meetings: [5, 8]
+`,
+	)
+
+	r := &Rules{Path: path}
+	if _, err := r.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		code       string
+		categories []string
+		meetings   []int64
+	}{
+		{code: "US", categories: []string{"POLITICS", "NOVELTY"}},
+		{code: "US-CA", categories: []string{"POLITICS", "NOVELTY"}},
+		{
+			code:       "AU-SA",
+			categories: []string{"POLITICS", "NOVELTY"},
+			meetings:   []int64{5, 8},
+		},
+		{code: "AU-NSW", meetings: []int64{5, 8}},
+		{code: "NZ"},
+		{
+			code:       "",
+			categories: []string{"POLITICS", "NOVELTY"},
+			meetings:   []int64{5, 8},
+		},
+		{code: Unrestricted},
+	}
+
+	for _, c := range cases {
+		t.Run(c.code, func(t *testing.T) {
+			if actual := r.Categories(c.code); !slices.Equal(
+				actual,
+				c.categories,
+			) {
+				t.Fatalf("expected categories %v, got %v", c.categories, actual)
+			}
+
+			if actual := r.Meetings(c.code); !slices.Equal(
+				actual,
+				c.meetings,
+			) {
+				t.Fatalf("expected meetings %v, got %v", c.meetings, actual)
+			}
+		})
+	}
+
+	t.Run("does not reload unchanged rules", func(t *testing.T) {
+		loaded, err := r.Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if loaded {
+			t.Fatal("expected the rules not to be reloaded")
+		}
+	})
+
+	t.Run("reloads changed rules", func(t *testing.T) {
+		writeRules(
+			t,
+			path,
+			`rules:
+  - jurisdictions: [NZ]
+    categories: [ESPORTS]
+`,
+		)
+
+		loaded, err := r.Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !loaded {
+			t.Fatal("expected the rules to be reloaded")
+		}
+
+		if actual := r.Categories("NZ"); !slices.Equal(
+			actual,
+			[]string{"ESPORTS"},
+		) {
+			t.Fatalf("expected categories [ESPORTS], got %v", actual)
+		}
+
+		if actual := r.Categories("US"); actual != nil {
+			t.Fatalf("expected no categories, got %v", actual)
+		}
+	})
+
+	t.Run("keeps the rules if the file is not valid", func(t *testing.T) {
+		writeRules(t, path, "rules: [{jurisdictions: [NZ]}]\n")
+
+		if _, err := r.Load(); err == nil {
+			t.Fatal("expected an error")
+		}
+
+		// The invalid file is not reported again until it changes.
+		if loaded, err := r.Load(); loaded || err != nil {
+			t.Fatalf("expected the rules not to be reloaded, got %v", err)
+		}
+
+		if actual := r.Categories("NZ"); !slices.Equal(
+			actual,
+			[]string{"ESPORTS"},
+		) {
+			t.Fatalf("expected categories [ESPORTS], got %v", actual)
+		}
+	})
+
+	t.Run("nil rules restrict nothing", func(t *testing.T) {
+		var r *Rules
+		if r.Categories("US") != nil || r.Meetings("AU") != nil {
+			t.Fatal("expected no restrictions")
+		}
+	})
+}
+
+func TestParseRulesInvalid(t *testing.T) {
+	cases := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "no jurisdictions",
+			input: "rules: [{categories: [POLITICS]}]",
+		},
+		{
+			name:  "no restrictions",
+			input: "rules: [{jurisdictions: [US]}]",
+		},
+		{
+			name:  "invalid jurisdiction",
+			input: "rules: [{jurisdictions: [us], categories: [POLITICS]}]",
+		},
+		{
+			name:  "invalid category",
+			input: "rules: [{jurisdictions: [US], categories: [CHESS]}]",
+		},
+		{
+			name: "unspecified category",
+			input: "rules: [{jurisdictions: [US], " +
+				"categories: [UNSPECIFIED_CATEGORY]}]",
+		},
+		{
+			name:  "invalid meeting",
+			input: "rules: [{jurisdictions: [US], meetings: [0]}]",
+		},
+		{
+			name:  "unknown field",
+			input: "rules: [{jurisdictions: [US], codes: [GREYHOUNDS]}]",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := ParseRules([]byte(c.input)); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+// writeRules writes the rules file, making sure its modification time
+// changes even on file systems with a coarse time resolution.
+func writeRules(t *testing.T, path, content string) {
+	t.Helper()
+
+	mtime := time.Now()
+	if info, err := os.Stat(path); err == nil {
+		mtime = info.ModTime().Add(time.Second)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,472 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// maxRequestSize is the maximum size of a message sent by a client.
+	maxRequestSize = 64 << 10
+
+	// writeTimeout is the maximum duration of writing a message to a client.
+	writeTimeout = 10 * time.Second
+
+	// loadBatchSize is the maximum number of races or sports events loaded by
+	// a single BatchGetRaces or BatchGetEvents call. It is the default
+	// maximum batch size of the services.
+	loadBatchSize = 100
+)
+
+var (
+	// errSlowConsumer closes a connection whose client does not receive the
+	// updates as fast as they are published.
+	errSlowConsumer = websocket.CloseError{
+		Code:   websocket.StatusTryAgainLater,
+		Reason: "connection is too slow",
+	}
+
+	// errLoadFailed closes a connection whose updates cannot be loaded from
+	// the services.
+	errLoadFailed = websocket.CloseError{
+		Code:   websocket.StatusTryAgainLater,
+		Reason: "updates cannot be loaded",
+	}
+
+	// errShutdown closes the connections when the gateway is shutting down.
+	errShutdown = websocket.CloseError{
+		Code:   websocket.StatusGoingAway,
+		Reason: "gateway is shutting down",
+	}
+
+	// errBinaryMessage closes a connection whose client sends a binary
+	// message.
+	errBinaryMessage = websocket.CloseError{
+		Code:   websocket.StatusUnsupportedData,
+		Reason: "only text messages are supported",
+	}
+)
+
+// marshaler encodes the races and sports events as the API Gateway does.
+var marshaler = protojson.MarshalOptions{EmitUnpopulated: true}
+
+// request is a message sent by a client.
+type request struct {
+	// Type is the type of the request, either "subscribe" or "unsubscribe".
+	Type string `json:"type"`
+	topicList
+}
+
+// message is a message sent to a client.
+type message struct {
+	Type string `json:"type"`
+	topicList
+	Race               json.RawMessage `json:"race,omitempty"`
+	Event              json.RawMessage `json:"event,omitempty"`
+	PreviousStatus     string          `json:"previousStatus,omitempty"`
+	PreviousMatchState string          `json:"previousMatchState,omitempty"`
+	Message            string          `json:"message,omitempty"`
+	Token              string          `json:"token,omitempty"`
+}
+
+// conn is a WebSocket connection of a client.
+type conn struct {
+	ws     *websocket.Conn
+	racing racingapi.RacingClient
+	sports sportsapi.SportsClient
+	epoch  int64
+
+	// updates is the queue of the updates published to the connection. The
+	// connection is closed when it is full.
+	updates chan update
+	// cancel closes the connection with the given cause.
+	cancel context.CancelCauseFunc
+
+	// m guards the topics and the sequence number, and orders the messages
+	// written to the client, so that the tokens they carry never go back.
+	m      sync.Mutex
+	topics *topics
+	// seq is the sequence number of the last update processed by the
+	// connection.
+	seq int64
+}
+
+// serve serves the connection until it is closed by either side. The context
+// carries the metadata of the calls made to load the updates.
+func (c *conn) serve(
+	ctx context.Context,
+	h *hub,
+	resume *token,
+	pingInterval time.Duration,
+) {
+	ctx, c.cancel = context.WithCancelCause(ctx)
+	defer c.cancel(nil)
+
+	seq, missed, resumed, err := h.join(c, resume)
+	if err != nil {
+		c.close(err)
+		return
+	}
+	defer h.leave(c)
+
+	c.seq = seq
+
+	if !resumed {
+		if err := c.write(ctx, message{Type: "resync"}); err != nil {
+			c.close(err)
+			return
+		}
+	}
+
+	if err := c.acknowledge(ctx); err != nil {
+		c.close(err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		c.cancel(c.receive(ctx))
+	})
+	wg.Go(func() {
+		c.cancel(c.deliver(ctx, missed))
+	})
+	wg.Go(func() {
+		c.cancel(c.heartbeat(ctx, pingInterval))
+	})
+
+	<-ctx.Done()
+	// Closing the connection unblocks the receiving goroutine.
+	c.close(context.Cause(ctx))
+	wg.Wait()
+}
+
+// close closes the connection with the status of the given cause, if it is a
+// websocket.CloseError, or without a close handshake otherwise.
+func (c *conn) close(cause error) {
+	var ce websocket.CloseError
+	if errors.As(cause, &ce) {
+		_ = c.ws.Close(ce.Code, ce.Reason)
+		return
+	}
+	_ = c.ws.CloseNow()
+}
+
+// receive handles the requests of the client until the connection is closed.
+func (c *conn) receive(ctx context.Context) error {
+	for {
+		// The reads are unblocked by closing the connection, rather than by
+		// the context, so that it can be closed with a status.
+		typ, data, err := c.ws.Read(context.WithoutCancel(ctx))
+		if err != nil {
+			return err
+		}
+
+		if typ != websocket.MessageText {
+			return errBinaryMessage
+		}
+
+		if err := c.handle(ctx, data); err != nil {
+			return err
+		}
+	}
+}
+
+// handle handles a request of the client, answering it with the topics the
+// connection is subscribed to, or with an error if the request is invalid.
+// It returns an error if the answer cannot be written.
+func (c *conn) handle(ctx context.Context, data []byte) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	var req request
+	err := json.Unmarshal(data, &req)
+	if err == nil {
+		switch req.Type {
+		case "subscribe":
+			err = c.topics.add(req.topicList)
+		case "unsubscribe":
+			err = c.topics.remove(req.topicList)
+		default:
+			err = fmt.Errorf("unknown request type %q", req.Type)
+		}
+	}
+
+	if err != nil {
+		return c.writeLocked(ctx, message{
+			Type:    "error",
+			Message: fmt.Sprintf("invalid request: %v", err),
+		})
+	}
+
+	return c.acknowledgeLocked(ctx)
+}
+
+// acknowledge sends the topics the connection is subscribed to, along with a
+// token to resume the connection with.
+func (c *conn) acknowledge(ctx context.Context) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.acknowledgeLocked(ctx)
+}
+
+// acknowledgeLocked is acknowledge called with c.m locked.
+func (c *conn) acknowledgeLocked(ctx context.Context) error {
+	return c.writeLocked(ctx, message{
+		Type:      "subscribed",
+		topicList: c.topics.list(),
+		Token:     c.token(c.seq),
+	})
+}
+
+// deliver sends the missed updates, followed by the queued ones, until the
+// context is done.
+func (c *conn) deliver(ctx context.Context, missed []update) error {
+	for batch := range slices.Chunk(missed, loadBatchSize) {
+		if err := c.send(ctx, batch); err != nil {
+			return err
+		}
+	}
+
+	for {
+		var batch []update
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case u := <-c.updates:
+			batch = append(batch, u)
+		}
+
+		// Send the updates queued meanwhile along with the first one, so
+		// that they are loaded together.
+	drain:
+		for len(batch) < loadBatchSize {
+			select {
+			case u := <-c.updates:
+				batch = append(batch, u)
+			default:
+				break drain
+			}
+		}
+
+		if err := c.send(ctx, batch); err != nil {
+			return err
+		}
+	}
+}
+
+// send sends the updates of the topics of the connection. The races and sports
+// events are loaded with the metadata of the connection, so that the client
+// receives them as it would get them from the API Gateway. Those it cannot get
+// are not sent.
+func (c *conn) send(ctx context.Context, batch []update) error {
+	c.m.Lock()
+	var matched []update
+	for _, u := range batch {
+		if c.topics.matches(u) {
+			matched = append(matched, u)
+		}
+	}
+	c.m.Unlock()
+
+	races, events, err := c.load(ctx, matched)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		slog.WarnContext(
+			ctx,
+			"error loading live updates",
+			slog.Any("error", err),
+		)
+		return errLoadFailed
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	for _, u := range matched {
+		msg, ok := updateMessage(u, races, events)
+		if !ok {
+			continue
+		}
+
+		msg.Token = c.token(u.seq)
+		if err := c.writeLocked(ctx, msg); err != nil {
+			return err
+		}
+	}
+
+	c.seq = batch[len(batch)-1].seq
+
+	return nil
+}
+
+// load loads the races and sports events of the updates.
+func (c *conn) load(
+	ctx context.Context,
+	updates []update,
+) (map[int64][]byte, map[int64][]byte, error) {
+	raceIDs := map[int64]struct{}{}
+	eventIDs := map[int64]struct{}{}
+
+	for _, u := range updates {
+		switch u.kind {
+		case raceUpdate:
+			raceIDs[u.id] = struct{}{}
+		case eventUpdate:
+			eventIDs[u.id] = struct{}{}
+		}
+	}
+
+	races := map[int64][]byte{}
+	if len(raceIDs) > 0 {
+		resp, err := c.racing.BatchGetRaces(
+			ctx,
+			&racingapi.BatchGetRacesRequest{
+				RaceId: slices.Sorted(maps.Keys(raceIDs)),
+			},
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting races: %w", err)
+		}
+
+		for _, r := range resp.GetRaces() {
+			if races[r.GetId()], err = marshaler.Marshal(r); err != nil {
+				return nil, nil, fmt.Errorf("error encoding race: %w", err)
+			}
+		}
+	}
+
+	events := map[int64][]byte{}
+	if len(eventIDs) > 0 {
+		resp, err := c.sports.BatchGetEvents(
+			ctx,
+			&sportsapi.BatchGetEventsRequest{
+				EventId: slices.Sorted(maps.Keys(eventIDs)),
+			},
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting sports events: %w", err)
+		}
+
+		for _, e := range resp.GetEvents() {
+			if events[e.GetId()], err = marshaler.Marshal(e); err != nil {
+				return nil, nil, fmt.Errorf(
+					"error encoding sports event: %w",
+					err,
+				)
+			}
+		}
+	}
+
+	return races, events, nil
+}
+
+// updateMessage returns the message of an update of a loaded race or sports
+// event. It returns false if the race or the sports event is not loaded.
+func updateMessage(
+	u update,
+	races, events map[int64][]byte,
+) (message, bool) {
+	switch u.kind {
+	case raceUpdate:
+		r, ok := races[u.id]
+		if !ok {
+			return message{}, false
+		}
+
+		msg := message{Type: "race", Race: r}
+		if u.raceStatus != racingapi.Race_UNSPECIFIED {
+			msg.PreviousStatus = u.raceStatus.String()
+		}
+		return msg, true
+
+	case eventUpdate:
+		e, ok := events[u.id]
+		if !ok {
+			return message{}, false
+		}
+
+		msg := message{Type: "event", Event: e}
+		if u.eventStatus != sportsapi.Event_UNSPECIFIED_STATUS {
+			msg.PreviousStatus = u.eventStatus.String()
+		}
+		if u.matchState != sportsapi.Event_UNSPECIFIED_MATCH_STATE {
+			msg.PreviousMatchState = u.matchState.String()
+		}
+		return msg, true
+	}
+
+	return message{}, false
+}
+
+// heartbeat pings the client at the given interval until the context is done.
+// It returns an error if the client does not answer a ping within the
+// interval.
+func (c *conn) heartbeat(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		pingCtx, cancel := context.WithTimeout(
+			context.WithoutCancel(ctx),
+			interval,
+		)
+		err := c.ws.Ping(pingCtx)
+		cancel()
+
+		if err != nil {
+			return fmt.Errorf("error pinging client: %w", err)
+		}
+	}
+}
+
+// token returns the encoded token resuming the connection after the update
+// with the given sequence number. It must be called with c.m locked.
+func (c *conn) token(seq int64) string {
+	return token{
+		Epoch:  c.epoch,
+		Seq:    seq,
+		Topics: c.topics.list(),
+	}.encode()
+}
+
+// write writes a message to the client.
+func (c *conn) write(ctx context.Context, msg message) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.writeLocked(ctx, msg)
+}
+
+// writeLocked is write called with c.m locked.
+func (c *conn) writeLocked(ctx context.Context, msg message) error {
+	// The writes are not interrupted by closing the connection, which would
+	// close it without a status.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), writeTimeout)
+	defer cancel()
+
+	return wsjson.Write(ctx, c.ws, msg)
+}
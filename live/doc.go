@@ -0,0 +1,29 @@
+// Package live pushes the updates of races and sports events to the clients
+// over WebSocket connections.
+//
+// A client subscribes to the races with given IDs or of given race meetings,
+// and to the sports events with given IDs or of given categories, by sending
+// JSON messages over a single connection:
+//
+//	{"type": "subscribe", "raceIds": [1, 2], "meetingIds": [5]}
+//	{"type": "subscribe", "categories": ["SOCCER"]}
+//	{"type": "unsubscribe", "raceIds": [2]}
+//
+// Every request is answered with a "subscribed" message listing the topics of
+// the connection, or with an "error" message. Every transition of the status
+// of a race, or of the status or the match state of a sports event, is pushed
+// as a "race" or "event" message carrying the race or the sports event along
+// with its previous status. Until the services can push the changes
+// themselves, the transitions are detected by polling the ListRaces and
+// ListEvents RPCs.
+//
+// Every message carries a token the client can reconnect with to resume the
+// connection, restoring its topics and receiving the updates missed while it
+// was disconnected. When they can no longer be replayed, the client receives
+// a "resync" message instead, and must get the current races and sports
+// events again.
+//
+// The clients are pinged to detect broken connections, and the clients that do
+// not receive the updates as fast as they are published are disconnected
+// rather than slowing down the others.
+package live
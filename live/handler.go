@@ -0,0 +1,195 @@
+package live
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/coder/websocket"
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// DefaultPollInterval is the default interval between polls of the
+	// services.
+	DefaultPollInterval = 5 * time.Second
+
+	// DefaultPingInterval is the default interval between pings of the
+	// clients.
+	DefaultPingInterval = 30 * time.Second
+
+	// DefaultQueueSize is the default maximum number of updates queued to a
+	// connection.
+	DefaultQueueSize = 256
+)
+
+// Handler is an HTTP handler serving WebSocket connections that push the
+// updates of races and sports events to the clients.
+//
+// The updates are detected by polling the services with Run, which must be
+// running for the clients to receive any updates.
+type Handler struct {
+	// Racing is a connection to the racing service.
+	Racing grpc.ClientConnInterface
+	// Sports is a connection to the sports service.
+	Sports grpc.ClientConnInterface
+
+	// Context returns the context of the gRPC calls made to load the updates
+	// pushed to a connection, for example one carrying the metadata
+	// forwarded from the headers of the request opening the connection. If it
+	// is nil, the context of the request is used.
+	Context func(context.Context, *http.Request) (context.Context, error)
+
+	// AdminToken is the bearer token of the admin the services are polled as.
+	// The requests of admins are not restricted in any jurisdiction, so that
+	// the transitions of the races and sports events restricted in some
+	// jurisdictions are pushed to the clients in the others. If it is empty,
+	// the services are polled from an unknown jurisdiction, and such
+	// transitions are never detected.
+	AdminToken string
+
+	// OriginPatterns is a list of host patterns of the origins, other than
+	// the host of the request, that the browsers are allowed to open
+	// connections from, for example "*.example.com". The patterns are matched
+	// with path.Match.
+	OriginPatterns []string
+
+	// PollInterval is the interval between polls of the services. If it is
+	// zero, DefaultPollInterval is used.
+	PollInterval time.Duration
+	// PingInterval is the interval between pings of the clients. A client
+	// that does not answer a ping within the interval is disconnected. If it
+	// is zero, DefaultPingInterval is used.
+	PingInterval time.Duration
+	// QueueSize is the maximum number of updates queued to a connection. A
+	// client that lets more updates queue up is disconnected, and can
+	// reconnect to resume receiving them. If it is zero, DefaultQueueSize is
+	// used.
+	QueueSize int
+
+	once sync.Once
+	hub  *hub
+}
+
+// Run polls the services for the transitions of the statuses of races and
+// sports events until the context is cancelled, and pushes them to the
+// connected clients. Failed polls are logged and retried at the next
+// interval. The connections are closed when Run returns.
+func (h *Handler) Run(ctx context.Context) error {
+	hub := h.getHub()
+	defer hub.close()
+
+	pollCtx := ctx
+	if h.AdminToken != "" {
+		pollCtx = metadata.AppendToOutgoingContext(
+			ctx,
+			"authorization",
+			"Bearer "+h.AdminToken,
+		)
+	}
+
+	p := &poller{
+		racing: racingapi.NewRacingClient(h.Racing),
+		sports: sportsapi.NewSportsClient(h.Sports),
+	}
+
+	interval := h.PollInterval
+	if interval == 0 {
+		interval = DefaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		updates, err := p.poll(pollCtx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(
+				ctx,
+				"polling live updates failed",
+				slog.Any("error", err),
+			)
+		}
+
+		if len(updates) > 0 {
+			hub.publish(updates)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ServeHTTP implements http.Handler.
+//
+// The connection is resumed from the token given in the "resume" query
+// parameter, if any.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	t := newTopics()
+
+	var resume *token
+	if s := r.URL.Query().Get("resume"); s != "" {
+		tok, restored, err := decodeToken(s)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		resume, t = &tok, restored
+	}
+
+	ctx := r.Context()
+	if h.Context != nil {
+		var err error
+		if ctx, err = h.Context(ctx, r); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	ws, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		OriginPatterns: h.OriginPatterns,
+	})
+	if err != nil {
+		// Accept has already written the response.
+		return
+	}
+	ws.SetReadLimit(maxRequestSize)
+
+	hub := h.getHub()
+
+	queueSize := h.QueueSize
+	if queueSize == 0 {
+		queueSize = DefaultQueueSize
+	}
+
+	pingInterval := h.PingInterval
+	if pingInterval == 0 {
+		pingInterval = DefaultPingInterval
+	}
+
+	c := &conn{
+		ws:      ws,
+		racing:  racingapi.NewRacingClient(h.Racing),
+		sports:  sportsapi.NewSportsClient(h.Sports),
+		epoch:   hub.epoch,
+		updates: make(chan update, queueSize),
+		topics:  t,
+	}
+	c.serve(ctx, hub, resume, pingInterval)
+}
+
+// getHub returns the hub of the handler.
+func (h *Handler) getHub() *hub {
+	h.once.Do(func() {
+		h.hub = newHub()
+	})
+	return h.hub
+}
@@ -0,0 +1,308 @@
+package live_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/danilvpetrov/entain/jurisdiction"
+	. "github.com/danilvpetrov/entain/live"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestHandler(t *testing.T) {
+	t.Run("pushes the transitions of races", func(t *testing.T) {
+		env := setupEnv(t)
+		c := dial(t, env.serve(t, nil))
+		c.next(t, "subscribed")
+
+		// The seeded meetings may have no open races, so the meeting of the
+		// next race is subscribed to.
+		race := env.nextRace(t, 0)
+		meetingID := strconv.FormatInt(race.GetMeetingId(), 10)
+
+		c.send(t, `{"type": "subscribe", "meetingIds": [`+meetingID+`]}`)
+		ack := c.next(t, "subscribed")
+		if ids, _ := ack["meetingIds"].([]any); len(ids) != 1 {
+			t.Fatalf("expected subscription to meeting %s, got %v", meetingID, ack)
+		}
+
+		env.startRace(race)
+
+		msg := c.next(t, "race")
+		got, _ := msg["race"].(map[string]any)
+
+		if id := strconv.FormatInt(race.GetId(), 10); got["id"] != id {
+			t.Fatalf("expected race %s, got %v", id, got["id"])
+		}
+
+		if got["status"] != "CLOSED" || msg["previousStatus"] != "OPEN" {
+			t.Fatalf("expected race to close, got %v", msg)
+		}
+
+		if tok, _ := msg["token"].(string); tok == "" {
+			t.Fatal("expected resume token")
+		}
+	})
+
+	t.Run("pushes the transitions of sports events", func(t *testing.T) {
+		env := setupEnv(t)
+		c := dial(t, env.serve(t, nil))
+		c.next(t, "subscribed")
+
+		event := env.nextEvent(t)
+		category := event.GetCategory().String()
+
+		c.send(t, `{"type": "subscribe", "categories": ["`+category+`"]}`)
+		c.next(t, "subscribed")
+
+		env.startEvent(event)
+
+		msg := c.next(t, "event")
+		got, _ := msg["event"].(map[string]any)
+
+		if id := strconv.FormatInt(event.GetId(), 10); got["id"] != id {
+			t.Fatalf("expected event %s, got %v", id, got["id"])
+		}
+
+		if got["status"] != "CLOSED" || msg["previousStatus"] != "OPEN" {
+			t.Fatalf("expected event to close, got %v", msg)
+		}
+	})
+
+	t.Run("pushes the updates of subscribed topics only", func(t *testing.T) {
+		env := setupEnv(t)
+		c := dial(t, env.serve(t, nil))
+		c.next(t, "subscribed")
+
+		race := env.lastRace(t)
+		id := strconv.FormatInt(race.GetId(), 10)
+
+		c.send(t, `{"type": "subscribe", "raceIds": ["`+id+`"]}`)
+		c.next(t, "subscribed")
+
+		// All races close, but only the subscribed one is pushed.
+		env.startRace(race)
+
+		msg := c.next(t, "race")
+		got, _ := msg["race"].(map[string]any)
+		if got["id"] != id {
+			t.Fatalf("expected race %s only, got %v", id, got["id"])
+		}
+	})
+
+	t.Run("pushes the transitions of restricted races", func(t *testing.T) {
+		env := setupEnv(t)
+		race := env.nextRace(t, 0)
+		meetingID := strconv.FormatInt(race.GetMeetingId(), 10)
+
+		// The race is restricted in AU, but offered to the clients in NZ.
+		env.restrict(
+			t,
+			"rules: [{jurisdictions: [AU], meetings: ["+meetingID+"]}]",
+		)
+
+		c := dial(t, env.serve(t, func(h *Handler) {
+			h.AdminToken = testAdminToken
+			h.Context = func(
+				ctx context.Context,
+				_ *http.Request,
+			) (context.Context, error) {
+				return metadata.AppendToOutgoingContext(
+					ctx,
+					jurisdiction.MetadataKey,
+					"NZ",
+				), nil
+			}
+		}))
+		c.next(t, "subscribed")
+
+		c.send(t, `{"type": "subscribe", "meetingIds": [`+meetingID+`]}`)
+		c.next(t, "subscribed")
+
+		env.startRace(race)
+
+		msg := c.next(t, "race")
+		got, _ := msg["race"].(map[string]any)
+		if id := strconv.FormatInt(race.GetId(), 10); got["id"] != id {
+			t.Fatalf("expected race %s, got %v", id, got["id"])
+		}
+	})
+
+	t.Run("answers invalid requests with errors", func(t *testing.T) {
+		env := setupEnv(t)
+		c := dial(t, env.serve(t, nil))
+		c.next(t, "subscribed")
+
+		for _, req := range []string{
+			`{"type": "unknown"}`,
+			`{"type": "subscribe", "raceIds": [-1]}`,
+			`{"type": "subscribe", "categories": ["UNKNOWN"]}`,
+			`not JSON`,
+		} {
+			c.send(t, req)
+			c.next(t, "error")
+		}
+
+		// The connection stays open.
+		c.send(t, `{"type": "subscribe", "raceIds": [1]}`)
+		c.next(t, "subscribed")
+	})
+
+	t.Run("closes connections sending binary messages", func(t *testing.T) {
+		env := setupEnv(t)
+		c := dial(t, env.serve(t, nil))
+		c.next(t, "subscribed")
+
+		if err := c.ws.Write(
+			t.Context(),
+			websocket.MessageBinary,
+			[]byte("{}"),
+		); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := c.closed(t); got != websocket.StatusUnsupportedData {
+			t.Fatalf(
+				"expected status %v, got %v",
+				websocket.StatusUnsupportedData,
+				got,
+			)
+		}
+	})
+}
+
+func TestHandlerResume(t *testing.T) {
+	t.Run("replays the missed updates", func(t *testing.T) {
+		env := setupEnv(t)
+		u := env.serve(t, nil)
+
+		race := env.nextRace(t, 0)
+		meetingID := strconv.FormatInt(race.GetMeetingId(), 10)
+
+		c := dial(t, u)
+		c.next(t, "subscribed")
+		c.send(t, `{"type": "subscribe", "meetingIds": [`+meetingID+`]}`)
+		tok, _ := c.next(t, "subscribed")["token"].(string)
+
+		if err := c.ws.Close(websocket.StatusNormalClosure, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		env.startRace(race)
+		env.waitForPoll(t)
+
+		c = dial(t, u+"?resume="+url.QueryEscape(tok))
+
+		ack := c.next(t, "subscribed")
+		if ids, _ := ack["meetingIds"].([]any); len(ids) != 1 {
+			t.Fatalf("expected subscription to be restored, got %v", ack)
+		}
+
+		msg := c.next(t, "race")
+		got, _ := msg["race"].(map[string]any)
+		if id := strconv.FormatInt(race.GetId(), 10); got["id"] != id {
+			t.Fatalf("expected race %s, got %v", id, got["id"])
+		}
+	})
+
+	t.Run("asks to resync if updates cannot be replayed", func(t *testing.T) {
+		env := setupEnv(t)
+
+		// The tokens of other handlers cannot be resumed, for example after
+		// the gateway restarts.
+		other := dial(t, env.serve(t, nil))
+		other.send(t, `{"type": "subscribe", "raceIds": [1]}`)
+		other.next(t, "subscribed")
+		tok, _ := other.next(t, "subscribed")["token"].(string)
+
+		c := dial(t, env.serve(t, nil)+"?resume="+url.QueryEscape(tok))
+
+		if msg := c.next(t, "resync"); msg == nil {
+			t.Fatal("expected resync")
+		}
+
+		ack := c.next(t, "subscribed")
+		if ids, _ := ack["raceIds"].([]any); len(ids) != 1 {
+			t.Fatalf("expected subscription to be restored, got %v", ack)
+		}
+	})
+
+	t.Run("rejects invalid tokens", func(t *testing.T) {
+		env := setupEnv(t)
+
+		_, resp, err := websocket.Dial(
+			t.Context(),
+			env.serve(t, nil)+"?resume=invalid",
+			nil,
+		)
+		if err == nil {
+			t.Fatal("expected connection to be rejected")
+		}
+
+		if resp == nil || resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected status %d, got %v", http.StatusBadRequest, resp)
+		}
+	})
+}
+
+func TestHandlerBackpressure(t *testing.T) {
+	t.Run("disconnects slow clients", func(t *testing.T) {
+		env := setupEnv(t)
+		c := dial(t, env.serve(t, func(h *Handler) {
+			h.QueueSize = 1
+		}))
+		c.next(t, "subscribed")
+
+		race := env.nextRace(t, 0)
+		meetingID := strconv.FormatInt(race.GetMeetingId(), 10)
+
+		c.send(t, `{"type": "subscribe", "meetingIds": [`+meetingID+`]}`)
+		c.next(t, "subscribed")
+
+		// The first update is never delivered, and the others overflow the
+		// queue.
+		env.stall.Store(true)
+
+		env.startRace(race)
+		env.waitForPoll(t)
+		env.startRace(env.lastRace(t))
+
+		if got := c.closed(t); got != websocket.StatusTryAgainLater {
+			t.Fatalf(
+				"expected status %v, got %v",
+				websocket.StatusTryAgainLater,
+				got,
+			)
+		}
+	})
+
+	t.Run("disconnects clients not answering pings", func(t *testing.T) {
+		env := setupEnv(t)
+
+		ws, _, err := websocket.Dial(t.Context(), env.serve(t, nil), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer ws.CloseNow()
+
+		// The client does not read, so it does not answer the pings.
+		time.Sleep(200 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(t.Context(), testTimeout)
+		defer cancel()
+
+		for {
+			if _, _, err := ws.Read(ctx); err != nil {
+				if ctx.Err() != nil {
+					t.Fatal("expected connection to be closed")
+				}
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,501 @@
+package live_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/danilvpetrov/entain/admin"
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/jurisdiction"
+	. "github.com/danilvpetrov/entain/live"
+	"github.com/danilvpetrov/entain/racing"
+	"github.com/danilvpetrov/entain/sports"
+	_ "github.com/mattn/go-sqlite3" // underscore import for the SQLite driver
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// testTimeout is the maximum duration a test waits for a message.
+const testTimeout = 5 * time.Second
+
+// testAdminToken is the bearer token of the admin of the services.
+const testAdminToken = "<admin-token>"
+
+// testClock is a clock of the services that the tests move forward.
+type testClock struct {
+	m   sync.Mutex
+	now time.Time
+}
+
+// Now returns the current time of the clock.
+func (c *testClock) Now() time.Time {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.now
+}
+
+// set moves the clock to the given time.
+func (c *testClock) set(t time.Time) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.now = t
+}
+
+// testEnv is the environment of a test of the live updates.
+type testEnv struct {
+	// racing is a client of the racing service.
+	racing racingapi.RacingClient
+	// sports is a client of the sports service.
+	sports sportsapi.SportsClient
+
+	// racingClock is the clock of the racing service.
+	racingClock *testClock
+	// sportsClock is the clock of the sports service.
+	sportsClock *testClock
+
+	// rules are the jurisdiction rules of the services, which restrict
+	// nothing until they are loaded by restrict.
+	rules *jurisdiction.Rules
+
+	racingConn *grpc.ClientConn
+	sportsConn *grpc.ClientConn
+
+	// polls counts the ListEvents calls, which are made last by every poll.
+	polls atomic.Int64
+	// stall holds the BatchGetRaces calls until they are cancelled while it
+	// is set.
+	stall atomic.Bool
+}
+
+// setupEnv is a test helper that sets up the racing and sports services with
+// seeded test data and movable clocks.
+func setupEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	env := &testEnv{
+		racingClock: &testClock{now: time.Now()},
+		sportsClock: &testClock{now: time.Now()},
+		rules: &jurisdiction.Rules{
+			Path: filepath.Join(t.TempDir(), "rules.yaml"),
+		},
+	}
+
+	env.racingConn = setupServer(t, env, func(s *grpc.Server) {
+		racingapi.RegisterRacingServer(s, &racing.Service{
+			DB:            setupRacingDatabase(t),
+			Clock:         env.racingClock,
+			Jurisdictions: env.rules,
+		})
+	})
+
+	env.sportsConn = setupServer(t, env, func(s *grpc.Server) {
+		sportsapi.RegisterSportsServer(s, &sports.Service{
+			DB:            setupSportsDatabase(t),
+			Clock:         env.sportsClock,
+			Jurisdictions: env.rules,
+		})
+	})
+
+	env.racing = racingapi.NewRacingClient(env.racingConn)
+	env.sports = sportsapi.NewSportsClient(env.sportsConn)
+
+	return env
+}
+
+// serve is a test helper that serves a handler polling the services of the
+// environment every 10 milliseconds, and returns the URL of its WebSocket
+// endpoint. The handler is configured further by the given function, if any.
+// It returns once the services have been polled for the first time.
+func (env *testEnv) serve(t *testing.T, configure func(*Handler)) string {
+	t.Helper()
+
+	h := &Handler{
+		Racing:       env.racingConn,
+		Sports:       env.sportsConn,
+		PollInterval: 10 * time.Millisecond,
+		PingInterval: 50 * time.Millisecond,
+	}
+	if configure != nil {
+		configure(h)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = h.Run(ctx)
+	}()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		srv.Close()
+	})
+
+	env.waitForPoll(t)
+
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+// waitForPoll is a test helper that waits until the services have been
+// polled entirely after it is called.
+func (env *testEnv) waitForPoll(t *testing.T) {
+	t.Helper()
+
+	// The poll in progress may have listed the races before the call, so
+	// wait for the one after it.
+	want := env.polls.Load() + 2
+	deadline := time.Now().Add(testTimeout)
+
+	for env.polls.Load() < want {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the services to be polled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// restrict is a test helper that loads the jurisdiction rules of the services
+// from the given content.
+func (env *testEnv) restrict(t *testing.T, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(env.rules.Path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := env.rules.Load(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// nextRace is a test helper that returns the earliest open race of the given
+// meeting, or of any meeting if it is zero.
+func (env *testEnv) nextRace(t *testing.T, meetingID int64) *racingapi.Race {
+	t.Helper()
+
+	req := &racingapi.ListRacesRequest{
+		OrderBy: []racingapi.ListRacesRequest_OrderBy{
+			racingapi.ListRacesRequest_ADVERTISED_START_TIME_ASC,
+		},
+	}
+	if meetingID != 0 {
+		req.MeetingId = []int64{meetingID}
+	}
+
+	resp, err := env.racing.ListRaces(t.Context(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range resp.GetRaces() {
+		if r.GetStatus() == racingapi.Race_OPEN {
+			return r
+		}
+	}
+
+	t.Fatal("no open race")
+	return nil
+}
+
+// lastRace is a test helper that returns the race starting last.
+func (env *testEnv) lastRace(t *testing.T) *racingapi.Race {
+	t.Helper()
+
+	resp, err := env.racing.ListRaces(
+		t.Context(),
+		&racingapi.ListRacesRequest{
+			OrderBy: []racingapi.ListRacesRequest_OrderBy{
+				racingapi.ListRacesRequest_ADVERTISED_START_TIME_DESC,
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.GetRaces()) == 0 {
+		t.Fatal("no race")
+	}
+
+	return resp.GetRaces()[0]
+}
+
+// nextEvent is a test helper that returns the earliest open sports event.
+func (env *testEnv) nextEvent(t *testing.T) *sportsapi.Event {
+	t.Helper()
+
+	resp, err := env.sports.ListEvents(
+		t.Context(),
+		&sportsapi.ListEventsRequest{
+			OrderBy: []sportsapi.ListEventsRequest_OrderBy{
+				sportsapi.ListEventsRequest_ADVERTISED_START_TIME_ASC,
+			},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range resp.GetEvents() {
+		if e.GetStatus() == sportsapi.Event_OPEN {
+			return e
+		}
+	}
+
+	t.Fatal("no open sports event")
+	return nil
+}
+
+// startRace is a test helper that moves the clock of the racing service past
+// the advertised start time of a race, closing it.
+func (env *testEnv) startRace(r *racingapi.Race) {
+	env.racingClock.set(r.GetAdvertisedStartTime().AsTime().Add(time.Second))
+}
+
+// startEvent is a test helper that moves the clock of the sports service past
+// the advertised start time of a sports event, closing it.
+func (env *testEnv) startEvent(e *sportsapi.Event) {
+	env.sportsClock.set(e.GetAdvertisedStartTime().AsTime().Add(time.Second))
+}
+
+// setupRacingDatabase is a test helper that sets up a racing database seeded
+// with test data.
+func setupRacingDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := openDatabase(t)
+
+	if err := racing.ApplySchema(t.Context(), db); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := racing.SeedTestData(t.Context(), db); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+// setupSportsDatabase is a test helper that sets up a sports database seeded
+// with test data.
+func setupSportsDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := openDatabase(t)
+
+	if err := sports.ApplySchema(t.Context(), db); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := sports.SeedTestData(
+		t.Context(),
+		db,
+		"../sports/testdata/testdata.json",
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+// openDatabase is a test helper that opens an in-memory database.
+func openDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every connection opens a distinct in-memory database, and the services
+	// are polled while the tests call them.
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	return db
+}
+
+// setupServer is a test helper that sets up a gRPC server with the services
+// registered by the given function, and returns a connection to it. The
+// requests bearing testAdminToken are made by an admin. The polls made through
+// the connection are counted by the environment, which can also stall the
+// BatchGetRaces calls.
+func setupServer(
+	t *testing.T,
+	env *testEnv,
+	register func(*grpc.Server),
+) *grpc.ClientConn {
+	t.Helper()
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		admin.UnaryServerInterceptor(admin.Tokens{testAdminToken: "admin"}),
+		jurisdiction.UnaryServerInterceptor(),
+		func(
+			ctx context.Context,
+			req any,
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (any, error) {
+			if info.FullMethod == racingapi.Racing_BatchGetRaces_FullMethodName &&
+				env.stall.Load() {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+
+			resp, err := handler(ctx, req)
+
+			if info.FullMethod == sportsapi.Sports_ListEvents_FullMethodName {
+				env.polls.Add(1)
+			}
+
+			return resp, err
+		},
+	))
+	register(server)
+
+	listenCfg := net.ListenConfig{}
+	// Listen on a random port.
+	listener, err := listenCfg.Listen(t.Context(), "tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(server.Stop)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient(
+		listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+// client is a WebSocket client of the live updates. It reads the messages in
+// the background, so that the pings of the server are answered.
+type client struct {
+	ws       *websocket.Conn
+	messages chan map[string]any
+	// err is the error the reading stopped with, set once messages is
+	// closed.
+	err error
+}
+
+// dial is a test helper that connects to the given URL.
+func dial(t *testing.T, url string) *client {
+	t.Helper()
+
+	ws, _, err := websocket.Dial(t.Context(), url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = ws.CloseNow()
+	})
+
+	c := &client{
+		ws:       ws,
+		messages: make(chan map[string]any, 100),
+	}
+
+	go func() {
+		defer close(c.messages)
+
+		for {
+			_, data, err := ws.Read(context.Background())
+			if err != nil {
+				c.err = err
+				return
+			}
+
+			var msg map[string]any
+			if err := json.Unmarshal(data, &msg); err != nil {
+				c.err = err
+				return
+			}
+			c.messages <- msg
+		}
+	}()
+
+	return c
+}
+
+// send is a test helper that sends a JSON message to the server.
+func (c *client) send(t *testing.T, msg string) {
+	t.Helper()
+
+	if err := c.ws.Write(
+		t.Context(),
+		websocket.MessageText,
+		[]byte(msg),
+	); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// next is a test helper that returns the next message of the given type,
+// skipping the messages of other types.
+func (c *client) next(t *testing.T, typ string) map[string]any {
+	t.Helper()
+
+	timeout := time.After(testTimeout)
+	for {
+		select {
+		case msg, ok := <-c.messages:
+			if !ok {
+				t.Fatalf("connection closed: %v", c.err)
+			}
+			if msg["type"] == typ {
+				return msg
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for a %q message", typ)
+		}
+	}
+}
+
+// closed is a test helper that waits for the connection to be closed by the
+// server, and returns the status it is closed with.
+func (c *client) closed(t *testing.T) websocket.StatusCode {
+	t.Helper()
+
+	timeout := time.After(testTimeout)
+	for {
+		select {
+		case _, ok := <-c.messages:
+			if !ok {
+				return websocket.CloseStatus(c.err)
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for the connection to be closed")
+		}
+	}
+}
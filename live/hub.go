@@ -0,0 +1,155 @@
+package live
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+)
+
+// historySize is the number of the latest updates kept by a hub, so that they
+// can be replayed to the clients resuming their connections.
+const historySize = 1000
+
+// updateKind is the kind of the entity an update is of.
+type updateKind int
+
+const (
+	// raceUpdate is the kind of the updates of races.
+	raceUpdate updateKind = iota + 1
+	// eventUpdate is the kind of the updates of sports events.
+	eventUpdate
+)
+
+// update is a transition of the status of a race or a sports event.
+type update struct {
+	// seq is the sequence number of the update, assigned by the hub.
+	seq int64
+
+	kind updateKind
+	id   int64
+
+	// meetingID is the ID of the meeting of an updated race.
+	meetingID int64
+	// category is the category of an updated sports event.
+	category sportsapi.Event_Category
+
+	// raceStatus is the status of an updated race before the update.
+	raceStatus racingapi.Race_Status
+	// eventStatus is the status of an updated sports event before the
+	// update.
+	eventStatus sportsapi.Event_Status
+	// matchState is the match state of an updated sports event before the
+	// update.
+	matchState sportsapi.Event_MatchState
+}
+
+// hub publishes the updates to the connections, keeping the latest updates to
+// replay them to the resuming connections.
+type hub struct {
+	// epoch identifies the hub in the resume tokens.
+	epoch int64
+
+	m       sync.Mutex
+	seq     int64
+	history []update
+	conns   map[*conn]struct{}
+	closed  bool
+}
+
+// newHub returns a new hub.
+func newHub() *hub {
+	return &hub{
+		epoch: time.Now().UnixNano(),
+		conns: map[*conn]struct{}{},
+	}
+}
+
+// publish assigns sequence numbers to the updates and queues them to all
+// connections. The connections whose queues are full are closed, rather than
+// slowing down the others.
+func (h *hub) publish(updates []update) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	for _, u := range updates {
+		h.seq++
+		u.seq = h.seq
+
+		h.history = append(h.history, u)
+
+		for c := range h.conns {
+			select {
+			case c.updates <- u:
+			default:
+				delete(h.conns, c)
+				c.cancel(errSlowConsumer)
+			}
+		}
+	}
+
+	if n := len(h.history) - historySize; n > 0 {
+		h.history = slices.Delete(h.history, 0, n)
+	}
+}
+
+// join registers a connection to receive the updates published from now on,
+// and returns the sequence number of the latest update.
+//
+// If the connection resumes from a token, join returns the sequence number of
+// the update the token was issued at instead, along with the updates
+// published since, which are not queued to the connection. It reports whether
+// the connection can be resumed, which it cannot if the token was issued by
+// another hub or if the updates published since are no longer kept.
+func (h *hub) join(
+	c *conn,
+	t *token,
+) (seq int64, missed []update, resumed bool, err error) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	if h.closed {
+		return 0, nil, false, errShutdown
+	}
+
+	h.conns[c] = struct{}{}
+
+	if t == nil {
+		return h.seq, nil, true, nil
+	}
+
+	if t.Epoch != h.epoch || t.Seq > h.seq {
+		return h.seq, nil, false, nil
+	}
+
+	// The updates after the given one must still be in the history.
+	if t.Seq < h.seq && h.history[0].seq > t.Seq+1 {
+		return h.seq, nil, false, nil
+	}
+
+	missed = slices.Clone(h.history[len(h.history)-int(h.seq-t.Seq):])
+
+	return t.Seq, missed, true, nil
+}
+
+// leave unregisters a connection.
+func (h *hub) leave(c *conn) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	delete(h.conns, c)
+}
+
+// close closes all connections, and rejects the new ones.
+func (h *hub) close() {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	h.closed = true
+	for c := range h.conns {
+		delete(h.conns, c)
+		c.cancel(errShutdown)
+	}
+}
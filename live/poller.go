@@ -0,0 +1,125 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// eventState is the state of a sports event the poller detects the
+// transitions of.
+type eventState struct {
+	status     sportsapi.Event_Status
+	matchState sportsapi.Event_MatchState
+}
+
+// poller polls the racing and sports services for the statuses of races and
+// sports events, and detects their transitions.
+//
+// The first poll of each service only records the statuses, so that no
+// transitions are detected for the races and sports events existing before
+// the gateway starts. The races and sports events created afterwards are
+// detected as transitions from the unspecified status.
+type poller struct {
+	racing racingapi.RacingClient
+	sports sportsapi.SportsClient
+
+	races  map[int64]racingapi.Race_Status
+	events map[int64]eventState
+}
+
+// poll polls both services and returns the detected transitions. The
+// transitions detected by one service are returned even if the other fails.
+func (p *poller) poll(ctx context.Context) ([]update, error) {
+	races, racesErr := p.pollRaces(ctx)
+	if racesErr != nil {
+		racesErr = fmt.Errorf("error polling races: %w", racesErr)
+	}
+
+	events, eventsErr := p.pollEvents(ctx)
+	if eventsErr != nil {
+		eventsErr = fmt.Errorf("error polling sports events: %w", eventsErr)
+	}
+
+	return append(races, events...), errors.Join(racesErr, eventsErr)
+}
+
+// pollRaces lists the races and returns the transitions of their statuses.
+func (p *poller) pollRaces(ctx context.Context) ([]update, error) {
+	resp, err := p.racing.ListRaces(ctx, &racingapi.ListRacesRequest{
+		ReadMask: &fieldmaskpb.FieldMask{
+			Paths: []string{"id", "meeting_id", "status"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	first := p.races == nil
+	prev := p.races
+	p.races = make(map[int64]racingapi.Race_Status, len(resp.GetRaces()))
+
+	var updates []update
+	for _, r := range resp.GetRaces() {
+		p.races[r.GetId()] = r.GetStatus()
+
+		status, ok := prev[r.GetId()]
+		if first || ok && status == r.GetStatus() {
+			continue
+		}
+
+		updates = append(updates, update{
+			kind:       raceUpdate,
+			id:         r.GetId(),
+			meetingID:  r.GetMeetingId(),
+			raceStatus: status,
+		})
+	}
+
+	return updates, nil
+}
+
+// pollEvents lists the sports events and returns the transitions of their
+// statuses and match states.
+func (p *poller) pollEvents(ctx context.Context) ([]update, error) {
+	resp, err := p.sports.ListEvents(ctx, &sportsapi.ListEventsRequest{
+		ReadMask: &fieldmaskpb.FieldMask{
+			Paths: []string{"id", "category", "status", "match_state"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	first := p.events == nil
+	prev := p.events
+	p.events = make(map[int64]eventState, len(resp.GetEvents()))
+
+	var updates []update
+	for _, e := range resp.GetEvents() {
+		state := eventState{
+			status:     e.GetStatus(),
+			matchState: e.GetMatchState(),
+		}
+		p.events[e.GetId()] = state
+
+		before, ok := prev[e.GetId()]
+		if first || ok && before == state {
+			continue
+		}
+
+		updates = append(updates, update{
+			kind:        eventUpdate,
+			id:          e.GetId(),
+			category:    e.GetCategory(),
+			eventStatus: before.status,
+			matchState:  before.matchState,
+		})
+	}
+
+	return updates, nil
+}
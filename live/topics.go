@@ -0,0 +1,220 @@
+package live
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+)
+
+// maxTopics is the maximum number of topics a connection can be subscribed
+// to.
+const maxTopics = 1000
+
+// ids is a list of IDs of races, race meetings or sports events. They are
+// decoded from JSON numbers or strings, as the IDs of the races and sports
+// events are encoded as strings in JSON.
+type ids []int64
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *ids) UnmarshalJSON(data []byte) error {
+	var nums []json.Number
+	if err := json.Unmarshal(data, &nums); err != nil {
+		return err
+	}
+
+	*l = make(ids, 0, len(nums))
+	for _, n := range nums {
+		id, err := strconv.ParseInt(n.String(), 10, 64)
+		if err != nil || id <= 0 {
+			return fmt.Errorf("invalid ID %s", n)
+		}
+		*l = append(*l, id)
+	}
+
+	return nil
+}
+
+// topicList is the JSON encoding of a set of topics.
+type topicList struct {
+	RaceIDs    ids      `json:"raceIds,omitempty"`
+	MeetingIDs ids      `json:"meetingIds,omitempty"`
+	EventIDs   ids      `json:"eventIds,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+// topics is a set of topics a connection is subscribed to. A connection
+// receives the updates of the races with the given IDs or of the given race
+// meetings, and of the sports events with the given IDs or of the given
+// categories.
+type topics struct {
+	races      map[int64]bool
+	meetings   map[int64]bool
+	events     map[int64]bool
+	categories map[sportsapi.Event_Category]bool
+}
+
+// newTopics returns an empty set of topics.
+func newTopics() *topics {
+	return &topics{
+		races:      map[int64]bool{},
+		meetings:   map[int64]bool{},
+		events:     map[int64]bool{},
+		categories: map[sportsapi.Event_Category]bool{},
+	}
+}
+
+// matches reports whether an update is of one of the topics.
+func (t *topics) matches(u update) bool {
+	switch u.kind {
+	case raceUpdate:
+		return t.races[u.id] || t.meetings[u.meetingID]
+	case eventUpdate:
+		return t.events[u.id] || t.categories[u.category]
+	}
+	return false
+}
+
+// add adds the given topics to the set. It returns an error without changing
+// the set if any of the topics is invalid, or if the set would have more than
+// maxTopics topics.
+func (t *topics) add(l topicList) error {
+	categories, err := parseCategories(l.Categories)
+	if err != nil {
+		return err
+	}
+
+	n := t.len() + len(l.RaceIDs) + len(l.MeetingIDs) + len(l.EventIDs) +
+		len(categories)
+	if n > maxTopics {
+		return fmt.Errorf("cannot subscribe to more than %d topics", maxTopics)
+	}
+
+	for _, id := range l.RaceIDs {
+		t.races[id] = true
+	}
+	for _, id := range l.MeetingIDs {
+		t.meetings[id] = true
+	}
+	for _, id := range l.EventIDs {
+		t.events[id] = true
+	}
+	for _, c := range categories {
+		t.categories[c] = true
+	}
+
+	return nil
+}
+
+// remove removes the given topics from the set. It returns an error without
+// changing the set if any of the topics is invalid.
+func (t *topics) remove(l topicList) error {
+	categories, err := parseCategories(l.Categories)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range l.RaceIDs {
+		delete(t.races, id)
+	}
+	for _, id := range l.MeetingIDs {
+		delete(t.meetings, id)
+	}
+	for _, id := range l.EventIDs {
+		delete(t.events, id)
+	}
+	for _, c := range categories {
+		delete(t.categories, c)
+	}
+
+	return nil
+}
+
+// len returns the number of topics in the set.
+func (t *topics) len() int {
+	return len(t.races) + len(t.meetings) + len(t.events) +
+		len(t.categories)
+}
+
+// list returns the topics of the set, in a stable order.
+func (t *topics) list() topicList {
+	var categories []string
+	for _, c := range slices.Sorted(maps.Keys(t.categories)) {
+		categories = append(categories, c.String())
+	}
+
+	return topicList{
+		RaceIDs:    slices.Sorted(maps.Keys(t.races)),
+		MeetingIDs: slices.Sorted(maps.Keys(t.meetings)),
+		EventIDs:   slices.Sorted(maps.Keys(t.events)),
+		Categories: categories,
+	}
+}
+
+// parseCategories returns the categories of sports events with the given
+// names.
+func parseCategories(names []string) ([]sportsapi.Event_Category, error) {
+	categories := make([]sportsapi.Event_Category, 0, len(names))
+
+	for _, n := range names {
+		v, ok := sportsapi.Event_Category_value[n]
+		if !ok || v == int32(sportsapi.Event_UNSPECIFIED_CATEGORY) {
+			return nil, fmt.Errorf("invalid category %q", n)
+		}
+		categories = append(categories, sportsapi.Event_Category(v))
+	}
+
+	return categories, nil
+}
+
+// token is the state of a connection a client can resume by reconnecting
+// with it. It is encoded as an opaque string.
+type token struct {
+	// Epoch identifies the hub the sequence number is issued by, so that the
+	// tokens issued before the gateway restarts are not resumed.
+	Epoch int64 `json:"e"`
+	// Seq is the sequence number of the last update processed by the
+	// connection.
+	Seq int64 `json:"s"`
+	// Topics are the topics the connection is subscribed to.
+	Topics topicList `json:"t"`
+}
+
+// errInvalidToken indicates a resume token cannot be decoded.
+var errInvalidToken = errors.New("invalid resume token")
+
+// encode returns the string encoding of the token.
+func (t token) encode() string {
+	data, err := json.Marshal(t)
+	if err != nil {
+		// A token consists of numbers and strings only, which are always
+		// encoded.
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeToken decodes a token from its string encoding, restoring its topics.
+func decodeToken(s string) (token, *topics, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return token{}, nil, errInvalidToken
+	}
+
+	var t token
+	if err := json.Unmarshal(data, &t); err != nil {
+		return token{}, nil, errInvalidToken
+	}
+
+	tp := newTopics()
+	if err := tp.add(t.Topics); err != nil {
+		return token{}, nil, errInvalidToken
+	}
+
+	return t, tp, nil
+}
@@ -0,0 +1,9 @@
+// Package outbox relays the changes recorded by the services to downstream
+// systems such as pricing, notifications and search indexing.
+//
+// The services record every change of a race or a sports event in a change
+// log, within the same transaction as the change itself. A Relay reads the
+// changes that have not been published yet from a Source and publishes them
+// to a Sink, such as a NATS or Kafka topic. Changes are published at least
+// once and in the order they were made.
+package outbox
@@ -0,0 +1,46 @@
+package outbox
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// MemorySink is a sink that keeps the published messages in memory. It is
+// intended to be used in tests.
+type MemorySink struct {
+	// Err is an optional function called before each message is published.
+	// If it returns an error, the message is not published and the error is
+	// returned by Publish.
+	Err func(msg Message) error
+
+	msgs []Message
+	mu   sync.Mutex
+}
+
+// Make sure MemorySink implements the Sink interface.
+var _ Sink = (*MemorySink)(nil)
+
+// Publish keeps the message in memory.
+func (s *MemorySink) Publish(_ context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.Err != nil {
+		if err := s.Err(msg); err != nil {
+			return err
+		}
+	}
+
+	s.msgs = append(s.msgs, msg)
+
+	return nil
+}
+
+// Messages returns the published messages in the order they were published.
+func (s *MemorySink) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.msgs)
+}
@@ -0,0 +1,38 @@
+package outbox
+
+import "context"
+
+// Message is a change published to a sink.
+type Message struct {
+	// Topic is the name of the topic or the subject the message is published
+	// to.
+	Topic string
+	// Key identifies the changed entity. Messages with the same key must be
+	// delivered in order, for example by publishing them to the same
+	// partition of a Kafka topic.
+	Key string
+	// Value is the encoded change.
+	Value []byte
+	// Sequence is the position of the change in the change log of its source.
+	Sequence int64
+}
+
+// Source is a change log of a service.
+type Source interface {
+	// Pending returns up to limit changes that have not been acknowledged
+	// yet, in the order they were made.
+	Pending(ctx context.Context, limit int) ([]Message, error)
+
+	// Ack acknowledges that all changes up to and including the one with the
+	// given sequence number have been published.
+	Ack(ctx context.Context, sequence int64) error
+}
+
+// Sink publishes messages to downstream systems. It matches the publishing
+// API of message brokers such as NATS or Kafka, so that their clients can be
+// adapted to it.
+type Sink interface {
+	// Publish publishes a message. It returns once the message has been
+	// accepted by the broker.
+	Publish(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,115 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+const (
+	// DefaultInterval is the default interval between polls of a source.
+	DefaultInterval = time.Second
+
+	// DefaultBatchSize is the default maximum number of changes read from a
+	// source at once.
+	DefaultBatchSize = 100
+)
+
+// Relay publishes the changes of a source to a sink.
+type Relay struct {
+	// Source is the change log to publish the changes of.
+	Source Source
+	// Sink is the sink to publish the changes to.
+	Sink Sink
+
+	// Interval is the interval between polls of the source when there are no
+	// pending changes. If it is zero, DefaultInterval is used.
+	Interval time.Duration
+	// BatchSize is the maximum number of changes read from the source at
+	// once. If it is zero, DefaultBatchSize is used.
+	BatchSize int
+}
+
+// Run publishes the pending changes until the context is cancelled. Failed
+// publications are logged and retried at the next interval.
+func (r *Relay) Run(ctx context.Context) error {
+	interval := r.Interval
+	if interval == 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := r.RunOnce(ctx)
+		if err != nil {
+			slog.ErrorContext(
+				ctx,
+				"relaying changes failed",
+				slog.Any("error", err),
+			)
+		}
+
+		// Keep going without waiting while there is a backlog of changes.
+		if err == nil && n == r.batchSize() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce publishes a single batch of pending changes and returns the number
+// of published changes. The changes published before a failure are
+// acknowledged, so that they are not published again.
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	msgs, err := r.Source.Pending(ctx, r.batchSize())
+	if err != nil {
+		return 0, fmt.Errorf("error reading pending changes: %w", err)
+	}
+
+	published := 0
+	var pubErr error
+	for _, msg := range msgs {
+		if err := r.Sink.Publish(ctx, msg); err != nil {
+			pubErr = fmt.Errorf(
+				"error publishing change %d: %w",
+				msg.Sequence,
+				err,
+			)
+			break
+		}
+		published++
+	}
+
+	if published > 0 {
+		if err := r.Source.Ack(
+			context.WithoutCancel(ctx),
+			msgs[published-1].Sequence,
+		); err != nil {
+			return published, fmt.Errorf(
+				"error acknowledging changes: %w",
+				err,
+			)
+		}
+	}
+
+	return published, pubErr
+}
+
+// batchSize returns the maximum number of changes read at once.
+func (r *Relay) batchSize() int {
+	if r.BatchSize == 0 {
+		return DefaultBatchSize
+	}
+	return r.BatchSize
+}
@@ -0,0 +1,167 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/danilvpetrov/entain/outbox"
+)
+
+// stubSource is a source of a fixed list of changes.
+type stubSource struct {
+	msgs  []Message
+	acked int64
+	mu    sync.Mutex
+}
+
+func newStubSource(n int) *stubSource {
+	s := &stubSource{}
+	for i := range n {
+		s.msgs = append(s.msgs, Message{
+			Topic:    "races",
+			Key:      strconv.Itoa(i%3 + 1),
+			Value:    []byte(strconv.Itoa(i + 1)),
+			Sequence: int64(i + 1),
+		})
+	}
+	return s
+}
+
+func (s *stubSource) Pending(_ context.Context, limit int) ([]Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var pending []Message
+	for _, msg := range s.msgs {
+		if msg.Sequence > s.acked && len(pending) < limit {
+			pending = append(pending, msg)
+		}
+	}
+	return pending, nil
+}
+
+func (s *stubSource) Ack(_ context.Context, sequence int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.acked = sequence
+	return nil
+}
+
+func (s *stubSource) ackedSequence() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.acked
+}
+
+func TestRelayRunOnce(t *testing.T) {
+	t.Run("publishes a batch of changes in order", func(t *testing.T) {
+		src := newStubSource(5)
+		sink := &MemorySink{}
+		r := &Relay{Source: src, Sink: sink, BatchSize: 3}
+
+		n, err := r.RunOnce(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if n != 3 {
+			t.Fatalf("expected 3 published changes, got %d", n)
+		}
+
+		n, err = r.RunOnce(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if n != 2 {
+			t.Fatalf("expected 2 published changes, got %d", n)
+		}
+
+		msgs := sink.Messages()
+		if len(msgs) != 5 {
+			t.Fatalf("expected 5 messages, got %d", len(msgs))
+		}
+		for i, msg := range msgs {
+			if msg.Sequence != int64(i+1) {
+				t.Fatalf(
+					"expected sequence %d, got %d",
+					i+1,
+					msg.Sequence,
+				)
+			}
+		}
+
+		if src.ackedSequence() != 5 {
+			t.Fatalf("expected sequence 5 acked, got %d", src.ackedSequence())
+		}
+	})
+
+	t.Run("acknowledges changes published before a failure", func(t *testing.T) {
+		src := newStubSource(5)
+		fail := true
+		sink := &MemorySink{
+			Err: func(msg Message) error {
+				if fail && msg.Sequence == 3 {
+					return errors.New("broker unavailable")
+				}
+				return nil
+			},
+		}
+		r := &Relay{Source: src, Sink: sink}
+
+		n, err := r.RunOnce(t.Context())
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if n != 2 {
+			t.Fatalf("expected 2 published changes, got %d", n)
+		}
+		if src.ackedSequence() != 2 {
+			t.Fatalf("expected sequence 2 acked, got %d", src.ackedSequence())
+		}
+
+		fail = false
+		if _, err := r.RunOnce(t.Context()); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(sink.Messages()) != 5 {
+			t.Fatalf("expected 5 messages, got %d", len(sink.Messages()))
+		}
+	})
+}
+
+func TestRelayRun(t *testing.T) {
+	src := newStubSource(10)
+	sink := &MemorySink{}
+	r := &Relay{
+		Source:    src,
+		Sink:      sink,
+		Interval:  time.Hour,
+		BatchSize: 3,
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Run(ctx)
+	}()
+
+	// The backlog is published without waiting for the interval.
+	deadline := time.Now().Add(5 * time.Second)
+	for src.ackedSequence() != 10 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected sequence 10 acked, got %d", src.ackedSequence())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %v error, got %v", context.Canceled, err)
+	}
+}
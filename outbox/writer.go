@@ -0,0 +1,30 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// WriterSink is a sink that writes the messages to a writer, one per line, in
+// the "<topic> <key> <value>" format. It is a local stand-in for a message
+// broker, for example to watch the changes on the standard output.
+type WriterSink struct {
+	// W is the writer the messages are written to.
+	W io.Writer
+
+	mu sync.Mutex
+}
+
+// Make sure WriterSink implements the Sink interface.
+var _ Sink = (*WriterSink)(nil)
+
+// Publish writes the message to the writer.
+func (s *WriterSink) Publish(_ context.Context, msg Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := fmt.Fprintf(s.W, "%s %s %s\n", msg.Topic, msg.Key, msg.Value)
+	return err
+}
@@ -0,0 +1,29 @@
+package outbox_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/danilvpetrov/entain/outbox"
+)
+
+func TestWriterSink(t *testing.T) {
+	var w strings.Builder
+	sink := &WriterSink{W: &w}
+
+	for _, msg := range []Message{
+		{Topic: "racing.changes", Key: "1", Value: []byte(`{"sequence":"1"}`)},
+		{Topic: "racing.changes", Key: "2", Value: []byte(`{"sequence":"2"}`)},
+	} {
+		if err := sink.Publish(t.Context(), msg); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	expected := `racing.changes 1 {"sequence":"1"}
+racing.changes 2 {"sequence":"2"}
+`
+	if w.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, w.String())
+	}
+}
@@ -0,0 +1,284 @@
+package racing
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/danilvpetrov/entain/admin"
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/audit"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// raceEntityType is the entity type of races in the audit log.
+const raceEntityType = "race"
+
+// defaultAuditPageSize is the maximum number of audit entries returned by a
+// single ListAuditEntries call, unless the request sets a page size.
+const defaultAuditPageSize = 100
+
+// ListAuditEntries returns the audit entries of the changes made to races
+// through the API, in the order they were made.
+func (s *Service) ListAuditEntries(
+	ctx context.Context,
+	req *racingapi.ListAuditEntriesRequest,
+) (*racingapi.ListAuditEntriesResponse, error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"audit entries can only be listed by admins",
+		)
+	}
+
+	after, err := parseCursor(ctx, req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+
+	filter, args, err := parseAuditFilter(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultAuditPageSize
+	}
+
+	entries, err := readAuditEntries(
+		ctx,
+		s.DB,
+		filter,
+		append([]any{after}, args...),
+		pageSize,
+	)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if len(entries) > 0 {
+		after = entries[len(entries)-1].GetId()
+	}
+
+	return &racingapi.ListAuditEntriesResponse{
+		Entries:    entries,
+		NextCursor: formatCursor(after),
+	}, nil
+}
+
+// parseAuditFilter builds SQL filter query and its arguments from the
+// provided ListAuditEntries request.
+func parseAuditFilter(
+	ctx context.Context,
+	req *racingapi.ListAuditEntriesRequest,
+) (string, []any, error) {
+	var (
+		w    strings.Builder
+		args []any
+	)
+
+	if req.GetEntityType() != "" {
+		_, _ = w.WriteString(" AND entity_type = ?")
+		args = append(args, req.GetEntityType())
+	}
+
+	if req.GetEntityId() != 0 {
+		_, _ = w.WriteString(" AND entity_id = ?")
+		args = append(args, req.GetEntityId())
+	}
+
+	if req.GetActor() != "" {
+		_, _ = w.WriteString(" AND actor = ?")
+		args = append(args, req.GetActor())
+	}
+
+	for _, bound := range []struct {
+		time  *timestamppb.Timestamp
+		field string
+		op    string
+	}{
+		{req.GetStartTime(), "start_time", ">="},
+		{req.GetEndTime(), "end_time", "<"},
+	} {
+		if bound.time == nil {
+			continue
+		}
+
+		if err := bound.time.CheckValid(); err != nil {
+			return "", nil, apierror.InvalidArgument(
+				ctx,
+				"invalid time range",
+				apierror.FieldViolation{
+					Field:       bound.field,
+					Description: err.Error(),
+				},
+			)
+		}
+
+		_, _ = w.WriteString(
+			" AND julianday(entry_time) " + bound.op + " julianday(?)",
+		)
+		args = append(
+			args,
+			bound.time.AsTime().UTC().Format(time.RFC3339Nano),
+		)
+	}
+
+	return w.String(), args, nil
+}
+
+// readAuditEntries returns up to limit audit entries matching the given
+// filter. The first argument is the ID of the entry to list the entries
+// after.
+func readAuditEntries(
+	ctx context.Context,
+	db *sql.DB,
+	filter string,
+	args []any,
+	limit int,
+) ([]*racingapi.AuditEntry, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT id, actor, method, entity_type, entity_id, entry_time, changes
+		FROM audit_entries
+		WHERE id > ?`+filter+`
+		ORDER BY id
+		LIMIT ?`,
+		append(args, limit)...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	var entries []*racingapi.AuditEntry
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// scanAuditEntry scans an audit entry from the given scanner.
+func scanAuditEntry(s scanner) (*racingapi.AuditEntry, error) {
+	var (
+		entry     racingapi.AuditEntry
+		entryTime time.Time
+		changes   []byte
+	)
+
+	if err := s.Scan(
+		&entry.Id,
+		&entry.Actor,
+		&entry.Method,
+		&entry.EntityType,
+		&entry.EntityId,
+		&entryTime,
+		&changes,
+	); err != nil {
+		return nil, err
+	}
+
+	// The changes are stored as an audit entry holding nothing but them, and
+	// are merged into the scanned entry.
+	if err := (proto.UnmarshalOptions{Merge: true}).Unmarshal(
+		changes,
+		&entry,
+	); err != nil {
+		return nil, err
+	}
+	entry.EntryTime = timestamppb.New(entryTime)
+
+	return &entry, nil
+}
+
+// recordAudit appends an entry describing a change of the race with the given
+// ID to the audit log at the given time. The entry is attributed to the admin
+// making the request of the given context. It must be called within the
+// transaction that made the change, with the snapshots of the race before and
+// after the change. Changes that leave the race as it was are not recorded.
+func recordAudit(
+	ctx context.Context,
+	tx *sql.Tx,
+	now time.Time,
+	method string,
+	raceID int64,
+	before, after *racingapi.Race,
+) error {
+	diff, err := audit.Diff(before, after)
+	if err != nil {
+		return err
+	}
+
+	return insertAuditEntry(ctx, tx, now, method, raceID, diff)
+}
+
+// insertAuditEntry appends an entry with the given changes of the race with
+// the given ID to the audit log at the given time. The entry is attributed to
+// the admin making the request of the given context. Entries without changes
+// are not recorded.
+func insertAuditEntry(
+	ctx context.Context,
+	tx *sql.Tx,
+	now time.Time,
+	method string,
+	raceID int64,
+	diff []audit.FieldChange,
+) error {
+	if len(diff) == 0 {
+		return nil
+	}
+
+	var entry racingapi.AuditEntry
+	for _, c := range diff {
+		entry.Changes = append(entry.Changes, &racingapi.FieldChange{
+			Field:  c.Field,
+			Before: c.Before,
+			After:  c.After,
+		})
+	}
+
+	changes, err := proto.Marshal(&entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO audit_entries (
+			actor,
+			method,
+			entity_type,
+			entity_id,
+			entry_time,
+			changes
+		)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		admin.Name(ctx),
+		method,
+		raceEntityType,
+		raceID,
+		now.UTC().Format(time.RFC3339Nano),
+		changes,
+	)
+
+	return err
+}
@@ -0,0 +1,59 @@
+package racing_test
+
+import (
+	"testing"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	. "github.com/danilvpetrov/entain/racing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListAuditEntries(t *testing.T) {
+	db := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db})
+
+	t.Run("no entries", func(t *testing.T) {
+		resp, err := client.ListAuditEntries(
+			asAdmin(t.Context()),
+			&racingapi.ListAuditEntriesRequest{Actor: testAdminName},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(resp.GetEntries()) != 0 {
+			t.Fatalf("expected no entries, got %v", resp.GetEntries())
+		}
+
+		if resp.GetNextCursor() == "" {
+			t.Fatal("expected next cursor to be set")
+		}
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		_, err := client.ListAuditEntries(
+			t.Context(),
+			&racingapi.ListAuditEntriesRequest{},
+		)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+	})
+
+	t.Run("unknown entity type", func(t *testing.T) {
+		_, err := client.ListAuditEntries(
+			asAdmin(t.Context()),
+			&racingapi.ListAuditEntriesRequest{EntityType: "event"},
+		)
+		assertInvalidArgument(t, err, "entity_type")
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := client.ListAuditEntries(
+			asAdmin(t.Context()),
+			&racingapi.ListAuditEntriesRequest{Cursor: "<cursor>"},
+		)
+		assertInvalidArgument(t, err, "cursor")
+	})
+}
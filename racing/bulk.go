@@ -0,0 +1,513 @@
+package racing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/danilvpetrov/entain/admin"
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/etag"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultMaxImportSize is the default maximum number of races that can be
+// imported in a single ImportRaces call.
+const DefaultMaxImportSize = 10000
+
+// exportPageSize is the number of races read from the database at a time by
+// ExportRaces.
+const exportPageSize = 500
+
+// importOutcome is the outcome of importing a single race.
+type importOutcome int
+
+const (
+	// raceCreated indicates that a new race has been created.
+	raceCreated importOutcome = iota
+	// raceUpdated indicates that an existing race has been updated.
+	raceUpdated
+	// raceUnchanged indicates that an existing race has been imported as it
+	// was.
+	raceUnchanged
+)
+
+// ImportRaces creates and updates the races streamed by the client within a
+// single transaction, and returns the outcome of the import once the stream
+// is closed.
+func (s *Service) ImportRaces(
+	stream grpc.ClientStreamingServer[
+		racingapi.ImportRacesRequest,
+		racingapi.ImportRacesResponse,
+	],
+) error {
+	ctx := stream.Context()
+
+	if !admin.IsAdmin(ctx) {
+		return apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"races can only be imported by admins",
+		)
+	}
+
+	maxImportSize := s.MaxImportSize
+	if maxImportSize == 0 {
+		maxImportSize = DefaultMaxImportSize
+	}
+
+	var (
+		opts  *racingapi.ImportOptions
+		races []*racingapi.Race
+	)
+
+	for first := true; ; first = false {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if first {
+			opts = req.GetOptions()
+		}
+
+		if req.GetRace() == nil {
+			continue
+		}
+
+		if len(races) == maxImportSize {
+			return apierror.InvalidArgument(
+				ctx,
+				"too many races imported",
+				apierror.FieldViolation{
+					Field: "race",
+					Description: fmt.Sprintf(
+						"at most %d races can be imported at once",
+						maxImportSize,
+					),
+				},
+			)
+		}
+
+		races = append(races, req.GetRace())
+	}
+
+	resp, err := importRaces(ctx, s.DB, s.now(), races, opts)
+	if err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+// importRaces imports the given races within a single transaction. The
+// transaction is rolled back for dry runs, and for all-or-nothing imports with
+// invalid races.
+func importRaces(
+	ctx context.Context,
+	db *sql.DB,
+	now time.Time,
+	races []*racingapi.Race,
+	opts *racingapi.ImportOptions,
+) (*racingapi.ImportRacesResponse, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Rolling back a committed transaction is a no-op.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var resp racingapi.ImportRacesResponse
+
+	for i, race := range races {
+		outcome, importErr, err := importRace(ctx, tx, now, race)
+		if err != nil {
+			return nil, err
+		}
+
+		if importErr != nil {
+			importErr.Row = int64(i + 1)
+			resp.Errors = append(resp.Errors, importErr)
+			continue
+		}
+
+		switch outcome {
+		case raceCreated:
+			resp.Created++
+		case raceUpdated:
+			resp.Updated++
+		case raceUnchanged:
+			resp.Unchanged++
+		}
+	}
+
+	if opts.GetDryRun() ||
+		(opts.GetAllOrNothing() && len(resp.GetErrors()) > 0) {
+		return &resp, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	resp.Committed = true
+
+	return &resp, nil
+}
+
+// importRace creates the given race if it has no ID, or updates the race with
+// its ID otherwise. It returns an import error if the race is invalid, in which
+// case nothing is changed.
+func importRace(
+	ctx context.Context,
+	tx *sql.Tx,
+	now time.Time,
+	race *racingapi.Race,
+) (importOutcome, *racingapi.ImportError, error) {
+	if importErr := validateImportedRace(race); importErr != nil {
+		return 0, importErr, nil
+	}
+
+	if race.GetId() == 0 {
+		return createImportedRace(ctx, tx, now, race)
+	}
+
+	return updateImportedRace(ctx, tx, now, race)
+}
+
+// validateImportedRace returns an import error if the fields of an imported
+// race that do not depend on the stored races are invalid.
+func validateImportedRace(race *racingapi.Race) *racingapi.ImportError {
+	switch {
+	case race.GetId() < 0:
+		return &racingapi.ImportError{
+			Field:       "id",
+			Description: "value must not be negative",
+		}
+	case race.GetName() == "":
+		return &racingapi.ImportError{
+			Field:       "name",
+			Description: "value is required",
+		}
+	case race.GetAdvertisedStartTime() == nil:
+		return &racingapi.ImportError{
+			Field:       "advertised_start_time",
+			Description: "value is required",
+		}
+	}
+
+	if err := race.GetAdvertisedStartTime().CheckValid(); err != nil {
+		return &racingapi.ImportError{
+			Field:       "advertised_start_time",
+			Description: err.Error(),
+		}
+	}
+
+	return nil
+}
+
+// createImportedRace creates an imported race without an ID.
+func createImportedRace(
+	ctx context.Context,
+	tx *sql.Tx,
+	now time.Time,
+	race *racingapi.Race,
+) (importOutcome, *racingapi.ImportError, error) {
+	if race.GetMeetingId() <= 0 {
+		return 0, &racingapi.ImportError{
+			Field:       "meeting_id",
+			Description: "value is required",
+		}, nil
+	}
+
+	var exists bool
+	if err := tx.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (SELECT 1 FROM meetings WHERE id = ?)`,
+		race.GetMeetingId(),
+	).Scan(&exists); err != nil {
+		return 0, nil, err
+	}
+	if !exists {
+		return 0, &racingapi.ImportError{
+			Field:       "meeting_id",
+			Description: "meeting not found",
+		}, nil
+	}
+
+	res, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO races (
+			meeting_id,
+			name,
+			number,
+			visible,
+			advertised_start_time
+		) VALUES (?, ?, ?, ?, ?)`,
+		race.GetMeetingId(),
+		race.GetName(),
+		race.GetNumber(),
+		race.GetVisible(),
+		formatStartTime(race),
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if err := recordChange(ctx, tx, now, id, racingapi.Change_CREATED); err != nil {
+		return 0, nil, err
+	}
+
+	after, err := readSnapshot(ctx, tx, id)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if err := recordAudit(
+		ctx,
+		tx,
+		now,
+		racingapi.Racing_ImportRaces_FullMethodName,
+		id,
+		nil,
+		after,
+	); err != nil {
+		return 0, nil, err
+	}
+
+	return raceCreated, nil, nil
+}
+
+// updateImportedRace updates the race with the ID of an imported race.
+// Archived and deleted races cannot be updated.
+func updateImportedRace(
+	ctx context.Context,
+	tx *sql.Tx,
+	now time.Time,
+	race *racingapi.Race,
+) (importOutcome, *racingapi.ImportError, error) {
+	var version, meetingID int64
+	if err := tx.QueryRowContext(
+		ctx,
+		`SELECT version, meeting_id
+		FROM races
+		WHERE races.id = ?`+lifecycleFilter(false),
+		race.GetId(),
+	).Scan(&version, &meetingID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, &racingapi.ImportError{
+				Field:       "id",
+				Description: "race not found",
+			}, nil
+		}
+		return 0, nil, err
+	}
+
+	if race.GetEtag() != "" && !etag.Matches(race.GetEtag(), version) {
+		return 0, &racingapi.ImportError{
+			Field:       "etag",
+			Description: "race has been changed since the etag was issued",
+		}, nil
+	}
+
+	if race.GetMeetingId() != 0 && race.GetMeetingId() != meetingID {
+		return 0, &racingapi.ImportError{
+			Field:       "meeting_id",
+			Description: "meeting of an existing race cannot be changed",
+		}, nil
+	}
+
+	before, err := readSnapshot(ctx, tx, race.GetId())
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`UPDATE races
+		SET
+			name = ?,
+			number = ?,
+			visible = ?,
+			advertised_start_time = ?
+		WHERE id = ?`,
+		race.GetName(),
+		race.GetNumber(),
+		race.GetVisible(),
+		formatStartTime(race),
+		race.GetId(),
+	); err != nil {
+		return 0, nil, err
+	}
+
+	if err := recordChange(
+		ctx,
+		tx,
+		now,
+		race.GetId(),
+		racingapi.Change_UPDATED,
+	); err != nil {
+		return 0, nil, err
+	}
+
+	after, err := readSnapshot(ctx, tx, race.GetId())
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if proto.Equal(before, after) {
+		return raceUnchanged, nil, nil
+	}
+
+	if err := recordAudit(
+		ctx,
+		tx,
+		now,
+		racingapi.Racing_ImportRaces_FullMethodName,
+		race.GetId(),
+		before,
+		after,
+	); err != nil {
+		return 0, nil, err
+	}
+
+	return raceUpdated, nil, nil
+}
+
+// formatStartTime returns the advertised start time of a race as it is stored
+// in the database.
+func formatStartTime(race *racingapi.Race) string {
+	return race.GetAdvertisedStartTime().AsTime().UTC().Format(time.RFC3339)
+}
+
+// ExportRaces streams all races matching the request in the order of their
+// IDs. The races are exported as seen by the tenant of the request, leaving
+// out the races restricted in its jurisdiction.
+func (s *Service) ExportRaces(
+	req *racingapi.ExportRacesRequest,
+	stream grpc.ServerStreamingServer[racingapi.Race],
+) error {
+	ctx := stream.Context()
+
+	filterQuery, args := parseFilter(&racingapi.ListRacesRequest{
+		MeetingId:   req.GetMeetingId(),
+		VisibleOnly: req.GetVisibleOnly(),
+	})
+
+	if req.GetLocalDate() != "" {
+		dateFilter, dateArgs, err := s.localDateFilter(ctx, req.GetLocalDate())
+		if err != nil {
+			return err
+		}
+		filterQuery += dateFilter
+		args = append(args, dateArgs...)
+	}
+
+	// The races restricted in the jurisdiction are not exported.
+	restriction, restrictionArgs := s.restrictionFilter(ctx)
+	filterQuery += restriction
+	args = append(args, restrictionArgs...)
+
+	from, fromArgs := racesFrom(ctx)
+
+	proj, err := parseReadMask(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM %s
+		WHERE races.id > ? %s %s
+		ORDER BY races.id
+		LIMIT ?`,
+		proj.selectList(),
+		from,
+		lifecycleFilter(req.GetIncludeArchived()),
+		filterQuery,
+	)
+
+	// The statuses of all exported races are computed at the same time, so
+	// that the export is consistent.
+	now := s.now()
+
+	var after int64
+	for {
+		races, err := readRacePage(
+			ctx,
+			s.DB,
+			query,
+			slices.Concat(fromArgs, []any{after}, args, []any{exportPageSize}),
+			proj,
+			now,
+		)
+		if err != nil {
+			return apierror.Internal(ctx, err)
+		}
+
+		for _, race := range races {
+			if err := stream.Send(race); err != nil {
+				return err
+			}
+		}
+
+		if len(races) < exportPageSize {
+			return nil
+		}
+
+		after = races[len(races)-1].GetId()
+	}
+}
+
+// readRacePage reads a single page of races exported by ExportRaces. The page
+// is read as a whole, so that the database is not held while the races are
+// sent to a slow client.
+func readRacePage(
+	ctx context.Context,
+	db *sql.DB,
+	query string,
+	args []any,
+	proj *projection,
+	now time.Time,
+) ([]*racingapi.Race, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	var races []*racingapi.Race
+	for rows.Next() {
+		race, err := scanRace(rows, proj, now)
+		if err != nil {
+			return nil, err
+		}
+		races = append(races, race)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return races, nil
+}
@@ -0,0 +1,378 @@
+package racing_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	. "github.com/danilvpetrov/entain/racing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestImportRaces(t *testing.T) {
+	newRace := func() *racingapi.Race {
+		return &racingapi.Race{
+			MeetingId:           1,
+			Name:                "Imported race",
+			Number:              3,
+			Visible:             true,
+			AdvertisedStartTime: timestamppb.New(time.Now().Add(time.Hour)),
+		}
+	}
+
+	t.Run("creates and updates races", func(t *testing.T) {
+		db := setupDatabase(t)
+		client := setupServer(t, &Service{DB: db})
+
+		race1 := getRace(t, client, 1)
+		race1.Name = "Renamed race"
+
+		race2 := getRace(t, client, 2)
+
+		stale := getRace(t, client, 3)
+		stale.Etag = "100"
+
+		unnamed := newRace()
+		unnamed.Name = ""
+
+		unknownMeeting := newRace()
+		unknownMeeting.MeetingId = 1000
+
+		resp, err := importRaces(
+			asAdmin(t.Context()),
+			client,
+			nil,
+			newRace(),
+			race1,
+			race2,
+			unnamed,
+			unknownMeeting,
+			stale,
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if resp.GetCreated() != 1 ||
+			resp.GetUpdated() != 1 ||
+			resp.GetUnchanged() != 1 ||
+			!resp.GetCommitted() {
+			t.Fatalf("unexpected outcome of the import: %v", resp)
+		}
+
+		assertImportErrors(
+			t,
+			resp.GetErrors(),
+			map[int64]string{
+				4: "name",
+				5: "meeting_id",
+				6: "etag",
+			},
+		)
+
+		if race := getRace(t, client, 1); race.GetName() != "Renamed race" {
+			t.Fatalf("expected race to be renamed, got %q", race.GetName())
+		}
+
+		if n := countRaces(t, client); n != NumberOfSeededRaces+1 {
+			t.Fatalf("expected %d races, got %d", NumberOfSeededRaces+1, n)
+		}
+
+		audit, err := client.ListAuditEntries(
+			asAdmin(t.Context()),
+			&racingapi.ListAuditEntriesRequest{},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(audit.GetEntries()) != 2 {
+			t.Fatalf("expected 2 audit entries, got %v", audit.GetEntries())
+		}
+
+		method := racingapi.Racing_ImportRaces_FullMethodName
+		for _, entry := range audit.GetEntries() {
+			if entry.GetMethod() != method {
+				t.Fatalf("unexpected audit entry: %v", entry)
+			}
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		db := setupDatabase(t)
+		client := setupServer(t, &Service{DB: db})
+
+		resp, err := importRaces(
+			asAdmin(t.Context()),
+			client,
+			&racingapi.ImportOptions{DryRun: true},
+			newRace(),
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if resp.GetCreated() != 1 || resp.GetCommitted() {
+			t.Fatalf("unexpected outcome of the import: %v", resp)
+		}
+
+		if n := countRaces(t, client); n != NumberOfSeededRaces {
+			t.Fatalf("expected %d races, got %d", NumberOfSeededRaces, n)
+		}
+	})
+
+	t.Run("all or nothing", func(t *testing.T) {
+		db := setupDatabase(t)
+		client := setupServer(t, &Service{DB: db})
+
+		invalid := newRace()
+		invalid.AdvertisedStartTime = nil
+
+		resp, err := importRaces(
+			asAdmin(t.Context()),
+			client,
+			&racingapi.ImportOptions{AllOrNothing: true},
+			newRace(),
+			invalid,
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if resp.GetCommitted() {
+			t.Fatal("expected the import not to be committed")
+		}
+
+		assertImportErrors(
+			t,
+			resp.GetErrors(),
+			map[int64]string{2: "advertised_start_time"},
+		)
+
+		if n := countRaces(t, client); n != NumberOfSeededRaces {
+			t.Fatalf("expected %d races, got %d", NumberOfSeededRaces, n)
+		}
+	})
+
+	t.Run("too many races", func(t *testing.T) {
+		db := setupDatabase(t)
+		client := setupServer(t, &Service{DB: db, MaxImportSize: 1})
+
+		_, err := importRaces(
+			asAdmin(t.Context()),
+			client,
+			nil,
+			newRace(),
+			newRace(),
+		)
+		assertInvalidArgument(t, err, "race")
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		db := setupDatabase(t)
+		client := setupServer(t, &Service{DB: db})
+
+		_, err := importRaces(t.Context(), client, nil, newRace())
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+	})
+}
+
+func TestExportRaces(t *testing.T) {
+	db := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db})
+
+	cases := []struct {
+		req    *racingapi.ExportRacesRequest
+		assert func(t *testing.T, race *racingapi.Race)
+		name   string
+	}{
+		{
+			name: "all races",
+			req:  &racingapi.ExportRacesRequest{},
+			assert: func(t *testing.T, race *racingapi.Race) {
+				if race.GetEtag() == "" {
+					t.Fatalf("expected etag to be set: %v", race)
+				}
+			},
+		},
+		{
+			name: "visible races of a meeting",
+			req: &racingapi.ExportRacesRequest{
+				MeetingId:   []int64{2},
+				VisibleOnly: true,
+			},
+			assert: func(t *testing.T, race *racingapi.Race) {
+				if race.GetMeetingId() != 2 || !race.GetVisible() {
+					t.Fatalf("unexpected race: %v", race)
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			stream, err := client.ExportRaces(t.Context(), c.req)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			var ids []int64
+			for {
+				race, err := stream.Recv()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				c.assert(t, race)
+				ids = append(ids, race.GetId())
+			}
+
+			if !slices.IsSorted(ids) {
+				t.Fatalf("expected races in the order of their IDs: %v", ids)
+			}
+
+			list, err := client.ListRaces(
+				t.Context(),
+				&racingapi.ListRacesRequest{
+					MeetingId:   c.req.GetMeetingId(),
+					VisibleOnly: c.req.GetVisibleOnly(),
+				},
+			)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if len(ids) != len(list.GetRaces()) {
+				t.Fatalf(
+					"expected %d races, got %d",
+					len(list.GetRaces()),
+					len(ids),
+				)
+			}
+		})
+	}
+}
+
+// importRaces is a test helper that imports the given races with the given
+// options in a single ImportRaces call.
+func importRaces(
+	ctx context.Context,
+	client racingapi.RacingClient,
+	opts *racingapi.ImportOptions,
+	races ...*racingapi.Race,
+) (*racingapi.ImportRacesResponse, error) {
+	stream, err := client.ImportRaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, race := range races {
+		req := &racingapi.ImportRacesRequest{Race: race}
+		if i == 0 {
+			req.Options = opts
+		}
+
+		if err := stream.Send(req); err != nil {
+			// The actual error is returned by CloseAndRecv.
+			break
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// exportRaces is a test helper that returns the races streamed by an
+// ExportRaces call with the given request.
+func exportRaces(
+	ctx context.Context,
+	t *testing.T,
+	client racingapi.RacingClient,
+	req *racingapi.ExportRacesRequest,
+) []*racingapi.Race {
+	t.Helper()
+
+	stream, err := client.ExportRaces(ctx, req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var races []*racingapi.Race
+	for {
+		race, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return races
+		}
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		races = append(races, race)
+	}
+}
+
+// getRace is a test helper that returns the race with the given ID.
+func getRace(
+	t *testing.T,
+	client racingapi.RacingClient,
+	id int64,
+) *racingapi.Race {
+	t.Helper()
+
+	race, err := client.GetRace(
+		t.Context(),
+		&racingapi.GetRaceRequest{RaceId: id},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	return race
+}
+
+// countRaces is a test helper that returns the number of races.
+func countRaces(t *testing.T, client racingapi.RacingClient) int {
+	t.Helper()
+
+	resp, err := client.ListRaces(t.Context(), &racingapi.ListRacesRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	return len(resp.GetRaces())
+}
+
+// assertImportErrors is a test helper that asserts that the import errors
+// report the given fields of the given rows.
+func assertImportErrors(
+	t *testing.T,
+	errs []*racingapi.ImportError,
+	expected map[int64]string,
+) {
+	t.Helper()
+
+	actual := map[int64]string{}
+	for _, e := range errs {
+		actual[e.GetRow()] = e.GetField()
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("expected errors of rows %v, got %v", expected, errs)
+	}
+
+	for row, field := range expected {
+		if actual[row] != field {
+			t.Fatalf("expected errors of rows %v, got %v", expected, errs)
+		}
+	}
+}
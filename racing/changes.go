@@ -0,0 +1,270 @@
+package racing
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/danilvpetrov/entain/admin"
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/apierror"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultChangesPageSize is the maximum number of changes returned by a
+// single ListChanges call, unless the request sets a page size.
+const defaultChangesPageSize = 100
+
+// ListChanges returns the changes of races in the order they were made.
+// The snapshots of the changes are read as stored, regardless of tenants and
+// jurisdictions, so the changes can only be listed by admins.
+func (s *Service) ListChanges(
+	ctx context.Context,
+	req *racingapi.ListChangesRequest,
+) (*racingapi.ListChangesResponse, error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"changes can only be listed by admins",
+		)
+	}
+
+	after, err := parseCursor(ctx, req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultChangesPageSize
+	}
+
+	changes, err := readChanges(ctx, s.DB, after, pageSize)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if len(changes) > 0 {
+		after = changes[len(changes)-1].GetSequence()
+	}
+
+	return &racingapi.ListChangesResponse{
+		Changes:    changes,
+		NextCursor: formatCursor(after),
+	}, nil
+}
+
+// readChanges returns up to limit changes made after the change with the
+// given sequence number.
+func readChanges(
+	ctx context.Context,
+	db *sql.DB,
+	after int64,
+	limit int,
+) ([]*racingapi.Change, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT sequence, operation, change_time, data
+		FROM changes
+		WHERE sequence > ?
+		ORDER BY sequence
+		LIMIT ?`,
+		after,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	var changes []*racingapi.Change
+	for rows.Next() {
+		change, err := scanChange(rows)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// scanChange scans a change from the given scanner.
+func scanChange(s scanner) (*racingapi.Change, error) {
+	var (
+		change     racingapi.Change
+		operation  string
+		changeTime time.Time
+		data       []byte
+	)
+
+	if err := s.Scan(
+		&change.Sequence,
+		&operation,
+		&changeTime,
+		&data,
+	); err != nil {
+		return nil, err
+	}
+
+	change.Operation = racingapi.Change_Operation(
+		racingapi.Change_Operation_value[operation],
+	)
+	change.ChangeTime = timestamppb.New(changeTime)
+	change.Race = &racingapi.Race{}
+	if err := proto.Unmarshal(data, change.Race); err != nil {
+		return nil, err
+	}
+
+	return &change, nil
+}
+
+// recordChange appends a change of the race with the given ID to the change
+// log at the given time, and increments the version of the race unless it was
+// created by the change. It must be called within the transaction that made
+// the change, after the change has been made. Updates that leave the race as it
+// was are neither recorded nor change the version.
+func recordChange(
+	ctx context.Context,
+	tx *sql.Tx,
+	now time.Time,
+	raceID int64,
+	operation racingapi.Change_Operation,
+) error {
+	race, err := readSnapshot(ctx, tx, raceID)
+	if err != nil {
+		return err
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(race)
+	if err != nil {
+		return err
+	}
+
+	if operation == racingapi.Change_UPDATED {
+		var last []byte
+		err := tx.QueryRowContext(
+			ctx,
+			`SELECT data
+			FROM changes
+			WHERE race_id = ?
+			ORDER BY sequence DESC
+			LIMIT 1`,
+			raceID,
+		).Scan(&last)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if bytes.Equal(last, data) {
+			return nil
+		}
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO changes (race_id, operation, change_time, data)
+		VALUES (?, ?, ?, ?)`,
+		raceID,
+		operation.String(),
+		now.UTC().Format(time.RFC3339Nano),
+		data,
+	); err != nil {
+		return err
+	}
+
+	if operation == racingapi.Change_CREATED {
+		return nil
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE races SET version = version + 1 WHERE id = ?`,
+		raceID,
+	)
+
+	return err
+}
+
+// readSnapshot reads all fields of the race with the given ID except the etag,
+// which changes along with the snapshot. The status is read as it is stored,
+// as the status computed from the advertised start time depends on the time
+// the race is read at. Archived and deleted races are read as well.
+func readSnapshot(
+	ctx context.Context,
+	q querier,
+	raceID int64,
+) (*racingapi.Race, error) {
+	p, err := parseReadMask(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	delete(p.fields, "etag")
+	p.storedStatus = true
+
+	return scanRace(
+		q.QueryRowContext(
+			ctx,
+			fmt.Sprintf(
+				`SELECT %s
+				FROM %s
+				WHERE races.id = ?`,
+				p.selectList(),
+				racesTable,
+			),
+			raceID,
+		),
+		p,
+		time.Time{},
+	)
+}
+
+// parseCursor returns the sequence number of the change encoded in a cursor
+// returned by ListChanges. An empty cursor points before the first change.
+func parseCursor(ctx context.Context, cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		var sequence int64
+		sequence, err = strconv.ParseInt(string(raw), 10, 64)
+		if err == nil && sequence >= 0 {
+			return sequence, nil
+		}
+	}
+
+	return 0, apierror.InvalidArgument(
+		ctx,
+		"invalid cursor",
+		apierror.FieldViolation{
+			Field:       "cursor",
+			Description: "cursor must be returned by a previous call",
+		},
+	)
+}
+
+// formatCursor returns a cursor pointing after the change with the given
+// sequence number. Cursors are opaque to clients, so that their format can be
+// changed in the future.
+func formatCursor(sequence int64) string {
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(strconv.FormatInt(sequence, 10)),
+	)
+}
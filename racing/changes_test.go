@@ -0,0 +1,317 @@
+package racing_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/clock"
+	"github.com/danilvpetrov/entain/ingest"
+	"github.com/danilvpetrov/entain/outbox"
+	. "github.com/danilvpetrov/entain/racing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// changesFeed returns a feed with a single race named as given.
+func changesFeed(name string) *ingest.Feed {
+	return &ingest.Feed{
+		Meetings: []ingest.Meeting{
+			{ExternalID: "M1", Name: "Flemington"},
+		},
+		Races: []ingest.Race{
+			{
+				AdvertisedStartTime: time.Date(2025, 11, 4, 4, 0, 0, 0, time.UTC),
+				ExternalID:          "R1",
+				MeetingExternalID:   "M1",
+				Name:                name,
+				Number:              7,
+				Visible:             true,
+			},
+		},
+	}
+}
+
+// listAllChanges is a test helper that lists the changes after the given
+// cursor page by page, and returns them along with the cursor to resume
+// listing from.
+func listAllChanges(
+	t *testing.T,
+	client racingapi.RacingClient,
+	cursor string,
+) ([]*racingapi.Change, string) {
+	t.Helper()
+
+	var changes []*racingapi.Change
+	for {
+		resp, err := client.ListChanges(
+			asAdmin(t.Context()),
+			&racingapi.ListChangesRequest{
+				Cursor:   cursor,
+				PageSize: 30,
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if resp.GetNextCursor() == "" {
+			t.Fatal("expected next cursor to be set")
+		}
+		cursor = resp.GetNextCursor()
+
+		if len(resp.GetChanges()) == 0 {
+			return changes, cursor
+		}
+		changes = append(changes, resp.GetChanges()...)
+	}
+}
+
+func TestListChanges(t *testing.T) {
+	db := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db})
+	sink := &IngestSink{DB: db}
+
+	changes, cursor := listAllChanges(t, client, "")
+	if len(changes) != NumberOfSeededRaces {
+		t.Fatalf(
+			"expected %d changes, got %d",
+			NumberOfSeededRaces,
+			len(changes),
+		)
+	}
+
+	for i, change := range changes {
+		if change.GetOperation() != racingapi.Change_CREATED {
+			t.Fatalf(
+				"expected operation %v, got %v",
+				racingapi.Change_CREATED,
+				change.GetOperation(),
+			)
+		}
+		if i > 0 && change.GetSequence() <= changes[i-1].GetSequence() {
+			t.Fatal("expected changes to be ordered by sequence")
+		}
+		if change.GetRace().GetId() == 0 || change.GetRace().GetName() == "" {
+			t.Fatalf("expected race to be set, got %v", change.GetRace())
+		}
+		// The races are created open, whatever their advertised start time.
+		if change.GetRace().GetStatus() != racingapi.Race_OPEN {
+			t.Fatalf(
+				"expected stored status %v, got %v",
+				racingapi.Race_OPEN,
+				change.GetRace().GetStatus(),
+			)
+		}
+	}
+
+	t.Run("records created and updated races", func(t *testing.T) {
+		for _, name := range []string{
+			"Melbourne Cup",
+			"Melbourne Cup", // unchanged
+			"Lexus Melbourne Cup",
+		} {
+			if err := sink.Upsert(
+				t.Context(),
+				"<provider>",
+				changesFeed(name),
+			); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var changes []*racingapi.Change
+		changes, cursor = listAllChanges(t, client, cursor)
+
+		if len(changes) != 2 {
+			t.Fatalf("expected 2 changes, got %d", len(changes))
+		}
+
+		if changes[0].GetOperation() != racingapi.Change_CREATED {
+			t.Fatalf(
+				"expected operation %v, got %v",
+				racingapi.Change_CREATED,
+				changes[0].GetOperation(),
+			)
+		}
+
+		if changes[1].GetOperation() != racingapi.Change_UPDATED {
+			t.Fatalf(
+				"expected operation %v, got %v",
+				racingapi.Change_UPDATED,
+				changes[1].GetOperation(),
+			)
+		}
+
+		if changes[1].GetRace().GetName() != "Lexus Melbourne Cup" {
+			t.Fatalf(
+				"expected race name %q, got %q",
+				"Lexus Melbourne Cup",
+				changes[1].GetRace().GetName(),
+			)
+		}
+
+		if changes[0].GetRace().GetId() != changes[1].GetRace().GetId() {
+			t.Fatal("expected changes of the same race")
+		}
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		_, err := client.ListChanges(
+			t.Context(),
+			&racingapi.ListChangesRequest{},
+		)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := client.ListChanges(
+			asAdmin(t.Context()),
+			&racingapi.ListChangesRequest{Cursor: "<invalid>"},
+		)
+		assertInvalidArgument(t, err, "cursor")
+	})
+
+	t.Run("page size too large", func(t *testing.T) {
+		_, err := client.ListChanges(
+			asAdmin(t.Context()),
+			&racingapi.ListChangesRequest{PageSize: 1001},
+		)
+		assertInvalidArgument(t, err, "page_size")
+	})
+}
+
+func TestChangeTimes(t *testing.T) {
+	ingested := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	updated := ingested.Add(time.Hour)
+
+	db := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db, Clock: clock.Fixed(updated)})
+	sink := &IngestSink{DB: db, Clock: clock.Fixed(ingested)}
+
+	_, cursor := listAllChanges(t, client, "")
+
+	if err := sink.Upsert(
+		t.Context(),
+		"<provider>",
+		changesFeed("Melbourne Cup"),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, _ := listAllChanges(t, client, cursor)
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %d", len(changes))
+	}
+
+	if _, err := client.UpdateRace(
+		asAdmin(t.Context()),
+		&racingapi.UpdateRaceRequest{
+			Race: &racingapi.Race{
+				Id:   changes[0].GetRace().GetId(),
+				Name: "Lexus Melbourne Cup",
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+			Etag:       "*",
+		},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, _ = listAllChanges(t, client, cursor)
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d", len(changes))
+	}
+
+	for i, expected := range []time.Time{ingested, updated} {
+		if actual := changes[i].GetChangeTime().AsTime(); !actual.Equal(
+			expected,
+		) {
+			t.Fatalf("expected change time %v, got %v", expected, actual)
+		}
+	}
+}
+
+func TestChangesAreAppendOnly(t *testing.T) {
+	db := setupDatabase(t)
+
+	if _, err := db.ExecContext(
+		t.Context(),
+		`UPDATE changes SET operation = 'UPDATED'`,
+	); err == nil {
+		t.Fatal("expected updating changes to fail")
+	}
+
+	if _, err := db.ExecContext(
+		t.Context(),
+		`DELETE FROM changes`,
+	); err == nil {
+		t.Fatal("expected deleting changes to fail")
+	}
+}
+
+func TestOutboxSource(t *testing.T) {
+	db := setupDatabase(t)
+	sink := &outbox.MemorySink{}
+	relay := &outbox.Relay{
+		Source:    &OutboxSource{DB: db},
+		Sink:      sink,
+		BatchSize: NumberOfSeededRaces,
+	}
+
+	if _, err := relay.RunOnce(t.Context()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if n, err := relay.RunOnce(t.Context()); err != nil || n != 0 {
+		t.Fatalf("expected no changes published again, got %d, %v", n, err)
+	}
+
+	if err := (&IngestSink{DB: db}).Upsert(
+		t.Context(),
+		"<provider>",
+		changesFeed("Melbourne Cup"),
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := relay.RunOnce(t.Context()); err != nil || n != 1 {
+		t.Fatalf("expected 1 change published, got %d, %v", n, err)
+	}
+
+	msgs := sink.Messages()
+	if len(msgs) != NumberOfSeededRaces+1 {
+		t.Fatalf(
+			"expected %d messages, got %d",
+			NumberOfSeededRaces+1,
+			len(msgs),
+		)
+	}
+
+	msg := msgs[len(msgs)-1]
+	if msg.Topic != ChangesTopic {
+		t.Fatalf("expected topic %q, got %q", ChangesTopic, msg.Topic)
+	}
+
+	var change racingapi.Change
+	if err := protojson.Unmarshal(msg.Value, &change); err != nil {
+		t.Fatal(err)
+	}
+
+	if change.GetRace().GetName() != "Melbourne Cup" {
+		t.Fatalf(
+			"expected race name %q, got %q",
+			"Melbourne Cup",
+			change.GetRace().GetName(),
+		)
+	}
+
+	if key := strconv.FormatInt(change.GetRace().GetId(), 10); msg.Key != key {
+		t.Fatalf("expected key %q, got %q", key, msg.Key)
+	}
+}
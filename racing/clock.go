@@ -0,0 +1,54 @@
+package racing
+
+import (
+	"context"
+	"time"
+
+	"github.com/danilvpetrov/entain/admin"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/clock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// now returns the current time according to the clock of the service.
+func (s *Service) now() time.Time {
+	if s.Clock == nil {
+		return clock.System.Now()
+	}
+	return s.Clock.Now()
+}
+
+// asOf returns the time to compute the statuses of races at. It is the given
+// "as of" time of a request if it is set, or the current time otherwise.
+//
+// Only admins can set the "as of" time, to see the races as customers saw them
+// at a past moment.
+func (s *Service) asOf(
+	ctx context.Context,
+	asOf *timestamppb.Timestamp,
+) (time.Time, error) {
+	if asOf == nil {
+		return s.now(), nil
+	}
+
+	if !admin.IsAdmin(ctx) {
+		return time.Time{}, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"as_of can only be set by admins",
+		)
+	}
+
+	if err := asOf.CheckValid(); err != nil {
+		return time.Time{}, apierror.InvalidArgument(
+			ctx,
+			"invalid as of time",
+			apierror.FieldViolation{
+				Field:       "as_of",
+				Description: err.Error(),
+			},
+		)
+	}
+
+	return asOf.AsTime(), nil
+}
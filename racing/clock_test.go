@@ -0,0 +1,148 @@
+package racing_test
+
+import (
+	"database/sql"
+	"math/rand"
+	"testing"
+	"time"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/clock"
+	. "github.com/danilvpetrov/entain/racing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListRacesAsOf(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	db := setupDatabase(t)
+	if err := (&Seeder{
+		DB:    db,
+		Rand:  rand.New(rand.NewSource(1)),
+		Clock: clock.Fixed(now),
+	}).Seed(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Service{
+		DB:    db,
+		Clock: clock.Fixed(now),
+	}
+	client := setupServer(t, s)
+
+	// All seeded races start within a day before and two days after now.
+	before := now.AddDate(0, 0, -2)
+	after := now.AddDate(0, 0, 3)
+
+	t.Run("admin sees statuses as of the given time", func(t *testing.T) {
+		for asOf, expected := range map[time.Time]racingapi.Race_Status{
+			before: racingapi.Race_OPEN,
+			after:  racingapi.Race_CLOSED,
+		} {
+			resp, err := client.ListRaces(
+				asAdmin(t.Context()),
+				&racingapi.ListRacesRequest{
+					AsOf: timestamppb.New(asOf),
+				},
+			)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			for _, race := range resp.GetRaces() {
+				if race.GetStatus() != expected {
+					t.Fatalf(
+						"expected status %v as of %v, got %v",
+						expected,
+						asOf,
+						race.GetStatus(),
+					)
+				}
+			}
+		}
+	})
+
+	t.Run("non-admin is denied", func(t *testing.T) {
+		_, err := client.ListRaces(
+			t.Context(),
+			&racingapi.ListRacesRequest{
+				AsOf: timestamppb.New(before),
+			},
+		)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+	})
+
+	t.Run("invalid time is rejected", func(t *testing.T) {
+		_, err := client.ListRaces(
+			asAdmin(t.Context()),
+			&racingapi.ListRacesRequest{
+				AsOf: &timestamppb.Timestamp{Nanos: -1},
+			},
+		)
+		assertInvalidArgument(t, err, "as_of")
+	})
+}
+
+func TestSeeder(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	seed := func(t *testing.T) []*racingapi.Race {
+		t.Helper()
+
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = db.Close()
+		})
+
+		if err := ApplySchema(t.Context(), db); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := (&Seeder{
+			DB:    db,
+			Rand:  rand.New(rand.NewSource(42)),
+			Clock: clock.Fixed(now),
+		}).Seed(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+
+		s := &Service{
+			DB:    db,
+			Clock: clock.Fixed(now),
+		}
+		resp, err := s.ListRaces(t.Context(), &racingapi.ListRacesRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return resp.GetRaces()
+	}
+
+	first := seed(t)
+	second := seed(t)
+
+	if len(first) != NumberOfSeededRaces {
+		t.Fatalf(
+			"expected %d races, got %d",
+			NumberOfSeededRaces,
+			len(first),
+		)
+	}
+
+	if len(first) != len(second) {
+		t.Fatalf("expected %d races, got %d", len(first), len(second))
+	}
+
+	for i := range first {
+		if !proto.Equal(first[i], second[i]) {
+			t.Fatalf("expected race %v, got %v", first[i], second[i])
+		}
+	}
+}
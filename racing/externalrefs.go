@@ -0,0 +1,108 @@
+package racing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/apierror"
+)
+
+// Kinds of entities mapped in the external_refs table.
+const (
+	entityMeeting = "meeting"
+	entityRace    = "race"
+)
+
+// purgedID is the internal ID the external references of purged races are
+// mapped to. IDs of races start at 1, so it never refers to a race.
+const purgedID = 0
+
+// GetRaceByExternalId returns a specific race by the ID assigned to it by an
+// external provider.
+//
+//nolint:revive // The name is dictated by the generated RacingServer interface.
+func (s *Service) GetRaceByExternalId(
+	ctx context.Context,
+	req *racingapi.GetRaceByExternalIdRequest,
+) (*racingapi.Race, error) {
+	id, ok, err := lookupExternalRef(
+		ctx,
+		s.DB,
+		entityRace,
+		req.GetProvider(),
+		req.GetExternalId(),
+	)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	if !ok {
+		return nil, apierror.NotFound(ctx, "RACE_NOT_FOUND", "race not found")
+	}
+
+	return s.GetRace(
+		ctx,
+		&racingapi.GetRaceRequest{
+			RaceId:          id,
+			ReadMask:        req.GetReadMask(),
+			IncludeArchived: req.GetIncludeArchived(),
+		},
+	)
+}
+
+// querier is an interface that abstracts sql.DB and sql.Tx types.
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// lookupExternalRef returns the internal ID of an entity with the given
+// external ID. It returns false if the entity has not been ingested from the
+// provider before.
+func lookupExternalRef(
+	ctx context.Context,
+	q querier,
+	entity, provider, externalID string,
+) (int64, bool, error) {
+	var id int64
+	err := q.QueryRowContext(
+		ctx,
+		`SELECT internal_id
+		FROM external_refs
+		WHERE entity = ? AND provider = ? AND external_id = ?`,
+		entity,
+		provider,
+		externalID,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	return id, err == nil, err
+}
+
+// insertExternalRef maps an external ID to the ID of the entity inserted by
+// the given result.
+func insertExternalRef(
+	ctx context.Context,
+	tx *sql.Tx,
+	entity, provider, externalID string,
+	res sql.Result,
+) error {
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO external_refs (entity, provider, external_id, internal_id)
+		VALUES (?, ?, ?, ?)`,
+		entity,
+		provider,
+		externalID,
+		id,
+	)
+
+	return err
+}
@@ -0,0 +1,157 @@
+package racing_test
+
+import (
+	"testing"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/ingest"
+	. "github.com/danilvpetrov/entain/racing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestGetRaceByExternalId(t *testing.T) {
+	db := setupDatabase(t)
+
+	sink := &IngestSink{DB: db}
+	if err := sink.Upsert(
+		t.Context(),
+		"<provider>",
+		&ingest.Feed{
+			Meetings: []ingest.Meeting{{ExternalID: "M1", Name: "Flemington"}},
+			Races: []ingest.Race{
+				{
+					ExternalID:        "1",
+					MeetingExternalID: "M1",
+					Name:              "Melbourne Cup",
+					Number:            7,
+				},
+			},
+		},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	client := setupServer(t, &Service{DB: db})
+
+	cases := []struct {
+		assertion func(
+			t *testing.T,
+			race *racingapi.Race,
+			err error,
+		)
+		req  *racingapi.GetRaceByExternalIdRequest
+		name string
+	}{
+		{
+			name: "gets seeded race",
+			req: &racingapi.GetRaceByExternalIdRequest{
+				Provider:   SeedProvider,
+				ExternalId: "1",
+			},
+			assertion: func(t *testing.T, race *racingapi.Race, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if race.GetId() != 1 {
+					t.Fatalf("expected race ID to be 1, got %d", race.GetId())
+				}
+			},
+		},
+		{
+			name: "gets ingested race with the same external ID",
+			req: &racingapi.GetRaceByExternalIdRequest{
+				Provider:   "<provider>",
+				ExternalId: "1",
+				ReadMask: &fieldmaskpb.FieldMask{
+					Paths: []string{"name"},
+				},
+			},
+			assertion: func(t *testing.T, race *racingapi.Race, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if race.GetName() != "Melbourne Cup" {
+					t.Fatalf(
+						"expected race name %q, got %q",
+						"Melbourne Cup",
+						race.GetName(),
+					)
+				}
+
+				if race.GetId() != 0 {
+					t.Fatalf("expected unmasked fields to be unset, got %+v", race)
+				}
+			},
+		},
+		{
+			name: "unknown external ID",
+			req: &racingapi.GetRaceByExternalIdRequest{
+				Provider:   SeedProvider,
+				ExternalId: "<external-id>",
+			},
+			assertion: func(t *testing.T, _ *racingapi.Race, err error) {
+				if status.Code(err) != codes.NotFound {
+					t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+				}
+			},
+		},
+		{
+			name: "unknown provider",
+			req: &racingapi.GetRaceByExternalIdRequest{
+				Provider:   "<unknown>",
+				ExternalId: "1",
+			},
+			assertion: func(t *testing.T, _ *racingapi.Race, err error) {
+				if status.Code(err) != codes.NotFound {
+					t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+				}
+			},
+		},
+		{
+			name: "missing provider",
+			req: &racingapi.GetRaceByExternalIdRequest{
+				ExternalId: "1",
+			},
+			assertion: func(t *testing.T, _ *racingapi.Race, err error) {
+				assertInvalidArgument(t, err, "provider")
+			},
+		},
+		{
+			name: "missing external ID",
+			req: &racingapi.GetRaceByExternalIdRequest{
+				Provider: SeedProvider,
+			},
+			assertion: func(t *testing.T, _ *racingapi.Race, err error) {
+				assertInvalidArgument(t, err, "external_id")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp, err := client.GetRaceByExternalId(t.Context(), c.req)
+			c.assertion(t, resp, err)
+		})
+	}
+}
+
+func TestSeedTestData(t *testing.T) {
+	db := setupDatabase(t)
+
+	// Seeding the database again updates the seeded races.
+	if err := SeedTestData(t.Context(), db); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := countRows(t, db, "races"); n != NumberOfSeededRaces {
+		t.Fatalf("expected %d races, got %d", NumberOfSeededRaces, n)
+	}
+
+	if n := countRows(t, db, "meetings"); n != NumberOfSeededMeetings {
+		t.Fatalf("expected %d meetings, got %d", NumberOfSeededMeetings, n)
+	}
+}
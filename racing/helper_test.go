@@ -1,17 +1,62 @@
 package racing_test
 
 import (
+	"context"
 	"database/sql"
 	"net"
 	"testing"
 
-	racingapi "github.com/danilvpetrov/entain/api/racing"
+	"github.com/danilvpetrov/entain/admin"
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	racingv2 "github.com/danilvpetrov/entain/api/racing/v2"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/jurisdiction"
 	. "github.com/danilvpetrov/entain/racing"
+	"github.com/danilvpetrov/entain/tenant"
+	"github.com/danilvpetrov/entain/validation"
 	_ "github.com/mattn/go-sqlite3" // underscore import for the SQLite driver
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
+const (
+	// testAdminToken is the token that identifies the admin in the test
+	// servers.
+	testAdminToken = "test-admin-token"
+
+	// testAdminName is the name of the admin identified by testAdminToken.
+	testAdminName = "test-admin"
+)
+
+// testAdminTokens are the admin tokens of the test servers.
+var testAdminTokens = admin.Tokens{testAdminToken: testAdminName}
+
+// asAdmin is a test helper that returns a context of a request made by an
+// admin.
+func asAdmin(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(
+		ctx,
+		"authorization",
+		"Bearer "+testAdminToken,
+	)
+}
+
+// asTenant is a test helper that returns a context of a request made on behalf
+// of the given tenant.
+func asTenant(ctx context.Context, name string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, tenant.MetadataKey, name)
+}
+
+// fromJurisdiction is a test helper that returns a context of a request made
+// from the jurisdiction with the given code.
+func fromJurisdiction(ctx context.Context, code string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, jurisdiction.MetadataKey, code)
+}
+
 // setupDatabase is a test helper that sets up a test database, seeds it with
 // test data, and returns a connection to it.
 func setupDatabase(t *testing.T) *sql.DB {
@@ -46,8 +91,63 @@ func setupServer(
 ) racingapi.RacingClient {
 	t.Helper()
 
-	server := grpc.NewServer()
-	racingapi.RegisterRacingServer(server, s)
+	return racingapi.NewRacingClient(
+		setupConn(t, func(server *grpc.Server) {
+			racingapi.RegisterRacingServer(server, s)
+		}),
+	)
+}
+
+// setupServerV2 is test helper that sets up a gRPC server of version 2 of the
+// API for testing and returns a client connected to it.
+func setupServerV2(
+	t *testing.T,
+	s racingv2.RacingServer,
+) racingv2.RacingClient {
+	t.Helper()
+
+	return racingv2.NewRacingClient(
+		setupConn(t, func(server *grpc.Server) {
+			racingv2.RegisterRacingServer(server, s)
+		}),
+	)
+}
+
+// setupConn is test helper that sets up a gRPC server with the services
+// registered by the given function, and returns a connection to it.
+func setupConn(
+	t *testing.T,
+	register func(*grpc.Server),
+) *grpc.ClientConn {
+	t.Helper()
+
+	validationInterceptor, err := validation.UnaryServerInterceptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	validationStreamInterceptor, err := validation.StreamServerInterceptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			apierror.UnaryServerInterceptor(),
+			admin.UnaryServerInterceptor(testAdminTokens),
+			tenant.UnaryServerInterceptor(),
+			jurisdiction.UnaryServerInterceptor(),
+			validationInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			apierror.StreamServerInterceptor(),
+			admin.StreamServerInterceptor(testAdminTokens),
+			tenant.StreamServerInterceptor(),
+			jurisdiction.StreamServerInterceptor(),
+			validationStreamInterceptor,
+		),
+	)
+	register(server)
 
 	listenCfg := net.ListenConfig{}
 	// Listen on a random port.
@@ -73,5 +173,31 @@ func setupServer(
 		_ = conn.Close()
 	})
 
-	return racingapi.NewRacingClient(conn)
+	return conn
+}
+
+// assertInvalidArgument is a test helper that asserts that the error is an
+// InvalidArgument error reporting a violation of the given request field.
+func assertInvalidArgument(t *testing.T, err error, field string) {
+	t.Helper()
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("expected %v error, got %v", codes.InvalidArgument, err)
+	}
+
+	for _, d := range st.Details() {
+		br, ok := d.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+
+		for _, v := range br.GetFieldViolations() {
+			if v.GetField() == field {
+				return
+			}
+		}
+	}
+
+	t.Fatalf("expected violation of field %q, got %v", field, st.Details())
 }
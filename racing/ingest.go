@@ -0,0 +1,227 @@
+package racing
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/clock"
+	"github.com/danilvpetrov/entain/ingest"
+)
+
+// IngestSink stores races and meetings ingested from external providers. It
+// implements ingest.Sink interface.
+type IngestSink struct {
+	// DB is a database connection pool used to store the ingested entities.
+	DB *sql.DB
+
+	// Clock is the source of the current time the changes of the ingested
+	// entities are recorded at. If it is nil, clock.System is used.
+	Clock clock.Clock
+}
+
+// Make sure IngestSink implements the ingest.Sink interface.
+var _ ingest.Sink = (*IngestSink)(nil)
+
+// Upsert stores the meetings and the races of a feed within a single
+// transaction. Sports events are ignored.
+func (s *IngestSink) Upsert(
+	ctx context.Context,
+	provider string,
+	feed *ingest.Feed,
+) (err error) {
+	if len(feed.GetMeetings()) == 0 && len(feed.GetRaces()) == 0 {
+		return nil
+	}
+
+	now := clock.System.Now()
+	if s.Clock != nil {
+		now = s.Clock.Now()
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for _, m := range feed.GetMeetings() {
+		if err := upsertMeeting(ctx, tx, provider, m); err != nil {
+			return fmt.Errorf("meeting %q: %w", m.ExternalID, err)
+		}
+	}
+
+	for _, r := range feed.GetRaces() {
+		if err := upsertRace(ctx, tx, now, provider, r); err != nil {
+			return fmt.Errorf("race %q: %w", r.ExternalID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// upsertMeeting stores an ingested meeting.
+func upsertMeeting(
+	ctx context.Context,
+	tx *sql.Tx,
+	provider string,
+	m ingest.Meeting,
+) error {
+	var timezone sql.Null[string]
+	if m.Timezone != "" {
+		if _, err := time.LoadLocation(m.Timezone); err != nil {
+			return fmt.Errorf("unknown timezone %q", m.Timezone)
+		}
+		timezone = sql.Null[string]{V: m.Timezone, Valid: true}
+	}
+
+	id, ok, err := lookupExternalRef(
+		ctx,
+		tx,
+		entityMeeting,
+		provider,
+		m.ExternalID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		_, err := tx.ExecContext(
+			ctx,
+			`UPDATE meetings SET name = ?, timezone = ? WHERE id = ?`,
+			m.Name,
+			timezone,
+			id,
+		)
+		return err
+	}
+
+	res, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO meetings (name, timezone) VALUES (?, ?)`,
+		m.Name,
+		timezone,
+	)
+	if err != nil {
+		return err
+	}
+
+	return insertExternalRef(
+		ctx,
+		tx,
+		entityMeeting,
+		provider,
+		m.ExternalID,
+		res,
+	)
+}
+
+// upsertRace stores an ingested race. The meeting of the race must have been
+// ingested from the same provider.
+func upsertRace(
+	ctx context.Context,
+	tx *sql.Tx,
+	now time.Time,
+	provider string,
+	r ingest.Race,
+) error {
+	meetingID, ok, err := lookupExternalRef(
+		ctx,
+		tx,
+		entityMeeting,
+		provider,
+		r.MeetingExternalID,
+	)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("unknown meeting %q", r.MeetingExternalID)
+	}
+
+	id, ok, err := lookupExternalRef(
+		ctx,
+		tx,
+		entityRace,
+		provider,
+		r.ExternalID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		// Deleted races are never updated, so that they are not resurrected
+		// by the providers still offering them.
+		res, err := tx.ExecContext(
+			ctx,
+			`UPDATE races
+			SET
+				meeting_id = ?,
+				name = ?,
+				number = ?,
+				visible = ?,
+				advertised_start_time = ?
+			WHERE id = ? AND deleted_at IS NULL`,
+			meetingID,
+			r.Name,
+			r.Number,
+			r.Visible,
+			r.AdvertisedStartTime.UTC().Format(time.RFC3339),
+			id,
+		)
+		if err != nil {
+			return err
+		}
+
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			return err
+		}
+
+		return recordChange(ctx, tx, now, id, racingapi.Change_UPDATED)
+	}
+
+	res, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO races (
+			meeting_id,
+			name,
+			number,
+			visible,
+			advertised_start_time
+		) VALUES (?, ?, ?, ?, ?)`,
+		meetingID,
+		r.Name,
+		r.Number,
+		r.Visible,
+		r.AdvertisedStartTime.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := insertExternalRef(
+		ctx,
+		tx,
+		entityRace,
+		provider,
+		r.ExternalID,
+		res,
+	); err != nil {
+		return err
+	}
+
+	id, err = res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	return recordChange(ctx, tx, now, id, racingapi.Change_CREATED)
+}
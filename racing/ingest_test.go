@@ -0,0 +1,139 @@
+package racing_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/danilvpetrov/entain/ingest"
+	. "github.com/danilvpetrov/entain/racing"
+)
+
+func TestIngestSink(t *testing.T) {
+	feed := &ingest.Feed{
+		Meetings: []ingest.Meeting{
+			{ExternalID: "M1", Name: "Flemington"},
+		},
+		Races: []ingest.Race{
+			{
+				AdvertisedStartTime: time.Date(2025, 11, 4, 4, 0, 0, 0, time.UTC),
+				ExternalID:          "R1",
+				MeetingExternalID:   "M1",
+				Name:                "Melbourne Cup",
+				Number:              7,
+				Visible:             true,
+			},
+		},
+	}
+
+	t.Run("stores races once per external ID", func(t *testing.T) {
+		db := setupDatabase(t)
+		sink := &IngestSink{DB: db}
+		before := countRows(t, db, "races")
+		meetings := countRows(t, db, "meetings")
+
+		if err := sink.Upsert(t.Context(), "<provider>", feed); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		updated := &ingest.Feed{
+			Meetings: feed.Meetings,
+			Races:    []ingest.Race{feed.Races[0]},
+		}
+		updated.Races[0].Name = "Lexus Melbourne Cup"
+
+		if err := sink.Upsert(t.Context(), "<provider>", updated); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if n := countRows(t, db, "races"); n != before+1 {
+			t.Fatalf("expected %d races, got %d", before+1, n)
+		}
+
+		if n := countRows(t, db, "meetings"); n != meetings+1 {
+			t.Fatalf("expected %d meetings, got %d", meetings+1, n)
+		}
+
+		var name string
+		if err := db.QueryRowContext(
+			t.Context(),
+			`SELECT name FROM races ORDER BY id DESC LIMIT 1`,
+		).Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+
+		if name != "Lexus Melbourne Cup" {
+			t.Fatalf("expected race name %q, got %q", "Lexus Melbourne Cup", name)
+		}
+	})
+
+	t.Run("external IDs are scoped to the provider", func(t *testing.T) {
+		db := setupDatabase(t)
+		sink := &IngestSink{DB: db}
+		before := countRows(t, db, "races")
+
+		for _, provider := range []string{"<provider-1>", "<provider-2>"} {
+			if err := sink.Upsert(t.Context(), provider, feed); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+
+		if n := countRows(t, db, "races"); n != before+2 {
+			t.Fatalf("expected %d races, got %d", before+2, n)
+		}
+	})
+
+	t.Run("unknown meeting", func(t *testing.T) {
+		db := setupDatabase(t)
+		sink := &IngestSink{DB: db}
+		before := countRows(t, db, "races")
+
+		err := sink.Upsert(
+			t.Context(),
+			"<provider>",
+			&ingest.Feed{Races: feed.Races},
+		)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if n := countRows(t, db, "races"); n != before {
+			t.Fatalf("expected the feed to be rolled back, got %d races", n)
+		}
+	})
+
+	t.Run("unknown timezone", func(t *testing.T) {
+		db := setupDatabase(t)
+		sink := &IngestSink{DB: db}
+		before := countRows(t, db, "races")
+
+		invalid := &ingest.Feed{
+			Meetings: []ingest.Meeting{feed.Meetings[0]},
+			Races:    feed.Races,
+		}
+		invalid.Meetings[0].Timezone = "<timezone>"
+
+		if err := sink.Upsert(t.Context(), "<provider>", invalid); err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if n := countRows(t, db, "races"); n != before {
+			t.Fatalf("expected the feed to be rolled back, got %d races", n)
+		}
+	})
+}
+
+// countRows is a test helper that returns the number of rows in a table.
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	t.Helper()
+
+	var n int
+	if err := db.QueryRowContext(
+		t.Context(),
+		`SELECT COUNT(*) FROM `+table,
+	).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+
+	return n
+}
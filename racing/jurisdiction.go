@@ -0,0 +1,65 @@
+package racing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/jurisdiction"
+)
+
+// restrictionFilter returns SQL filter query excluding the races of the
+// meetings that cannot be offered in the jurisdiction of the given context,
+// along with its arguments.
+func (s *Service) restrictionFilter(ctx context.Context) (string, []any) {
+	meetings := s.Jurisdictions.Meetings(jurisdiction.Code(ctx))
+	if len(meetings) == 0 {
+		return "", nil
+	}
+
+	args := make([]any, 0, len(meetings))
+	for _, id := range meetings {
+		args = append(args, id)
+	}
+
+	return fmt.Sprintf(
+		" AND races.meeting_id NOT IN (%s)",
+		placeholders(len(meetings)),
+	), args
+}
+
+// notFound returns the error of a read of a race with the given ID that is
+// not found with the given restriction filter applied. The error has
+// codes.PermissionDenied code if the race exists but it is restricted in the
+// jurisdiction of the given context, and codes.NotFound code otherwise.
+func (s *Service) notFound(
+	ctx context.Context,
+	restriction string,
+	raceID int64,
+	includeArchived bool,
+) error {
+	if restriction == "" {
+		return apierror.NotFound(ctx, "RACE_NOT_FOUND", "race not found")
+	}
+
+	var exists bool
+	if err := s.DB.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (
+			SELECT 1 FROM races WHERE races.id = ?`+
+			lifecycleFilter(includeArchived)+`
+		)`,
+		raceID,
+	).Scan(&exists); err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	if !exists {
+		return apierror.NotFound(ctx, "RACE_NOT_FOUND", "race not found")
+	}
+
+	return jurisdiction.Restricted(
+		ctx,
+		"race is not available in your jurisdiction",
+	)
+}
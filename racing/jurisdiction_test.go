@@ -0,0 +1,209 @@
+package racing_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/jurisdiction"
+	. "github.com/danilvpetrov/entain/racing"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestJurisdictions(t *testing.T) { //nolint:gocognit // Explicit test cases.
+	db := setupDatabase(t)
+	s := &Service{DB: db}
+	client := setupServer(t, s)
+
+	race, err := client.GetRace(
+		t.Context(),
+		&racingapi.GetRaceRequest{RaceId: 1},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	s.Jurisdictions = setupRules(
+		t,
+		fmt.Sprintf(
+			"rules: [{jurisdictions: [AU], meetings: [%d]}]",
+			race.GetMeetingId(),
+		),
+	)
+
+	t.Run("lists the races offered in the jurisdiction", func(t *testing.T) {
+		resp, err := client.ListRaces(
+			fromJurisdiction(t.Context(), "AU-NSW"),
+			&racingapi.ListRacesRequest{},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(resp.GetRaces()) == 0 {
+			t.Fatal("expected some races to be listed")
+		}
+
+		for _, r := range resp.GetRaces() {
+			if r.GetMeetingId() == race.GetMeetingId() {
+				t.Fatalf("expected restricted race not to be listed: %v", r)
+			}
+		}
+	})
+
+	t.Run("lists all races in other jurisdictions", func(t *testing.T) {
+		resp, err := client.ListRaces(
+			fromJurisdiction(t.Context(), "NZ"),
+			&racingapi.ListRacesRequest{},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(resp.GetRaces()) != NumberOfSeededRaces {
+			t.Fatalf(
+				"expected %d races, got %d",
+				NumberOfSeededRaces,
+				len(resp.GetRaces()),
+			)
+		}
+	})
+
+	t.Run("exports the races offered in the jurisdiction", func(t *testing.T) {
+		races := exportRaces(
+			fromJurisdiction(t.Context(), "AU-NSW"),
+			t,
+			client,
+			&racingapi.ExportRacesRequest{},
+		)
+
+		if len(races) == 0 {
+			t.Fatal("expected some races to be exported")
+		}
+
+		for _, r := range races {
+			if r.GetMeetingId() == race.GetMeetingId() {
+				t.Fatalf("expected restricted race not to be exported: %v", r)
+			}
+		}
+	})
+
+	t.Run("restricted race", func(t *testing.T) {
+		_, err := client.GetRace(
+			fromJurisdiction(t.Context(), "AU-NSW"),
+			&racingapi.GetRaceRequest{RaceId: 1},
+		)
+		assertRestricted(t, err)
+	})
+
+	t.Run("restricted race in a batch", func(t *testing.T) {
+		resp, err := client.BatchGetRaces(
+			fromJurisdiction(t.Context(), "AU"),
+			&racingapi.BatchGetRacesRequest{RaceId: []int64{1}},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(resp.GetRaces()) != 0 ||
+			len(resp.GetMissingRaceId()) != 1 {
+			t.Fatalf("expected race 1 to be missing, got %v", resp)
+		}
+	})
+
+	t.Run("unknown race", func(t *testing.T) {
+		_, err := client.GetRace(
+			fromJurisdiction(t.Context(), "AU"),
+			&racingapi.GetRaceRequest{RaceId: 1_000_000},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("updates a restricted race", func(t *testing.T) {
+		updated, err := client.UpdateRace(
+			fromJurisdiction(asAdmin(t.Context()), "AU"),
+			&racingapi.UpdateRaceRequest{
+				Race:       &racingapi.Race{Id: 1, Name: "Renamed race"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+				Etag:       race.GetEtag(),
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if updated.GetName() != "Renamed race" {
+			t.Fatalf("expected race to be updated, got %v", updated)
+		}
+	})
+
+	t.Run("reloads the rules", func(t *testing.T) {
+		if err := os.WriteFile(
+			s.Jurisdictions.Path,
+			[]byte("rules: [{jurisdictions: [NZ], meetings: [1000000]}]"),
+			0o600,
+		); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := s.Jurisdictions.Load(); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.GetRace(
+			fromJurisdiction(t.Context(), "AU"),
+			&racingapi.GetRaceRequest{RaceId: 1},
+		); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	})
+}
+
+// setupRules is a test helper that returns jurisdiction rules loaded from a
+// file with the given content.
+func setupRules(t *testing.T, content string) *jurisdiction.Rules {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &jurisdiction.Rules{Path: path}
+	if _, err := r.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+// assertRestricted is a test helper that asserts that the error is returned
+// because the requested entity is restricted in the jurisdiction.
+func assertRestricted(t *testing.T, err error) {
+	t.Helper()
+
+	st := status.Convert(err)
+	if st.Code() != codes.PermissionDenied {
+		t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+	}
+
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok &&
+			info.GetReason() == jurisdiction.ReasonRestricted {
+			return
+		}
+	}
+
+	t.Fatalf(
+		"expected reason %q, got %v",
+		jurisdiction.ReasonRestricted,
+		st.Details(),
+	)
+}
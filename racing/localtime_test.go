@@ -0,0 +1,167 @@
+package racing_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/ingest"
+	. "github.com/danilvpetrov/entain/racing"
+)
+
+func TestListRacesByLocalDate(t *testing.T) {
+	db := setupDatabase(t)
+
+	// The races are named after their external IDs. They are advertised to
+	// start around the daylight saving time transitions in Sydney, where
+	// 2025-10-05 lasts 23 hours, and in London, where 2025-10-26 lasts 25
+	// hours.
+	feed := &ingest.Feed{
+		Meetings: []ingest.Meeting{
+			{ExternalID: "SYD", Name: "Randwick", Timezone: "Australia/Sydney"},
+			{ExternalID: "LON", Name: "Ascot", Timezone: "Europe/London"},
+			{ExternalID: "UNK", Name: "Unknown"},
+		},
+	}
+
+	races := []struct {
+		meeting string
+		name    string
+		start   string
+	}{
+		{"SYD", "syd-before", "2025-10-04T13:59:00Z"},
+		{"SYD", "syd-first", "2025-10-04T14:00:00Z"},
+		{"SYD", "syd-last", "2025-10-05T12:59:00Z"},
+		{"SYD", "syd-after", "2025-10-05T13:00:00Z"},
+		{"LON", "lon-before", "2025-10-25T22:59:00Z"},
+		{"LON", "lon-first", "2025-10-25T23:00:00Z"},
+		{"LON", "lon-last", "2025-10-26T23:59:00Z"},
+		{"LON", "lon-after", "2025-10-27T00:00:00Z"},
+		{"UNK", "unk", "2025-10-05T00:00:00Z"},
+	}
+
+	for _, r := range races {
+		start, err := time.Parse(time.RFC3339, r.start)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		feed.Races = append(feed.Races, ingest.Race{
+			AdvertisedStartTime: start,
+			ExternalID:          r.name,
+			MeetingExternalID:   r.meeting,
+			Name:                r.name,
+		})
+	}
+
+	sink := &IngestSink{DB: db}
+	if err := sink.Upsert(t.Context(), "<provider>", feed); err != nil {
+		t.Fatal(err)
+	}
+
+	client := setupServer(t, &Service{DB: db})
+
+	// Limit the races to the ingested meetings, leaving out the seeded ones.
+	var meetingIDs []int64
+	for _, name := range []string{"syd-before", "lon-before", "unk"} {
+		race, err := client.GetRaceByExternalId(
+			t.Context(),
+			&racingapi.GetRaceByExternalIdRequest{
+				Provider:   "<provider>",
+				ExternalId: name,
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		meetingIDs = append(meetingIDs, race.GetMeetingId())
+	}
+
+	cases := []struct {
+		// expected maps the names of the expected races to their timezones
+		// and local advertised start times.
+		expected  map[string][2]string
+		localDate string
+		name      string
+	}{
+		{
+			name:      "day when daylight saving time starts",
+			localDate: "2025-10-05",
+			expected: map[string][2]string{
+				"syd-first": {"Australia/Sydney", "2025-10-05T00:00:00+10:00"},
+				"syd-last":  {"Australia/Sydney", "2025-10-05T23:59:00+11:00"},
+				"unk":       {"UTC", "2025-10-05T00:00:00Z"},
+			},
+		},
+		{
+			name:      "day before daylight saving time starts",
+			localDate: "2025-10-04",
+			expected: map[string][2]string{
+				"syd-before": {"Australia/Sydney", "2025-10-04T23:59:00+10:00"},
+			},
+		},
+		{
+			name:      "day when daylight saving time ends",
+			localDate: "2025-10-26",
+			expected: map[string][2]string{
+				"lon-first": {"Europe/London", "2025-10-26T00:00:00+01:00"},
+				"lon-last":  {"Europe/London", "2025-10-26T23:59:00Z"},
+			},
+		},
+		{
+			name:      "day after daylight saving time ends",
+			localDate: "2025-10-27",
+			expected: map[string][2]string{
+				"lon-after": {"Europe/London", "2025-10-27T00:00:00Z"},
+			},
+		},
+		{
+			name:      "day without races",
+			localDate: "2025-10-01",
+			expected:  map[string][2]string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp, err := client.ListRaces(
+				t.Context(),
+				&racingapi.ListRacesRequest{
+					MeetingId: meetingIDs,
+					LocalDate: c.localDate,
+				},
+			)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			actual := map[string][2]string{}
+			for _, race := range resp.GetRaces() {
+				actual[race.GetName()] = [2]string{
+					race.GetTimezone(),
+					race.GetLocalAdvertisedStartTime(),
+				}
+			}
+
+			if !maps.Equal(actual, c.expected) {
+				t.Fatalf(
+					"expected races %v, got %v",
+					slices.Sorted(maps.Keys(c.expected)),
+					actual,
+				)
+			}
+		})
+	}
+
+	t.Run("invalid local date", func(t *testing.T) {
+		for _, date := range []string{"2025-02-30", "05/10/2025"} {
+			_, err := client.ListRaces(
+				t.Context(),
+				&racingapi.ListRacesRequest{LocalDate: date},
+			)
+			assertInvalidArgument(t, err, "local_date")
+		}
+	})
+}
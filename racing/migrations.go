@@ -0,0 +1,150 @@
+package racing
+
+import (
+	"context"
+	"database/sql"
+)
+
+// migrateMeetingTimezones adds the IANA timezone of the venue to meetings.
+// The timezone of the existing meetings is left unknown.
+func migrateMeetingTimezones(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`ALTER TABLE meetings ADD COLUMN timezone TEXT`,
+	)
+
+	return err
+}
+
+// migrateChanges adds the append-only change log of races, and the table
+// recording the position of the outbox relay in it. The changes made before
+// the migration are not recorded.
+func migrateChanges(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`CREATE TABLE changes (
+			sequence INTEGER PRIMARY KEY AUTOINCREMENT,
+			race_id INTEGER NOT NULL,
+			operation TEXT NOT NULL,
+			change_time DATETIME NOT NULL,
+			data BLOB NOT NULL
+		);
+
+		CREATE INDEX idx_changes_race_id ON changes(race_id);
+
+		CREATE TRIGGER changes_no_update
+		BEFORE UPDATE ON changes
+		BEGIN
+			SELECT RAISE(ABORT, 'changes are append-only');
+		END;
+
+		CREATE TRIGGER changes_no_delete
+		BEFORE DELETE ON changes
+		BEGIN
+			SELECT RAISE(ABORT, 'changes are append-only');
+		END;
+
+		CREATE TABLE outbox_offset (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			sequence INTEGER NOT NULL
+		);`,
+	)
+
+	return err
+}
+
+// migrateLifecycle adds the times races were archived and deleted at. The
+// existing races are neither archived nor deleted.
+func migrateLifecycle(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`ALTER TABLE races ADD COLUMN archived_at DATETIME;
+
+		ALTER TABLE races ADD COLUMN deleted_at DATETIME;
+
+		CREATE INDEX idx_races_archived_at ON races(archived_at);`,
+	)
+
+	return err
+}
+
+// migrateAuditLog adds the append-only audit log of the changes made to
+// races through the API.
+func migrateAuditLog(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`CREATE TABLE audit_entries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			actor TEXT NOT NULL,
+			method TEXT NOT NULL,
+			entity_type TEXT NOT NULL,
+			entity_id INTEGER NOT NULL,
+			entry_time DATETIME NOT NULL,
+			changes BLOB NOT NULL
+		);
+
+		CREATE INDEX idx_audit_entries_entity
+		ON audit_entries(entity_type, entity_id);
+
+		CREATE INDEX idx_audit_entries_actor ON audit_entries(actor);
+
+		CREATE TRIGGER audit_entries_no_update
+		BEFORE UPDATE ON audit_entries
+		BEGIN
+			SELECT RAISE(ABORT, 'audit entries are append-only');
+		END;
+
+		CREATE TRIGGER audit_entries_no_delete
+		BEFORE DELETE ON audit_entries
+		BEGIN
+			SELECT RAISE(ABORT, 'audit entries are append-only');
+		END;`,
+	)
+
+	return err
+}
+
+// migrateVersions adds the versions of races, which are incremented every time
+// a race is changed. The existing races start at the first version.
+func migrateVersions(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`ALTER TABLE races ADD COLUMN version INTEGER NOT NULL DEFAULT 1`,
+	)
+
+	return err
+}
+
+// migrateTenantOverrides adds the overrides of the visibility and the ordering
+// of races for tenants. A NULL visibility or position is not overridden.
+func migrateTenantOverrides(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`CREATE TABLE tenant_races (
+			tenant TEXT NOT NULL,
+			race_id INTEGER NOT NULL,
+			visible INTEGER,
+			position INTEGER,
+			PRIMARY KEY (tenant, race_id)
+		)`,
+	)
+
+	return err
+}
+
+// migrateStatuses adds the stored statuses of races. The existing races are
+// open unless they are advertised to have started already.
+func migrateStatuses(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`ALTER TABLE races ADD COLUMN status TEXT NOT NULL DEFAULT 'OPEN';
+
+		UPDATE races
+		SET status = 'CLOSED'
+		WHERE datetime(advertised_start_time) <= datetime('now');
+
+		CREATE INDEX idx_races_status ON races(status, advertised_start_time);`,
+	)
+
+	return err
+}
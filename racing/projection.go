@@ -0,0 +1,226 @@
+package racing
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/etag"
+	"github.com/danilvpetrov/entain/tenant"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// racesTable is the FROM clause of queries reading races. Meetings are joined
+// to read the timezones of their venues.
+const racesTable = `races
+	LEFT JOIN meetings ON meetings.id = races.meeting_id`
+
+// tenantRacesTable is the FROM clause of queries reading races as seen by a
+// tenant, given as its only argument. The races are read with the visibility
+// overridden for the tenant, along with their positions for the tenant.
+const tenantRacesTable = `(
+		SELECT
+			races.id,
+			races.meeting_id,
+			races.name,
+			races.number,
+			COALESCE(tenant_races.visible, races.visible) AS visible,
+			races.advertised_start_time,
+			races.archived_at,
+			races.deleted_at,
+			races.version,
+			races.status,
+			tenant_races.position
+		FROM races
+		LEFT JOIN tenant_races
+			ON tenant_races.race_id = races.id
+			AND tenant_races.tenant = ?
+	) AS races
+	LEFT JOIN meetings ON meetings.id = races.meeting_id`
+
+// racesFrom returns the FROM clause of queries reading races as seen by the
+// tenant of the given context, along with its arguments. The arguments must
+// precede the arguments of the rest of the query.
+func racesFrom(ctx context.Context) (string, []any) {
+	name := tenant.Name(ctx)
+	if name == "" {
+		return racesTable, nil
+	}
+
+	return tenantRacesTable, []any{name}
+}
+
+// raceColumns maps the fields of racingapi.Race to the database columns they
+// are read from.
+var raceColumns = map[protoreflect.Name][]string{
+	"id":                    {"races.id"},
+	"meeting_id":            {"races.meeting_id"},
+	"name":                  {"races.name"},
+	"number":                {"races.number"},
+	"visible":               {"races.visible"},
+	"advertised_start_time": {"races.advertised_start_time"},
+	// Status is computed from the advertised start time and the stored status.
+	"status":   {"races.advertised_start_time", "races.status"},
+	"timezone": {"meetings.timezone"},
+	// Local time is computed from the advertised start time and the timezone.
+	"local_advertised_start_time": {
+		"races.advertised_start_time",
+		"meetings.timezone",
+	},
+	"archive_time": {"races.archived_at"},
+	"etag":         {"races.version"},
+}
+
+// projection describes which fields of races are read from the database.
+type projection struct {
+	// fields is a set of names of racingapi.Race fields to read.
+	fields map[protoreflect.Name]bool
+	// columns is a list of database columns that need to be selected to read
+	// the fields.
+	columns []string
+	// storedStatus is true if the status is read as it is stored, rather than
+	// computed at the time the race is read at.
+	storedStatus bool
+}
+
+// parseReadMask builds a projection from the read mask of a request. If the
+// mask is empty, all fields are read.
+//
+// Paths of the mask are names of racingapi.Race fields, either in snake_case
+// or in lowerCamelCase as they appear in JSON.
+func parseReadMask(
+	ctx context.Context,
+	mask *fieldmaskpb.FieldMask,
+) (*projection, error) {
+	desc := (&racingapi.Race{}).ProtoReflect().Descriptor().Fields()
+	p := &projection{
+		fields: map[protoreflect.Name]bool{},
+	}
+
+	for _, path := range mask.GetPaths() {
+		fd := desc.ByName(protoreflect.Name(path))
+		if fd == nil {
+			fd = desc.ByJSONName(path)
+		}
+		if fd == nil {
+			return nil, apierror.InvalidArgument(
+				ctx,
+				"invalid read mask",
+				apierror.FieldViolation{
+					Field:       "read_mask",
+					Description: fmt.Sprintf("unknown field %q", path),
+				},
+			)
+		}
+		p.fields[fd.Name()] = true
+	}
+
+	all := len(p.fields) == 0
+
+	// Iterate over the fields in the order of their declaration, so that the
+	// columns are always selected in the same order.
+	for i := range desc.Len() {
+		name := desc.Get(i).Name()
+		if !all && !p.fields[name] {
+			continue
+		}
+		p.fields[name] = true
+
+		for _, col := range raceColumns[name] {
+			if !slices.Contains(p.columns, col) {
+				p.columns = append(p.columns, col)
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// selectList returns a list of columns to be used in the SELECT clause.
+func (p *projection) selectList() string {
+	return strings.Join(p.columns, ", ")
+}
+
+// scanRace scans a race from the given scanner, reading only the columns of
+// the projection. Unless the projection reads the stored status, the status of
+// the race is computed at the given time.
+func scanRace(
+	s scanner,
+	p *projection,
+	now time.Time,
+) (*racingapi.Race, error) {
+	var (
+		race                racingapi.Race
+		advertisedStartTime time.Time
+		timezone            sql.Null[string]
+		archivedAt          sql.Null[time.Time]
+		version             int64
+		status              string
+	)
+
+	dest := make([]any, 0, len(p.columns))
+	for _, col := range p.columns {
+		switch col {
+		case "races.id":
+			dest = append(dest, &race.Id)
+		case "races.meeting_id":
+			dest = append(dest, &race.MeetingId)
+		case "races.name":
+			dest = append(dest, &race.Name)
+		case "races.number":
+			dest = append(dest, &race.Number)
+		case "races.visible":
+			dest = append(dest, &race.Visible)
+		case "races.advertised_start_time":
+			dest = append(dest, &advertisedStartTime)
+		case "meetings.timezone":
+			dest = append(dest, &timezone)
+		case "races.archived_at":
+			dest = append(dest, &archivedAt)
+		case "races.version":
+			dest = append(dest, &version)
+		case "races.status":
+			dest = append(dest, &status)
+		}
+	}
+
+	if err := s.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	if p.fields["advertised_start_time"] {
+		race.AdvertisedStartTime = timestamppb.New(advertisedStartTime)
+	}
+	if p.storedStatus {
+		race.Status = parseStoredStatusV1(status)
+	} else if p.fields["status"] {
+		race.Status = computeRaceStatus(status, advertisedStartTime, now)
+	}
+
+	// The timezone of a venue may be unknown, in which case the local time is
+	// in UTC.
+	loc := loadLocation(timezone.V)
+	if p.fields["timezone"] {
+		race.Timezone = loc.String()
+	}
+	if p.fields["local_advertised_start_time"] {
+		race.LocalAdvertisedStartTime = formatLocalTime(advertisedStartTime, loc)
+	}
+
+	if archivedAt.Valid {
+		race.ArchiveTime = timestamppb.New(archivedAt.V)
+	}
+
+	if p.fields["etag"] {
+		race.Etag = etag.Format(version)
+	}
+
+	return &race, nil
+}
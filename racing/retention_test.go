@@ -0,0 +1,361 @@
+package racing_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/clock"
+	. "github.com/danilvpetrov/entain/racing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestRetention(t *testing.T) {
+	db := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db})
+
+	// All seeded races start at most two days from now.
+	now := time.Now().AddDate(0, 0, 4)
+	r := &Retention{
+		DB:           db,
+		ExportDir:    t.TempDir(),
+		Clock:        clock.Fixed(now),
+		ArchiveAfter: 24 * time.Hour,
+		PurgeAfter:   24 * time.Hour,
+	}
+
+	t.Run("archives old races", func(t *testing.T) {
+		archived, purged, err := r.RunOnce(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if archived != NumberOfSeededRaces {
+			t.Fatalf(
+				"expected %d archived races, got %d",
+				NumberOfSeededRaces,
+				archived,
+			)
+		}
+
+		if purged != 0 {
+			t.Fatalf("expected no purged races, got %d", purged)
+		}
+
+		resp, err := client.ListRaces(
+			t.Context(),
+			&racingapi.ListRacesRequest{},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(resp.GetRaces()) != 0 {
+			t.Fatalf("expected no races, got %d", len(resp.GetRaces()))
+		}
+
+		resp, err = client.ListRaces(
+			t.Context(),
+			&racingapi.ListRacesRequest{IncludeArchived: true},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(resp.GetRaces()) != NumberOfSeededRaces {
+			t.Fatalf(
+				"expected %d races, got %d",
+				NumberOfSeededRaces,
+				len(resp.GetRaces()),
+			)
+		}
+		for _, race := range resp.GetRaces() {
+			if !race.GetArchiveTime().AsTime().Equal(now.Truncate(time.Second)) {
+				t.Fatalf(
+					"expected archive time %v, got %v",
+					now,
+					race.GetArchiveTime().AsTime(),
+				)
+			}
+		}
+
+		_, err = client.GetRace(
+			t.Context(),
+			&racingapi.GetRaceRequest{RaceId: 1},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+
+		if _, err := client.GetRace(
+			t.Context(),
+			&racingapi.GetRaceRequest{RaceId: 1, IncludeArchived: true},
+		); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		batch, err := client.BatchGetRaces(
+			t.Context(),
+			&racingapi.BatchGetRacesRequest{RaceId: []int64{1, 2}},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(batch.GetMissingRaceId()) != 2 {
+			t.Fatalf(
+				"expected 2 missing races, got %v",
+				batch.GetMissingRaceId(),
+			)
+		}
+	})
+
+	t.Run("purges archived races", func(t *testing.T) {
+		r.Clock = clock.Fixed(now.AddDate(0, 0, 2))
+
+		archived, purged, err := r.RunOnce(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if archived != 0 {
+			t.Fatalf("expected no archived races, got %d", archived)
+		}
+
+		if purged != NumberOfSeededRaces {
+			t.Fatalf(
+				"expected %d purged races, got %d",
+				NumberOfSeededRaces,
+				purged,
+			)
+		}
+
+		exported := readExport(t, r.ExportDir)
+		if len(exported) != NumberOfSeededRaces {
+			t.Fatalf(
+				"expected %d exported races, got %d",
+				NumberOfSeededRaces,
+				len(exported),
+			)
+		}
+
+		resp, err := client.ListRaces(
+			t.Context(),
+			&racingapi.ListRacesRequest{IncludeArchived: true},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(resp.GetRaces()) != 0 {
+			t.Fatalf("expected no races, got %d", len(resp.GetRaces()))
+		}
+
+		_, err = client.GetRace(
+			t.Context(),
+			&racingapi.GetRaceRequest{RaceId: 1, IncludeArchived: true},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+
+		var n int
+		if err := db.QueryRowContext(
+			t.Context(),
+			`SELECT COUNT(*) FROM races`,
+		).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		if n != 0 {
+			t.Fatalf("expected purged races to be removed, got %d races", n)
+		}
+
+		changes, _ := listAllChanges(t, client, "")
+		last := changes[len(changes)-1]
+		if last.GetOperation() != racingapi.Change_DELETED {
+			t.Fatalf(
+				"expected operation %v, got %v",
+				racingapi.Change_DELETED,
+				last.GetOperation(),
+			)
+		}
+	})
+
+	t.Run("purged races are not ingested again", func(t *testing.T) {
+		// The new race may be given the ID of a purged race.
+		sink := &IngestSink{DB: db}
+		if err := sink.Upsert(
+			t.Context(),
+			"<provider>",
+			changesFeed("Melbourne Cup"),
+		); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := SeedTestData(t.Context(), db); err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := client.ListRaces(
+			t.Context(),
+			&racingapi.ListRacesRequest{IncludeArchived: true},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(resp.GetRaces()) != 1 {
+			t.Fatalf("expected 1 race, got %d", len(resp.GetRaces()))
+		}
+		if resp.GetRaces()[0].GetName() != "Melbourne Cup" {
+			t.Fatalf(
+				"expected race %q to be left as it was, got %q",
+				"Melbourne Cup",
+				resp.GetRaces()[0].GetName(),
+			)
+		}
+	})
+
+	t.Run("archival age must be positive", func(t *testing.T) {
+		r := &Retention{DB: db}
+		if _, _, err := r.RunOnce(t.Context()); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestRetentionOfDeletedRaces(t *testing.T) {
+	db := setupDatabase(t)
+
+	// All seeded races start at most two days from now.
+	now := time.Now().AddDate(0, 0, 4)
+	client := setupServer(t, &Service{DB: db, Clock: clock.Fixed(now)})
+	r := &Retention{
+		DB:           db,
+		ExportDir:    t.TempDir(),
+		Clock:        clock.Fixed(now),
+		ArchiveAfter: 24 * time.Hour,
+		PurgeAfter:   24 * time.Hour,
+	}
+
+	if _, err := client.DeleteRace(
+		asAdmin(t.Context()),
+		&racingapi.DeleteRaceRequest{RaceId: 1, Etag: "1"},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("keeps recently deleted races", func(t *testing.T) {
+		archived, purged, err := r.RunOnce(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if archived != NumberOfSeededRaces-1 {
+			t.Fatalf(
+				"expected %d archived races, got %d",
+				NumberOfSeededRaces-1,
+				archived,
+			)
+		}
+
+		if purged != 0 {
+			t.Fatalf("expected no purged races, got %d", purged)
+		}
+	})
+
+	t.Run("purges deleted races", func(t *testing.T) {
+		r.Clock = clock.Fixed(now.AddDate(0, 0, 2))
+
+		_, purged, err := r.RunOnce(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if purged != NumberOfSeededRaces {
+			t.Fatalf(
+				"expected %d purged races, got %d",
+				NumberOfSeededRaces,
+				purged,
+			)
+		}
+
+		var exported bool
+		for _, race := range readExport(t, r.ExportDir) {
+			if race.GetId() == 1 {
+				exported = true
+			}
+		}
+		if !exported {
+			t.Fatal("expected the deleted race to be exported")
+		}
+
+		var n int
+		if err := db.QueryRowContext(
+			t.Context(),
+			`SELECT COUNT(*) FROM races WHERE id = 1`,
+		).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		if n != 0 {
+			t.Fatal("expected the deleted race to be removed")
+		}
+
+		// The deletion is only recorded once, when the race is deleted.
+		changes, _ := listAllChanges(t, client, "")
+
+		var deletions int
+		for _, c := range changes {
+			if c.GetOperation() == racingapi.Change_DELETED &&
+				c.GetRace().GetId() == 1 {
+				deletions++
+			}
+		}
+		if deletions != 1 {
+			t.Fatalf("expected 1 deletion, got %d", deletions)
+		}
+	})
+}
+
+// readExport is a test helper that reads the races exported to the files in
+// the given directory.
+func readExport(t *testing.T, dir string) []*racingapi.Race {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join(dir, "races-*.jsonl.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var races []*racingapi.Race
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = f.Close()
+		})
+
+		r, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := bufio.NewScanner(r)
+		for s.Scan() {
+			var race racingapi.Race
+			if err := protojson.Unmarshal(s.Bytes(), &race); err != nil {
+				t.Fatal(err)
+			}
+			races = append(races, &race)
+		}
+
+		if err := s.Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return races
+}
@@ -13,3 +13,27 @@ CREATE INDEX IF NOT EXISTS idx_races_meeting_id ON races(meeting_id);
 
 -- Add an index on visible to optimize query filtering by this column
 CREATE INDEX IF NOT EXISTS idx_races_visible ON races(visible);
+
+-- meetings table to store race meetings
+CREATE TABLE IF NOT EXISTS meetings (
+    id INTEGER PRIMARY KEY,
+    name TEXT
+);
+
+-- external_refs table to map IDs assigned by external providers to IDs of
+-- races and meetings
+CREATE TABLE IF NOT EXISTS external_refs (
+    entity TEXT NOT NULL,
+    provider TEXT NOT NULL,
+    external_id TEXT NOT NULL,
+    internal_id INTEGER NOT NULL,
+    PRIMARY KEY (entity, provider, external_id)
+);
+
+-- Map races seeded before external references were introduced to the "seed"
+-- provider, so that seeding the database again updates them rather than adding
+-- duplicates. The external ID of a seeded race is its ID.
+INSERT OR IGNORE INTO external_refs (entity, provider, external_id, internal_id)
+SELECT 'race', 'seed', CAST(id AS TEXT), id
+FROM races
+WHERE id NOT IN (SELECT internal_id FROM external_refs WHERE entity = 'race');
@@ -0,0 +1,104 @@
+package racing_test
+
+import (
+	"database/sql"
+	"testing"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	racingv2 "github.com/danilvpetrov/entain/api/racing/v2"
+	. "github.com/danilvpetrov/entain/racing"
+)
+
+func TestApplySchema(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Set up a database with the schema that predates the timezones of
+	// meetings.
+	if _, err := db.ExecContext(
+		t.Context(),
+		`CREATE TABLE races (
+			id INTEGER PRIMARY KEY,
+			meeting_id INTEGER,
+			name TEXT,
+			number INTEGER,
+			visible INTEGER,
+			advertised_start_time DATETIME
+		);
+
+		CREATE TABLE meetings (
+			id INTEGER PRIMARY KEY,
+			name TEXT
+		);
+
+		INSERT INTO meetings VALUES (1, 'Flemington');
+
+		INSERT INTO races VALUES
+		(1, 1, 'Melbourne Cup', 7, 1, '2025-11-04T04:00:00Z');`,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	// Apply the schema twice to make sure the migrations are applied only
+	// once.
+	for range 2 {
+		if err := ApplySchema(t.Context(), db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client := setupServer(t, &Service{DB: db})
+
+	race, err := client.GetRace(
+		t.Context(),
+		&racingapi.GetRaceRequest{RaceId: 1},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The timezone of the existing meeting is not known.
+	if race.GetTimezone() != "UTC" ||
+		race.GetLocalAdvertisedStartTime() != "2025-11-04T04:00:00Z" {
+		t.Fatalf("expected the race to be in UTC, got %+v", race)
+	}
+
+	// The existing race is mapped to the seed provider by its ID.
+	byRef, err := client.GetRaceByExternalId(
+		t.Context(),
+		&racingapi.GetRaceByExternalIdRequest{
+			Provider:   SeedProvider,
+			ExternalId: "1",
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if byRef.GetId() != 1 {
+		t.Fatalf(
+			"expected race 1 to be mapped to the seed provider, got %d",
+			byRef.GetId(),
+		)
+	}
+
+	// The existing race has started already, so it is closed.
+	raceV2, err := setupServerV2(t, &ServiceV2{V1: &Service{DB: db}}).GetRace(
+		t.Context(),
+		&racingv2.GetRaceRequest{RaceId: 1},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if raceV2.GetStatus() != racingv2.Race_STATUS_CLOSED {
+		t.Fatalf("expected the race to be closed, got %v", raceV2.GetStatus())
+	}
+}
@@ -3,44 +3,120 @@ package racing
 import (
 	"context"
 	"database/sql"
+	"math/rand"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/danilvpetrov/entain/clock"
+	"github.com/danilvpetrov/entain/ingest"
 	"syreclabs.com/go/faker"
 )
 
 const (
 	// NumberOfSeededRaces defines how many races are seeded in the database.
 	NumberOfSeededRaces = 100
+
+	// NumberOfSeededMeetings defines how many meetings the seeded races are
+	// spread across.
+	NumberOfSeededMeetings = 10
+
+	// SeedProvider is the name of the provider the seeded meetings and races
+	// are mapped to in the external references. Their external IDs are their
+	// sequence numbers starting from 1.
+	SeedProvider = "seed"
 )
 
-// SeedTestData seeds the database with test data.
+// seededTimezones is a list of timezones of the venues of the seeded meetings.
+var seededTimezones = []string{
+	"Australia/Adelaide",
+	"Australia/Brisbane",
+	"Australia/Melbourne",
+	"Australia/Perth",
+	"Australia/Sydney",
+	"Pacific/Auckland",
+}
+
+// SeedTestData seeds the database with test data, using a Seeder with the
+// default random source and clock.
+//
 // This function is intended to be used in tests only. Please avoid using it
 // in a production setup.
 func SeedTestData(ctx context.Context, db *sql.DB) error {
+	s := &Seeder{DB: db}
+	return s.Seed(ctx)
+}
+
+// Seeder seeds the database with test data. Seeding the same database again
+// updates the previously seeded races rather than adding new ones.
+//
+// It is intended to be used in tests only. Please avoid using it in a
+// production setup.
+type Seeder struct {
+	// DB is a database connection pool used to store the seeded data.
+	DB *sql.DB
+
+	// Rand is the source of randomness of the seeded data. Seeders with
+	// sources created from the same seed and with the same clock produce the
+	// same data. If it is nil, the data are random.
+	Rand *rand.Rand
+
+	// Clock is the source of the current time the advertised start times of
+	// the seeded races are spread around. If it is nil, clock.System is used.
+	Clock clock.Clock
+}
+
+// fakerMu serialises the seeders with a random source, as faker draws from a
+// global one.
+var fakerMu sync.Mutex
+
+// Seed seeds the database with test data.
+func (s *Seeder) Seed(ctx context.Context) error {
+	feed := s.generate()
+
+	sink := &IngestSink{DB: s.DB}
+	return sink.Upsert(ctx, SeedProvider, feed)
+}
+
+// generate generates the seeded meetings and races.
+func (s *Seeder) generate() *ingest.Feed {
+	if s.Rand != nil {
+		fakerMu.Lock()
+		defer fakerMu.Unlock()
+
+		faker.Seed(s.Rand.Int63())
+	}
+
+	now := clock.System.Now()
+	if s.Clock != nil {
+		now = s.Clock.Now()
+	}
+
+	feed := &ingest.Feed{}
+
+	for i := range NumberOfSeededMeetings {
+		feed.Meetings = append(feed.Meetings, ingest.Meeting{
+			ExternalID: strconv.Itoa(i + 1),
+			Name:       faker.Address().City(),
+			Timezone:   faker.RandomChoice(seededTimezones),
+		})
+	}
+
 	for i := range NumberOfSeededRaces {
-		if _, err := db.ExecContext(
-			ctx,
-			`INSERT OR IGNORE INTO races(
-					id,
-					meeting_id,
-					name,
-					number,
-					visible,
-					advertised_start_time
-				) VALUES (?,?,?,?,?,?)`,
-			i+1,
-			faker.Number().Between(1, 10),
-			faker.Team().Name(),
-			faker.Number().Between(1, 12),
-			faker.Number().Between(0, 1),
-			faker.Time().Between(
-				time.Now().AddDate(0, 0, -1),
-				time.Now().AddDate(0, 0, 2),
-			).Format(time.RFC3339),
-		); err != nil {
-			return err
-		}
+		feed.Races = append(feed.Races, ingest.Race{
+			AdvertisedStartTime: faker.Time().Between(
+				now.AddDate(0, 0, -1),
+				now.AddDate(0, 0, 2),
+			).Truncate(time.Second),
+			ExternalID: strconv.Itoa(i + 1),
+			MeetingExternalID: strconv.Itoa(
+				faker.RandomInt(1, NumberOfSeededMeetings),
+			),
+			Name:    faker.Team().Name(),
+			Number:  faker.RandomInt64(1, 12),
+			Visible: faker.RandomInt(0, 1) == 1,
+		})
 	}
 
-	return nil
+	return feed
 }
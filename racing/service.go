@@ -9,10 +9,11 @@ import (
 	"strings"
 	"time"
 
-	racingapi "github.com/danilvpetrov/entain/api/racing"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/timestamppb"
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/clock"
+	"github.com/danilvpetrov/entain/jurisdiction"
+	"github.com/danilvpetrov/entain/tenant"
 )
 
 // Service handles all requests related to racing. It implements
@@ -21,8 +22,28 @@ type Service struct {
 	// DB is a database connection pool used to perform queries against the
 	// underlying database.
 	DB *sql.DB
+
+	// MaxBatchSize is the maximum number of races that can be requested in a
+	// single BatchGetRaces call. If it is zero, DefaultMaxBatchSize is used.
+	MaxBatchSize int
+
+	// MaxImportSize is the maximum number of races that can be imported in a
+	// single ImportRaces call. If it is zero, DefaultMaxImportSize is used.
+	MaxImportSize int
+
+	// Clock is the source of the current time the statuses of races are
+	// computed at. If it is nil, clock.System is used.
+	Clock clock.Clock
+
+	// Jurisdictions is the set of rules restricting the race meetings offered
+	// in jurisdictions. If it is nil, races are offered in all jurisdictions.
+	Jurisdictions *jurisdiction.Rules
 }
 
+// DefaultMaxBatchSize is the default maximum number of races that can be
+// requested in a single BatchGetRaces call.
+const DefaultMaxBatchSize = 100
+
 // Make sure Service implements the racingapi.RacingServer interface.
 var _ racingapi.RacingServer = (*Service)(nil)
 
@@ -33,7 +54,32 @@ func (s *Service) ListRaces(
 ) (*racingapi.ListRacesResponse, error) {
 	filterQuery, args := parseFilter(req)
 
-	orderBy, err := parseOrderBy(req)
+	if req.GetLocalDate() != "" {
+		dateFilter, dateArgs, err := s.localDateFilter(ctx, req.GetLocalDate())
+		if err != nil {
+			return nil, err
+		}
+		filterQuery += dateFilter
+		args = append(args, dateArgs...)
+	}
+
+	restriction, restrictionArgs := s.restrictionFilter(ctx)
+	filterQuery += restriction
+	args = append(args, restrictionArgs...)
+
+	from, fromArgs := racesFrom(ctx)
+
+	orderBy, err := parseOrderBy(ctx, req, tenant.Name(ctx) != "")
+	if err != nil {
+		return nil, err
+	}
+
+	proj, err := parseReadMask(ctx, req.GetReadMask())
+	if err != nil {
+		return nil, err
+	}
+
+	now, err := s.asOf(ctx, req.GetAsOf())
 	if err != nil {
 		return nil, err
 	}
@@ -41,22 +87,19 @@ func (s *Service) ListRaces(
 	rows, err := s.DB.QueryContext(
 		ctx,
 		fmt.Sprintf(
-			`SELECT
-				id,
-				meeting_id,
-				name,
-				number,
-				visible,
-				advertised_start_time
-			FROM races
-		 	WHERE id <> 0 %s %s`,
+			`SELECT %s
+			FROM %s
+		 	WHERE races.id <> 0 %s %s %s`,
+			proj.selectList(),
+			from,
+			lifecycleFilter(req.GetIncludeArchived()),
 			filterQuery,
 			orderBy,
 		),
-		args...,
+		append(fromArgs, args...)...,
 	)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, apierror.Internal(ctx, err)
 	}
 	defer func() {
 		if err = rows.Close(); err != nil {
@@ -66,15 +109,15 @@ func (s *Service) ListRaces(
 
 	var races []*racingapi.Race
 	for rows.Next() {
-		race, err := scanRace(rows)
+		race, err := scanRace(rows, proj, now)
 		if err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
+			return nil, apierror.Internal(ctx, err)
 		}
 		races = append(races, race)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, apierror.Internal(ctx, err)
 	}
 
 	return &racingapi.ListRacesResponse{
@@ -87,57 +130,149 @@ func (s *Service) GetRace(
 	ctx context.Context,
 	req *racingapi.GetRaceRequest,
 ) (*racingapi.Race, error) {
+	proj, err := parseReadMask(ctx, req.GetReadMask())
+	if err != nil {
+		return nil, err
+	}
+
+	from, args := racesFrom(ctx)
+	restriction, restrictionArgs := s.restrictionFilter(ctx)
+
+	args = append(args, req.GetRaceId())
 	row := s.DB.QueryRowContext(
 		ctx,
-		`SELECT
-			id,
-			meeting_id,
-			name,
-			number,
-			visible,
-			advertised_start_time
-		FROM races
-		WHERE id = ?`,
-		req.GetRaceId(),
+		fmt.Sprintf(
+			`SELECT %s
+			FROM %s
+			WHERE races.id = ? %s %s`,
+			proj.selectList(),
+			from,
+			lifecycleFilter(req.GetIncludeArchived()),
+			restriction,
+		),
+		append(args, restrictionArgs...)...,
 	)
 
-	race, err := scanRace(row)
+	race, err := scanRace(row, proj, s.now())
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Error(codes.NotFound, "race not found")
+			return nil, s.notFound(
+				ctx,
+				restriction,
+				req.GetRaceId(),
+				req.GetIncludeArchived(),
+			)
 		}
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, apierror.Internal(ctx, err)
 	}
 
 	return race, nil
 }
 
-// scanner is an interface that abstracts sql.Row and sql.Rows types.
-type scanner interface {
-	Scan(dest ...any) error
-}
+// BatchGetRaces returns multiple races by their IDs.
+func (s *Service) BatchGetRaces(
+	ctx context.Context,
+	req *racingapi.BatchGetRacesRequest,
+) (*racingapi.BatchGetRacesResponse, error) {
+	ids := req.GetRaceId()
 
-// scanRace scans a race from the given scanner.
-func scanRace(s scanner) (*racingapi.Race, error) {
-	var (
-		race                racingapi.Race
-		advertisedStartTime time.Time
-	)
-	if err := s.Scan(
-		&race.Id,
-		&race.MeetingId,
-		&race.Name,
-		&race.Number,
-		&race.Visible,
-		&advertisedStartTime,
-	); err != nil {
+	maxBatchSize := s.MaxBatchSize
+	if maxBatchSize == 0 {
+		maxBatchSize = DefaultMaxBatchSize
+	}
+
+	if len(ids) > maxBatchSize {
+		return nil, apierror.InvalidArgument(
+			ctx,
+			"too many races requested",
+			apierror.FieldViolation{
+				Field: "race_id",
+				Description: fmt.Sprintf(
+					"at most %d IDs can be requested, got %d",
+					maxBatchSize,
+					len(ids),
+				),
+			},
+		)
+	}
+
+	from, args := racesFrom(ctx)
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	// The races restricted in the jurisdiction are reported as missing.
+	restriction, restrictionArgs := s.restrictionFilter(ctx)
+	args = append(args, restrictionArgs...)
+
+	// All fields are read, including the ID required to match the races
+	// with the requested IDs.
+	proj, err := parseReadMask(ctx, nil)
+	if err != nil {
 		return nil, err
 	}
 
-	race.AdvertisedStartTime = timestamppb.New(advertisedStartTime)
-	race.Status = computeRaceStatus(advertisedStartTime)
+	rows, err := s.DB.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			`SELECT %s
+			FROM %s
+			WHERE races.id IN (%s) %s %s`,
+			proj.selectList(),
+			from,
+			placeholders(len(ids)),
+			lifecycleFilter(req.GetIncludeArchived()),
+			restriction,
+		),
+		args...,
+	)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	now := s.now()
+	found := make(map[int64]*racingapi.Race, len(ids))
+	for rows.Next() {
+		race, err := scanRace(rows, proj, now)
+		if err != nil {
+			return nil, apierror.Internal(ctx, err)
+		}
+		found[race.GetId()] = race
+	}
 
-	return &race, nil
+	if err := rows.Err(); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	// Return the races in the order of the requested IDs.
+	resp := &racingapi.BatchGetRacesResponse{
+		Races: make([]*racingapi.Race, 0, len(found)),
+	}
+	for _, id := range ids {
+		if race, ok := found[id]; ok {
+			resp.Races = append(resp.Races, race)
+		} else {
+			resp.MissingRaceId = append(resp.MissingRaceId, id)
+		}
+	}
+
+	return resp, nil
+}
+
+// placeholders returns a comma-separated list of n query placeholders, for
+// use in IN clauses.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// scanner is an interface that abstracts sql.Row and sql.Rows types.
+type scanner interface {
+	Scan(dest ...any) error
 }
 
 // parseFilter builds SQL filter query and its arguments from the provided
@@ -148,7 +283,7 @@ func parseFilter(
 	var w strings.Builder
 
 	if len(req.GetMeetingId()) > 0 {
-		_, _ = w.WriteString(" AND meeting_id IN (")
+		_, _ = w.WriteString(" AND races.meeting_id IN (")
 
 		for i, id := range req.GetMeetingId() {
 			_, _ = w.WriteString("?")
@@ -166,7 +301,7 @@ func parseFilter(
 	}
 
 	if req.GetVisibleOnly() {
-		_, _ = w.WriteString(" AND visible = true")
+		_, _ = w.WriteString(" AND races.visible = true")
 	}
 
 	return w.String(), args
@@ -184,41 +319,73 @@ var conflictingOrdering = map[racingapi.ListRacesRequest_OrderBy]racingapi.ListR
 	racingapi.ListRacesRequest_NUMBER_DESC:                racingapi.ListRacesRequest_NUMBER_ASC,
 }
 
-func parseOrderBy(req *racingapi.ListRacesRequest) (string, error) {
+// parseOrderBy builds SQL ORDER BY clause from the ordering of the request. If
+// positioned is true, the races are read as seen by a tenant, and the races
+// positioned for the tenant are ordered first, in the order of their
+// positions.
+func parseOrderBy(
+	ctx context.Context,
+	req *racingapi.ListRacesRequest,
+	positioned bool,
+) (string, error) {
 	var w strings.Builder
 
-	if len(req.GetOrderBy()) == 0 {
+	switch {
+	case positioned && len(req.GetOrderBy()) == 0:
+		// The races that are not positioned are kept in the default order.
+		return " ORDER BY races.position IS NULL, races.position, races.id", nil
+	case positioned:
+		w.WriteString(" ORDER BY races.position IS NULL, races.position, ")
+	case len(req.GetOrderBy()) == 0:
 		return "", nil
+	default:
+		w.WriteString(" ORDER BY ")
 	}
 
-	w.WriteString(" ORDER BY ")
 	visited := map[racingapi.ListRacesRequest_OrderBy]bool{}
 
 	for i, order := range req.GetOrderBy() {
 		if visited[conflictingOrdering[order]] {
-			return "", status.Error(
-				codes.InvalidArgument,
+			return "", apierror.InvalidArgument(
+				ctx,
 				"conflicting order by fields",
+				apierror.FieldViolation{
+					Field: "order_by",
+					Description: fmt.Sprintf(
+						"%v conflicts with %v",
+						order,
+						conflictingOrdering[order],
+					),
+				},
 			)
 		}
 
 		switch order {
 		case racingapi.ListRacesRequest_ADVERTISED_START_TIME_ASC:
-			_, _ = w.WriteString("advertised_start_time ASC")
+			_, _ = w.WriteString("races.advertised_start_time ASC")
 		case racingapi.ListRacesRequest_ADVERTISED_START_TIME_DESC:
-			_, _ = w.WriteString("advertised_start_time DESC")
+			_, _ = w.WriteString("races.advertised_start_time DESC")
 		case racingapi.ListRacesRequest_MEETING_ID_ASC:
-			_, _ = w.WriteString("meeting_id ASC")
+			_, _ = w.WriteString("races.meeting_id ASC")
 		case racingapi.ListRacesRequest_MEETING_ID_DESC:
-			_, _ = w.WriteString("meeting_id DESC")
+			_, _ = w.WriteString("races.meeting_id DESC")
 		case racingapi.ListRacesRequest_NAME_ASC:
-			_, _ = w.WriteString("name ASC")
+			_, _ = w.WriteString("races.name ASC")
 		case racingapi.ListRacesRequest_NAME_DESC:
-			_, _ = w.WriteString("name DESC")
+			_, _ = w.WriteString("races.name DESC")
 		case racingapi.ListRacesRequest_NUMBER_ASC:
-			_, _ = w.WriteString("number ASC")
+			_, _ = w.WriteString("races.number ASC")
 		case racingapi.ListRacesRequest_NUMBER_DESC:
-			_, _ = w.WriteString("number DESC")
+			_, _ = w.WriteString("races.number DESC")
+		default:
+			return "", apierror.InvalidArgument(
+				ctx,
+				"unknown order by field",
+				apierror.FieldViolation{
+					Field:       "order_by",
+					Description: fmt.Sprintf("unknown value %v", order),
+				},
+			)
 		}
 
 		visited[order] = true
@@ -232,13 +399,21 @@ func parseOrderBy(req *racingapi.ListRacesRequest) (string, error) {
 	return w.String(), nil
 }
 
-// computeRaceStatus computes the status of a race based on its advertised start
-// time.
+// computeRaceStatus computes the status of a race at the given time based on
+// its stored status and its advertised start time. The races that are closed
+// or abandoned are closed regardless of their advertised start time.
 func computeRaceStatus(
-	advertisedStartTime time.Time,
+	status string,
+	advertisedStartTime, now time.Time,
 ) racingapi.Race_Status {
-	if advertisedStartTime.After(time.Now()) {
+	if status == storedStatusOpen && advertisedStartTime.After(now) {
 		return racingapi.Race_OPEN
 	}
 	return racingapi.Race_CLOSED
 }
+
+// parseStoredStatusV1 returns the status of version 1 of the API a race with
+// the given stored status has, regardless of its advertised start time.
+func parseStoredStatusV1(status string) racingapi.Race_Status {
+	return racingapi.Race_Status(racingapi.Race_Status_value[status])
+}
@@ -5,8 +5,12 @@ import (
 	"testing"
 	"time"
 
-	racingapi "github.com/danilvpetrov/entain/api/racing"
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/clock"
 	. "github.com/danilvpetrov/entain/racing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 const (
@@ -19,8 +23,10 @@ const (
 )
 
 func TestListRaces(t *testing.T) { //nolint:gocognit // Explicit test cases.
+	now := time.Now()
 	s := &Service{
-		DB: setupDatabase(t),
+		DB:    setupDatabase(t),
+		Clock: clock.Fixed(now),
 	}
 	client := setupServer(t, s)
 
@@ -362,11 +368,107 @@ func TestListRaces(t *testing.T) { //nolint:gocognit // Explicit test cases.
 				},
 			},
 			assertion: func(t *testing.T, _ *racingapi.ListRacesResponse, err error) {
-				if err == nil {
-					t.Fatalf("expected error, got %v", err)
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf(
+						"expected %v error, got %v",
+						codes.InvalidArgument,
+						err,
+					)
+				}
+			},
+		},
+		{
+			name: "invalid meeting ID",
+			req: &racingapi.ListRacesRequest{
+				MeetingId: []int64{1, -1},
+			},
+			assertion: func(t *testing.T, _ *racingapi.ListRacesResponse, err error) {
+				assertInvalidArgument(t, err, "meeting_id[1]")
+			},
+		},
+		{
+			name: "duplicate meeting IDs",
+			req: &racingapi.ListRacesRequest{
+				MeetingId: []int64{1, 1},
+			},
+			assertion: func(t *testing.T, _ *racingapi.ListRacesResponse, err error) {
+				assertInvalidArgument(t, err, "meeting_id")
+			},
+		},
+		{
+			name: "unknown ordering",
+			req: &racingapi.ListRacesRequest{
+				OrderBy: []racingapi.ListRacesRequest_OrderBy{999},
+			},
+			assertion: func(t *testing.T, _ *racingapi.ListRacesResponse, err error) {
+				assertInvalidArgument(t, err, "order_by[0]")
+			},
+		},
+		{
+			name: "unspecified ordering",
+			req: &racingapi.ListRacesRequest{
+				OrderBy: []racingapi.ListRacesRequest_OrderBy{
+					racingapi.ListRacesRequest_NAME_ASC,
+					racingapi.ListRacesRequest_UNSPECIFIED,
+				},
+			},
+			assertion: func(t *testing.T, _ *racingapi.ListRacesResponse, err error) {
+				assertInvalidArgument(t, err, "order_by[1]")
+			},
+		},
+		{
+			name: "duplicate orderings",
+			req: &racingapi.ListRacesRequest{
+				OrderBy: []racingapi.ListRacesRequest_OrderBy{
+					racingapi.ListRacesRequest_NAME_ASC,
+					racingapi.ListRacesRequest_NAME_ASC,
+				},
+			},
+			assertion: func(t *testing.T, _ *racingapi.ListRacesResponse, err error) {
+				assertInvalidArgument(t, err, "order_by")
+			},
+		},
+		{
+			name: "read mask",
+			req: &racingapi.ListRacesRequest{
+				ReadMask: &fieldmaskpb.FieldMask{
+					Paths: []string{"id", "name", "advertisedStartTime"},
+				},
+			},
+			assertion: func(t *testing.T, resp *racingapi.ListRacesResponse, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if len(resp.GetRaces()) == 0 {
+					t.Fatal("expected at least one race")
+				}
+
+				for _, race := range resp.GetRaces() {
+					if race.GetId() == 0 ||
+						race.GetName() == "" ||
+						race.GetAdvertisedStartTime() == nil {
+						t.Fatalf("expected masked fields to be set, got %+v", race)
+					}
+
+					if race.GetMeetingId() != 0 ||
+						race.GetStatus() != 0 {
+						t.Fatalf("expected unmasked fields to be unset, got %+v", race)
+					}
 				}
 			},
 		},
+		{
+			name: "read mask with unknown field",
+			req: &racingapi.ListRacesRequest{
+				ReadMask: &fieldmaskpb.FieldMask{
+					Paths: []string{"id", "unknown"},
+				},
+			},
+			assertion: func(t *testing.T, _ *racingapi.ListRacesResponse, err error) {
+				assertInvalidArgument(t, err, "read_mask")
+			},
+		},
 		{
 			name: "status field is computed correctly",
 			req:  &racingapi.ListRacesRequest{},
@@ -377,8 +479,7 @@ func TestListRaces(t *testing.T) { //nolint:gocognit // Explicit test cases.
 
 				for _, race := range resp.GetRaces() {
 					expected := racingapi.Race_OPEN
-					now := time.Now()
-					if race.GetAdvertisedStartTime().AsTime().Before(now) {
+					if !race.GetAdvertisedStartTime().AsTime().After(now) {
 						expected = racingapi.Race_CLOSED
 					}
 
@@ -438,11 +539,42 @@ func TestGetRace(t *testing.T) {
 				RaceId: 999,
 			},
 			assertion: func(t *testing.T, _ *racingapi.Race, err error) {
-				if err == nil {
-					t.Fatalf("expected error, got %v", err)
+				if status.Code(err) != codes.NotFound {
+					t.Fatalf("expected %v error, got %v", codes.NotFound, err)
 				}
 			},
 		},
+		{
+			name: "gets race status only",
+			req: &racingapi.GetRaceRequest{
+				RaceId: 1,
+				ReadMask: &fieldmaskpb.FieldMask{
+					Paths: []string{"status"},
+				},
+			},
+			assertion: func(t *testing.T, race *racingapi.Race, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if race.GetStatus() == 0 {
+					t.Fatal("expected status to be set")
+				}
+
+				if race.GetId() != 0 || race.GetAdvertisedStartTime() != nil {
+					t.Fatalf("expected unmasked fields to be unset, got %+v", race)
+				}
+			},
+		},
+		{
+			name: "invalid race ID",
+			req: &racingapi.GetRaceRequest{
+				RaceId: -1,
+			},
+			assertion: func(t *testing.T, _ *racingapi.Race, err error) {
+				assertInvalidArgument(t, err, "race_id")
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -452,3 +584,72 @@ func TestGetRace(t *testing.T) {
 		})
 	}
 }
+
+func TestBatchGetRaces(t *testing.T) {
+	s := &Service{
+		DB:           setupDatabase(t),
+		MaxBatchSize: 3,
+	}
+	client := setupServer(t, s)
+
+	cases := []struct {
+		assertion func(
+			t *testing.T,
+			resp *racingapi.BatchGetRacesResponse,
+			err error,
+		)
+		req  *racingapi.BatchGetRacesRequest
+		name string
+	}{
+		{
+			name: "gets races by IDs in the requested order",
+			req: &racingapi.BatchGetRacesRequest{
+				RaceId: []int64{3, 999, 1},
+			},
+			assertion: func(t *testing.T, resp *racingapi.BatchGetRacesResponse, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				var ids []int64
+				for _, race := range resp.GetRaces() {
+					ids = append(ids, race.GetId())
+				}
+
+				if !slices.Equal(ids, []int64{3, 1}) {
+					t.Fatalf("expected race IDs [3 1], got %v", ids)
+				}
+
+				if !slices.Equal(resp.GetMissingRaceId(), []int64{999}) {
+					t.Fatalf(
+						"expected missing race IDs [999], got %v",
+						resp.GetMissingRaceId(),
+					)
+				}
+			},
+		},
+		{
+			name: "no IDs",
+			req:  &racingapi.BatchGetRacesRequest{},
+			assertion: func(t *testing.T, _ *racingapi.BatchGetRacesResponse, err error) {
+				assertInvalidArgument(t, err, "race_id")
+			},
+		},
+		{
+			name: "too many IDs",
+			req: &racingapi.BatchGetRacesRequest{
+				RaceId: []int64{1, 2, 3, 4},
+			},
+			assertion: func(t *testing.T, _ *racingapi.BatchGetRacesResponse, err error) {
+				assertInvalidArgument(t, err, "race_id")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp, err := client.BatchGetRaces(t.Context(), c.req)
+			c.assertion(t, resp, err)
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package racing
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/clock"
+)
+
+// The stored statuses of races.
+const (
+	storedStatusOpen      = "OPEN"
+	storedStatusClosed    = "CLOSED"
+	storedStatusAbandoned = "ABANDONED"
+)
+
+// DefaultStatusInterval is the default interval between the runs of the
+// status updater.
+const DefaultStatusInterval = 5 * time.Second
+
+// StatusUpdater keeps the stored statuses of races up to date by closing the
+// open races once their advertised start time has passed.
+//
+// The closed and abandoned races are left as they are, so that the statuses
+// set by admins are not overridden.
+type StatusUpdater struct {
+	// DB is a database connection pool used to update the statuses of races.
+	DB *sql.DB
+
+	// Clock is the source of the current time the races are closed at. If it
+	// is nil, clock.System is used.
+	Clock clock.Clock
+
+	// Interval is the interval between the runs of the updater. If it is
+	// zero, DefaultStatusInterval is used.
+	Interval time.Duration
+}
+
+// Run updates the statuses of races right away and then at the interval,
+// until the context is cancelled. Failed runs are logged and retried at the
+// next interval.
+func (u *StatusUpdater) Run(ctx context.Context) error {
+	interval := u.Interval
+	if interval == 0 {
+		interval = DefaultStatusInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		closed, err := u.RunOnce(ctx)
+		if err != nil {
+			slog.ErrorContext(
+				ctx,
+				"updating statuses of races failed",
+				slog.Any("error", err),
+			)
+		} else {
+			slog.DebugContext(
+				ctx,
+				"updated statuses of races",
+				slog.Int("closed", closed),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce closes the open races advertised to start before the current time,
+// within a single transaction. The closure of every race is recorded in the
+// change log. It returns the number of closed races.
+func (u *StatusUpdater) RunOnce(ctx context.Context) (int, error) {
+	now := clock.System.Now()
+	if u.Clock != nil {
+		now = u.Clock.Now()
+	}
+
+	n, err := u.close(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("error closing races: %w", err)
+	}
+
+	return n, nil
+}
+
+// close closes the open races advertised to start before the given time.
+// Deleted races are left as they are.
+func (u *StatusUpdater) close(
+	ctx context.Context,
+	now time.Time,
+) (_ int, err error) {
+	tx, err := u.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	ids, err := selectIDs(
+		ctx,
+		tx,
+		`SELECT id
+		FROM races
+		WHERE status = ?
+		AND deleted_at IS NULL
+		AND datetime(advertised_start_time) <= ?`,
+		storedStatusOpen,
+		now.UTC().Format(sqliteDateTime),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		if _, err := tx.ExecContext(
+			ctx,
+			`UPDATE races SET status = ? WHERE id = ?`,
+			storedStatusClosed,
+			id,
+		); err != nil {
+			return 0, err
+		}
+
+		if err := recordChange(
+			ctx,
+			tx,
+			now,
+			id,
+			racingapi.Change_UPDATED,
+		); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(ids), tx.Commit()
+}
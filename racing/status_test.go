@@ -0,0 +1,115 @@
+package racing_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	racingv2 "github.com/danilvpetrov/entain/api/racing/v2"
+	"github.com/danilvpetrov/entain/clock"
+	"github.com/danilvpetrov/entain/etag"
+	. "github.com/danilvpetrov/entain/racing"
+)
+
+func TestStatusUpdater(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	db := setupDatabase(t)
+	if err := (&Seeder{
+		DB:    db,
+		Rand:  rand.New(rand.NewSource(1)),
+		Clock: clock.Fixed(now),
+	}).Seed(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	v1 := &Service{DB: db, Clock: clock.Fixed(now)}
+	client := setupServer(t, v1)
+	clientV2 := setupServerV2(t, &ServiceV2{V1: v1})
+
+	u := &StatusUpdater{DB: db, Clock: clock.Fixed(now)}
+
+	t.Run("closes the races that have started", func(t *testing.T) {
+		resp, err := client.ListRaces(
+			t.Context(),
+			&racingapi.ListRacesRequest{},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		var started int
+		for _, race := range resp.GetRaces() {
+			if race.GetStatus() == racingapi.Race_CLOSED {
+				started++
+			}
+		}
+
+		_, cursor := listAllChanges(t, client, "")
+
+		closed, err := u.RunOnce(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if closed != started {
+			t.Fatalf("expected %d closed races, got %d", started, closed)
+		}
+
+		respV2, err := clientV2.ListRaces(
+			t.Context(),
+			&racingv2.ListRacesRequest{
+				Status: []racingv2.Race_Status{
+					racingv2.Race_STATUS_CLOSED,
+				},
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		for _, race := range respV2.GetRaces() {
+			if race.GetAdvertisedStartTime().AsTime().After(now) {
+				t.Fatalf("expected race %d to be open", race.GetId())
+			}
+		}
+
+		if len(respV2.GetRaces()) != started {
+			t.Fatalf(
+				"expected %d closed races, got %d",
+				started,
+				len(respV2.GetRaces()),
+			)
+		}
+
+		for _, race := range respV2.GetRaces() {
+			if race.GetEtag() == etag.Format(1) {
+				t.Fatalf("expected the etag of race %d to change", race.GetId())
+			}
+		}
+
+		changes, _ := listAllChanges(t, client, cursor)
+		if len(changes) != started {
+			t.Fatalf("expected %d changes, got %d", started, len(changes))
+		}
+
+		for _, change := range changes {
+			if change.GetOperation() != racingapi.Change_UPDATED ||
+				change.GetRace().GetStatus() != racingapi.Race_CLOSED ||
+				!change.GetChangeTime().AsTime().Equal(now) {
+				t.Fatalf("unexpected change: %v", change)
+			}
+		}
+	})
+
+	t.Run("closes the races only once", func(t *testing.T) {
+		closed, err := u.RunOnce(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if closed != 0 {
+			t.Fatalf("expected no closed races, got %d", closed)
+		}
+	})
+}
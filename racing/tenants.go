@@ -0,0 +1,343 @@
+package racing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/danilvpetrov/entain/admin"
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/audit"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListTenantOverrides returns the overrides of the visibility and the ordering
+// of races for a tenant, in the order of the IDs of the races.
+func (s *Service) ListTenantOverrides(
+	ctx context.Context,
+	req *racingapi.ListTenantOverridesRequest,
+) (*racingapi.ListTenantOverridesResponse, error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"tenant overrides can only be listed by admins",
+		)
+	}
+
+	rows, err := s.DB.QueryContext(
+		ctx,
+		`SELECT
+			tenant_races.tenant,
+			tenant_races.race_id,
+			tenant_races.visible,
+			tenant_races.position
+		FROM tenant_races
+		JOIN races ON races.id = tenant_races.race_id
+		WHERE tenant_races.tenant = ?
+		AND races.deleted_at IS NULL
+		ORDER BY tenant_races.race_id`,
+		req.GetTenant(),
+	)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	resp := &racingapi.ListTenantOverridesResponse{}
+	for rows.Next() {
+		override, err := scanTenantOverride(rows)
+		if err != nil {
+			return nil, apierror.Internal(ctx, err)
+		}
+		resp.Overrides = append(resp.Overrides, override)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	return resp, nil
+}
+
+// SetTenantOverride creates or replaces the override of the visibility and the
+// ordering of a race for a tenant.
+func (s *Service) SetTenantOverride(
+	ctx context.Context,
+	req *racingapi.SetTenantOverrideRequest,
+) (_ *racingapi.TenantOverride, err error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"tenant overrides can only be set by admins",
+		)
+	}
+
+	override := req.GetOverride()
+	if override.GetVisibility() == racingapi.TenantOverride_UNSPECIFIED &&
+		override.GetPosition() == 0 {
+		return nil, apierror.InvalidArgument(
+			ctx,
+			"empty tenant override",
+			apierror.FieldViolation{
+				Field:       "override",
+				Description: "visibility or position must be set",
+			},
+		)
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var exists bool
+	if err := tx.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (
+			SELECT 1 FROM races WHERE id = ? AND deleted_at IS NULL
+		)`,
+		override.GetRaceId(),
+	).Scan(&exists); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if !exists {
+		return nil, apierror.NotFound(ctx, "RACE_NOT_FOUND", "race not found")
+	}
+
+	before, err := readTenantOverride(
+		ctx,
+		tx,
+		override.GetTenant(),
+		override.GetRaceId(),
+	)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	var (
+		visible  sql.Null[bool]
+		position sql.Null[int32]
+	)
+	switch override.GetVisibility() {
+	case racingapi.TenantOverride_VISIBLE:
+		visible = sql.Null[bool]{V: true, Valid: true}
+	case racingapi.TenantOverride_HIDDEN:
+		visible = sql.Null[bool]{V: false, Valid: true}
+	}
+	if p := override.GetPosition(); p != 0 {
+		position = sql.Null[int32]{V: p, Valid: true}
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO tenant_races (tenant, race_id, visible, position)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (tenant, race_id) DO UPDATE SET
+			visible = excluded.visible,
+			position = excluded.position`,
+		override.GetTenant(),
+		override.GetRaceId(),
+		visible,
+		position,
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := recordOverrideAudit(
+		ctx,
+		tx,
+		s.now(),
+		racingapi.Racing_SetTenantOverride_FullMethodName,
+		override.GetTenant(),
+		override.GetRaceId(),
+		before,
+		override,
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	return override, nil
+}
+
+// DeleteTenantOverride deletes the override of a race for a tenant.
+func (s *Service) DeleteTenantOverride(
+	ctx context.Context,
+	req *racingapi.DeleteTenantOverrideRequest,
+) (_ *emptypb.Empty, err error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"tenant overrides can only be deleted by admins",
+		)
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := readTenantOverride(
+		ctx,
+		tx,
+		req.GetTenant(),
+		req.GetRaceId(),
+	)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if before == nil {
+		return nil, apierror.NotFound(
+			ctx,
+			"TENANT_OVERRIDE_NOT_FOUND",
+			"tenant override not found",
+		)
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM tenant_races WHERE tenant = ? AND race_id = ?`,
+		req.GetTenant(),
+		req.GetRaceId(),
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := recordOverrideAudit(
+		ctx,
+		tx,
+		s.now(),
+		racingapi.Racing_DeleteTenantOverride_FullMethodName,
+		req.GetTenant(),
+		req.GetRaceId(),
+		before,
+		nil,
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// readTenantOverride reads the override of the race with the given ID for a
+// tenant. It returns nil if there is no such override.
+func readTenantOverride(
+	ctx context.Context,
+	tx *sql.Tx,
+	tenant string,
+	raceID int64,
+) (*racingapi.TenantOverride, error) {
+	override, err := scanTenantOverride(
+		tx.QueryRowContext(
+			ctx,
+			`SELECT tenant, race_id, visible, position
+			FROM tenant_races
+			WHERE tenant = ? AND race_id = ?`,
+			tenant,
+			raceID,
+		),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return override, err
+}
+
+// scanTenantOverride scans a tenant override from the given scanner.
+func scanTenantOverride(s scanner) (*racingapi.TenantOverride, error) {
+	var (
+		override racingapi.TenantOverride
+		visible  sql.Null[bool]
+		position sql.Null[int32]
+	)
+
+	if err := s.Scan(
+		&override.Tenant,
+		&override.RaceId,
+		&visible,
+		&position,
+	); err != nil {
+		return nil, err
+	}
+
+	if visible.Valid {
+		override.Visibility = racingapi.TenantOverride_HIDDEN
+		if visible.V {
+			override.Visibility = racingapi.TenantOverride_VISIBLE
+		}
+	}
+	override.Position = position.V
+
+	return &override, nil
+}
+
+// recordOverrideAudit appends an entry describing a change of the override of
+// the race with the given ID for a tenant to the audit log. The change is
+// recorded as a change of the race, with the names of the fields of the
+// override prefixed by "tenants.<tenant>.". Either override can be nil if it
+// was created or deleted by the change.
+func recordOverrideAudit(
+	ctx context.Context,
+	tx *sql.Tx,
+	now time.Time,
+	method string,
+	tenant string,
+	raceID int64,
+	before, after *racingapi.TenantOverride,
+) error {
+	diff, err := audit.Diff(overrideFields(before), overrideFields(after))
+	if err != nil {
+		return err
+	}
+
+	for i := range diff {
+		diff[i].Field = "tenants." + tenant + "." + diff[i].Field
+	}
+
+	return insertAuditEntry(ctx, tx, now, method, raceID, diff)
+}
+
+// overrideFields returns the overridden fields of a tenant override, without
+// the tenant and the race it applies to. It returns nil if o is nil.
+func overrideFields(o *racingapi.TenantOverride) *racingapi.TenantOverride {
+	if o == nil {
+		return nil
+	}
+
+	fields := proto.CloneOf(o)
+	fields.Tenant = ""
+	fields.RaceId = 0
+
+	return fields
+}
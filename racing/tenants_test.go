@@ -0,0 +1,302 @@
+package racing_test
+
+import (
+	"testing"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	. "github.com/danilvpetrov/entain/racing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTenantOverrides(t *testing.T) { //nolint:gocognit // Explicit test cases.
+	db := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db})
+
+	race, err := client.GetRace(
+		t.Context(),
+		&racingapi.GetRaceRequest{RaceId: 1},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// The race is made visible for the tenant if it is hidden from other
+	// clients, and the other way around.
+	visibility := racingapi.TenantOverride_VISIBLE
+	if race.GetVisible() {
+		visibility = racingapi.TenantOverride_HIDDEN
+	}
+
+	t.Run("sets the overrides", func(t *testing.T) {
+		for _, o := range []*racingapi.TenantOverride{
+			{Tenant: "brand-1", RaceId: 1, Visibility: visibility},
+			{Tenant: "brand-1", RaceId: 3, Position: 2},
+			{Tenant: "brand-1", RaceId: 2, Position: 1},
+		} {
+			override, err := client.SetTenantOverride(
+				asAdmin(t.Context()),
+				&racingapi.SetTenantOverrideRequest{Override: o},
+			)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if override.GetVisibility() != o.GetVisibility() ||
+				override.GetPosition() != o.GetPosition() {
+				t.Fatalf("expected override %v, got %v", o, override)
+			}
+		}
+	})
+
+	t.Run("gets the race as seen by the tenant", func(t *testing.T) {
+		r, err := client.GetRace(
+			asTenant(t.Context(), "brand-1"),
+			&racingapi.GetRaceRequest{RaceId: 1},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if r.GetVisible() == race.GetVisible() {
+			t.Fatalf("expected visible to be %v", !race.GetVisible())
+		}
+
+		resp, err := client.BatchGetRaces(
+			asTenant(t.Context(), "brand-1"),
+			&racingapi.BatchGetRacesRequest{RaceId: []int64{1}},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if resp.GetRaces()[0].GetVisible() == race.GetVisible() {
+			t.Fatalf("expected visible to be %v", !race.GetVisible())
+		}
+	})
+
+	t.Run("gets the race as seen by other tenants", func(t *testing.T) {
+		r, err := client.GetRace(
+			asTenant(t.Context(), "brand-2"),
+			&racingapi.GetRaceRequest{RaceId: 1},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if r.GetVisible() != race.GetVisible() {
+			t.Fatalf("expected visible to be %v", race.GetVisible())
+		}
+	})
+
+	t.Run("lists the visible races of the tenant", func(t *testing.T) {
+		resp, err := client.ListRaces(
+			asTenant(t.Context(), "brand-1"),
+			&racingapi.ListRacesRequest{VisibleOnly: true},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		listed := false
+		for _, r := range resp.GetRaces() {
+			if !r.GetVisible() {
+				t.Fatalf("expected only visible races, got %v", r)
+			}
+			listed = listed || r.GetId() == 1
+		}
+
+		if listed == race.GetVisible() {
+			t.Fatalf("expected race 1 to be listed: %v", !race.GetVisible())
+		}
+	})
+
+	t.Run("exports the visible races of the tenant", func(t *testing.T) {
+		races := exportRaces(
+			asTenant(t.Context(), "brand-1"),
+			t,
+			client,
+			&racingapi.ExportRacesRequest{VisibleOnly: true},
+		)
+
+		exported := false
+		for _, r := range races {
+			if !r.GetVisible() {
+				t.Fatalf("expected only visible races, got %v", r)
+			}
+			exported = exported || r.GetId() == 1
+		}
+
+		if exported == race.GetVisible() {
+			t.Fatalf("expected race 1 to be exported: %v", !race.GetVisible())
+		}
+	})
+
+	t.Run("lists the positioned races first", func(t *testing.T) {
+		resp, err := client.ListRaces(
+			asTenant(t.Context(), "brand-1"),
+			&racingapi.ListRacesRequest{
+				OrderBy: []racingapi.ListRacesRequest_OrderBy{
+					racingapi.ListRacesRequest_NUMBER_DESC,
+				},
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		races := resp.GetRaces()
+		if len(races) != NumberOfSeededRaces {
+			t.Fatalf(
+				"expected %d races, got %d",
+				NumberOfSeededRaces,
+				len(races),
+			)
+		}
+
+		if races[0].GetId() != 2 || races[1].GetId() != 3 {
+			t.Fatalf(
+				"expected races 2 and 3 first, got %d and %d",
+				races[0].GetId(),
+				races[1].GetId(),
+			)
+		}
+
+		for i := 3; i < len(races); i++ {
+			if races[i].GetNumber() > races[i-1].GetNumber() {
+				t.Fatalf("expected the other races ordered by number DESC")
+			}
+		}
+	})
+
+	t.Run("lists the overrides", func(t *testing.T) {
+		resp, err := client.ListTenantOverrides(
+			asAdmin(t.Context()),
+			&racingapi.ListTenantOverridesRequest{Tenant: "brand-1"},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		overrides := resp.GetOverrides()
+		if len(overrides) != 3 ||
+			overrides[0].GetVisibility() != visibility ||
+			overrides[1].GetPosition() != 1 ||
+			overrides[2].GetPosition() != 2 {
+			t.Fatalf("unexpected overrides %v", overrides)
+		}
+	})
+
+	t.Run("records the changes in the audit log", func(t *testing.T) {
+		resp, err := client.ListAuditEntries(
+			asAdmin(t.Context()),
+			&racingapi.ListAuditEntriesRequest{EntityType: "race", EntityId: 1},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(resp.GetEntries()) != 1 {
+			t.Fatalf("expected 1 entry, got %v", resp.GetEntries())
+		}
+
+		entry := resp.GetEntries()[0]
+		method := racingapi.Racing_SetTenantOverride_FullMethodName
+		if entry.GetMethod() != method ||
+			len(entry.GetChanges()) != 1 ||
+			entry.GetChanges()[0].GetField() != "tenants.brand-1.visibility" {
+			t.Fatalf("unexpected entry %v", entry)
+		}
+	})
+
+	t.Run("deletes the override", func(t *testing.T) {
+		_, err := client.DeleteTenantOverride(
+			asAdmin(t.Context()),
+			&racingapi.DeleteTenantOverrideRequest{
+				Tenant: "brand-1",
+				RaceId: 1,
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		r, err := client.GetRace(
+			asTenant(t.Context(), "brand-1"),
+			&racingapi.GetRaceRequest{RaceId: 1},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if r.GetVisible() != race.GetVisible() {
+			t.Fatalf("expected visible to be %v", race.GetVisible())
+		}
+
+		_, err = client.DeleteTenantOverride(
+			asAdmin(t.Context()),
+			&racingapi.DeleteTenantOverrideRequest{
+				Tenant: "brand-1",
+				RaceId: 1,
+			},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("empty override", func(t *testing.T) {
+		_, err := client.SetTenantOverride(
+			asAdmin(t.Context()),
+			&racingapi.SetTenantOverrideRequest{
+				Override: &racingapi.TenantOverride{
+					Tenant: "brand-1",
+					RaceId: 1,
+				},
+			},
+		)
+		assertInvalidArgument(t, err, "override")
+	})
+
+	t.Run("unknown race", func(t *testing.T) {
+		_, err := client.SetTenantOverride(
+			asAdmin(t.Context()),
+			&racingapi.SetTenantOverrideRequest{
+				Override: &racingapi.TenantOverride{
+					Tenant:   "brand-1",
+					RaceId:   1_000_000,
+					Position: 1,
+				},
+			},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		_, err := client.SetTenantOverride(
+			asTenant(t.Context(), "brand-1"),
+			&racingapi.SetTenantOverrideRequest{
+				Override: &racingapi.TenantOverride{
+					Tenant:   "brand-1",
+					RaceId:   1,
+					Position: 1,
+				},
+			},
+		)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+	})
+
+	t.Run("invalid tenant", func(t *testing.T) {
+		_, err := client.GetRace(
+			asTenant(t.Context(), "Brand 1"),
+			&racingapi.GetRaceRequest{RaceId: 1},
+		)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected %v error, got %v", codes.InvalidArgument, err)
+		}
+	})
+}
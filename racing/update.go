@@ -0,0 +1,358 @@
+package racing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/danilvpetrov/entain/admin"
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/etag"
+	"github.com/danilvpetrov/entain/jurisdiction"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// updatableRaceFields is a list of the names of racingapi.Race fields that can
+// be updated by UpdateRace, in the order they are updated in.
+var updatableRaceFields = []protoreflect.Name{
+	"name",
+	"number",
+	"visible",
+	"advertised_start_time",
+}
+
+// UpdateRace updates the fields of a race listed in the update mask.
+func (s *Service) UpdateRace(
+	ctx context.Context,
+	req *racingapi.UpdateRaceRequest,
+) (*racingapi.Race, error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"races can only be updated by admins",
+		)
+	}
+
+	race := req.GetRace()
+	if race.GetId() <= 0 {
+		return nil, apierror.InvalidArgument(
+			ctx,
+			"invalid race ID",
+			apierror.FieldViolation{
+				Field:       "race.id",
+				Description: "value must be greater than 0",
+			},
+		)
+	}
+
+	set, args, err := parseUpdateMask(ctx, req.GetUpdateMask(), race)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.updateRace(
+		ctx,
+		race.GetId(),
+		req.GetEtag(),
+		set,
+		args,
+	); err != nil {
+		return nil, err
+	}
+
+	// The updated race is returned to the admin even if it is restricted in
+	// the jurisdiction of the request.
+	return s.GetRace(
+		jurisdiction.WithCode(ctx, jurisdiction.Unrestricted),
+		&racingapi.GetRaceRequest{RaceId: race.GetId()},
+	)
+}
+
+// updateRace sets the given columns of a race within a single transaction,
+// along with the change and the audit entry describing it. Archived races
+// cannot be updated.
+func (s *Service) updateRace(
+	ctx context.Context,
+	raceID int64,
+	tag string,
+	set string,
+	args []any,
+) (err error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := checkVersion(ctx, tx, raceID, tag, false)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`UPDATE races SET `+set+` WHERE id = ?`,
+		append(args, raceID)...,
+	); err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	if err := recordChange(
+		ctx,
+		tx,
+		s.now(),
+		raceID,
+		racingapi.Change_UPDATED,
+	); err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	after, err := readSnapshot(ctx, tx, raceID)
+	if err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	if err := recordAudit(
+		ctx,
+		tx,
+		s.now(),
+		racingapi.Racing_UpdateRace_FullMethodName,
+		raceID,
+		before,
+		after,
+	); err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	return nil
+}
+
+// DeleteRace deletes a race. The deleted race is kept as a tombstone, so that
+// it is not ingested again.
+func (s *Service) DeleteRace(
+	ctx context.Context,
+	req *racingapi.DeleteRaceRequest,
+) (_ *emptypb.Empty, err error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"races can only be deleted by admins",
+		)
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := checkVersion(
+		ctx,
+		tx,
+		req.GetRaceId(),
+		req.GetEtag(),
+		true,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`UPDATE races SET deleted_at = ? WHERE id = ?`,
+		s.now().UTC().Format(time.RFC3339),
+		req.GetRaceId(),
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := recordChange(
+		ctx,
+		tx,
+		s.now(),
+		req.GetRaceId(),
+		racingapi.Change_DELETED,
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := recordAudit(
+		ctx,
+		tx,
+		s.now(),
+		racingapi.Racing_DeleteRace_FullMethodName,
+		req.GetRaceId(),
+		before,
+		nil,
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// checkVersion checks the etag of a request changing the race with the given
+// ID against the current version of the race, and returns the snapshot of the
+// race before the change. It must be called within the transaction making the
+// change, before the change is made.
+func checkVersion(
+	ctx context.Context,
+	tx *sql.Tx,
+	raceID int64,
+	tag string,
+	includeArchived bool,
+) (*racingapi.Race, error) {
+	var version int64
+	if err := tx.QueryRowContext(
+		ctx,
+		`SELECT version
+		FROM races
+		WHERE races.id = ?`+lifecycleFilter(includeArchived),
+		raceID,
+	).Scan(&version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.NotFound(
+				ctx,
+				"RACE_NOT_FOUND",
+				"race not found",
+			)
+		}
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := etag.Check(ctx, tag, version); err != nil {
+		return nil, err
+	}
+
+	race, err := readSnapshot(ctx, tx, raceID)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	return race, nil
+}
+
+// parseUpdateMask builds the SET clause of the query updating a race and its
+// arguments from the update mask of a request. If the mask is empty, all
+// updatable fields are updated.
+//
+// Paths of the mask are names of racingapi.Race fields, either in snake_case
+// or in lowerCamelCase as they appear in JSON.
+func parseUpdateMask(
+	ctx context.Context,
+	mask *fieldmaskpb.FieldMask,
+	race *racingapi.Race,
+) (string, []any, error) {
+	desc := race.ProtoReflect().Descriptor().Fields()
+	fields := map[protoreflect.Name]bool{}
+
+	for _, path := range mask.GetPaths() {
+		fd := desc.ByName(protoreflect.Name(path))
+		if fd == nil {
+			fd = desc.ByJSONName(path)
+		}
+		if fd == nil {
+			return "", nil, apierror.InvalidArgument(
+				ctx,
+				"invalid update mask",
+				apierror.FieldViolation{
+					Field:       "update_mask",
+					Description: fmt.Sprintf("unknown field %q", path),
+				},
+			)
+		}
+		if !slices.Contains(updatableRaceFields, fd.Name()) {
+			return "", nil, apierror.InvalidArgument(
+				ctx,
+				"invalid update mask",
+				apierror.FieldViolation{
+					Field: "update_mask",
+					Description: fmt.Sprintf(
+						"field %q cannot be updated",
+						path,
+					),
+				},
+			)
+		}
+		fields[fd.Name()] = true
+	}
+
+	var (
+		set  []string
+		args []any
+	)
+
+	for _, name := range updatableRaceFields {
+		if len(fields) > 0 && !fields[name] {
+			continue
+		}
+
+		switch name {
+		case "name":
+			if race.GetName() == "" {
+				return "", nil, apierror.InvalidArgument(
+					ctx,
+					"invalid race",
+					apierror.FieldViolation{
+						Field:       "race.name",
+						Description: "value is required",
+					},
+				)
+			}
+			set = append(set, "name = ?")
+			args = append(args, race.GetName())
+		case "number":
+			set = append(set, "number = ?")
+			args = append(args, race.GetNumber())
+		case "visible":
+			set = append(set, "visible = ?")
+			args = append(args, race.GetVisible())
+		case "advertised_start_time":
+			if err := race.GetAdvertisedStartTime().CheckValid(); err != nil {
+				return "", nil, apierror.InvalidArgument(
+					ctx,
+					"invalid race",
+					apierror.FieldViolation{
+						Field:       "race.advertised_start_time",
+						Description: err.Error(),
+					},
+				)
+			}
+			set = append(set, "advertised_start_time = ?")
+			args = append(
+				args,
+				race.GetAdvertisedStartTime().
+					AsTime().
+					UTC().
+					Format(time.RFC3339),
+			)
+		}
+	}
+
+	return strings.Join(set, ", "), args, nil
+}
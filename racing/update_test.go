@@ -0,0 +1,251 @@
+package racing_test
+
+import (
+	"testing"
+	"time"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	"github.com/danilvpetrov/entain/clock"
+	. "github.com/danilvpetrov/entain/racing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestUpdateRace(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	db := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db, Clock: clock.Fixed(now)})
+
+	race, err := client.GetRace(
+		t.Context(),
+		&racingapi.GetRaceRequest{RaceId: 1},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if race.GetEtag() == "" {
+		t.Fatal("expected etag to be set")
+	}
+
+	t.Run("updates the fields in the mask", func(t *testing.T) {
+		updated, err := client.UpdateRace(
+			asAdmin(t.Context()),
+			&racingapi.UpdateRaceRequest{
+				Race: &racingapi.Race{
+					Id:     1,
+					Name:   "Renamed race",
+					Number: race.GetNumber() + 100,
+				},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+				Etag:       race.GetEtag(),
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if updated.GetName() != "Renamed race" {
+			t.Fatalf("expected name to be updated, got %q", updated.GetName())
+		}
+		if updated.GetNumber() != race.GetNumber() {
+			t.Fatalf(
+				"expected number %d to be left as it was, got %d",
+				race.GetNumber(),
+				updated.GetNumber(),
+			)
+		}
+		if updated.GetEtag() == race.GetEtag() {
+			t.Fatal("expected etag to change")
+		}
+
+		race = updated
+	})
+
+	t.Run("stale etag", func(t *testing.T) {
+		_, err := client.UpdateRace(
+			asAdmin(t.Context()),
+			&racingapi.UpdateRaceRequest{
+				Race:       &racingapi.Race{Id: 1, Name: "Stale race"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+				Etag:       "1",
+			},
+		)
+		if status.Code(err) != codes.Aborted {
+			t.Fatalf("expected %v error, got %v", codes.Aborted, err)
+		}
+	})
+
+	t.Run("etag in the If-Match metadata", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(
+			asAdmin(t.Context()),
+			"if-match",
+			`"`+race.GetEtag()+`"`,
+		)
+
+		updated, err := client.UpdateRace(
+			ctx,
+			&racingapi.UpdateRaceRequest{
+				Race:       &racingapi.Race{Id: 1, Visible: !race.GetVisible()},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"visible"}},
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		race = updated
+	})
+
+	t.Run("update leaving the race as it was", func(t *testing.T) {
+		updated, err := client.UpdateRace(
+			asAdmin(t.Context()),
+			&racingapi.UpdateRaceRequest{
+				Race:       race,
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+				Etag:       "*",
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if updated.GetEtag() != race.GetEtag() {
+			t.Fatalf(
+				"expected etag %q to be left as it was, got %q",
+				race.GetEtag(),
+				updated.GetEtag(),
+			)
+		}
+	})
+
+	t.Run("audit entries", func(t *testing.T) {
+		resp, err := client.ListAuditEntries(
+			asAdmin(t.Context()),
+			&racingapi.ListAuditEntriesRequest{EntityId: 1},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		entries := resp.GetEntries()
+		if len(entries) != 2 {
+			t.Fatalf("expected 2 entries, got %v", entries)
+		}
+
+		method := racingapi.Racing_UpdateRace_FullMethodName
+		for _, entry := range entries {
+			if entry.GetMethod() != method ||
+				entry.GetActor() != testAdminName ||
+				!entry.GetEntryTime().AsTime().Equal(now) {
+				t.Fatalf("unexpected entry: %v", entry)
+			}
+		}
+	})
+
+	t.Run("missing etag", func(t *testing.T) {
+		_, err := client.UpdateRace(
+			asAdmin(t.Context()),
+			&racingapi.UpdateRaceRequest{
+				Race:       &racingapi.Race{Id: 1, Name: "Race"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+			},
+		)
+		assertInvalidArgument(t, err, "etag")
+	})
+
+	t.Run("field that cannot be updated", func(t *testing.T) {
+		_, err := client.UpdateRace(
+			asAdmin(t.Context()),
+			&racingapi.UpdateRaceRequest{
+				Race: &racingapi.Race{Id: 1, MeetingId: 2},
+				UpdateMask: &fieldmaskpb.FieldMask{
+					Paths: []string{"meetingId"},
+				},
+				Etag: "*",
+			},
+		)
+		assertInvalidArgument(t, err, "update_mask")
+	})
+
+	t.Run("unknown race", func(t *testing.T) {
+		_, err := client.UpdateRace(
+			asAdmin(t.Context()),
+			&racingapi.UpdateRaceRequest{
+				Race:       &racingapi.Race{Id: 1000, Name: "Race"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+				Etag:       "*",
+			},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		_, err := client.UpdateRace(
+			t.Context(),
+			&racingapi.UpdateRaceRequest{
+				Race: &racingapi.Race{Id: 1, Name: "Race"},
+				Etag: "*",
+			},
+		)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+	})
+}
+
+func TestDeleteRace(t *testing.T) {
+	db := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db})
+
+	t.Run("stale etag", func(t *testing.T) {
+		_, err := client.DeleteRace(
+			asAdmin(t.Context()),
+			&racingapi.DeleteRaceRequest{RaceId: 1, Etag: "2"},
+		)
+		if status.Code(err) != codes.Aborted {
+			t.Fatalf("expected %v error, got %v", codes.Aborted, err)
+		}
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		_, err := client.DeleteRace(
+			t.Context(),
+			&racingapi.DeleteRaceRequest{RaceId: 1, Etag: "1"},
+		)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+	})
+
+	t.Run("deletes the race", func(t *testing.T) {
+		if _, err := client.DeleteRace(
+			asAdmin(t.Context()),
+			&racingapi.DeleteRaceRequest{RaceId: 1, Etag: "1"},
+		); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		_, err := client.GetRace(
+			t.Context(),
+			&racingapi.GetRaceRequest{RaceId: 1},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("deleted race", func(t *testing.T) {
+		_, err := client.DeleteRace(
+			asAdmin(t.Context()),
+			&racingapi.DeleteRaceRequest{RaceId: 1, Etag: "*"},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+	})
+}
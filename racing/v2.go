@@ -0,0 +1,387 @@
+package racing
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danilvpetrov/entain/admin"
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	racingv2 "github.com/danilvpetrov/entain/api/racing/v2"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/audit"
+	"github.com/danilvpetrov/entain/etag"
+	"github.com/danilvpetrov/entain/jurisdiction"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultRacesPageSize is the maximum number of races returned by a single
+// ListRaces call of version 2 of the API, unless the request sets a page size.
+const defaultRacesPageSize = 100
+
+// raceV2Columns is the list of columns races are read from by version 2 of
+// the API, in the order they are scanned by scanRaceV2.
+const raceV2Columns = `races.id,
+	races.meeting_id,
+	races.name,
+	races.number,
+	races.visible,
+	races.advertised_start_time,
+	races.status,
+	races.version`
+
+// ServiceV2 handles the requests to version 2 of the Racing API. It implements
+// racingv2.RacingServer interface.
+//
+// Both versions of the API share the storage, and the version 2 service shares
+// the configuration of the version 1 one.
+type ServiceV2 struct {
+	// V1 is the version 1 service, whose database, clock and jurisdiction
+	// rules are used to serve the requests.
+	V1 *Service
+}
+
+// Make sure ServiceV2 implements the racingv2.RacingServer interface.
+var _ racingv2.RacingServer = (*ServiceV2)(nil)
+
+// ListRaces returns a page of races in the order of their advertised start
+// times.
+func (s *ServiceV2) ListRaces(
+	ctx context.Context,
+	req *racingv2.ListRacesRequest,
+) (*racingv2.ListRacesResponse, error) {
+	after, afterID, err := parsePageToken(ctx, req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	filterQuery, args := parseFilterV2(req)
+
+	restriction, restrictionArgs := s.V1.restrictionFilter(ctx)
+	filterQuery += restriction
+	args = append(args, restrictionArgs...)
+
+	if req.GetPageToken() != "" {
+		filterQuery += ` AND (datetime(races.advertised_start_time), races.id)
+			> (?, ?)`
+		args = append(args, after, afterID)
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultRacesPageSize
+	}
+
+	from, fromArgs := racesFrom(ctx)
+
+	// One more race than requested is read to tell whether there is a next
+	// page.
+	args = append(args, pageSize+1)
+	rows, err := s.V1.DB.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			`SELECT %s
+			FROM %s
+			WHERE races.id <> 0 %s %s
+			ORDER BY datetime(races.advertised_start_time), races.id
+			LIMIT ?`,
+			raceV2Columns,
+			from,
+			lifecycleFilter(false),
+			filterQuery,
+		),
+		append(fromArgs, args...)...,
+	)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	resp := &racingv2.ListRacesResponse{}
+	for rows.Next() {
+		race, err := scanRaceV2(rows)
+		if err != nil {
+			return nil, apierror.Internal(ctx, err)
+		}
+		resp.Races = append(resp.Races, race)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if len(resp.Races) > pageSize {
+		resp.Races = resp.Races[:pageSize]
+		resp.NextPageToken = formatPageToken(resp.Races[pageSize-1])
+	}
+
+	return resp, nil
+}
+
+// GetRace returns a specific race by its ID.
+func (s *ServiceV2) GetRace(
+	ctx context.Context,
+	req *racingv2.GetRaceRequest,
+) (*racingv2.Race, error) {
+	from, args := racesFrom(ctx)
+	restriction, restrictionArgs := s.V1.restrictionFilter(ctx)
+
+	args = append(args, req.GetRaceId())
+	row := s.V1.DB.QueryRowContext(
+		ctx,
+		fmt.Sprintf(
+			`SELECT %s
+			FROM %s
+			WHERE races.id = ? %s %s`,
+			raceV2Columns,
+			from,
+			lifecycleFilter(false),
+			restriction,
+		),
+		append(args, restrictionArgs...)...,
+	)
+
+	race, err := scanRaceV2(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, s.V1.notFound(ctx, restriction, req.GetRaceId(), false)
+		}
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	return race, nil
+}
+
+// UpdateRaceStatus sets the status of a race.
+func (s *ServiceV2) UpdateRaceStatus(
+	ctx context.Context,
+	req *racingv2.UpdateRaceStatusRequest,
+) (_ *racingv2.Race, err error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"statuses of races can only be updated by admins",
+		)
+	}
+
+	tx, err := s.V1.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var (
+		version int64
+		status  string
+	)
+	if err := tx.QueryRowContext(
+		ctx,
+		`SELECT version, status
+		FROM races
+		WHERE races.id = ?`+lifecycleFilter(false),
+		req.GetRaceId(),
+	).Scan(&version, &status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.NotFound(
+				ctx,
+				"RACE_NOT_FOUND",
+				"race not found",
+			)
+		}
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := etag.Check(ctx, req.GetEtag(), version); err != nil {
+		return nil, err
+	}
+
+	before := parseStoredStatus(status)
+	if before != req.GetStatus() {
+		if _, err := tx.ExecContext(
+			ctx,
+			`UPDATE races SET status = ? WHERE id = ?`,
+			formatStoredStatus(req.GetStatus()),
+			req.GetRaceId(),
+		); err != nil {
+			return nil, apierror.Internal(ctx, err)
+		}
+
+		if err := recordChange(
+			ctx,
+			tx,
+			s.V1.now(),
+			req.GetRaceId(),
+			racingapi.Change_UPDATED,
+		); err != nil {
+			return nil, apierror.Internal(ctx, err)
+		}
+
+		diff, err := audit.Diff(
+			&racingv2.Race{Status: before},
+			&racingv2.Race{Status: req.GetStatus()},
+		)
+		if err != nil {
+			return nil, apierror.Internal(ctx, err)
+		}
+
+		if err := insertAuditEntry(
+			ctx,
+			tx,
+			s.V1.now(),
+			racingv2.Racing_UpdateRaceStatus_FullMethodName,
+			req.GetRaceId(),
+			diff,
+		); err != nil {
+			return nil, apierror.Internal(ctx, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	// The updated race is returned to the admin even if it is restricted in
+	// the jurisdiction of the request.
+	return s.GetRace(
+		jurisdiction.WithCode(ctx, jurisdiction.Unrestricted),
+		&racingv2.GetRaceRequest{RaceId: req.GetRaceId()},
+	)
+}
+
+// parseFilterV2 builds SQL filter query and its arguments from the provided
+// ListRaces request of version 2 of the API.
+func parseFilterV2(
+	req *racingv2.ListRacesRequest,
+) (filter string, args []any) {
+	var w strings.Builder
+
+	if ids := req.GetMeetingId(); len(ids) > 0 {
+		_, _ = fmt.Fprintf(
+			&w,
+			" AND races.meeting_id IN (%s)",
+			placeholders(len(ids)),
+		)
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
+
+	if req.GetVisibleOnly() {
+		_, _ = w.WriteString(" AND races.visible = true")
+	}
+
+	if statuses := req.GetStatus(); len(statuses) > 0 {
+		_, _ = fmt.Fprintf(
+			&w,
+			" AND races.status IN (%s)",
+			placeholders(len(statuses)),
+		)
+		for _, status := range statuses {
+			args = append(args, formatStoredStatus(status))
+		}
+	}
+
+	return w.String(), args
+}
+
+// scanRaceV2 scans a race of version 2 of the API from the given scanner,
+// reading the columns listed in raceV2Columns.
+func scanRaceV2(s scanner) (*racingv2.Race, error) {
+	var (
+		race                racingv2.Race
+		advertisedStartTime time.Time
+		status              string
+		version             int64
+	)
+
+	if err := s.Scan(
+		&race.Id,
+		&race.MeetingId,
+		&race.Name,
+		&race.Number,
+		&race.Visible,
+		&advertisedStartTime,
+		&status,
+		&version,
+	); err != nil {
+		return nil, err
+	}
+
+	race.AdvertisedStartTime = timestamppb.New(advertisedStartTime)
+	race.Status = parseStoredStatus(status)
+	race.Etag = etag.Format(version)
+
+	return &race, nil
+}
+
+// parseStoredStatus returns the status of version 2 of the API a race with the
+// given stored status has.
+func parseStoredStatus(status string) racingv2.Race_Status {
+	return racingv2.Race_Status(racingv2.Race_Status_value["STATUS_"+status])
+}
+
+// formatStoredStatus returns the stored status of a race with the given status
+// of version 2 of the API.
+func formatStoredStatus(status racingv2.Race_Status) string {
+	return strings.TrimPrefix(status.String(), "STATUS_")
+}
+
+// parsePageToken returns the advertised start time, in the format of SQLite,
+// and the ID of the race encoded in a page token returned by ListRaces. An
+// empty token points before the first race.
+func parsePageToken(
+	ctx context.Context,
+	token string,
+) (string, int64, error) {
+	if token == "" {
+		return "", 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err == nil {
+		start, id, _ := strings.Cut(string(raw), ",")
+		if _, err := time.Parse(sqliteDateTime, start); err == nil {
+			if id, err := strconv.ParseInt(id, 10, 64); err == nil {
+				return start, id, nil
+			}
+		}
+	}
+
+	return "", 0, apierror.InvalidArgument(
+		ctx,
+		"invalid page token",
+		apierror.FieldViolation{
+			Field:       "page_token",
+			Description: "page token must be returned by a previous call",
+		},
+	)
+}
+
+// formatPageToken returns a page token pointing after the given race. Page
+// tokens are opaque to clients, so that their format can be changed in the
+// future.
+func formatPageToken(race *racingv2.Race) string {
+	return base64.RawURLEncoding.EncodeToString(
+		fmt.Appendf(
+			nil,
+			"%s,%d",
+			race.GetAdvertisedStartTime().AsTime().UTC().Format(sqliteDateTime),
+			race.GetId(),
+		),
+	)
+}
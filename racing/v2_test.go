@@ -0,0 +1,335 @@
+package racing_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	racingapi "github.com/danilvpetrov/entain/api/racing/v1"
+	racingv2 "github.com/danilvpetrov/entain/api/racing/v2"
+	"github.com/danilvpetrov/entain/clock"
+	. "github.com/danilvpetrov/entain/racing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServiceV2(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	db := setupDatabase(t)
+	if err := (&Seeder{
+		DB:    db,
+		Rand:  rand.New(rand.NewSource(1)),
+		Clock: clock.Fixed(now),
+	}).Seed(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	u := &StatusUpdater{DB: db, Clock: clock.Fixed(now)}
+	if _, err := u.RunOnce(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	v1 := &Service{DB: db, Clock: clock.Fixed(now)}
+	client := setupServer(t, v1)
+	clientV2 := setupServerV2(t, &ServiceV2{V1: v1})
+
+	t.Run("lists races page by page", func(t *testing.T) {
+		var (
+			races []*racingv2.Race
+			pages int
+			token string
+		)
+
+		for {
+			resp, err := clientV2.ListRaces(
+				t.Context(),
+				&racingv2.ListRacesRequest{
+					PageSize:  30,
+					PageToken: token,
+				},
+			)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			races = append(races, resp.GetRaces()...)
+			pages++
+
+			token = resp.GetNextPageToken()
+			if token == "" {
+				break
+			}
+		}
+
+		if len(races) != NumberOfSeededRaces {
+			t.Fatalf(
+				"expected %d races, got %d",
+				NumberOfSeededRaces,
+				len(races),
+			)
+		}
+
+		if pages != 4 {
+			t.Fatalf("expected 4 pages, got %d", pages)
+		}
+
+		seen := map[int64]bool{}
+		for i, race := range races {
+			if seen[race.GetId()] {
+				t.Fatalf("expected race %d to be listed once", race.GetId())
+			}
+			seen[race.GetId()] = true
+
+			if i == 0 {
+				continue
+			}
+
+			prev := races[i-1].GetAdvertisedStartTime().AsTime()
+			if race.GetAdvertisedStartTime().AsTime().Before(prev) {
+				t.Fatalf(
+					"expected races in the order of start times, got %v",
+					races,
+				)
+			}
+		}
+	})
+
+	t.Run("lists the first page by default", func(t *testing.T) {
+		resp, err := clientV2.ListRaces(
+			t.Context(),
+			&racingv2.ListRacesRequest{},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		// There are as many seeded races as fit on the default page.
+		if len(resp.GetRaces()) != NumberOfSeededRaces {
+			t.Fatalf(
+				"expected %d races, got %d",
+				NumberOfSeededRaces,
+				len(resp.GetRaces()),
+			)
+		}
+
+		if resp.GetNextPageToken() != "" {
+			t.Fatalf(
+				"expected no next page, got %q",
+				resp.GetNextPageToken(),
+			)
+		}
+	})
+
+	t.Run("filters races by status", func(t *testing.T) {
+		var total int
+
+		for _, s := range []racingv2.Race_Status{
+			racingv2.Race_STATUS_OPEN,
+			racingv2.Race_STATUS_CLOSED,
+		} {
+			resp, err := clientV2.ListRaces(
+				t.Context(),
+				&racingv2.ListRacesRequest{
+					Status: []racingv2.Race_Status{s},
+				},
+			)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			for _, race := range resp.GetRaces() {
+				if race.GetStatus() != s {
+					t.Fatalf(
+						"expected status %v, got %v",
+						s,
+						race.GetStatus(),
+					)
+				}
+			}
+
+			total += len(resp.GetRaces())
+		}
+
+		if total != NumberOfSeededRaces {
+			t.Fatalf(
+				"expected %d races, got %d",
+				NumberOfSeededRaces,
+				total,
+			)
+		}
+	})
+
+	t.Run("rejects invalid page token", func(t *testing.T) {
+		_, err := clientV2.ListRaces(
+			t.Context(),
+			&racingv2.ListRacesRequest{PageToken: "invalid"},
+		)
+		assertInvalidArgument(t, err, "page_token")
+	})
+
+	t.Run("gets a race", func(t *testing.T) {
+		race, err := clientV2.GetRace(
+			t.Context(),
+			&racingv2.GetRaceRequest{RaceId: 1},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		expected, err := client.GetRace(
+			t.Context(),
+			&racingapi.GetRaceRequest{RaceId: 1},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if race.GetName() != expected.GetName() ||
+			race.GetEtag() != expected.GetEtag() ||
+			race.GetStatus().String() != "STATUS_"+
+				expected.GetStatus().String() {
+			t.Fatalf("expected race %v, got %v", expected, race)
+		}
+	})
+
+	t.Run("reports missing race as not found", func(t *testing.T) {
+		_, err := clientV2.GetRace(
+			t.Context(),
+			&racingv2.GetRaceRequest{RaceId: NumberOfSeededRaces + 1},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("updates status of a race", func(t *testing.T) {
+		resp, err := clientV2.ListRaces(
+			t.Context(),
+			&racingv2.ListRacesRequest{
+				Status:   []racingv2.Race_Status{racingv2.Race_STATUS_OPEN},
+				PageSize: 1,
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		open := resp.GetRaces()[0]
+
+		_, err = clientV2.UpdateRaceStatus(
+			t.Context(),
+			&racingv2.UpdateRaceStatusRequest{
+				RaceId: open.GetId(),
+				Status: racingv2.Race_STATUS_ABANDONED,
+			},
+		)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+
+		race, err := clientV2.UpdateRaceStatus(
+			asAdmin(t.Context()),
+			&racingv2.UpdateRaceStatusRequest{
+				RaceId: open.GetId(),
+				Status: racingv2.Race_STATUS_ABANDONED,
+				Etag:   open.GetEtag(),
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if race.GetStatus() != racingv2.Race_STATUS_ABANDONED {
+			t.Fatalf("expected abandoned race, got %v", race.GetStatus())
+		}
+
+		if race.GetEtag() == open.GetEtag() {
+			t.Fatal("expected the etag to change")
+		}
+
+		changes, _ := listAllChanges(t, client, "")
+		last := changes[len(changes)-1]
+		if last.GetOperation() != racingapi.Change_UPDATED ||
+			last.GetRace().GetId() != open.GetId() ||
+			last.GetRace().GetStatus() != racingapi.Race_ABANDONED {
+			t.Fatalf("expected a change of the status, got %v", last)
+		}
+
+		// The race is not updated without an etag.
+		_, err = clientV2.UpdateRaceStatus(
+			asAdmin(t.Context()),
+			&racingv2.UpdateRaceStatusRequest{
+				RaceId: open.GetId(),
+				Status: racingv2.Race_STATUS_OPEN,
+			},
+		)
+		assertInvalidArgument(t, err, "etag")
+
+		// The race is not updated again with the etag it had before.
+		_, err = clientV2.UpdateRaceStatus(
+			asAdmin(t.Context()),
+			&racingv2.UpdateRaceStatusRequest{
+				RaceId: open.GetId(),
+				Status: racingv2.Race_STATUS_OPEN,
+				Etag:   open.GetEtag(),
+			},
+		)
+		if status.Code(err) != codes.Aborted {
+			t.Fatalf("expected %v error, got %v", codes.Aborted, err)
+		}
+
+		// Version 1 of the API reports the abandoned race as closed.
+		v1Race, err := client.GetRace(
+			t.Context(),
+			&racingapi.GetRaceRequest{RaceId: open.GetId()},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if v1Race.GetStatus() != racingapi.Race_CLOSED {
+			t.Fatalf("expected closed race, got %v", v1Race.GetStatus())
+		}
+
+		audit, err := client.ListAuditEntries(
+			asAdmin(t.Context()),
+			&racingapi.ListAuditEntriesRequest{EntityId: open.GetId()},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		entries := audit.GetEntries()
+		if len(entries) != 1 ||
+			entries[0].GetMethod() !=
+				racingv2.Racing_UpdateRaceStatus_FullMethodName ||
+			entries[0].GetChanges()[0].GetField() != "status" {
+			t.Fatalf("expected an entry of the status change, got %v", entries)
+		}
+
+		// The abandoned race is not closed once it has started.
+		u := &StatusUpdater{DB: db, Clock: clock.Fixed(now.AddDate(0, 0, 3))}
+		if _, err := u.RunOnce(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+
+		race, err = clientV2.GetRace(
+			t.Context(),
+			&racingv2.GetRaceRequest{RaceId: open.GetId()},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if race.GetStatus() != racingv2.Race_STATUS_ABANDONED {
+			t.Fatalf("expected abandoned race, got %v", race.GetStatus())
+		}
+	})
+
+	t.Run("rejects unspecified status", func(t *testing.T) {
+		_, err := clientV2.UpdateRaceStatus(
+			asAdmin(t.Context()),
+			&racingv2.UpdateRaceStatusRequest{RaceId: 1},
+		)
+		assertInvalidArgument(t, err, "status")
+	})
+}
@@ -0,0 +1,291 @@
+package sports_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/clock"
+	. "github.com/danilvpetrov/entain/sports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListAuditEntries(t *testing.T) {
+	db, _ := setupDatabase(t)
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	client := setupServer(t, &Service{DB: db, Clock: clock.Fixed(now)})
+
+	updates := []struct {
+		req   *sportsapi.UpdateScoreRequest
+		token string
+	}{
+		{
+			token: testAdminToken,
+			req: &sportsapi.UpdateScoreRequest{
+				EventId:    1,
+				MatchState: sportsapi.Event_IN_PLAY,
+				Scores: []*sportsapi.PeriodScore{
+					{Period: 1, Home: 1, Away: 0},
+				},
+				Etag: "*",
+			},
+		},
+		{
+			token: testOtherAdminToken,
+			req: &sportsapi.UpdateScoreRequest{
+				EventId:    2,
+				MatchState: sportsapi.Event_POSTPONED,
+				Etag:       "*",
+			},
+		},
+		{
+			// An update leaving the event as it was is not audited.
+			token: testAdminToken,
+			req: &sportsapi.UpdateScoreRequest{
+				EventId:    1,
+				MatchState: sportsapi.Event_IN_PLAY,
+				Etag:       "*",
+			},
+		},
+	}
+
+	for _, u := range updates {
+		ctx := metadata.AppendToOutgoingContext(
+			t.Context(),
+			"authorization",
+			"Bearer "+u.token,
+		)
+
+		if _, err := client.UpdateScore(ctx, u.req); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	// A failed update is not audited.
+	if _, err := client.UpdateScore(
+		asAdmin(t.Context()),
+		&sportsapi.UpdateScoreRequest{
+			EventId:    2,
+			MatchState: sportsapi.Event_IN_PLAY,
+			Etag:       "*",
+		},
+	); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected %v error, got %v", codes.FailedPrecondition, err)
+	}
+
+	t.Run("all entries", func(t *testing.T) {
+		resp, err := client.ListAuditEntries(
+			asAdmin(t.Context()),
+			&sportsapi.ListAuditEntriesRequest{},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		entries := resp.GetEntries()
+		if len(entries) != 2 {
+			t.Fatalf("expected 2 entries, got %d", len(entries))
+		}
+
+		entry := entries[0]
+		if entry.GetActor() != testAdminName {
+			t.Fatalf(
+				"expected actor %q, got %q",
+				testAdminName,
+				entry.GetActor(),
+			)
+		}
+		if entry.GetMethod() != sportsapi.Sports_UpdateScore_FullMethodName {
+			t.Fatalf(
+				"expected method %q, got %q",
+				sportsapi.Sports_UpdateScore_FullMethodName,
+				entry.GetMethod(),
+			)
+		}
+		if entry.GetEntityType() != "event" || entry.GetEntityId() != 1 {
+			t.Fatalf(
+				"expected entity event 1, got %s %d",
+				entry.GetEntityType(),
+				entry.GetEntityId(),
+			)
+		}
+		if actual := entry.GetEntryTime().AsTime(); !actual.Equal(now) {
+			t.Fatalf("expected entry time %v, got %v", now, actual)
+		}
+
+		changes := entry.GetChanges()
+		if len(changes) != 2 {
+			t.Fatalf("expected 2 changed fields, got %v", changes)
+		}
+
+		if changes[0].GetField() != "matchState" ||
+			changes[0].GetBefore().GetStringValue() != "PRE_MATCH" ||
+			changes[0].GetAfter().GetStringValue() != "IN_PLAY" {
+			t.Fatalf("unexpected change of match state: %v", changes[0])
+		}
+
+		if changes[1].GetField() != "scores" ||
+			changes[1].GetBefore() != nil ||
+			len(changes[1].GetAfter().GetListValue().GetValues()) != 1 {
+			t.Fatalf("unexpected change of scores: %v", changes[1])
+		}
+
+		if entries[1].GetActor() != testOtherAdminName ||
+			entries[1].GetEntityId() != 2 {
+			t.Fatalf("unexpected entry of the other admin: %v", entries[1])
+		}
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		cases := []struct {
+			req      *sportsapi.ListAuditEntriesRequest
+			name     string
+			expected []int64
+		}{
+			{
+				name:     "by entity",
+				req:      &sportsapi.ListAuditEntriesRequest{EntityId: 2},
+				expected: []int64{2},
+			},
+			{
+				name: "by entity type",
+				req: &sportsapi.ListAuditEntriesRequest{
+					EntityType: "event",
+				},
+				expected: []int64{1, 2},
+			},
+			{
+				name: "by actor",
+				req: &sportsapi.ListAuditEntriesRequest{
+					Actor: testAdminName,
+				},
+				expected: []int64{1},
+			},
+			{
+				name: "by time range",
+				req: &sportsapi.ListAuditEntriesRequest{
+					StartTime: timestamppb.New(now),
+					EndTime:   timestamppb.New(now.Add(time.Minute)),
+				},
+				expected: []int64{1, 2},
+			},
+			{
+				name: "after the time range",
+				req: &sportsapi.ListAuditEntriesRequest{
+					StartTime: timestamppb.New(now.Add(time.Minute)),
+				},
+			},
+			{
+				name: "before the time range",
+				req: &sportsapi.ListAuditEntriesRequest{
+					EndTime: timestamppb.New(now),
+				},
+			},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				resp, err := client.ListAuditEntries(
+					asAdmin(t.Context()),
+					c.req,
+				)
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				var actual []int64
+				for _, entry := range resp.GetEntries() {
+					actual = append(actual, entry.GetEntityId())
+				}
+
+				if !slices.Equal(actual, c.expected) {
+					t.Fatalf(
+						"expected entities %v, got %v",
+						c.expected,
+						actual,
+					)
+				}
+			})
+		}
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		var (
+			cursor string
+			ids    []int64
+		)
+		for range 3 {
+			resp, err := client.ListAuditEntries(
+				asAdmin(t.Context()),
+				&sportsapi.ListAuditEntriesRequest{
+					Cursor:   cursor,
+					PageSize: 1,
+				},
+			)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			cursor = resp.GetNextCursor()
+			for _, entry := range resp.GetEntries() {
+				ids = append(ids, entry.GetEntityId())
+			}
+		}
+
+		if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+			t.Fatalf("expected entities [1 2], got %v", ids)
+		}
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		_, err := client.ListAuditEntries(
+			t.Context(),
+			&sportsapi.ListAuditEntriesRequest{},
+		)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+	})
+
+	t.Run("unknown entity type", func(t *testing.T) {
+		_, err := client.ListAuditEntries(
+			asAdmin(t.Context()),
+			&sportsapi.ListAuditEntriesRequest{EntityType: "race"},
+		)
+		assertInvalidArgument(t, err, "entity_type")
+	})
+}
+
+func TestAuditEntriesAreAppendOnly(t *testing.T) {
+	db, _ := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db})
+
+	if _, err := client.UpdateScore(
+		asAdmin(t.Context()),
+		&sportsapi.UpdateScoreRequest{
+			EventId:    1,
+			MatchState: sportsapi.Event_IN_PLAY,
+			Etag:       "*",
+		},
+	); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if _, err := db.ExecContext(
+		t.Context(),
+		`UPDATE audit_entries SET actor = 'someone else'`,
+	); err == nil {
+		t.Fatal("expected updating audit entries to fail")
+	}
+
+	if _, err := db.ExecContext(
+		t.Context(),
+		`DELETE FROM audit_entries`,
+	); err == nil {
+		t.Fatal("expected deleting audit entries to fail")
+	}
+}
@@ -0,0 +1,549 @@
+package sports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/danilvpetrov/entain/admin"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/etag"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultMaxImportSize is the default maximum number of events that can be
+// imported in a single ImportEvents call.
+const DefaultMaxImportSize = 10000
+
+// exportPageSize is the number of events read from the database at a time by
+// ExportEvents.
+const exportPageSize = 500
+
+// importOutcome is the outcome of importing a single sports event.
+type importOutcome int
+
+const (
+	// eventCreated indicates that a new event has been created.
+	eventCreated importOutcome = iota
+	// eventUpdated indicates that an existing event has been updated.
+	eventUpdated
+	// eventUnchanged indicates that an existing event has been imported as
+	// it was.
+	eventUnchanged
+)
+
+// ImportEvents creates and updates the sports events streamed by the client
+// within a single transaction, and returns the outcome of the import once the
+// stream is closed. The subscribers watching the updated events are notified
+// once the import is committed.
+func (s *Service) ImportEvents(
+	stream grpc.ClientStreamingServer[
+		sportsapi.ImportEventsRequest,
+		sportsapi.ImportEventsResponse,
+	],
+) error {
+	ctx := stream.Context()
+
+	if !admin.IsAdmin(ctx) {
+		return apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"events can only be imported by admins",
+		)
+	}
+
+	maxImportSize := s.MaxImportSize
+	if maxImportSize == 0 {
+		maxImportSize = DefaultMaxImportSize
+	}
+
+	var (
+		opts   *sportsapi.ImportOptions
+		events []*sportsapi.Event
+	)
+
+	for first := true; ; first = false {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if first {
+			opts = req.GetOptions()
+		}
+
+		if req.GetEvent() == nil {
+			continue
+		}
+
+		if len(events) == maxImportSize {
+			return apierror.InvalidArgument(
+				ctx,
+				"too many events imported",
+				apierror.FieldViolation{
+					Field: "event",
+					Description: fmt.Sprintf(
+						"at most %d events can be imported at once",
+						maxImportSize,
+					),
+				},
+			)
+		}
+
+		events = append(events, req.GetEvent())
+	}
+
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	resp, updated, err := importEvents(ctx, s.DB, s.now(), events, opts)
+	if err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	for _, id := range updated {
+		if _, err := s.publishEvent(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+// importEvents imports the given sports events within a single transaction,
+// and returns the IDs of the updated events if the import is committed. The
+// transaction is rolled back for dry runs, and for all-or-nothing imports with
+// invalid events.
+func importEvents(
+	ctx context.Context,
+	db *sql.DB,
+	now time.Time,
+	events []*sportsapi.Event,
+	opts *sportsapi.ImportOptions,
+) (*sportsapi.ImportEventsResponse, []int64, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Rolling back a committed transaction is a no-op.
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var (
+		resp    sportsapi.ImportEventsResponse
+		updated []int64
+	)
+
+	for i, event := range events {
+		outcome, importErr, err := importEvent(ctx, tx, now, event)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if importErr != nil {
+			importErr.Row = int64(i + 1)
+			resp.Errors = append(resp.Errors, importErr)
+			continue
+		}
+
+		switch outcome {
+		case eventCreated:
+			resp.Created++
+		case eventUpdated:
+			resp.Updated++
+			updated = append(updated, event.GetId())
+		case eventUnchanged:
+			resp.Unchanged++
+		}
+	}
+
+	if opts.GetDryRun() ||
+		(opts.GetAllOrNothing() && len(resp.GetErrors()) > 0) {
+		return &resp, nil, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	resp.Committed = true
+
+	return &resp, updated, nil
+}
+
+// importEvent creates the given sports event if it has no ID, or updates the
+// event with its ID otherwise. It returns an import error if the event is
+// invalid, in which case nothing is changed.
+func importEvent(
+	ctx context.Context,
+	tx *sql.Tx,
+	now time.Time,
+	event *sportsapi.Event,
+) (importOutcome, *sportsapi.ImportError, error) {
+	if importErr := validateImportedEvent(event); importErr != nil {
+		return 0, importErr, nil
+	}
+
+	if event.GetId() == 0 {
+		return createImportedEvent(ctx, tx, now, event)
+	}
+
+	return updateImportedEvent(ctx, tx, now, event)
+}
+
+// validateImportedEvent returns an import error if the fields of an imported
+// sports event that do not depend on the stored events are invalid.
+func validateImportedEvent(event *sportsapi.Event) *sportsapi.ImportError {
+	category := event.GetCategory().String()
+
+	switch {
+	case event.GetId() < 0:
+		return &sportsapi.ImportError{
+			Field:       "id",
+			Description: "value must not be negative",
+		}
+	case event.GetName() == "":
+		return &sportsapi.ImportError{
+			Field:       "name",
+			Description: "value is required",
+		}
+	case parseCategory(category) == sportsapi.Event_UNSPECIFIED_CATEGORY:
+		return &sportsapi.ImportError{
+			Field:       "category",
+			Description: "value must be a known category",
+		}
+	case event.GetAdvertisedStartTime() == nil:
+		return &sportsapi.ImportError{
+			Field:       "advertised_start_time",
+			Description: "value is required",
+		}
+	}
+
+	if err := event.GetAdvertisedStartTime().CheckValid(); err != nil {
+		return &sportsapi.ImportError{
+			Field:       "advertised_start_time",
+			Description: err.Error(),
+		}
+	}
+
+	return nil
+}
+
+// createImportedEvent creates an imported sports event without an ID, along
+// with its competition and participants.
+func createImportedEvent(
+	ctx context.Context,
+	tx *sql.Tx,
+	now time.Time,
+	event *sportsapi.Event,
+) (importOutcome, *sportsapi.ImportError, error) {
+	category := event.GetCategory().String()
+
+	refs, err := upsertEventRefs(
+		ctx,
+		tx,
+		event.GetName(),
+		category,
+		event.GetCompetition(),
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	res, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO events (
+			name,
+			category,
+			competition_id,
+			home_participant_id,
+			away_participant_id,
+			visible,
+			advertised_start_time
+		) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		event.GetName(),
+		category,
+		refs.competitionID,
+		refs.homeID,
+		refs.awayID,
+		event.GetVisible(),
+		formatStartTime(event),
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if err := recordChange(ctx, tx, now, id, sportsapi.Change_CREATED); err != nil {
+		return 0, nil, err
+	}
+
+	after, err := readSnapshot(ctx, tx, id)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if err := recordAudit(
+		ctx,
+		tx,
+		now,
+		sportsapi.Sports_ImportEvents_FullMethodName,
+		id,
+		nil,
+		after,
+	); err != nil {
+		return 0, nil, err
+	}
+
+	return eventCreated, nil, nil
+}
+
+// updateImportedEvent updates the sports event with the ID of an imported
+// event. Archived and deleted events cannot be updated.
+func updateImportedEvent(
+	ctx context.Context,
+	tx *sql.Tx,
+	now time.Time,
+	event *sportsapi.Event,
+) (importOutcome, *sportsapi.ImportError, error) {
+	var version int64
+	if err := tx.QueryRowContext(
+		ctx,
+		`SELECT version
+		FROM events
+		WHERE events.id = ?`+lifecycleFilter(false),
+		event.GetId(),
+	).Scan(&version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, &sportsapi.ImportError{
+				Field:       "id",
+				Description: "event not found",
+			}, nil
+		}
+		return 0, nil, err
+	}
+
+	if event.GetEtag() != "" && !etag.Matches(event.GetEtag(), version) {
+		return 0, &sportsapi.ImportError{
+			Field:       "etag",
+			Description: "event has been changed since the etag was issued",
+		}, nil
+	}
+
+	before, err := readSnapshot(ctx, tx, event.GetId())
+	if err != nil {
+		return 0, nil, err
+	}
+
+	category := event.GetCategory().String()
+
+	refs, err := upsertEventRefs(
+		ctx,
+		tx,
+		event.GetName(),
+		category,
+		event.GetCompetition(),
+	)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`UPDATE events
+		SET
+			name = ?,
+			category = ?,
+			competition_id = ?,
+			home_participant_id = ?,
+			away_participant_id = ?,
+			visible = ?,
+			advertised_start_time = ?
+		WHERE id = ?`,
+		event.GetName(),
+		category,
+		refs.competitionID,
+		refs.homeID,
+		refs.awayID,
+		event.GetVisible(),
+		formatStartTime(event),
+		event.GetId(),
+	); err != nil {
+		return 0, nil, err
+	}
+
+	if err := recordChange(
+		ctx,
+		tx,
+		now,
+		event.GetId(),
+		sportsapi.Change_UPDATED,
+	); err != nil {
+		return 0, nil, err
+	}
+
+	after, err := readSnapshot(ctx, tx, event.GetId())
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if proto.Equal(before, after) {
+		return eventUnchanged, nil, nil
+	}
+
+	if err := recordAudit(
+		ctx,
+		tx,
+		now,
+		sportsapi.Sports_ImportEvents_FullMethodName,
+		event.GetId(),
+		before,
+		after,
+	); err != nil {
+		return 0, nil, err
+	}
+
+	return eventUpdated, nil, nil
+}
+
+// formatStartTime returns the advertised start time of a sports event as it is
+// stored in the database.
+func formatStartTime(event *sportsapi.Event) string {
+	return event.GetAdvertisedStartTime().AsTime().UTC().Format(time.RFC3339)
+}
+
+// ExportEvents streams all sports events matching the request in the order of
+// their IDs. The events are exported as seen by the tenant of the request,
+// leaving out the events restricted in its jurisdiction.
+func (s *Service) ExportEvents(
+	req *sportsapi.ExportEventsRequest,
+	stream grpc.ServerStreamingServer[sportsapi.Event],
+) error {
+	ctx := stream.Context()
+
+	filterQuery, args := parseFilter(&sportsapi.ListEventsRequest{
+		Category:      req.GetCategory(),
+		VisibleOnly:   req.GetVisibleOnly(),
+		CompetitionId: req.GetCompetitionId(),
+		ParticipantId: req.GetParticipantId(),
+	})
+
+	if req.GetLocalDate() != "" {
+		dateFilter, dateArgs, err := s.localDateFilter(ctx, req.GetLocalDate())
+		if err != nil {
+			return err
+		}
+		filterQuery += dateFilter
+		args = append(args, dateArgs...)
+	}
+
+	// The events restricted in the jurisdiction are not exported.
+	restriction, restrictionArgs := s.restrictionFilter(ctx)
+	filterQuery += restriction
+	args = append(args, restrictionArgs...)
+
+	from, fromArgs := eventsFrom(ctx)
+
+	proj, err := parseReadMask(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM %s
+		WHERE events.id > ? %s %s
+		ORDER BY events.id
+		LIMIT ?`,
+		proj.selectList(),
+		from,
+		lifecycleFilter(req.GetIncludeArchived()),
+		filterQuery,
+	)
+
+	// The statuses of all exported events are computed at the same time, so
+	// that the export is consistent.
+	now := s.now()
+
+	var after int64
+	for {
+		events, err := readEventPage(
+			ctx,
+			s.DB,
+			query,
+			slices.Concat(fromArgs, []any{after}, args, []any{exportPageSize}),
+			proj,
+			now,
+		)
+		if err != nil {
+			return apierror.Internal(ctx, err)
+		}
+
+		for _, event := range events {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+
+		if len(events) < exportPageSize {
+			return nil
+		}
+
+		after = events[len(events)-1].GetId()
+	}
+}
+
+// readEventPage reads a single page of sports events exported by
+// ExportEvents, along with their scores. The page is read as a whole, so that
+// the database is not held while the events are sent to a slow client.
+func readEventPage(
+	ctx context.Context,
+	db *sql.DB,
+	query string,
+	args []any,
+	proj *projection,
+	now time.Time,
+) ([]*sportsapi.Event, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	var events []*sportsapi.Event
+	for rows.Next() {
+		event, err := scanEvent(rows, proj, now)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := proj.loadScores(ctx, db, events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
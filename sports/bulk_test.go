@@ -0,0 +1,322 @@
+package sports_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	. "github.com/danilvpetrov/entain/sports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestImportEvents(t *testing.T) {
+	newEvent := func() *sportsapi.Event {
+		return &sportsapi.Event{
+			Name:                "Home Team vs Away Team",
+			Category:            sportsapi.Event_SOCCER,
+			Competition:         "Imported League",
+			Visible:             true,
+			AdvertisedStartTime: timestamppb.New(time.Now().Add(time.Hour)),
+		}
+	}
+
+	t.Run("creates and updates events", func(t *testing.T) {
+		db, numRecords := setupDatabase(t)
+		client := setupServer(t, &Service{DB: db})
+
+		event1 := getEvent(t, client, 1)
+		event1.Category = sportsapi.Event_TENNIS
+
+		event2 := getEvent(t, client, 2)
+
+		uncategorised := newEvent()
+		uncategorised.Category = sportsapi.Event_UNSPECIFIED_CATEGORY
+
+		unknown := newEvent()
+		unknown.Id = 100000
+
+		resp, err := importEvents(
+			asAdmin(t.Context()),
+			client,
+			nil,
+			newEvent(),
+			event1,
+			event2,
+			uncategorised,
+			unknown,
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if resp.GetCreated() != 1 ||
+			resp.GetUpdated() != 1 ||
+			resp.GetUnchanged() != 1 ||
+			!resp.GetCommitted() {
+			t.Fatalf("unexpected outcome of the import: %v", resp)
+		}
+
+		assertImportErrors(
+			t,
+			resp.GetErrors(),
+			map[int64]string{
+				4: "category",
+				5: "id",
+			},
+		)
+
+		event := getEvent(t, client, 1)
+		if event.GetCategory() != sportsapi.Event_TENNIS {
+			t.Fatalf("expected category to be updated, got %v", event)
+		}
+
+		list, err := client.ListEvents(
+			t.Context(),
+			&sportsapi.ListEventsRequest{},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		events := list.GetEvents()
+		if len(events) != numRecords+1 {
+			t.Fatalf("expected %d events, got %d", numRecords+1, len(events))
+		}
+
+		created := events[len(events)-1]
+		if created.GetCompetition() != "Imported League" ||
+			created.GetHomeParticipantId() == 0 ||
+			created.GetAwayParticipantId() == 0 {
+			t.Fatalf("unexpected created event: %v", created)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		db, numRecords := setupDatabase(t)
+		client := setupServer(t, &Service{DB: db})
+
+		resp, err := importEvents(
+			asAdmin(t.Context()),
+			client,
+			&sportsapi.ImportOptions{DryRun: true},
+			newEvent(),
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if resp.GetCreated() != 1 || resp.GetCommitted() {
+			t.Fatalf("unexpected outcome of the import: %v", resp)
+		}
+
+		if n := countEvents(t, client); n != numRecords {
+			t.Fatalf("expected %d events, got %d", numRecords, n)
+		}
+	})
+
+	t.Run("all or nothing", func(t *testing.T) {
+		db, numRecords := setupDatabase(t)
+		client := setupServer(t, &Service{DB: db})
+
+		stale := getEvent(t, client, 1)
+		stale.Etag = "100"
+
+		resp, err := importEvents(
+			asAdmin(t.Context()),
+			client,
+			&sportsapi.ImportOptions{AllOrNothing: true},
+			newEvent(),
+			stale,
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if resp.GetCommitted() {
+			t.Fatal("expected the import not to be committed")
+		}
+
+		assertImportErrors(t, resp.GetErrors(), map[int64]string{2: "etag"})
+
+		if n := countEvents(t, client); n != numRecords {
+			t.Fatalf("expected %d events, got %d", numRecords, n)
+		}
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		db, _ := setupDatabase(t)
+		client := setupServer(t, &Service{DB: db})
+
+		_, err := importEvents(t.Context(), client, nil, newEvent())
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+	})
+}
+
+func TestExportEvents(t *testing.T) {
+	db, numRecords := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db})
+
+	stream, err := client.ExportEvents(
+		t.Context(),
+		&sportsapi.ExportEventsRequest{},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var ids []int64
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		ids = append(ids, event.GetId())
+	}
+
+	if len(ids) != numRecords {
+		t.Fatalf("expected %d events, got %d", numRecords, len(ids))
+	}
+
+	if !slices.IsSorted(ids) {
+		t.Fatalf("expected events in the order of their IDs: %v", ids)
+	}
+
+	t.Run("invalid filter", func(t *testing.T) {
+		stream, err := client.ExportEvents(
+			t.Context(),
+			&sportsapi.ExportEventsRequest{CompetitionId: []int64{-1}},
+		)
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		assertInvalidArgument(t, err, "competition_id[0]")
+	})
+}
+
+// importEvents is a test helper that imports the given events with the given
+// options in a single ImportEvents call.
+func importEvents(
+	ctx context.Context,
+	client sportsapi.SportsClient,
+	opts *sportsapi.ImportOptions,
+	events ...*sportsapi.Event,
+) (*sportsapi.ImportEventsResponse, error) {
+	stream, err := client.ImportEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, event := range events {
+		req := &sportsapi.ImportEventsRequest{Event: event}
+		if i == 0 {
+			req.Options = opts
+		}
+
+		if err := stream.Send(req); err != nil {
+			// The actual error is returned by CloseAndRecv.
+			break
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// exportEvents is a test helper that returns the events streamed by an
+// ExportEvents call with the given request.
+func exportEvents(
+	ctx context.Context,
+	t *testing.T,
+	client sportsapi.SportsClient,
+	req *sportsapi.ExportEventsRequest,
+) []*sportsapi.Event {
+	t.Helper()
+
+	stream, err := client.ExportEvents(ctx, req)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var events []*sportsapi.Event
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return events
+		}
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		events = append(events, event)
+	}
+}
+
+// getEvent is a test helper that returns the event with the given ID.
+func getEvent(
+	t *testing.T,
+	client sportsapi.SportsClient,
+	id int64,
+) *sportsapi.Event {
+	t.Helper()
+
+	event, err := client.GetEvent(
+		t.Context(),
+		&sportsapi.GetEventRequest{EventId: id},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	return event
+}
+
+// countEvents is a test helper that returns the number of events.
+func countEvents(t *testing.T, client sportsapi.SportsClient) int {
+	t.Helper()
+
+	resp, err := client.ListEvents(
+		t.Context(),
+		&sportsapi.ListEventsRequest{},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	return len(resp.GetEvents())
+}
+
+// assertImportErrors is a test helper that asserts that the import errors
+// report the given fields of the given rows.
+func assertImportErrors(
+	t *testing.T,
+	errs []*sportsapi.ImportError,
+	expected map[int64]string,
+) {
+	t.Helper()
+
+	actual := map[int64]string{}
+	for _, e := range errs {
+		actual[e.GetRow()] = e.GetField()
+	}
+
+	if len(actual) != len(expected) {
+		t.Fatalf("expected errors of rows %v, got %v", expected, errs)
+	}
+
+	for row, field := range expected {
+		if actual[row] != field {
+			t.Fatalf("expected errors of rows %v, got %v", expected, errs)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package sports
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/danilvpetrov/entain/admin"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/apierror"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// defaultChangesPageSize is the maximum number of changes returned by a
+// single ListChanges call, unless the request sets a page size.
+const defaultChangesPageSize = 100
+
+// ListChanges returns the changes of sports events in the order they were made.
+// The snapshots of the changes are read as stored, regardless of tenants and
+// jurisdictions, so the changes can only be listed by admins.
+func (s *Service) ListChanges(
+	ctx context.Context,
+	req *sportsapi.ListChangesRequest,
+) (*sportsapi.ListChangesResponse, error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"changes can only be listed by admins",
+		)
+	}
+
+	after, err := parseCursor(ctx, req.GetCursor())
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultChangesPageSize
+	}
+
+	changes, err := readChanges(ctx, s.DB, after, pageSize)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if len(changes) > 0 {
+		after = changes[len(changes)-1].GetSequence()
+	}
+
+	return &sportsapi.ListChangesResponse{
+		Changes:    changes,
+		NextCursor: formatCursor(after),
+	}, nil
+}
+
+// readChanges returns up to limit changes made after the change with the
+// given sequence number.
+func readChanges(
+	ctx context.Context,
+	db *sql.DB,
+	after int64,
+	limit int,
+) ([]*sportsapi.Change, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT sequence, operation, change_time, data
+		FROM changes
+		WHERE sequence > ?
+		ORDER BY sequence
+		LIMIT ?`,
+		after,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	var changes []*sportsapi.Change
+	for rows.Next() {
+		change, err := scanChange(rows)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// scanChange scans a change from the given scanner.
+func scanChange(s scanner) (*sportsapi.Change, error) {
+	var (
+		change     sportsapi.Change
+		operation  string
+		changeTime time.Time
+		data       []byte
+	)
+
+	if err := s.Scan(
+		&change.Sequence,
+		&operation,
+		&changeTime,
+		&data,
+	); err != nil {
+		return nil, err
+	}
+
+	change.Operation = sportsapi.Change_Operation(
+		sportsapi.Change_Operation_value[operation],
+	)
+	change.ChangeTime = timestamppb.New(changeTime)
+	change.Event = &sportsapi.Event{}
+	if err := proto.Unmarshal(data, change.Event); err != nil {
+		return nil, err
+	}
+
+	return &change, nil
+}
+
+// recordChange appends a change of the sports event with the given ID to the
+// change log at the given time, and increments the version of the event unless
+// it was created by the change. It must be called within the transaction that
+// made the change, after the change has been made. Updates that leave the event
+// as it was are neither recorded nor change the version.
+func recordChange(
+	ctx context.Context,
+	q querier,
+	now time.Time,
+	eventID int64,
+	operation sportsapi.Change_Operation,
+) error {
+	event, err := readSnapshot(ctx, q, eventID)
+	if err != nil {
+		return err
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if operation == sportsapi.Change_UPDATED {
+		var last []byte
+		err := q.QueryRowContext(
+			ctx,
+			`SELECT data
+			FROM changes
+			WHERE event_id = ?
+			ORDER BY sequence DESC
+			LIMIT 1`,
+			eventID,
+		).Scan(&last)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if bytes.Equal(last, data) {
+			return nil
+		}
+	}
+
+	if _, err := q.ExecContext(
+		ctx,
+		`INSERT INTO changes (event_id, operation, change_time, data)
+		VALUES (?, ?, ?, ?)`,
+		eventID,
+		operation.String(),
+		now.UTC().Format(time.RFC3339Nano),
+		data,
+	); err != nil {
+		return err
+	}
+
+	if operation == sportsapi.Change_CREATED {
+		return nil
+	}
+
+	_, err = q.ExecContext(
+		ctx,
+		`UPDATE events SET version = version + 1 WHERE id = ?`,
+		eventID,
+	)
+
+	return err
+}
+
+// readSnapshot reads all fields of the sports event with the given ID, along
+// with its scores, except the status, which depends on the time the event is
+// read at, and the etag, which changes along with the snapshot. Archived and
+// deleted events are read as well.
+func readSnapshot(
+	ctx context.Context,
+	q querier,
+	eventID int64,
+) (*sportsapi.Event, error) {
+	p, err := parseReadMask(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	delete(p.fields, "status")
+	delete(p.fields, "etag")
+
+	event, err := scanEvent(
+		q.QueryRowContext(
+			ctx,
+			fmt.Sprintf(
+				`SELECT %s
+				FROM %s
+				WHERE events.id = ?`,
+				p.selectList(),
+				eventsTable,
+			),
+			eventID,
+		),
+		p,
+		time.Time{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.loadScores(ctx, q, []*sportsapi.Event{event}); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// parseCursor returns the sequence number of the change encoded in a cursor
+// returned by ListChanges. An empty cursor points before the first change.
+func parseCursor(ctx context.Context, cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err == nil {
+		var sequence int64
+		sequence, err = strconv.ParseInt(string(raw), 10, 64)
+		if err == nil && sequence >= 0 {
+			return sequence, nil
+		}
+	}
+
+	return 0, apierror.InvalidArgument(
+		ctx,
+		"invalid cursor",
+		apierror.FieldViolation{
+			Field:       "cursor",
+			Description: "cursor must be returned by a previous call",
+		},
+	)
+}
+
+// formatCursor returns a cursor pointing after the change with the given
+// sequence number. Cursors are opaque to clients, so that their format can be
+// changed in the future.
+func formatCursor(sequence int64) string {
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(strconv.FormatInt(sequence, 10)),
+	)
+}
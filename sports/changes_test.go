@@ -0,0 +1,313 @@
+package sports_test
+
+import (
+	"testing"
+	"time"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/clock"
+	"github.com/danilvpetrov/entain/ingest"
+	"github.com/danilvpetrov/entain/outbox"
+	. "github.com/danilvpetrov/entain/sports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// listAllChanges is a test helper that lists the changes after the given
+// cursor page by page, and returns them along with the cursor to resume
+// listing from.
+func listAllChanges(
+	t *testing.T,
+	client sportsapi.SportsClient,
+	cursor string,
+) ([]*sportsapi.Change, string) {
+	t.Helper()
+
+	var changes []*sportsapi.Change
+	for {
+		resp, err := client.ListChanges(
+			asAdmin(t.Context()),
+			&sportsapi.ListChangesRequest{
+				Cursor:   cursor,
+				PageSize: 30,
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if resp.GetNextCursor() == "" {
+			t.Fatal("expected next cursor to be set")
+		}
+		cursor = resp.GetNextCursor()
+
+		if len(resp.GetChanges()) == 0 {
+			return changes, cursor
+		}
+		changes = append(changes, resp.GetChanges()...)
+	}
+}
+
+func TestListChanges(t *testing.T) {
+	db, numberOfSeedRecords := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db})
+
+	changes, cursor := listAllChanges(t, client, "")
+	if len(changes) != numberOfSeedRecords {
+		t.Fatalf(
+			"expected %d changes, got %d",
+			numberOfSeedRecords,
+			len(changes),
+		)
+	}
+
+	for i, change := range changes {
+		if change.GetOperation() != sportsapi.Change_CREATED {
+			t.Fatalf(
+				"expected operation %v, got %v",
+				sportsapi.Change_CREATED,
+				change.GetOperation(),
+			)
+		}
+		if i > 0 && change.GetSequence() <= changes[i-1].GetSequence() {
+			t.Fatal("expected changes to be ordered by sequence")
+		}
+		if change.GetEvent().GetId() == 0 {
+			t.Fatalf("expected event to be set, got %v", change.GetEvent())
+		}
+		if change.GetEvent().GetStatus() != sportsapi.Event_UNSPECIFIED_STATUS {
+			t.Fatalf(
+				"expected status not to be set, got %v",
+				change.GetEvent().GetStatus(),
+			)
+		}
+	}
+
+	t.Run("records updated scores", func(t *testing.T) {
+		for _, req := range []*sportsapi.UpdateScoreRequest{
+			{
+				EventId:    1,
+				MatchState: sportsapi.Event_IN_PLAY,
+				Scores: []*sportsapi.PeriodScore{
+					{Period: 1, Home: 1, Away: 0},
+				},
+				Etag: "*",
+			},
+			// Unchanged.
+			{
+				EventId: 1,
+				Scores: []*sportsapi.PeriodScore{
+					{Period: 1, Home: 1, Away: 0},
+				},
+				Etag: "*",
+			},
+		} {
+			if _, err := client.UpdateScore(
+				asAdmin(t.Context()),
+				req,
+			); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var changes []*sportsapi.Change
+		changes, cursor = listAllChanges(t, client, cursor)
+
+		if len(changes) != 1 {
+			t.Fatalf("expected 1 change, got %d", len(changes))
+		}
+
+		change := changes[0]
+		if change.GetOperation() != sportsapi.Change_UPDATED {
+			t.Fatalf(
+				"expected operation %v, got %v",
+				sportsapi.Change_UPDATED,
+				change.GetOperation(),
+			)
+		}
+
+		if change.GetEvent().GetMatchState() != sportsapi.Event_IN_PLAY {
+			t.Fatalf(
+				"expected match state %v, got %v",
+				sportsapi.Event_IN_PLAY,
+				change.GetEvent().GetMatchState(),
+			)
+		}
+
+		if len(change.GetEvent().GetScores()) != 1 {
+			t.Fatalf(
+				"expected 1 score, got %d",
+				len(change.GetEvent().GetScores()),
+			)
+		}
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		_, err := client.ListChanges(
+			t.Context(),
+			&sportsapi.ListChangesRequest{},
+		)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := client.ListChanges(
+			asAdmin(t.Context()),
+			&sportsapi.ListChangesRequest{Cursor: "<invalid>"},
+		)
+		assertInvalidArgument(t, err, "cursor")
+	})
+
+	t.Run("page size too large", func(t *testing.T) {
+		_, err := client.ListChanges(
+			asAdmin(t.Context()),
+			&sportsapi.ListChangesRequest{PageSize: 1001},
+		)
+		assertInvalidArgument(t, err, "page_size")
+	})
+}
+
+func TestChangeTimes(t *testing.T) {
+	ingested := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	updated := ingested.Add(time.Hour)
+
+	db, _ := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db, Clock: clock.Fixed(updated)})
+	sink := &IngestSink{DB: db, Clock: clock.Fixed(ingested)}
+
+	_, cursor := listAllChanges(t, client, "")
+
+	if err := sink.Upsert(
+		t.Context(),
+		"<provider>",
+		&ingest.Feed{
+			Events: []ingest.Event{
+				{
+					AdvertisedStartTime: ingested.Add(24 * time.Hour),
+					Category:            "AUSTRALIAN_RULES",
+					Competition:         "AFL",
+					ExternalID:          "E1",
+					Name:                "Sydney Swans vs Brisbane Lions",
+					Visible:             true,
+				},
+			},
+		},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, _ := listAllChanges(t, client, cursor)
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 change, got %d", len(changes))
+	}
+
+	if _, err := client.UpdateScore(
+		asAdmin(t.Context()),
+		&sportsapi.UpdateScoreRequest{
+			EventId:    changes[0].GetEvent().GetId(),
+			MatchState: sportsapi.Event_IN_PLAY,
+			Etag:       "*",
+		},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, _ = listAllChanges(t, client, cursor)
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changes, got %d", len(changes))
+	}
+
+	for i, expected := range []time.Time{ingested, updated} {
+		if actual := changes[i].GetChangeTime().AsTime(); !actual.Equal(
+			expected,
+		) {
+			t.Fatalf("expected change time %v, got %v", expected, actual)
+		}
+	}
+}
+
+func TestChangesAreAppendOnly(t *testing.T) {
+	db, _ := setupDatabase(t)
+
+	if _, err := db.ExecContext(
+		t.Context(),
+		`UPDATE changes SET operation = 'UPDATED'`,
+	); err == nil {
+		t.Fatal("expected updating changes to fail")
+	}
+
+	if _, err := db.ExecContext(
+		t.Context(),
+		`DELETE FROM changes`,
+	); err == nil {
+		t.Fatal("expected deleting changes to fail")
+	}
+}
+
+func TestOutboxSource(t *testing.T) {
+	db, numberOfSeedRecords := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db})
+	sink := &outbox.MemorySink{}
+	relay := &outbox.Relay{
+		Source:    &OutboxSource{DB: db},
+		Sink:      sink,
+		BatchSize: numberOfSeedRecords,
+	}
+
+	if _, err := relay.RunOnce(t.Context()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if n, err := relay.RunOnce(t.Context()); err != nil || n != 0 {
+		t.Fatalf("expected no changes published again, got %d, %v", n, err)
+	}
+
+	if _, err := client.UpdateScore(
+		asAdmin(t.Context()),
+		&sportsapi.UpdateScoreRequest{
+			EventId:    2,
+			MatchState: sportsapi.Event_IN_PLAY,
+			Etag:       "*",
+		},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := relay.RunOnce(t.Context()); err != nil || n != 1 {
+		t.Fatalf("expected 1 change published, got %d, %v", n, err)
+	}
+
+	msgs := sink.Messages()
+	if len(msgs) != numberOfSeedRecords+1 {
+		t.Fatalf(
+			"expected %d messages, got %d",
+			numberOfSeedRecords+1,
+			len(msgs),
+		)
+	}
+
+	msg := msgs[len(msgs)-1]
+	if msg.Topic != ChangesTopic {
+		t.Fatalf("expected topic %q, got %q", ChangesTopic, msg.Topic)
+	}
+
+	if msg.Key != "2" {
+		t.Fatalf("expected key %q, got %q", "2", msg.Key)
+	}
+
+	var change sportsapi.Change
+	if err := protojson.Unmarshal(msg.Value, &change); err != nil {
+		t.Fatal(err)
+	}
+
+	if change.GetEvent().GetMatchState() != sportsapi.Event_IN_PLAY {
+		t.Fatalf(
+			"expected match state %v, got %v",
+			sportsapi.Event_IN_PLAY,
+			change.GetEvent().GetMatchState(),
+		)
+	}
+}
@@ -0,0 +1,151 @@
+package sports_test
+
+import (
+	"database/sql"
+	"math/rand"
+	"testing"
+	"time"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/clock"
+	. "github.com/danilvpetrov/entain/sports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestListEventsAsOf(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	db, _ := setupDatabase(t)
+	if _, err := (&Seeder{
+		DB:           db,
+		TestDataFile: "testdata/testdata.json",
+		Rand:         rand.New(rand.NewSource(1)),
+		Clock:        clock.Fixed(now),
+	}).Seed(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	s := &Service{
+		DB:    db,
+		Clock: clock.Fixed(now),
+	}
+	client := setupServer(t, s)
+
+	// All seeded events start within a day before and two days after now.
+	before := now.AddDate(0, 0, -2)
+	after := now.AddDate(0, 0, 3)
+
+	t.Run("admin sees statuses as of the given time", func(t *testing.T) {
+		for asOf, expected := range map[time.Time]sportsapi.Event_Status{
+			before: sportsapi.Event_OPEN,
+			after:  sportsapi.Event_CLOSED,
+		} {
+			resp, err := client.ListEvents(
+				asAdmin(t.Context()),
+				&sportsapi.ListEventsRequest{
+					AsOf: timestamppb.New(asOf),
+				},
+			)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			for _, event := range resp.GetEvents() {
+				if event.GetStatus() != expected {
+					t.Fatalf(
+						"expected status %v as of %v, got %v",
+						expected,
+						asOf,
+						event.GetStatus(),
+					)
+				}
+			}
+		}
+	})
+
+	t.Run("non-admin is denied", func(t *testing.T) {
+		_, err := client.ListEvents(
+			t.Context(),
+			&sportsapi.ListEventsRequest{
+				AsOf: timestamppb.New(before),
+			},
+		)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+	})
+
+	t.Run("invalid time is rejected", func(t *testing.T) {
+		_, err := client.ListEvents(
+			asAdmin(t.Context()),
+			&sportsapi.ListEventsRequest{
+				AsOf: &timestamppb.Timestamp{Nanos: -1},
+			},
+		)
+		assertInvalidArgument(t, err, "as_of")
+	})
+}
+
+func TestSeeder(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	seed := func(t *testing.T) (_ []*sportsapi.Event, numberOfSeedRecords int) {
+		t.Helper()
+
+		db, err := sql.Open("sqlite3", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = db.Close()
+		})
+
+		if err := ApplySchema(t.Context(), db); err != nil {
+			t.Fatal(err)
+		}
+
+		numberOfSeedRecords, err = (&Seeder{
+			DB:           db,
+			TestDataFile: "testdata/testdata.json",
+			Rand:         rand.New(rand.NewSource(42)),
+			Clock:        clock.Fixed(now),
+		}).Seed(t.Context())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := &Service{
+			DB:    db,
+			Clock: clock.Fixed(now),
+		}
+		resp, err := s.ListEvents(t.Context(), &sportsapi.ListEventsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return resp.GetEvents(), numberOfSeedRecords
+	}
+
+	first, numberOfSeedRecords := seed(t)
+	second, _ := seed(t)
+
+	if len(first) != numberOfSeedRecords {
+		t.Fatalf(
+			"expected %d events, got %d",
+			numberOfSeedRecords,
+			len(first),
+		)
+	}
+
+	if len(first) != len(second) {
+		t.Fatalf("expected %d events, got %d", len(first), len(second))
+	}
+
+	for i := range first {
+		if !proto.Equal(first[i], second[i]) {
+			t.Fatalf("expected event %v, got %v", first[i], second[i])
+		}
+	}
+}
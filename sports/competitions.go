@@ -0,0 +1,298 @@
+package sports
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/apierror"
+)
+
+// ListCompetitions returns a list of competitions sports events are part of.
+func (s *Service) ListCompetitions(
+	ctx context.Context,
+	req *sportsapi.ListCompetitionsRequest,
+) (*sportsapi.ListCompetitionsResponse, error) {
+	var (
+		w    strings.Builder
+		args []any
+	)
+
+	if len(req.GetCategory()) > 0 {
+		_, _ = w.WriteString(" AND category IN (")
+		_, _ = w.WriteString(placeholders(len(req.GetCategory())))
+		_, _ = w.WriteString(")")
+		args = append(args, categoryArgs(req.GetCategory())...)
+	}
+
+	rows, err := s.DB.QueryContext(
+		ctx,
+		`SELECT id, name, category, timezone
+		FROM competitions
+		WHERE id <> 0`+w.String()+`
+		ORDER BY name ASC, id ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	resp := &sportsapi.ListCompetitionsResponse{}
+	for rows.Next() {
+		var (
+			c        sportsapi.Competition
+			category string
+			timezone sql.Null[string]
+		)
+		if err := rows.Scan(&c.Id, &c.Name, &category, &timezone); err != nil {
+			return nil, apierror.Internal(ctx, err)
+		}
+		c.Category = parseCategory(category)
+		c.Timezone = timezone.V
+		resp.Competitions = append(resp.Competitions, &c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	return resp, nil
+}
+
+// ListParticipants returns a list of participants of sports events.
+func (s *Service) ListParticipants(
+	ctx context.Context,
+	req *sportsapi.ListParticipantsRequest,
+) (*sportsapi.ListParticipantsResponse, error) {
+	var (
+		w    strings.Builder
+		args []any
+	)
+
+	if len(req.GetCategory()) > 0 {
+		_, _ = w.WriteString(" AND category IN (")
+		_, _ = w.WriteString(placeholders(len(req.GetCategory())))
+		_, _ = w.WriteString(")")
+		args = append(args, categoryArgs(req.GetCategory())...)
+	}
+
+	if ids := req.GetCompetitionId(); len(ids) > 0 {
+		_, _ = w.WriteString(
+			` AND id IN (
+				SELECT home_participant_id FROM events
+				WHERE competition_id IN (` + placeholders(len(ids)) + `)
+				UNION
+				SELECT away_participant_id FROM events
+				WHERE competition_id IN (` + placeholders(len(ids)) + `)
+			)`,
+		)
+		for range 2 {
+			for _, id := range ids {
+				args = append(args, id)
+			}
+		}
+	}
+
+	rows, err := s.DB.QueryContext(
+		ctx,
+		`SELECT id, name, category
+		FROM participants
+		WHERE id <> 0`+w.String()+`
+		ORDER BY name ASC, id ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	resp := &sportsapi.ListParticipantsResponse{}
+	for rows.Next() {
+		var (
+			p        sportsapi.Participant
+			category string
+		)
+		if err := rows.Scan(&p.Id, &p.Name, &category); err != nil {
+			return nil, apierror.Internal(ctx, err)
+		}
+		p.Category = parseCategory(category)
+		resp.Participants = append(resp.Participants, &p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	return resp, nil
+}
+
+// categoryArgs converts a list of categories to query arguments, as they are
+// stored in the database.
+func categoryArgs(categories []sportsapi.Event_Category) []any {
+	args := make([]any, 0, len(categories))
+	for _, cat := range categories {
+		args = append(args, sportsapi.Event_Category_name[int32(cat)])
+	}
+	return args
+}
+
+// parseCategory converts a category stored in the database to its API
+// representation.
+func parseCategory(category string) sportsapi.Event_Category {
+	return sportsapi.Event_Category(sportsapi.Event_Category_value[category])
+}
+
+// querier is an interface that abstracts sql.DB and sql.Tx types.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// upsertCompetition stores a competition with the given name and category,
+// unless it already exists, and returns its ID.
+func upsertCompetition(
+	ctx context.Context,
+	q querier,
+	name, category string,
+) (int64, error) {
+	if _, err := q.ExecContext(
+		ctx,
+		`INSERT OR IGNORE INTO competitions (name, category) VALUES (?, ?)`,
+		name,
+		category,
+	); err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err := q.QueryRowContext(
+		ctx,
+		`SELECT id FROM competitions WHERE name = ? AND category = ?`,
+		name,
+		category,
+	).Scan(&id)
+
+	return id, err
+}
+
+// upsertParticipant stores a participant with the given name and category,
+// unless it already exists, and returns its ID.
+func upsertParticipant(
+	ctx context.Context,
+	q querier,
+	name, category string,
+) (int64, error) {
+	if _, err := q.ExecContext(
+		ctx,
+		`INSERT OR IGNORE INTO participants (name, category) VALUES (?, ?)`,
+		name,
+		category,
+	); err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err := q.QueryRowContext(
+		ctx,
+		`SELECT id FROM participants WHERE name = ? AND category = ?`,
+		name,
+		category,
+	).Scan(&id)
+
+	return id, err
+}
+
+// eventRefs holds the IDs of the competition and the participants of a sports
+// event. The IDs are not valid if the event has no competition or its name
+// does not name two participants.
+type eventRefs struct {
+	competitionID sql.Null[int64]
+	homeID        sql.Null[int64]
+	awayID        sql.Null[int64]
+}
+
+// upsertEventRefs stores the competition and the participants of a sports
+// event with the given name, category and competition name, unless they
+// already exist, and returns their IDs.
+func upsertEventRefs(
+	ctx context.Context,
+	q querier,
+	name, category, competition string,
+) (eventRefs, error) {
+	var (
+		refs eventRefs
+		err  error
+	)
+
+	if competition != "" {
+		refs.competitionID.Valid = true
+		refs.competitionID.V, err = upsertCompetition(
+			ctx,
+			q,
+			competition,
+			category,
+		)
+		if err != nil {
+			return eventRefs{}, err
+		}
+	}
+
+	if home, away, ok := parseParticipants(name); ok {
+		refs.homeID.Valid, refs.awayID.Valid = true, true
+
+		refs.homeID.V, err = upsertParticipant(ctx, q, home, category)
+		if err != nil {
+			return eventRefs{}, err
+		}
+
+		refs.awayID.V, err = upsertParticipant(ctx, q, away, category)
+		if err != nil {
+			return eventRefs{}, err
+		}
+	}
+
+	return refs, nil
+}
+
+// bestOfSuffix matches the "best of N" suffix of esports event names, for
+// example "(Bo1)".
+var bestOfSuffix = regexp.MustCompile(`\s*\(Bo\d+\)$`)
+
+// participantSeparators is a list of separators between the home and away
+// participants in event names, in the order of precedence.
+var participantSeparators = []string{" vs ", " v ", " - "}
+
+// parseParticipants extracts the home and away participants from an event
+// name, for example "Team A vs Team B". It returns false if the name does not
+// name two participants.
+func parseParticipants(name string) (home, away string, ok bool) {
+	name = bestOfSuffix.ReplaceAllString(name, "")
+
+	for _, sep := range participantSeparators {
+		home, away, ok = strings.Cut(name, sep)
+		if !ok {
+			continue
+		}
+
+		home = strings.TrimSpace(home)
+		away = strings.TrimSpace(away)
+		if home != "" && away != "" {
+			return home, away, true
+		}
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,224 @@
+package sports_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	. "github.com/danilvpetrov/entain/sports"
+)
+
+func TestListCompetitions(t *testing.T) {
+	db, _ := setupDatabase(t)
+	s := &Service{
+		DB: db,
+	}
+	client := setupServer(t, s)
+
+	cases := []struct {
+		assertion func(
+			t *testing.T,
+			resp *sportsapi.ListCompetitionsResponse,
+			err error,
+		)
+		req  *sportsapi.ListCompetitionsRequest
+		name string
+	}{
+		{
+			name: "no filter",
+			req:  &sportsapi.ListCompetitionsRequest{},
+			assertion: func(t *testing.T, resp *sportsapi.ListCompetitionsResponse, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if len(resp.GetCompetitions()) == 0 {
+					t.Fatal("expected at least one competition")
+				}
+
+				if !slices.IsSortedFunc(
+					resp.GetCompetitions(),
+					func(a, b *sportsapi.Competition) int {
+						return strings.Compare(a.GetName(), b.GetName())
+					},
+				) {
+					t.Fatal("expected competitions to be ordered by name")
+				}
+			},
+		},
+		{
+			name: "filtered by categories",
+			req: &sportsapi.ListCompetitionsRequest{
+				Category: []sportsapi.Event_Category{
+					sportsapi.Event_ESPORTS,
+				},
+			},
+			assertion: func(t *testing.T, resp *sportsapi.ListCompetitionsResponse, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				var names []string
+				for _, c := range resp.GetCompetitions() {
+					names = append(names, c.GetName())
+				}
+
+				if !slices.Contains(names, "eSoccer - EPC") {
+					t.Fatalf("expected eSoccer - EPC competition, got %v", names)
+				}
+
+				for _, c := range resp.GetCompetitions() {
+					if c.GetCategory() != sportsapi.Event_ESPORTS {
+						t.Errorf("unexpected competition category %+v", c)
+					}
+				}
+			},
+		},
+		{
+			name: "unspecified category",
+			req: &sportsapi.ListCompetitionsRequest{
+				Category: []sportsapi.Event_Category{
+					sportsapi.Event_UNSPECIFIED_CATEGORY,
+				},
+			},
+			assertion: func(t *testing.T, _ *sportsapi.ListCompetitionsResponse, err error) {
+				assertInvalidArgument(t, err, "category[0]")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp, err := client.ListCompetitions(t.Context(), c.req)
+			c.assertion(t, resp, err)
+		})
+	}
+}
+
+func TestListParticipants(t *testing.T) {
+	db, _ := setupDatabase(t)
+	s := &Service{
+		DB: db,
+	}
+	client := setupServer(t, s)
+
+	// Find the competition that has events with names in the "Home - Away
+	// (Bo1)" format.
+	competitions, err := client.ListCompetitions(
+		t.Context(),
+		&sportsapi.ListCompetitionsRequest{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var eSoccerID int64
+	for _, c := range competitions.GetCompetitions() {
+		if c.GetName() == "eSoccer - EPC" {
+			eSoccerID = c.GetId()
+		}
+	}
+
+	cases := []struct {
+		assertion func(
+			t *testing.T,
+			resp *sportsapi.ListParticipantsResponse,
+			err error,
+		)
+		req  *sportsapi.ListParticipantsRequest
+		name string
+	}{
+		{
+			name: "no filter",
+			req:  &sportsapi.ListParticipantsRequest{},
+			assertion: func(t *testing.T, resp *sportsapi.ListParticipantsResponse, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				var names []string
+				for _, p := range resp.GetParticipants() {
+					names = append(names, p.GetName())
+				}
+
+				for _, name := range []string{
+					"Adam Linek",
+					"Wojciech Gronowski",
+					"Northland",
+					"Canterbury",
+				} {
+					if !slices.Contains(names, name) {
+						t.Errorf("expected participant %q, got %v", name, names)
+					}
+				}
+			},
+		},
+		{
+			name: "filtered by competitions",
+			req: &sportsapi.ListParticipantsRequest{
+				CompetitionId: []int64{eSoccerID},
+			},
+			assertion: func(t *testing.T, resp *sportsapi.ListParticipantsResponse, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				var names []string
+				for _, p := range resp.GetParticipants() {
+					names = append(names, p.GetName())
+				}
+
+				expected := []string{
+					"Atalanta (luxiqq)",
+					"Juventus (Galikooo)",
+					"Lazio (TOMMY)",
+					"Napoli (BiBiB)",
+					"Roma (Sava)",
+				}
+				if !slices.Equal(names, expected) {
+					t.Fatalf("expected participants %v, got %v", expected, names)
+				}
+			},
+		},
+		{
+			name: "filtered by categories",
+			req: &sportsapi.ListParticipantsRequest{
+				Category: []sportsapi.Event_Category{
+					sportsapi.Event_RUGBY_UNION,
+				},
+			},
+			assertion: func(t *testing.T, resp *sportsapi.ListParticipantsResponse, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if len(resp.GetParticipants()) == 0 {
+					t.Fatal("expected at least one participant")
+				}
+
+				for _, p := range resp.GetParticipants() {
+					if p.GetCategory() != sportsapi.Event_RUGBY_UNION {
+						t.Errorf("unexpected participant category %+v", p)
+					}
+				}
+			},
+		},
+		{
+			name: "invalid competition ID",
+			req: &sportsapi.ListParticipantsRequest{
+				CompetitionId: []int64{0},
+			},
+			assertion: func(t *testing.T, _ *sportsapi.ListParticipantsResponse, err error) {
+				assertInvalidArgument(t, err, "competition_id[0]")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp, err := client.ListParticipants(t.Context(), c.req)
+			c.assertion(t, resp, err)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package sports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/apierror"
+)
+
+// entityEvent is the kind of events mapped in the external_refs table.
+const entityEvent = "event"
+
+// purgedID is the internal ID the external references of purged events are
+// mapped to. IDs of events start at 1, so it never refers to an event.
+const purgedID = 0
+
+// GetEventByExternalId returns a specific sport event by the ID assigned to it
+// by an external provider.
+//
+//nolint:revive // The name is dictated by the generated SportsServer interface.
+func (s *Service) GetEventByExternalId(
+	ctx context.Context,
+	req *sportsapi.GetEventByExternalIdRequest,
+) (*sportsapi.Event, error) {
+	id, ok, err := lookupExternalRef(
+		ctx,
+		s.DB,
+		entityEvent,
+		req.GetProvider(),
+		req.GetExternalId(),
+	)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	if !ok {
+		return nil, apierror.NotFound(ctx, "EVENT_NOT_FOUND", "event not found")
+	}
+
+	return s.GetEvent(
+		ctx,
+		&sportsapi.GetEventRequest{
+			EventId:         id,
+			ReadMask:        req.GetReadMask(),
+			IncludeArchived: req.GetIncludeArchived(),
+		},
+	)
+}
+
+// lookupExternalRef returns the internal ID of an entity with the given
+// external ID. It returns false if the entity has not been ingested from the
+// provider before.
+func lookupExternalRef(
+	ctx context.Context,
+	q querier,
+	entity, provider, externalID string,
+) (int64, bool, error) {
+	var id int64
+	err := q.QueryRowContext(
+		ctx,
+		`SELECT internal_id
+		FROM external_refs
+		WHERE entity = ? AND provider = ? AND external_id = ?`,
+		entity,
+		provider,
+		externalID,
+	).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	return id, err == nil, err
+}
+
+// insertExternalRef maps an external ID to the ID of the entity inserted by
+// the given result.
+func insertExternalRef(
+	ctx context.Context,
+	tx *sql.Tx,
+	entity, provider, externalID string,
+	res sql.Result,
+) error {
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO external_refs (entity, provider, external_id, internal_id)
+		VALUES (?, ?, ?, ?)`,
+		entity,
+		provider,
+		externalID,
+		id,
+	)
+
+	return err
+}
@@ -0,0 +1,144 @@
+package sports_test
+
+import (
+	"testing"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/ingest"
+	. "github.com/danilvpetrov/entain/sports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestGetEventByExternalId(t *testing.T) {
+	db, _ := setupDatabase(t)
+
+	sink := &IngestSink{DB: db}
+	if err := sink.Upsert(
+		t.Context(),
+		"<provider>",
+		&ingest.Feed{
+			Events: []ingest.Event{
+				{
+					Category:    "AUSTRALIAN_RULES",
+					Competition: "AFL",
+					ExternalID:  "1",
+					Name:        "Sydney Swans vs Brisbane Lions",
+				},
+			},
+		},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	client := setupServer(t, &Service{DB: db})
+
+	cases := []struct {
+		assertion func(
+			t *testing.T,
+			event *sportsapi.Event,
+			err error,
+		)
+		req  *sportsapi.GetEventByExternalIdRequest
+		name string
+	}{
+		{
+			name: "gets seeded event",
+			req: &sportsapi.GetEventByExternalIdRequest{
+				Provider:   SeedProvider,
+				ExternalId: "1",
+			},
+			assertion: func(t *testing.T, event *sportsapi.Event, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if event.GetId() != 1 {
+					t.Fatalf("expected event ID to be 1, got %d", event.GetId())
+				}
+			},
+		},
+		{
+			name: "gets ingested event with the same external ID",
+			req: &sportsapi.GetEventByExternalIdRequest{
+				Provider:   "<provider>",
+				ExternalId: "1",
+				ReadMask: &fieldmaskpb.FieldMask{
+					Paths: []string{"name"},
+				},
+			},
+			assertion: func(t *testing.T, event *sportsapi.Event, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if event.GetName() != "Sydney Swans vs Brisbane Lions" {
+					t.Fatalf(
+						"expected event name %q, got %q",
+						"Sydney Swans vs Brisbane Lions",
+						event.GetName(),
+					)
+				}
+
+				if event.GetId() != 0 {
+					t.Fatalf("expected unmasked fields to be unset, got %+v", event)
+				}
+			},
+		},
+		{
+			name: "unknown external ID",
+			req: &sportsapi.GetEventByExternalIdRequest{
+				Provider:   SeedProvider,
+				ExternalId: "<external-id>",
+			},
+			assertion: func(t *testing.T, _ *sportsapi.Event, err error) {
+				if status.Code(err) != codes.NotFound {
+					t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+				}
+			},
+		},
+		{
+			name: "missing provider",
+			req: &sportsapi.GetEventByExternalIdRequest{
+				ExternalId: "1",
+			},
+			assertion: func(t *testing.T, _ *sportsapi.Event, err error) {
+				assertInvalidArgument(t, err, "provider")
+			},
+		},
+		{
+			name: "missing external ID",
+			req: &sportsapi.GetEventByExternalIdRequest{
+				Provider: SeedProvider,
+			},
+			assertion: func(t *testing.T, _ *sportsapi.Event, err error) {
+				assertInvalidArgument(t, err, "external_id")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp, err := client.GetEventByExternalId(t.Context(), c.req)
+			c.assertion(t, resp, err)
+		})
+	}
+}
+
+func TestSeedTestData(t *testing.T) {
+	db, numOfRecords := setupDatabase(t)
+
+	// Seeding the database again updates the seeded events.
+	if _, err := SeedTestData(
+		t.Context(),
+		db,
+		"testdata/testdata.json",
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := countRows(t, db, "events"); n != numOfRecords {
+		t.Fatalf("expected %d events, got %d", numOfRecords, n)
+	}
+}
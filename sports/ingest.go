@@ -0,0 +1,208 @@
+package sports
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/clock"
+	"github.com/danilvpetrov/entain/ingest"
+)
+
+// IngestSink stores sports events ingested from external providers. It
+// implements ingest.Sink interface.
+type IngestSink struct {
+	// DB is a database connection pool used to store the ingested events.
+	DB *sql.DB
+
+	// Clock is the source of the current time the changes of the ingested
+	// entities are recorded at. If it is nil, clock.System is used.
+	Clock clock.Clock
+}
+
+// Make sure IngestSink implements the ingest.Sink interface.
+var _ ingest.Sink = (*IngestSink)(nil)
+
+// Upsert stores the sports events of a feed within a single transaction.
+// Meetings and races are ignored.
+func (s *IngestSink) Upsert(
+	ctx context.Context,
+	provider string,
+	feed *ingest.Feed,
+) (err error) {
+	if len(feed.GetEvents()) == 0 {
+		return nil
+	}
+
+	now := clock.System.Now()
+	if s.Clock != nil {
+		now = s.Clock.Now()
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	for _, ev := range feed.GetEvents() {
+		if err := upsertEvent(ctx, tx, now, provider, ev); err != nil {
+			return fmt.Errorf("event %q: %w", ev.ExternalID, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// upsertEvent stores an ingested sports event, along with its competition
+// and participants.
+func upsertEvent(
+	ctx context.Context,
+	tx *sql.Tx,
+	now time.Time,
+	provider string,
+	ev ingest.Event,
+) error {
+	if parseCategory(ev.Category) == sportsapi.Event_UNSPECIFIED_CATEGORY {
+		return fmt.Errorf("unknown category %q", ev.Category)
+	}
+
+	advertisedStartTime := ev.AdvertisedStartTime.UTC().Format(time.RFC3339)
+
+	refs, err := upsertEventRefs(
+		ctx,
+		tx,
+		ev.Name,
+		ev.Category,
+		ev.Competition,
+	)
+	if err != nil {
+		return err
+	}
+
+	if refs.competitionID.Valid && ev.CompetitionTimezone != "" {
+		if err := setCompetitionTimezone(
+			ctx,
+			tx,
+			refs.competitionID.V,
+			ev.CompetitionTimezone,
+		); err != nil {
+			return err
+		}
+	}
+
+	id, ok, err := lookupExternalRef(
+		ctx,
+		tx,
+		entityEvent,
+		provider,
+		ev.ExternalID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		// Deleted events are never updated, so that they are not
+		// resurrected by the providers still offering them.
+		res, err := tx.ExecContext(
+			ctx,
+			`UPDATE events
+			SET
+				name = ?,
+				category = ?,
+				competition_id = ?,
+				home_participant_id = ?,
+				away_participant_id = ?,
+				visible = ?,
+				advertised_start_time = ?
+			WHERE id = ? AND deleted_at IS NULL`,
+			ev.Name,
+			ev.Category,
+			refs.competitionID,
+			refs.homeID,
+			refs.awayID,
+			ev.Visible,
+			advertisedStartTime,
+			id,
+		)
+		if err != nil {
+			return err
+		}
+
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			return err
+		}
+
+		return recordChange(ctx, tx, now, id, sportsapi.Change_UPDATED)
+	}
+
+	res, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO events (
+			name,
+			category,
+			competition_id,
+			home_participant_id,
+			away_participant_id,
+			visible,
+			advertised_start_time
+		) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		ev.Name,
+		ev.Category,
+		refs.competitionID,
+		refs.homeID,
+		refs.awayID,
+		ev.Visible,
+		advertisedStartTime,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := insertExternalRef(
+		ctx,
+		tx,
+		entityEvent,
+		provider,
+		ev.ExternalID,
+		res,
+	); err != nil {
+		return err
+	}
+
+	id, err = res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	return recordChange(ctx, tx, now, id, sportsapi.Change_CREATED)
+}
+
+// setCompetitionTimezone sets the IANA timezone of the venue of a
+// competition.
+func setCompetitionTimezone(
+	ctx context.Context,
+	tx *sql.Tx,
+	competitionID int64,
+	timezone string,
+) error {
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q", timezone)
+	}
+
+	_, err := tx.ExecContext(
+		ctx,
+		`UPDATE competitions SET timezone = ? WHERE id = ?`,
+		timezone,
+		competitionID,
+	)
+
+	return err
+}
@@ -0,0 +1,137 @@
+package sports_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/danilvpetrov/entain/ingest"
+	. "github.com/danilvpetrov/entain/sports"
+)
+
+func TestIngestSink(t *testing.T) {
+	feed := &ingest.Feed{
+		Events: []ingest.Event{
+			{
+				AdvertisedStartTime: time.Date(2025, 9, 27, 4, 30, 0, 0, time.UTC),
+				Category:            "AUSTRALIAN_RULES",
+				Competition:         "AFL",
+				ExternalID:          "E1",
+				Name:                "Sydney Swans vs Brisbane Lions",
+				Visible:             true,
+			},
+		},
+	}
+
+	t.Run("stores events once per external ID", func(t *testing.T) {
+		db, numOfRecords := setupDatabase(t)
+		sink := &IngestSink{DB: db}
+
+		if err := sink.Upsert(t.Context(), "<provider>", feed); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		updated := &ingest.Feed{
+			Events: []ingest.Event{feed.Events[0]},
+		}
+		updated.Events[0].Name = "Brisbane Lions vs Sydney Swans"
+
+		if err := sink.Upsert(t.Context(), "<provider>", updated); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if n := countRows(t, db, "events"); n != numOfRecords+1 {
+			t.Fatalf("expected %d events, got %d", numOfRecords+1, n)
+		}
+
+		var home, away string
+		if err := db.QueryRowContext(
+			t.Context(),
+			`SELECT h.name, a.name
+			FROM events e
+			JOIN participants h ON h.id = e.home_participant_id
+			JOIN participants a ON a.id = e.away_participant_id
+			ORDER BY e.id DESC
+			LIMIT 1`,
+		).Scan(&home, &away); err != nil {
+			t.Fatal(err)
+		}
+
+		if home != "Brisbane Lions" || away != "Sydney Swans" {
+			t.Fatalf(
+				"expected participants %q and %q, got %q and %q",
+				"Brisbane Lions",
+				"Sydney Swans",
+				home,
+				away,
+			)
+		}
+	})
+
+	t.Run("external IDs are scoped to the provider", func(t *testing.T) {
+		db, numOfRecords := setupDatabase(t)
+		sink := &IngestSink{DB: db}
+
+		for _, provider := range []string{"<provider-1>", "<provider-2>"} {
+			if err := sink.Upsert(t.Context(), provider, feed); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+		}
+
+		if n := countRows(t, db, "events"); n != numOfRecords+2 {
+			t.Fatalf("expected %d events, got %d", numOfRecords+2, n)
+		}
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		db, numOfRecords := setupDatabase(t)
+		sink := &IngestSink{DB: db}
+
+		invalid := &ingest.Feed{
+			Events: []ingest.Event{feed.Events[0], feed.Events[0]},
+		}
+		invalid.Events[1].ExternalID = "E2"
+		invalid.Events[1].Category = "<category>"
+
+		if err := sink.Upsert(t.Context(), "<provider>", invalid); err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if n := countRows(t, db, "events"); n != numOfRecords {
+			t.Fatalf("expected the feed to be rolled back, got %d events", n)
+		}
+	})
+
+	t.Run("unknown timezone", func(t *testing.T) {
+		db, numOfRecords := setupDatabase(t)
+		sink := &IngestSink{DB: db}
+
+		invalid := &ingest.Feed{
+			Events: []ingest.Event{feed.Events[0]},
+		}
+		invalid.Events[0].CompetitionTimezone = "<timezone>"
+
+		if err := sink.Upsert(t.Context(), "<provider>", invalid); err == nil {
+			t.Fatal("expected an error")
+		}
+
+		if n := countRows(t, db, "events"); n != numOfRecords {
+			t.Fatalf("expected the feed to be rolled back, got %d events", n)
+		}
+	})
+}
+
+// countRows is a test helper that returns the number of rows in a table.
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	t.Helper()
+
+	var n int
+	if err := db.QueryRowContext(
+		t.Context(),
+		`SELECT COUNT(*) FROM `+table,
+	).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+
+	return n
+}
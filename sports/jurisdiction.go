@@ -0,0 +1,85 @@
+package sports
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/jurisdiction"
+)
+
+// restrictionFilter returns SQL filter query excluding the sports events of
+// the categories that cannot be offered in the jurisdiction of the given
+// context, along with its arguments.
+func (s *Service) restrictionFilter(ctx context.Context) (string, []any) {
+	categories := s.Jurisdictions.Categories(jurisdiction.Code(ctx))
+	if len(categories) == 0 {
+		return "", nil
+	}
+
+	args := make([]any, 0, len(categories))
+	for _, name := range categories {
+		args = append(args, name)
+	}
+
+	return fmt.Sprintf(
+		" AND events.category NOT IN (%s)",
+		placeholders(len(categories)),
+	), args
+}
+
+// isRestricted returns true if the sports events of the given category cannot
+// be offered in the jurisdiction of the given context.
+func (s *Service) isRestricted(
+	ctx context.Context,
+	category sportsapi.Event_Category,
+) bool {
+	return slices.Contains(
+		s.Jurisdictions.Categories(jurisdiction.Code(ctx)),
+		category.String(),
+	)
+}
+
+// restricted returns the error of a read of a sports event that cannot be
+// offered in the jurisdiction of the given context.
+func restricted(ctx context.Context) error {
+	return jurisdiction.Restricted(
+		ctx,
+		"event is not available in your jurisdiction",
+	)
+}
+
+// notFound returns the error of a read of a sports event with the given ID
+// that is not found with the given restriction filter applied. The error has
+// codes.PermissionDenied code if the event exists but it is restricted in the
+// jurisdiction of the given context, and codes.NotFound code otherwise.
+func (s *Service) notFound(
+	ctx context.Context,
+	restriction string,
+	eventID int64,
+	includeArchived bool,
+) error {
+	if restriction == "" {
+		return apierror.NotFound(ctx, "EVENT_NOT_FOUND", "event not found")
+	}
+
+	var exists bool
+	if err := s.DB.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (
+			SELECT 1 FROM events WHERE events.id = ?`+
+			lifecycleFilter(includeArchived)+`
+		)`,
+		eventID,
+	).Scan(&exists); err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	if !exists {
+		return apierror.NotFound(ctx, "EVENT_NOT_FOUND", "event not found")
+	}
+
+	return restricted(ctx)
+}
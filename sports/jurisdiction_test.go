@@ -0,0 +1,227 @@
+package sports_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/jurisdiction"
+	. "github.com/danilvpetrov/entain/sports"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestJurisdictions(t *testing.T) { //nolint:gocognit // Explicit test cases.
+	db, numOfRecords := setupDatabase(t)
+	s := &Service{DB: db}
+	client := setupServer(t, s)
+
+	s.Jurisdictions = setupRules(
+		t,
+		"rules: [{jurisdictions: [US], categories: [POLITICS, NOVELTY]}]",
+	)
+
+	updateCategory(t, client, 1, sportsapi.Event_POLITICS)
+	updateCategory(t, client, 2, sportsapi.Event_SOCCER)
+
+	t.Run("lists the events offered in the jurisdiction", func(t *testing.T) {
+		resp, err := client.ListEvents(
+			fromJurisdiction(t.Context(), "US-CA"),
+			&sportsapi.ListEventsRequest{},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(resp.GetEvents()) == 0 {
+			t.Fatal("expected some events to be listed")
+		}
+
+		for _, e := range resp.GetEvents() {
+			if e.GetCategory() == sportsapi.Event_POLITICS ||
+				e.GetCategory() == sportsapi.Event_NOVELTY {
+				t.Fatalf("expected restricted event not to be listed: %v", e)
+			}
+		}
+	})
+
+	t.Run("lists all events in other jurisdictions", func(t *testing.T) {
+		resp, err := client.ListEvents(
+			fromJurisdiction(t.Context(), "AU"),
+			&sportsapi.ListEventsRequest{},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(resp.GetEvents()) != numOfRecords {
+			t.Fatalf(
+				"expected %d events, got %d",
+				numOfRecords,
+				len(resp.GetEvents()),
+			)
+		}
+	})
+
+	t.Run("exports the events offered in the jurisdiction", func(t *testing.T) {
+		events := exportEvents(
+			fromJurisdiction(t.Context(), "US-CA"),
+			t,
+			client,
+			&sportsapi.ExportEventsRequest{},
+		)
+
+		if len(events) == 0 {
+			t.Fatal("expected some events to be exported")
+		}
+
+		for _, e := range events {
+			if e.GetCategory() == sportsapi.Event_POLITICS ||
+				e.GetCategory() == sportsapi.Event_NOVELTY {
+				t.Fatalf("expected restricted event not to be exported: %v", e)
+			}
+		}
+	})
+
+	t.Run("restricted event", func(t *testing.T) {
+		_, err := client.GetEvent(
+			fromJurisdiction(t.Context(), "US"),
+			&sportsapi.GetEventRequest{EventId: 1},
+		)
+		assertRestricted(t, err)
+	})
+
+	t.Run("restricted event in a batch", func(t *testing.T) {
+		resp, err := client.BatchGetEvents(
+			fromJurisdiction(t.Context(), "US"),
+			&sportsapi.BatchGetEventsRequest{EventId: []int64{1, 2}},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(resp.GetEvents()) != 1 ||
+			len(resp.GetMissingEventId()) != 1 ||
+			resp.GetMissingEventId()[0] != 1 {
+			t.Fatalf("expected event 1 to be missing, got %v", resp)
+		}
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		_, err := client.GetEvent(
+			fromJurisdiction(t.Context(), "US"),
+			&sportsapi.GetEventRequest{EventId: 1_000_000},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("watches a restricted event", func(t *testing.T) {
+		stream, err := client.WatchEvent(
+			fromJurisdiction(t.Context(), "US"),
+			&sportsapi.WatchEventRequest{EventId: 1},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = stream.Recv()
+		assertRestricted(t, err)
+	})
+
+	t.Run("watches an event becoming restricted", func(t *testing.T) {
+		stream, err := client.WatchEvent(
+			fromJurisdiction(t.Context(), "US"),
+			&sportsapi.WatchEventRequest{EventId: 2},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := stream.Recv(); err != nil {
+			t.Fatal(err)
+		}
+
+		updateCategory(t, client, 2, sportsapi.Event_NOVELTY)
+
+		_, err = stream.Recv()
+		assertRestricted(t, err)
+	})
+}
+
+// updateCategory is a test helper that changes the category of the sports
+// event with the given ID. The update is made from a jurisdiction the category
+// may be restricted in.
+func updateCategory(
+	t *testing.T,
+	client sportsapi.SportsClient,
+	id int64,
+	category sportsapi.Event_Category,
+) {
+	t.Helper()
+
+	event, err := client.GetEvent(
+		t.Context(),
+		&sportsapi.GetEventRequest{EventId: id},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.UpdateEvent(
+		fromJurisdiction(asAdmin(t.Context()), "US"),
+		&sportsapi.UpdateEventRequest{
+			Event:      &sportsapi.Event{Id: id, Category: category},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"category"}},
+			Etag:       event.GetEtag(),
+		},
+	); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// setupRules is a test helper that returns jurisdiction rules loaded from a
+// file with the given content.
+func setupRules(t *testing.T, content string) *jurisdiction.Rules {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &jurisdiction.Rules{Path: path}
+	if _, err := r.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+// assertRestricted is a test helper that asserts that the error is returned
+// because the requested entity is restricted in the jurisdiction.
+func assertRestricted(t *testing.T, err error) {
+	t.Helper()
+
+	st := status.Convert(err)
+	if st.Code() != codes.PermissionDenied {
+		t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+	}
+
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok &&
+			info.GetReason() == jurisdiction.ReasonRestricted {
+			return
+		}
+	}
+
+	t.Fatalf(
+		"expected reason %q, got %v",
+		jurisdiction.ReasonRestricted,
+		st.Details(),
+	)
+}
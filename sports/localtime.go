@@ -0,0 +1,152 @@
+package sports
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata" // embedded timezone database for hosts without one
+
+	"github.com/danilvpetrov/entain/apierror"
+)
+
+// sqliteDateTime is the format of date and time values returned by the SQLite
+// datetime() function, which normalises them to UTC.
+const sqliteDateTime = time.DateTime
+
+// locations caches the loaded timezones by their names.
+var locations sync.Map // map[string]*time.Location
+
+// loadLocation returns the timezone with the given IANA name. It returns UTC
+// if the name is empty or unknown.
+func loadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		slog.Warn(
+			"unknown timezone, falling back to UTC",
+			slog.String("timezone", name),
+			slog.Any("error", err),
+		)
+		loc = time.UTC
+	}
+
+	locations.Store(name, loc)
+	return loc
+}
+
+// localDateFilter builds SQL filter query and its arguments matching the events
+// advertised to start on the given date in the timezone of their
+// competition's venue.
+func (s *Service) localDateFilter(
+	ctx context.Context,
+	date string,
+) (string, []any, error) {
+	d, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return "", nil, apierror.InvalidArgument(
+			ctx,
+			"invalid local date",
+			apierror.FieldViolation{
+				Field:       "local_date",
+				Description: fmt.Sprintf("%q is not a valid date", date),
+			},
+		)
+	}
+
+	rows, err := s.DB.QueryContext(
+		ctx,
+		`SELECT DISTINCT timezone
+		FROM competitions
+		WHERE timezone IS NOT NULL AND timezone <> ''`,
+	)
+	if err != nil {
+		return "", nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	var timezones []string
+	for rows.Next() {
+		var tz string
+		if err := rows.Scan(&tz); err != nil {
+			return "", nil, apierror.Internal(ctx, err)
+		}
+		timezones = append(timezones, tz)
+	}
+
+	if err := rows.Err(); err != nil {
+		return "", nil, apierror.Internal(ctx, err)
+	}
+
+	filter, args := dateRangeFilter(
+		d,
+		timezones,
+		"competitions.timezone",
+		"events.advertised_start_time",
+	)
+
+	return filter, args, nil
+}
+
+// dateRangeFilter builds SQL filter query and its arguments matching the rows
+// whose start time falls on the given date in the timezone of their venue.
+//
+// SQLite knows nothing about timezones, so the date is converted to a range of
+// UTC times for each of the given timezones. The conversion accounts for the
+// days that are shorter or longer than 24 hours due to daylight saving time
+// transitions. The rows whose timezone is not known are matched in UTC.
+func dateRangeFilter(
+	date time.Time,
+	timezones []string,
+	timezoneColumn, startTimeColumn string,
+) (filter string, args []any) {
+	var w strings.Builder
+
+	_, _ = w.WriteString(" AND (")
+
+	for i, tz := range append([]string{""}, timezones...) {
+		loc := loadLocation(tz)
+		start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc)
+		end := start.AddDate(0, 0, 1)
+
+		if i > 0 {
+			_, _ = w.WriteString(" OR ")
+		}
+
+		_, _ = fmt.Fprintf(
+			&w,
+			"(COALESCE(%[1]s, '') = ? AND datetime(%[2]s) >= ? AND datetime(%[2]s) < ?)",
+			timezoneColumn,
+			startTimeColumn,
+		)
+		args = append(
+			args,
+			tz,
+			start.UTC().Format(sqliteDateTime),
+			end.UTC().Format(sqliteDateTime),
+		)
+	}
+
+	_, _ = w.WriteString(")")
+
+	return w.String(), args
+}
+
+// formatLocalTime formats a time in the given timezone as RFC 3339, including
+// the local UTC offset.
+func formatLocalTime(t time.Time, loc *time.Location) string {
+	return t.In(loc).Format(time.RFC3339)
+}
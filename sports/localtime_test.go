@@ -0,0 +1,180 @@
+package sports_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/ingest"
+	. "github.com/danilvpetrov/entain/sports"
+)
+
+func TestListEventsByLocalDate(t *testing.T) {
+	db, _ := setupDatabase(t)
+
+	// The events are named after their external IDs. They are advertised to
+	// start around the daylight saving time transitions in Sydney, where
+	// 2025-10-05 lasts 23 hours, and in London, where 2025-10-26 lasts 25
+	// hours.
+	events := []struct {
+		competition string
+		timezone    string
+		name        string
+		start       string
+	}{
+		{"<syd>", "Australia/Sydney", "syd-before", "2025-10-04T13:59:00Z"},
+		{"<syd>", "Australia/Sydney", "syd-first", "2025-10-04T14:00:00Z"},
+		{"<syd>", "Australia/Sydney", "syd-last", "2025-10-05T12:59:00Z"},
+		{"<syd>", "Australia/Sydney", "syd-after", "2025-10-05T13:00:00Z"},
+		{"<lon>", "Europe/London", "lon-before", "2025-10-25T22:59:00Z"},
+		{"<lon>", "Europe/London", "lon-first", "2025-10-25T23:00:00Z"},
+		{"<lon>", "Europe/London", "lon-last", "2025-10-26T23:59:00Z"},
+		{"<lon>", "Europe/London", "lon-after", "2025-10-27T00:00:00Z"},
+		{"<unk>", "", "unk", "2025-10-05T00:00:00Z"},
+	}
+
+	feed := &ingest.Feed{}
+	for _, ev := range events {
+		start, err := time.Parse(time.RFC3339, ev.start)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		feed.Events = append(feed.Events, ingest.Event{
+			AdvertisedStartTime: start,
+			Category:            "SOCCER",
+			Competition:         ev.competition,
+			CompetitionTimezone: ev.timezone,
+			ExternalID:          ev.name,
+			Name:                ev.name,
+		})
+	}
+
+	sink := &IngestSink{DB: db}
+	if err := sink.Upsert(t.Context(), "<provider>", feed); err != nil {
+		t.Fatal(err)
+	}
+
+	client := setupServer(t, &Service{DB: db})
+
+	// Limit the events to the ingested competitions, leaving out the seeded
+	// ones.
+	competitions, err := client.ListCompetitions(
+		t.Context(),
+		&sportsapi.ListCompetitionsRequest{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timezones := map[string]string{}
+	var competitionIDs []int64
+	for _, c := range competitions.GetCompetitions() {
+		switch c.GetName() {
+		case "<syd>", "<lon>", "<unk>":
+			timezones[c.GetName()] = c.GetTimezone()
+			competitionIDs = append(competitionIDs, c.GetId())
+		}
+	}
+
+	expectedTimezones := map[string]string{
+		"<syd>": "Australia/Sydney",
+		"<lon>": "Europe/London",
+		"<unk>": "",
+	}
+	if !maps.Equal(timezones, expectedTimezones) {
+		t.Fatalf(
+			"expected competition timezones %v, got %v",
+			expectedTimezones,
+			timezones,
+		)
+	}
+
+	cases := []struct {
+		// expected maps the names of the expected events to their timezones
+		// and local advertised start times.
+		expected  map[string][2]string
+		localDate string
+		name      string
+	}{
+		{
+			name:      "day when daylight saving time starts",
+			localDate: "2025-10-05",
+			expected: map[string][2]string{
+				"syd-first": {"Australia/Sydney", "2025-10-05T00:00:00+10:00"},
+				"syd-last":  {"Australia/Sydney", "2025-10-05T23:59:00+11:00"},
+				"unk":       {"UTC", "2025-10-05T00:00:00Z"},
+			},
+		},
+		{
+			name:      "day before daylight saving time starts",
+			localDate: "2025-10-04",
+			expected: map[string][2]string{
+				"syd-before": {"Australia/Sydney", "2025-10-04T23:59:00+10:00"},
+			},
+		},
+		{
+			name:      "day when daylight saving time ends",
+			localDate: "2025-10-26",
+			expected: map[string][2]string{
+				"lon-first": {"Europe/London", "2025-10-26T00:00:00+01:00"},
+				"lon-last":  {"Europe/London", "2025-10-26T23:59:00Z"},
+			},
+		},
+		{
+			name:      "day after daylight saving time ends",
+			localDate: "2025-10-27",
+			expected: map[string][2]string{
+				"lon-after": {"Europe/London", "2025-10-27T00:00:00Z"},
+			},
+		},
+		{
+			name:      "day without events",
+			localDate: "2025-10-01",
+			expected:  map[string][2]string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp, err := client.ListEvents(
+				t.Context(),
+				&sportsapi.ListEventsRequest{
+					CompetitionId: competitionIDs,
+					LocalDate:     c.localDate,
+				},
+			)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			actual := map[string][2]string{}
+			for _, event := range resp.GetEvents() {
+				actual[event.GetName()] = [2]string{
+					event.GetTimezone(),
+					event.GetLocalAdvertisedStartTime(),
+				}
+			}
+
+			if !maps.Equal(actual, c.expected) {
+				t.Fatalf(
+					"expected events %v, got %v",
+					slices.Sorted(maps.Keys(c.expected)),
+					actual,
+				)
+			}
+		})
+	}
+
+	t.Run("invalid local date", func(t *testing.T) {
+		for _, date := range []string{"2025-02-30", "05/10/2025"} {
+			_, err := client.ListEvents(
+				t.Context(),
+				&sportsapi.ListEventsRequest{LocalDate: date},
+			)
+			assertInvalidArgument(t, err, "local_date")
+		}
+	})
+}
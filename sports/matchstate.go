@@ -0,0 +1,56 @@
+package sports
+
+import (
+	"slices"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+)
+
+// matchStateTransitions maps each match state to the states the match can
+// move to from it. FINISHED and CANCELLED are final states, the match cannot
+// leave them.
+var matchStateTransitions = map[sportsapi.Event_MatchState][]sportsapi.Event_MatchState{
+	sportsapi.Event_PRE_MATCH: {
+		sportsapi.Event_IN_PLAY,
+		sportsapi.Event_POSTPONED,
+		sportsapi.Event_CANCELLED,
+	},
+	sportsapi.Event_IN_PLAY: {
+		sportsapi.Event_SUSPENDED,
+		sportsapi.Event_FINISHED,
+		sportsapi.Event_CANCELLED,
+	},
+	sportsapi.Event_SUSPENDED: {
+		sportsapi.Event_IN_PLAY,
+		sportsapi.Event_FINISHED,
+		sportsapi.Event_CANCELLED,
+	},
+	sportsapi.Event_POSTPONED: {
+		sportsapi.Event_PRE_MATCH,
+		sportsapi.Event_CANCELLED,
+	},
+}
+
+// canTransition reports whether a match can move from one state to another.
+// Staying in the same state is always allowed.
+func canTransition(from, to sportsapi.Event_MatchState) bool {
+	return from == to || slices.Contains(matchStateTransitions[from], to)
+}
+
+// acceptsScores reports whether the scores of a match can be updated while it
+// moves from one state to another. Scores are only recorded for matches that
+// are or have just been put in play.
+func acceptsScores(from, to sportsapi.Event_MatchState) bool {
+	switch from {
+	case sportsapi.Event_IN_PLAY, sportsapi.Event_SUSPENDED:
+		return true
+	default:
+		return to == sportsapi.Event_IN_PLAY
+	}
+}
+
+// isMatchOver reports whether a match is in a final state.
+func isMatchOver(state sportsapi.Event_MatchState) bool {
+	return state == sportsapi.Event_FINISHED ||
+		state == sportsapi.Event_CANCELLED
+}
@@ -0,0 +1,321 @@
+package sports
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+)
+
+// migrateCompetitionsAndParticipants moves competitions and participants of
+// events to their own tables.
+//
+// Competitions are taken from the free-text competition column of events,
+// which is dropped afterwards. Participants are parsed from the event names,
+// for example "Team A vs Team B". Events whose names do not name two
+// participants are left without participants.
+func migrateCompetitionsAndParticipants(
+	ctx context.Context,
+	tx *sql.Tx,
+) error {
+	if _, err := tx.ExecContext(
+		ctx,
+		`CREATE TABLE competitions (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			category TEXT NOT NULL,
+			UNIQUE (name, category)
+		);
+
+		CREATE TABLE participants (
+			id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			category TEXT NOT NULL,
+			UNIQUE (name, category)
+		);
+
+		ALTER TABLE events
+		ADD COLUMN competition_id INTEGER REFERENCES competitions(id);
+
+		ALTER TABLE events
+		ADD COLUMN home_participant_id INTEGER REFERENCES participants(id);
+
+		ALTER TABLE events
+		ADD COLUMN away_participant_id INTEGER REFERENCES participants(id);
+
+		CREATE INDEX idx_events_competition_id
+		ON events(competition_id);
+
+		CREATE INDEX idx_events_home_participant_id
+		ON events(home_participant_id);
+
+		CREATE INDEX idx_events_away_participant_id
+		ON events(away_participant_id);
+
+		INSERT INTO competitions (name, category)
+		SELECT DISTINCT competition, category
+		FROM events
+		WHERE competition <> '' AND category IS NOT NULL;
+
+		UPDATE events
+		SET competition_id = (
+			SELECT id
+			FROM competitions
+			WHERE competitions.name = events.competition
+			AND competitions.category = events.category
+		);
+
+		ALTER TABLE events DROP COLUMN competition;`,
+	); err != nil {
+		return err
+	}
+
+	type event struct {
+		name     string
+		category string
+		id       int64
+	}
+
+	rows, err := tx.QueryContext(
+		ctx,
+		`SELECT id, COALESCE(name, ''), COALESCE(category, '')
+		FROM events`,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	var events []event
+	for rows.Next() {
+		var ev event
+		if err := rows.Scan(&ev.id, &ev.name, &ev.category); err != nil {
+			return err
+		}
+		events = append(events, ev)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, ev := range events {
+		home, away, ok := parseParticipants(ev.name)
+		if !ok {
+			continue
+		}
+
+		homeID, err := upsertParticipant(ctx, tx, home, ev.category)
+		if err != nil {
+			return err
+		}
+
+		awayID, err := upsertParticipant(ctx, tx, away, ev.category)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(
+			ctx,
+			`UPDATE events
+			SET home_participant_id = ?, away_participant_id = ?
+			WHERE id = ?`,
+			homeID,
+			awayID,
+			ev.id,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// migrateMatchStatesAndScores adds the match state to events and a table to
+// store their scores per period. Existing events are put in the PRE_MATCH
+// state.
+func migrateMatchStatesAndScores(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`ALTER TABLE events
+		ADD COLUMN match_state TEXT NOT NULL DEFAULT 'PRE_MATCH';
+
+		CREATE TABLE scores (
+			event_id INTEGER NOT NULL REFERENCES events(id),
+			period INTEGER NOT NULL,
+			home INTEGER NOT NULL,
+			away INTEGER NOT NULL,
+			PRIMARY KEY (event_id, period)
+		);`,
+	)
+
+	return err
+}
+
+// migrateExternalRefs adds a table mapping IDs assigned by external providers
+// to IDs of events.
+func migrateExternalRefs(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`CREATE TABLE external_refs (
+			entity TEXT NOT NULL,
+			provider TEXT NOT NULL,
+			external_id TEXT NOT NULL,
+			internal_id INTEGER NOT NULL,
+			PRIMARY KEY (entity, provider, external_id)
+		);`,
+	)
+
+	return err
+}
+
+// migrateSeededExternalRefs maps the events seeded before external references
+// were introduced to the seed provider, so that seeding the database again
+// updates them rather than adding duplicates. The external ID of a seeded
+// event is its ID.
+func migrateSeededExternalRefs(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO external_refs (entity, provider, external_id, internal_id)
+		SELECT ?, ?, CAST(id AS TEXT), id
+		FROM events
+		WHERE id NOT IN (
+			SELECT internal_id FROM external_refs WHERE entity = ?
+		)`,
+		entityEvent,
+		SeedProvider,
+		entityEvent,
+	)
+
+	return err
+}
+
+// migrateCompetitionTimezones adds the IANA timezone of the venue to
+// competitions. The timezone of the existing competitions is left unknown.
+func migrateCompetitionTimezones(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`ALTER TABLE competitions ADD COLUMN timezone TEXT`,
+	)
+
+	return err
+}
+
+// migrateChanges adds the append-only change log of events, and the table
+// recording the position of the outbox relay in it. The changes made before
+// the migration are not recorded.
+func migrateChanges(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`CREATE TABLE changes (
+			sequence INTEGER PRIMARY KEY AUTOINCREMENT,
+			event_id INTEGER NOT NULL,
+			operation TEXT NOT NULL,
+			change_time DATETIME NOT NULL,
+			data BLOB NOT NULL
+		);
+
+		CREATE INDEX idx_changes_event_id ON changes(event_id);
+
+		CREATE TRIGGER changes_no_update
+		BEFORE UPDATE ON changes
+		BEGIN
+			SELECT RAISE(ABORT, 'changes are append-only');
+		END;
+
+		CREATE TRIGGER changes_no_delete
+		BEFORE DELETE ON changes
+		BEGIN
+			SELECT RAISE(ABORT, 'changes are append-only');
+		END;
+
+		CREATE TABLE outbox_offset (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			sequence INTEGER NOT NULL
+		);`,
+	)
+
+	return err
+}
+
+// migrateLifecycle adds the times events were archived and deleted at. The
+// existing events are neither archived nor deleted.
+func migrateLifecycle(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`ALTER TABLE events ADD COLUMN archived_at DATETIME;
+
+		ALTER TABLE events ADD COLUMN deleted_at DATETIME;
+
+		CREATE INDEX idx_events_archived_at ON events(archived_at);`,
+	)
+
+	return err
+}
+
+// migrateAuditLog adds the append-only audit log of the changes made to
+// events through the API.
+func migrateAuditLog(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`CREATE TABLE audit_entries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			actor TEXT NOT NULL,
+			method TEXT NOT NULL,
+			entity_type TEXT NOT NULL,
+			entity_id INTEGER NOT NULL,
+			entry_time DATETIME NOT NULL,
+			changes BLOB NOT NULL
+		);
+
+		CREATE INDEX idx_audit_entries_entity
+		ON audit_entries(entity_type, entity_id);
+
+		CREATE INDEX idx_audit_entries_actor ON audit_entries(actor);
+
+		CREATE TRIGGER audit_entries_no_update
+		BEFORE UPDATE ON audit_entries
+		BEGIN
+			SELECT RAISE(ABORT, 'audit entries are append-only');
+		END;
+
+		CREATE TRIGGER audit_entries_no_delete
+		BEFORE DELETE ON audit_entries
+		BEGIN
+			SELECT RAISE(ABORT, 'audit entries are append-only');
+		END;`,
+	)
+
+	return err
+}
+
+// migrateVersions adds the versions of events, which are incremented every time
+// an event is changed. The existing events start at the first version.
+func migrateVersions(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`ALTER TABLE events ADD COLUMN version INTEGER NOT NULL DEFAULT 1`,
+	)
+
+	return err
+}
+
+// migrateTenantOverrides adds the overrides of the visibility and the ordering
+// of events for tenants. A NULL visibility or position is not overridden.
+func migrateTenantOverrides(ctx context.Context, tx *sql.Tx) error {
+	_, err := tx.ExecContext(
+		ctx,
+		`CREATE TABLE tenant_events (
+			tenant TEXT NOT NULL,
+			event_id INTEGER NOT NULL,
+			visible INTEGER,
+			position INTEGER,
+			PRIMARY KEY (tenant, event_id)
+		)`,
+	)
+
+	return err
+}
@@ -0,0 +1,76 @@
+package sports
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/danilvpetrov/entain/outbox"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// ChangesTopic is the topic the changes of sports events are published to.
+const ChangesTopic = "sports.changes"
+
+// OutboxSource reads the changes of sports events to be published by an outbox
+// relay. It implements outbox.Source interface.
+//
+// The changes are published to ChangesTopic, keyed by the ID of the event. The
+// value of a message is a sportsapi.Change message encoded as JSON.
+type OutboxSource struct {
+	// DB is a database connection pool used to read the changes and to
+	// record the published ones.
+	DB *sql.DB
+}
+
+// Make sure OutboxSource implements the outbox.Source interface.
+var _ outbox.Source = (*OutboxSource)(nil)
+
+// Pending returns up to limit changes that have not been acknowledged yet.
+func (s *OutboxSource) Pending(
+	ctx context.Context,
+	limit int,
+) ([]outbox.Message, error) {
+	var acked int64
+	if err := s.DB.QueryRowContext(
+		ctx,
+		`SELECT COALESCE(MAX(sequence), 0) FROM outbox_offset`,
+	).Scan(&acked); err != nil {
+		return nil, err
+	}
+
+	changes, err := readChanges(ctx, s.DB, acked, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]outbox.Message, 0, len(changes))
+	for _, change := range changes {
+		value, err := protojson.Marshal(change)
+		if err != nil {
+			return nil, err
+		}
+
+		msgs = append(msgs, outbox.Message{
+			Topic:    ChangesTopic,
+			Key:      strconv.FormatInt(change.GetEvent().GetId(), 10),
+			Value:    value,
+			Sequence: change.GetSequence(),
+		})
+	}
+
+	return msgs, nil
+}
+
+// Ack records that all changes up to and including the one with the given
+// sequence number have been published.
+func (s *OutboxSource) Ack(ctx context.Context, sequence int64) error {
+	_, err := s.DB.ExecContext(
+		ctx,
+		`INSERT INTO outbox_offset (id, sequence) VALUES (1, ?)
+		ON CONFLICT (id) DO UPDATE SET sequence = excluded.sequence`,
+		sequence,
+	)
+
+	return err
+}
@@ -0,0 +1,330 @@
+package sports
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/etag"
+	"github.com/danilvpetrov/entain/tenant"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// eventsTable is the FROM clause of queries reading events. Competitions are
+// joined to read their names.
+const eventsTable = `events
+	LEFT JOIN competitions ON competitions.id = events.competition_id`
+
+// tenantEventsTable is the FROM clause of queries reading events as seen by a
+// tenant, given as its only argument. The events are read with the visibility
+// overridden for the tenant, along with their positions for the tenant.
+const tenantEventsTable = `(
+		SELECT
+			events.id,
+			events.name,
+			events.category,
+			COALESCE(tenant_events.visible, events.visible) AS visible,
+			events.advertised_start_time,
+			events.competition_id,
+			events.home_participant_id,
+			events.away_participant_id,
+			events.match_state,
+			events.archived_at,
+			events.deleted_at,
+			events.version,
+			tenant_events.position
+		FROM events
+		LEFT JOIN tenant_events
+			ON tenant_events.event_id = events.id
+			AND tenant_events.tenant = ?
+	) AS events
+	LEFT JOIN competitions ON competitions.id = events.competition_id`
+
+// eventsFrom returns the FROM clause of queries reading events as seen by the
+// tenant of the given context, along with its arguments. The arguments must
+// precede the arguments of the rest of the query.
+func eventsFrom(ctx context.Context) (string, []any) {
+	name := tenant.Name(ctx)
+	if name == "" {
+		return eventsTable, nil
+	}
+
+	return tenantEventsTable, []any{name}
+}
+
+// eventColumns maps the fields of sportsapi.Event to the database columns they
+// are read from.
+var eventColumns = map[protoreflect.Name][]string{
+	"id":                    {"events.id"},
+	"name":                  {"events.name"},
+	"category":              {"events.category"},
+	"competition":           {"competitions.name"},
+	"visible":               {"events.visible"},
+	"advertised_start_time": {"events.advertised_start_time"},
+	// Status is computed from the advertised start time.
+	"status":              {"events.advertised_start_time"},
+	"competition_id":      {"events.competition_id"},
+	"home_participant_id": {"events.home_participant_id"},
+	"away_participant_id": {"events.away_participant_id"},
+	"match_state":         {"events.match_state"},
+	// Scores are read from their own table by loadScores, the IDs of events
+	// are required to match them with their scores.
+	"scores":   {"events.id"},
+	"timezone": {"competitions.timezone"},
+	// Local time is computed from the advertised start time and the timezone.
+	"local_advertised_start_time": {
+		"events.advertised_start_time",
+		"competitions.timezone",
+	},
+	"archive_time": {"events.archived_at"},
+	"etag":         {"events.version"},
+}
+
+// projection describes which fields of events are read from the database.
+type projection struct {
+	// fields is a set of names of sportsapi.Event fields to read.
+	fields map[protoreflect.Name]bool
+	// columns is a list of database columns that need to be selected to read
+	// the fields.
+	columns []string
+}
+
+// parseReadMask builds a projection from the read mask of a request. If the
+// mask is empty, all fields are read.
+//
+// Paths of the mask are names of sportsapi.Event fields, either in snake_case
+// or in lowerCamelCase as they appear in JSON.
+func parseReadMask(
+	ctx context.Context,
+	mask *fieldmaskpb.FieldMask,
+) (*projection, error) {
+	desc := (&sportsapi.Event{}).ProtoReflect().Descriptor().Fields()
+	p := &projection{
+		fields: map[protoreflect.Name]bool{},
+	}
+
+	for _, path := range mask.GetPaths() {
+		fd := desc.ByName(protoreflect.Name(path))
+		if fd == nil {
+			fd = desc.ByJSONName(path)
+		}
+		if fd == nil {
+			return nil, apierror.InvalidArgument(
+				ctx,
+				"invalid read mask",
+				apierror.FieldViolation{
+					Field:       "read_mask",
+					Description: fmt.Sprintf("unknown field %q", path),
+				},
+			)
+		}
+		p.fields[fd.Name()] = true
+	}
+
+	all := len(p.fields) == 0
+
+	// Iterate over the fields in the order of their declaration, so that the
+	// columns are always selected in the same order.
+	for i := range desc.Len() {
+		name := desc.Get(i).Name()
+		if !all && !p.fields[name] {
+			continue
+		}
+		p.fields[name] = true
+
+		for _, col := range eventColumns[name] {
+			if !slices.Contains(p.columns, col) {
+				p.columns = append(p.columns, col)
+			}
+		}
+	}
+
+	return p, nil
+}
+
+// selectList returns a list of columns to be used in the SELECT clause.
+func (p *projection) selectList() string {
+	return strings.Join(p.columns, ", ")
+}
+
+// scanEvent scans a sports event from the given scanner, reading only the
+// columns of the projection. The status of the event is computed at the given
+// time.
+func scanEvent(
+	s scanner,
+	p *projection,
+	now time.Time,
+) (*sportsapi.Event, error) {
+	var (
+		event               sportsapi.Event
+		category            string
+		competition         sql.Null[string]
+		competitionID       sql.Null[int64]
+		homeParticipantID   sql.Null[int64]
+		awayParticipantID   sql.Null[int64]
+		matchState          string
+		advertisedStartTime time.Time
+		timezone            sql.Null[string]
+		archivedAt          sql.Null[time.Time]
+		version             int64
+	)
+
+	dest := make([]any, 0, len(p.columns))
+	for _, col := range p.columns {
+		switch col {
+		case "events.id":
+			dest = append(dest, &event.Id)
+		case "events.name":
+			dest = append(dest, &event.Name)
+		case "events.category":
+			dest = append(dest, &category)
+		case "competitions.name":
+			dest = append(dest, &competition)
+		case "events.visible":
+			dest = append(dest, &event.Visible)
+		case "events.advertised_start_time":
+			dest = append(dest, &advertisedStartTime)
+		case "events.competition_id":
+			dest = append(dest, &competitionID)
+		case "events.home_participant_id":
+			dest = append(dest, &homeParticipantID)
+		case "events.away_participant_id":
+			dest = append(dest, &awayParticipantID)
+		case "events.match_state":
+			dest = append(dest, &matchState)
+		case "competitions.timezone":
+			dest = append(dest, &timezone)
+		case "events.archived_at":
+			dest = append(dest, &archivedAt)
+		case "events.version":
+			dest = append(dest, &version)
+		}
+	}
+
+	if err := s.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	if p.fields["category"] {
+		event.Category = parseCategory(category)
+	}
+	if p.fields["advertised_start_time"] {
+		event.AdvertisedStartTime = timestamppb.New(advertisedStartTime)
+	}
+	if p.fields["status"] {
+		event.Status = computeEventStatus(advertisedStartTime, now)
+	}
+	if p.fields["match_state"] {
+		event.MatchState = sportsapi.Event_MatchState(
+			sportsapi.Event_MatchState_value[matchState],
+		)
+	}
+
+	// The timezone of a venue may be unknown, in which case the local time is
+	// in UTC.
+	loc := loadLocation(timezone.V)
+	if p.fields["timezone"] {
+		event.Timezone = loc.String()
+	}
+	if p.fields["local_advertised_start_time"] {
+		event.LocalAdvertisedStartTime = formatLocalTime(
+			advertisedStartTime,
+			loc,
+		)
+	}
+
+	if archivedAt.Valid {
+		event.ArchiveTime = timestamppb.New(archivedAt.V)
+	}
+
+	if p.fields["etag"] {
+		event.Etag = etag.Format(version)
+	}
+
+	// The competition and participants of an event may be unknown, in which
+	// case they are left empty.
+	event.Competition = competition.V
+	event.CompetitionId = competitionID.V
+	event.HomeParticipantId = homeParticipantID.V
+	event.AwayParticipantId = awayParticipantID.V
+
+	return &event, nil
+}
+
+// loadScores reads the scores of the given events, if they are read by the
+// projection. The events must have been scanned with the same projection.
+func (p *projection) loadScores(
+	ctx context.Context,
+	q querier,
+	events []*sportsapi.Event,
+) error {
+	if !p.fields["scores"] || len(events) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*sportsapi.Event, len(events))
+	args := make([]any, 0, len(events))
+	for _, event := range events {
+		byID[event.GetId()] = event
+		args = append(args, event.GetId())
+	}
+
+	rows, err := q.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			`SELECT event_id, period, home, away
+			FROM scores
+			WHERE event_id IN (%s)
+			ORDER BY event_id, period`,
+			placeholders(len(events)),
+		),
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	for rows.Next() {
+		var (
+			eventID int64
+			score   sportsapi.PeriodScore
+		)
+		if err := rows.Scan(
+			&eventID,
+			&score.Period,
+			&score.Home,
+			&score.Away,
+		); err != nil {
+			return err
+		}
+
+		event := byID[eventID]
+		event.Scores = append(event.Scores, &score)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// The IDs have only been read to match the events with their scores.
+	if !p.fields["id"] {
+		for _, event := range events {
+			event.Id = 0
+		}
+	}
+
+	return nil
+}
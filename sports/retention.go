@@ -0,0 +1,380 @@
+package sports
+
+import (
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/clock"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// DefaultRetentionInterval is the default interval between runs of the
+// retention policy.
+const DefaultRetentionInterval = time.Hour
+
+// lifecycleFilter returns SQL filter query excluding the deleted events and,
+// unless requested otherwise, the archived ones.
+func lifecycleFilter(includeArchived bool) string {
+	if includeArchived {
+		return " AND events.deleted_at IS NULL"
+	}
+	return " AND events.deleted_at IS NULL AND events.archived_at IS NULL"
+}
+
+// Retention applies the retention policy to sports events. Events are
+// archived once they are old enough, and archived and deleted events are
+// optionally purged some time later.
+//
+// Archived events are excluded from the results of the read calls, unless
+// requested otherwise. Purged events are exported to a file and removed from
+// the database. Only their external references are kept, so that they are not
+// ingested again.
+type Retention struct {
+	// DB is a database connection pool used to archive and purge the events.
+	DB *sql.DB
+
+	// ExportDir is the directory the purged events are exported to. Every
+	// purge creates a gzip-compressed file in it, holding one event encoded as
+	// JSON per line.
+	ExportDir string
+
+	// Clock is the source of the current time the age of events is computed
+	// at. If it is nil, clock.System is used.
+	Clock clock.Clock
+
+	// ArchiveAfter is the time since the advertised start time of an event
+	// after which the event is archived. It must be positive.
+	ArchiveAfter time.Duration
+
+	// PurgeAfter is the time since the archival or the deletion of an event
+	// after which the event is purged. If it is zero, archived and deleted
+	// events are never purged.
+	PurgeAfter time.Duration
+
+	// Interval is the interval between runs of the retention policy. If it is
+	// zero, DefaultRetentionInterval is used.
+	Interval time.Duration
+}
+
+// Run applies the retention policy right away and then at the interval, until
+// the context is cancelled. Failed runs are logged and retried at the next
+// interval.
+func (r *Retention) Run(ctx context.Context) error {
+	interval := r.Interval
+	if interval == 0 {
+		interval = DefaultRetentionInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		archived, purged, err := r.RunOnce(ctx)
+		if err != nil {
+			slog.ErrorContext(
+				ctx,
+				"applying retention policy to events failed",
+				slog.Any("error", err),
+			)
+		} else {
+			slog.DebugContext(
+				ctx,
+				"applied retention policy to events",
+				slog.Int("archived", archived),
+				slog.Int("purged", purged),
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce archives the events that are old enough and purges the events that
+// have been archived or deleted long enough. It returns the number of archived
+// and purged events.
+func (r *Retention) RunOnce(
+	ctx context.Context,
+) (archived, purged int, err error) {
+	if r.ArchiveAfter <= 0 {
+		return 0, 0, errors.New("archival age must be positive")
+	}
+
+	now := clock.System.Now()
+	if r.Clock != nil {
+		now = r.Clock.Now()
+	}
+
+	archived, err = r.archive(ctx, now)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error archiving events: %w", err)
+	}
+
+	if r.PurgeAfter > 0 {
+		purged, err = r.purge(ctx, now)
+		if err != nil {
+			return archived, 0, fmt.Errorf("error purging events: %w", err)
+		}
+	}
+
+	return archived, purged, nil
+}
+
+// archive archives the events advertised to start before the archival age.
+func (r *Retention) archive(
+	ctx context.Context,
+	now time.Time,
+) (_ int, err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	ids, err := selectIDs(
+		ctx,
+		tx,
+		`SELECT id
+		FROM events
+		WHERE archived_at IS NULL
+		AND deleted_at IS NULL
+		AND datetime(advertised_start_time) < ?`,
+		now.Add(-r.ArchiveAfter).UTC().Format(sqliteDateTime),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, id := range ids {
+		if _, err := tx.ExecContext(
+			ctx,
+			`UPDATE events SET archived_at = ? WHERE id = ?`,
+			now.UTC().Format(time.RFC3339),
+			id,
+		); err != nil {
+			return 0, err
+		}
+
+		if err := recordChange(
+			ctx,
+			tx,
+			now,
+			id,
+			sportsapi.Change_UPDATED,
+		); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(ids), tx.Commit()
+}
+
+// purge exports the events archived or deleted before the purge age to a file
+// and deletes them. The deletion of archived events is recorded in the change
+// log before they are removed from the database, while that of deleted events
+// has been recorded when they were deleted. If the events cannot be deleted,
+// the file is removed.
+func (r *Retention) purge(
+	ctx context.Context,
+	now time.Time,
+) (_ int, err error) {
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	ids, err := selectIDs(
+		ctx,
+		tx,
+		`SELECT id
+		FROM events
+		WHERE datetime(COALESCE(deleted_at, archived_at)) < ?`,
+		now.Add(-r.PurgeAfter).UTC().Format(sqliteDateTime),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(ids) == 0 {
+		return 0, tx.Rollback()
+	}
+
+	path, err := r.export(ctx, tx, ids, now)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(path)
+		}
+	}()
+
+	for _, id := range ids {
+		res, err := tx.ExecContext(
+			ctx,
+			`UPDATE events SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`,
+			now.UTC().Format(time.RFC3339),
+			id,
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		if n != 0 {
+			if err := recordChange(
+				ctx,
+				tx,
+				now,
+				id,
+				sportsapi.Change_DELETED,
+			); err != nil {
+				return 0, err
+			}
+		}
+
+		if err := deletePurgedEvent(ctx, tx, id); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(ids), tx.Commit()
+}
+
+// deletePurgedEvent removes the event with the given ID from the database, along
+// with everything stored about it but the change log and the audit log. The
+// external references of the event are mapped to purgedID, so that the event is
+// not ingested again.
+func deletePurgedEvent(ctx context.Context, tx *sql.Tx, id int64) error {
+	if _, err := tx.ExecContext(
+		ctx,
+		`UPDATE external_refs
+		SET internal_id = ?
+		WHERE entity = ? AND internal_id = ?`,
+		purgedID,
+		entityEvent,
+		id,
+	); err != nil {
+		return err
+	}
+
+	for _, query := range []string{
+		`DELETE FROM tenant_events WHERE event_id = ?`,
+		`DELETE FROM scores WHERE event_id = ?`,
+		`DELETE FROM events WHERE id = ?`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// export writes the events with the given IDs to a new gzip-compressed file in
+// the export directory, and returns the path to the file.
+func (r *Retention) export(
+	ctx context.Context,
+	tx *sql.Tx,
+	ids []int64,
+	now time.Time,
+) (_ string, err error) {
+	if err := os.MkdirAll(r.ExportDir, 0o750); err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp(
+		r.ExportDir,
+		fmt.Sprintf(
+			"events-%s-*.jsonl.gz",
+			now.UTC().Format("20060102T150405Z"),
+		),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+		if err != nil {
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	w := gzip.NewWriter(f)
+
+	for _, id := range ids {
+		event, err := readSnapshot(ctx, tx, id)
+		if err != nil {
+			return "", err
+		}
+
+		line, err := protojson.Marshal(event)
+		if err != nil {
+			return "", err
+		}
+
+		if _, err := w.Write(append(line, '\n')); err != nil {
+			return "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// selectIDs returns the IDs selected by the given query.
+func selectIDs(
+	ctx context.Context,
+	tx *sql.Tx,
+	query string,
+	args ...any,
+) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
@@ -0,0 +1,402 @@
+package sports_test
+
+import (
+	"bufio"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/clock"
+	"github.com/danilvpetrov/entain/ingest"
+	. "github.com/danilvpetrov/entain/sports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestRetention(t *testing.T) {
+	db, numberOfSeedRecords := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db})
+
+	// All seeded events start at most two days from now.
+	now := time.Now().AddDate(0, 0, 4)
+	r := &Retention{
+		DB:           db,
+		ExportDir:    t.TempDir(),
+		Clock:        clock.Fixed(now),
+		ArchiveAfter: 24 * time.Hour,
+		PurgeAfter:   24 * time.Hour,
+	}
+
+	if _, err := client.UpdateScore(
+		asAdmin(t.Context()),
+		&sportsapi.UpdateScoreRequest{
+			EventId:    1,
+			MatchState: sportsapi.Event_IN_PLAY,
+			Scores: []*sportsapi.PeriodScore{
+				{Period: 1, Home: 1, Away: 0},
+			},
+			Etag: "*",
+		},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("archives old events", func(t *testing.T) {
+		archived, purged, err := r.RunOnce(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if archived != numberOfSeedRecords {
+			t.Fatalf(
+				"expected %d archived events, got %d",
+				numberOfSeedRecords,
+				archived,
+			)
+		}
+
+		if purged != 0 {
+			t.Fatalf("expected no purged events, got %d", purged)
+		}
+
+		resp, err := client.ListEvents(
+			t.Context(),
+			&sportsapi.ListEventsRequest{},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(resp.GetEvents()) != 0 {
+			t.Fatalf("expected no events, got %d", len(resp.GetEvents()))
+		}
+
+		resp, err = client.ListEvents(
+			t.Context(),
+			&sportsapi.ListEventsRequest{IncludeArchived: true},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(resp.GetEvents()) != numberOfSeedRecords {
+			t.Fatalf(
+				"expected %d events, got %d",
+				numberOfSeedRecords,
+				len(resp.GetEvents()),
+			)
+		}
+		for _, event := range resp.GetEvents() {
+			if !event.GetArchiveTime().AsTime().Equal(now.Truncate(time.Second)) {
+				t.Fatalf(
+					"expected archive time %v, got %v",
+					now,
+					event.GetArchiveTime().AsTime(),
+				)
+			}
+		}
+
+		_, err = client.GetEvent(
+			t.Context(),
+			&sportsapi.GetEventRequest{EventId: 1},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+
+		if _, err := client.GetEvent(
+			t.Context(),
+			&sportsapi.GetEventRequest{EventId: 1, IncludeArchived: true},
+		); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		_, err = client.UpdateScore(
+			asAdmin(t.Context()),
+			&sportsapi.UpdateScoreRequest{
+				EventId:    1,
+				MatchState: sportsapi.Event_IN_PLAY,
+				Etag:       "*",
+			},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+
+		batch, err := client.BatchGetEvents(
+			t.Context(),
+			&sportsapi.BatchGetEventsRequest{EventId: []int64{1, 2}},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(batch.GetMissingEventId()) != 2 {
+			t.Fatalf(
+				"expected 2 missing events, got %v",
+				batch.GetMissingEventId(),
+			)
+		}
+	})
+
+	t.Run("purges archived events", func(t *testing.T) {
+		r.Clock = clock.Fixed(now.AddDate(0, 0, 2))
+
+		archived, purged, err := r.RunOnce(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if archived != 0 {
+			t.Fatalf("expected no archived events, got %d", archived)
+		}
+
+		if purged != numberOfSeedRecords {
+			t.Fatalf(
+				"expected %d purged events, got %d",
+				numberOfSeedRecords,
+				purged,
+			)
+		}
+
+		exported := readExport(t, r.ExportDir)
+		if len(exported) != numberOfSeedRecords {
+			t.Fatalf(
+				"expected %d exported events, got %d",
+				numberOfSeedRecords,
+				len(exported),
+			)
+		}
+
+		resp, err := client.ListEvents(
+			t.Context(),
+			&sportsapi.ListEventsRequest{IncludeArchived: true},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(resp.GetEvents()) != 0 {
+			t.Fatalf("expected no events, got %d", len(resp.GetEvents()))
+		}
+
+		_, err = client.GetEvent(
+			t.Context(),
+			&sportsapi.GetEventRequest{EventId: 1, IncludeArchived: true},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+
+		for _, table := range []string{"events", "scores"} {
+			var n int
+			if err := db.QueryRowContext(
+				t.Context(),
+				`SELECT COUNT(*) FROM `+table,
+			).Scan(&n); err != nil {
+				t.Fatal(err)
+			}
+			if n != 0 {
+				t.Fatalf("expected no rows in %s, got %d", table, n)
+			}
+		}
+
+		changes, _ := listAllChanges(t, client, "")
+		last := changes[len(changes)-1]
+		if last.GetOperation() != sportsapi.Change_DELETED {
+			t.Fatalf(
+				"expected operation %v, got %v",
+				sportsapi.Change_DELETED,
+				last.GetOperation(),
+			)
+		}
+	})
+
+	t.Run("purged events are not ingested again", func(t *testing.T) {
+		// The new event may be given the ID of a purged event.
+		sink := &IngestSink{DB: db}
+		if err := sink.Upsert(
+			t.Context(),
+			"<provider>",
+			&ingest.Feed{
+				Events: []ingest.Event{
+					{
+						AdvertisedStartTime: now,
+						Category:            "AUSTRALIAN_RULES",
+						Competition:         "AFL",
+						ExternalID:          "E1",
+						Name:                "Sydney Swans vs Brisbane Lions",
+						Visible:             true,
+					},
+				},
+			},
+		); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := SeedTestData(
+			t.Context(),
+			db,
+			"testdata/testdata.json",
+		); err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := client.ListEvents(
+			t.Context(),
+			&sportsapi.ListEventsRequest{IncludeArchived: true},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(resp.GetEvents()) != 1 {
+			t.Fatalf("expected 1 event, got %d", len(resp.GetEvents()))
+		}
+		if name := resp.GetEvents()[0].GetName(); name !=
+			"Sydney Swans vs Brisbane Lions" {
+			t.Fatalf("expected the new event to be left as it was, got %q", name)
+		}
+	})
+
+	t.Run("archival age must be positive", func(t *testing.T) {
+		r := &Retention{DB: db}
+		if _, _, err := r.RunOnce(t.Context()); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestRetentionOfDeletedEvents(t *testing.T) {
+	db, numberOfSeedRecords := setupDatabase(t)
+
+	// All seeded events start at most two days from now.
+	now := time.Now().AddDate(0, 0, 4)
+	client := setupServer(t, &Service{DB: db, Clock: clock.Fixed(now)})
+	r := &Retention{
+		DB:           db,
+		ExportDir:    t.TempDir(),
+		Clock:        clock.Fixed(now),
+		ArchiveAfter: 24 * time.Hour,
+		PurgeAfter:   24 * time.Hour,
+	}
+
+	if _, err := client.DeleteEvent(
+		asAdmin(t.Context()),
+		&sportsapi.DeleteEventRequest{EventId: 1, Etag: "1"},
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("keeps recently deleted events", func(t *testing.T) {
+		archived, purged, err := r.RunOnce(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if archived != numberOfSeedRecords-1 {
+			t.Fatalf(
+				"expected %d archived events, got %d",
+				numberOfSeedRecords-1,
+				archived,
+			)
+		}
+
+		if purged != 0 {
+			t.Fatalf("expected no purged events, got %d", purged)
+		}
+	})
+
+	t.Run("purges deleted events", func(t *testing.T) {
+		r.Clock = clock.Fixed(now.AddDate(0, 0, 2))
+
+		_, purged, err := r.RunOnce(t.Context())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if purged != numberOfSeedRecords {
+			t.Fatalf(
+				"expected %d purged events, got %d",
+				numberOfSeedRecords,
+				purged,
+			)
+		}
+
+		var exported bool
+		for _, event := range readExport(t, r.ExportDir) {
+			if event.GetId() == 1 {
+				exported = true
+			}
+		}
+		if !exported {
+			t.Fatal("expected the deleted event to be exported")
+		}
+
+		var n int
+		if err := db.QueryRowContext(
+			t.Context(),
+			`SELECT COUNT(*) FROM events WHERE id = 1`,
+		).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		if n != 0 {
+			t.Fatal("expected the deleted event to be removed")
+		}
+
+		// The deletion is only recorded once, when the event is deleted.
+		changes, _ := listAllChanges(t, client, "")
+
+		var deletions int
+		for _, c := range changes {
+			if c.GetOperation() == sportsapi.Change_DELETED &&
+				c.GetEvent().GetId() == 1 {
+				deletions++
+			}
+		}
+		if deletions != 1 {
+			t.Fatalf("expected 1 deletion, got %d", deletions)
+		}
+	})
+}
+
+// readExport is a test helper that reads the events exported to the files in
+// the given directory.
+func readExport(t *testing.T, dir string) []*sportsapi.Event {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join(dir, "events-*.jsonl.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []*sportsapi.Event
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			_ = f.Close()
+		})
+
+		r, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := bufio.NewScanner(r)
+		for s.Scan() {
+			var event sportsapi.Event
+			if err := protojson.Unmarshal(s.Bytes(), &event); err != nil {
+				t.Fatal(err)
+			}
+			events = append(events, &event)
+		}
+
+		if err := s.Err(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return events
+}
@@ -4,16 +4,130 @@ import (
 	"context"
 	"database/sql"
 	_ "embed"
+	"fmt"
 )
 
 //go:embed schema.sql
 var schema string
 
-// ApplySchema applies Racing API database schema to a database.
+// ApplySchema applies Sports API database schema to a database, including all
+// migrations that have not been applied yet.
 func ApplySchema(
 	ctx context.Context,
 	db *sql.DB,
 ) error {
-	_, err := db.ExecContext(ctx, schema)
-	return err
+	if _, err := db.ExecContext(ctx, schema); err != nil {
+		return err
+	}
+
+	return migrate(ctx, db)
+}
+
+// migration is a change to the database schema, applied after the initial
+// schema.
+type migration struct {
+	// name is a human-readable name of the migration.
+	name string
+	// apply applies the migration within the given transaction.
+	apply func(ctx context.Context, tx *sql.Tx) error
+}
+
+// migrations is a list of migrations in the order they are applied. Each
+// migration is applied only once, the number of applied migrations is stored
+// in the user_version pragma of the database.
+//
+// Never remove or reorder the migrations, only append new ones.
+var migrations = []migration{
+	{
+		name:  "normalise competitions and participants",
+		apply: migrateCompetitionsAndParticipants,
+	},
+	{
+		name:  "add match states and scores",
+		apply: migrateMatchStatesAndScores,
+	},
+	{
+		name:  "add external references",
+		apply: migrateExternalRefs,
+	},
+	{
+		name:  "map seeded events to external references",
+		apply: migrateSeededExternalRefs,
+	},
+	{
+		name:  "add competition timezones",
+		apply: migrateCompetitionTimezones,
+	},
+	{
+		name:  "add change log",
+		apply: migrateChanges,
+	},
+	{
+		name:  "add archival and deletion of events",
+		apply: migrateLifecycle,
+	},
+	{
+		name:  "add audit log",
+		apply: migrateAuditLog,
+	},
+	{
+		name:  "add versions of events",
+		apply: migrateVersions,
+	},
+	{
+		name:  "add tenant overrides",
+		apply: migrateTenantOverrides,
+	},
+}
+
+// migrate applies the migrations that have not been applied to the database
+// yet, each in its own transaction.
+func migrate(ctx context.Context, db *sql.DB) error {
+	var version int
+	if err := db.QueryRowContext(
+		ctx,
+		`PRAGMA user_version`,
+	).Scan(&version); err != nil {
+		return err
+	}
+
+	for i := version; i < len(migrations); i++ {
+		if err := applyMigration(ctx, db, i); err != nil {
+			return fmt.Errorf(
+				"error applying migration %q: %w",
+				migrations[i].name,
+				err,
+			)
+		}
+	}
+
+	return nil
+}
+
+// applyMigration applies the migration with the given index and records it
+// as applied.
+func applyMigration(ctx context.Context, db *sql.DB, i int) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err := migrations[i].apply(ctx, tx); err != nil {
+		return err
+	}
+
+	// PRAGMA statements do not accept query parameters.
+	if _, err := tx.ExecContext(
+		ctx,
+		fmt.Sprintf(`PRAGMA user_version = %d`, i+1),
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
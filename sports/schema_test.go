@@ -0,0 +1,168 @@
+package sports_test
+
+import (
+	"database/sql"
+	"strconv"
+	"testing"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	. "github.com/danilvpetrov/entain/sports"
+)
+
+func TestApplySchema(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Set up a database with the schema that predates competitions and
+	// participants tables.
+	if _, err := db.ExecContext(
+		t.Context(),
+		`CREATE TABLE events (
+			id INTEGER PRIMARY KEY,
+			name TEXT,
+			category TEXT,
+			competition TEXT,
+			visible INTEGER,
+			advertised_start_time DATETIME
+		);
+
+		INSERT INTO events VALUES
+		(1, 'Team A vs Team B', 'SOCCER', 'Premier League', 1, '2025-01-01T00:00:00Z'),
+		(2, 'Team B v Team C', 'SOCCER', 'Premier League', 1, '2025-01-01T00:00:00Z'),
+		(3, 'Team A - Team C (Bo3)', 'ESPORTS', 'EPC', 1, '2025-01-01T00:00:00Z'),
+		(4, 'The Open', 'GOLF', 'Majors', 1, '2025-01-01T00:00:00Z');`,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	// Apply the schema twice to make sure the migrations are applied only
+	// once.
+	for range 2 {
+		if err := ApplySchema(t.Context(), db); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client := setupServer(t, &Service{DB: db})
+
+	competitions, err := client.ListCompetitions(
+		t.Context(),
+		&sportsapi.ListCompetitionsRequest{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(competitions.GetCompetitions()) != 3 {
+		t.Fatalf(
+			"expected 3 competitions, got %v",
+			competitions.GetCompetitions(),
+		)
+	}
+
+	participants, err := client.ListParticipants(
+		t.Context(),
+		&sportsapi.ListParticipantsRequest{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Participants are unique per category, hence Team A and Team C appear
+	// both in SOCCER and ESPORTS categories.
+	names := map[string]int64{}
+	for _, p := range participants.GetParticipants() {
+		names[p.GetCategory().String()+"/"+p.GetName()] = p.GetId()
+	}
+
+	if len(names) != 5 {
+		t.Fatalf("expected 5 participants, got %v", names)
+	}
+
+	cases := []struct {
+		competition string
+		home        string
+		away        string
+		eventID     int64
+	}{
+		{
+			eventID:     1,
+			competition: "Premier League",
+			home:        "SOCCER/Team A",
+			away:        "SOCCER/Team B",
+		},
+		{
+			eventID:     2,
+			competition: "Premier League",
+			home:        "SOCCER/Team B",
+			away:        "SOCCER/Team C",
+		},
+		{
+			eventID:     3,
+			competition: "EPC",
+			home:        "ESPORTS/Team A",
+			away:        "ESPORTS/Team C",
+		},
+		{
+			eventID:     4,
+			competition: "Majors",
+		},
+	}
+
+	for _, c := range cases {
+		event, err := client.GetEvent(
+			t.Context(),
+			&sportsapi.GetEventRequest{EventId: c.eventID},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if event.GetCompetition() != c.competition {
+			t.Errorf(
+				"expected competition of event %d to be %q, got %q",
+				c.eventID,
+				c.competition,
+				event.GetCompetition(),
+			)
+		}
+
+		if event.GetHomeParticipantId() != names[c.home] ||
+			event.GetAwayParticipantId() != names[c.away] {
+			t.Errorf(
+				"expected participants of event %d to be %q and %q, got %+v",
+				c.eventID,
+				c.home,
+				c.away,
+				event,
+			)
+		}
+
+		// Existing events are mapped to the seed provider by their IDs.
+		byRef, err := client.GetEventByExternalId(
+			t.Context(),
+			&sportsapi.GetEventByExternalIdRequest{
+				Provider:   SeedProvider,
+				ExternalId: strconv.FormatInt(c.eventID, 10),
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if byRef.GetId() != c.eventID {
+			t.Errorf(
+				"expected event %d to be mapped to the seed provider, got %d",
+				c.eventID,
+				byRef.GetId(),
+			)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package sports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/danilvpetrov/entain/admin"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/etag"
+)
+
+// UpdateScore updates the scores and the match state of a sports event, and
+// notifies the subscribers watching the event.
+func (s *Service) UpdateScore(
+	ctx context.Context,
+	req *sportsapi.UpdateScoreRequest,
+) (*sportsapi.Event, error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"scores can only be updated by admins",
+		)
+	}
+
+	periods := map[int32]bool{}
+	for i, score := range req.GetScores() {
+		if periods[score.GetPeriod()] {
+			return nil, apierror.InvalidArgument(
+				ctx,
+				"duplicate period",
+				apierror.FieldViolation{
+					Field: fmt.Sprintf("scores[%d].period", i),
+					Description: fmt.Sprintf(
+						"period %d is listed more than once",
+						score.GetPeriod(),
+					),
+				},
+			)
+		}
+		periods[score.GetPeriod()] = true
+	}
+
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	if err := s.updateScore(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return s.publishEvent(ctx, req.GetEventId())
+}
+
+// updateScore stores the scores and the match state of a sports event within
+// a single transaction, along with the change and the audit entry describing
+// it.
+func (s *Service) updateScore(
+	ctx context.Context,
+	req *sportsapi.UpdateScoreRequest,
+) (err error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var (
+		current string
+		version int64
+	)
+	if err := tx.QueryRowContext(
+		ctx,
+		`SELECT match_state, version
+		FROM events
+		WHERE id = ? AND deleted_at IS NULL AND archived_at IS NULL`,
+		req.GetEventId(),
+	).Scan(&current, &version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apierror.NotFound(ctx, "EVENT_NOT_FOUND", "event not found")
+		}
+		return apierror.Internal(ctx, err)
+	}
+
+	if err := etag.Check(ctx, req.GetEtag(), version); err != nil {
+		return err
+	}
+
+	before, err := readSnapshot(ctx, tx, req.GetEventId())
+	if err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	from := sportsapi.Event_MatchState(
+		sportsapi.Event_MatchState_value[current],
+	)
+	to := from
+	if req.GetMatchState() != sportsapi.Event_UNSPECIFIED_MATCH_STATE {
+		to = req.GetMatchState()
+	}
+
+	if !canTransition(from, to) {
+		return apierror.FailedPrecondition(
+			ctx,
+			"INVALID_MATCH_STATE_TRANSITION",
+			fmt.Sprintf("match cannot move from %v to %v", from, to),
+		)
+	}
+
+	if len(req.GetScores()) > 0 && !acceptsScores(from, to) {
+		return apierror.FailedPrecondition(
+			ctx,
+			"MATCH_NOT_IN_PLAY",
+			fmt.Sprintf("scores cannot be updated in %v state", from),
+		)
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`UPDATE events SET match_state = ? WHERE id = ?`,
+		to.String(),
+		req.GetEventId(),
+	); err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	for _, score := range req.GetScores() {
+		if _, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO scores (event_id, period, home, away)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (event_id, period)
+			DO UPDATE SET home = excluded.home, away = excluded.away`,
+			req.GetEventId(),
+			score.GetPeriod(),
+			score.GetHome(),
+			score.GetAway(),
+		); err != nil {
+			return apierror.Internal(ctx, err)
+		}
+	}
+
+	if err := recordChange(
+		ctx,
+		tx,
+		s.now(),
+		req.GetEventId(),
+		sportsapi.Change_UPDATED,
+	); err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	after, err := readSnapshot(ctx, tx, req.GetEventId())
+	if err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	if err := recordAudit(
+		ctx,
+		tx,
+		s.now(),
+		sportsapi.Sports_UpdateScore_FullMethodName,
+		req.GetEventId(),
+		before,
+		after,
+	); err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,326 @@
+package sports_test
+
+import (
+	"testing"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	. "github.com/danilvpetrov/entain/sports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUpdateScore(t *testing.T) {
+	db, _ := setupDatabase(t)
+	s := &Service{
+		DB: db,
+	}
+	client := setupServer(t, s)
+
+	// The cases are run in order, each one building on the state left by the
+	// previous ones.
+	cases := []struct {
+		assertion func(
+			t *testing.T,
+			event *sportsapi.Event,
+			err error,
+		)
+		req  *sportsapi.UpdateScoreRequest
+		name string
+	}{
+		{
+			name: "scores of a match that has not started",
+			req: &sportsapi.UpdateScoreRequest{
+				EventId: 1,
+				Scores: []*sportsapi.PeriodScore{
+					{Period: 1, Home: 1, Away: 0},
+				},
+				Etag: "*",
+			},
+			assertion: func(t *testing.T, _ *sportsapi.Event, err error) {
+				assertFailedPrecondition(t, err)
+			},
+		},
+		{
+			name: "match is put in play with scores",
+			req: &sportsapi.UpdateScoreRequest{
+				EventId:    1,
+				MatchState: sportsapi.Event_IN_PLAY,
+				Scores: []*sportsapi.PeriodScore{
+					{Period: 1, Home: 1, Away: 0},
+				},
+				Etag: "*",
+			},
+			assertion: func(t *testing.T, event *sportsapi.Event, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				assertMatch(
+					t,
+					event,
+					sportsapi.Event_IN_PLAY,
+					&sportsapi.PeriodScore{Period: 1, Home: 1, Away: 0},
+				)
+			},
+		},
+		{
+			name: "scores are updated per period",
+			req: &sportsapi.UpdateScoreRequest{
+				EventId: 1,
+				Scores: []*sportsapi.PeriodScore{
+					{Period: 2, Home: 0, Away: 2},
+					{Period: 1, Home: 1, Away: 1},
+				},
+				Etag: "*",
+			},
+			assertion: func(t *testing.T, event *sportsapi.Event, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				assertMatch(
+					t,
+					event,
+					sportsapi.Event_IN_PLAY,
+					&sportsapi.PeriodScore{Period: 1, Home: 1, Away: 1},
+					&sportsapi.PeriodScore{Period: 2, Home: 0, Away: 2},
+				)
+			},
+		},
+		{
+			name: "match is suspended",
+			req: &sportsapi.UpdateScoreRequest{
+				EventId:    1,
+				MatchState: sportsapi.Event_SUSPENDED,
+				Etag:       "*",
+			},
+			assertion: func(t *testing.T, event *sportsapi.Event, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				assertMatch(
+					t,
+					event,
+					sportsapi.Event_SUSPENDED,
+					&sportsapi.PeriodScore{Period: 1, Home: 1, Away: 1},
+					&sportsapi.PeriodScore{Period: 2, Home: 0, Away: 2},
+				)
+			},
+		},
+		{
+			name: "suspended match cannot be postponed",
+			req: &sportsapi.UpdateScoreRequest{
+				EventId:    1,
+				MatchState: sportsapi.Event_POSTPONED,
+				Etag:       "*",
+			},
+			assertion: func(t *testing.T, _ *sportsapi.Event, err error) {
+				assertFailedPrecondition(t, err)
+			},
+		},
+		{
+			name: "match is finished with final scores",
+			req: &sportsapi.UpdateScoreRequest{
+				EventId:    1,
+				MatchState: sportsapi.Event_FINISHED,
+				Scores: []*sportsapi.PeriodScore{
+					{Period: 2, Home: 0, Away: 3},
+				},
+				Etag: "*",
+			},
+			assertion: func(t *testing.T, event *sportsapi.Event, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				assertMatch(
+					t,
+					event,
+					sportsapi.Event_FINISHED,
+					&sportsapi.PeriodScore{Period: 1, Home: 1, Away: 1},
+					&sportsapi.PeriodScore{Period: 2, Home: 0, Away: 3},
+				)
+			},
+		},
+		{
+			name: "finished match cannot be put back in play",
+			req: &sportsapi.UpdateScoreRequest{
+				EventId:    1,
+				MatchState: sportsapi.Event_IN_PLAY,
+				Etag:       "*",
+			},
+			assertion: func(t *testing.T, _ *sportsapi.Event, err error) {
+				assertFailedPrecondition(t, err)
+			},
+		},
+		{
+			name: "scores of a finished match",
+			req: &sportsapi.UpdateScoreRequest{
+				EventId: 1,
+				Scores: []*sportsapi.PeriodScore{
+					{Period: 3, Home: 1, Away: 0},
+				},
+				Etag: "*",
+			},
+			assertion: func(t *testing.T, _ *sportsapi.Event, err error) {
+				assertFailedPrecondition(t, err)
+			},
+		},
+		{
+			name: "postponed match is rescheduled",
+			req: &sportsapi.UpdateScoreRequest{
+				EventId:    2,
+				MatchState: sportsapi.Event_POSTPONED,
+				Etag:       "*",
+			},
+			assertion: func(t *testing.T, event *sportsapi.Event, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				assertMatch(t, event, sportsapi.Event_POSTPONED)
+			},
+		},
+		{
+			name: "duplicate periods",
+			req: &sportsapi.UpdateScoreRequest{
+				EventId: 3,
+				Scores: []*sportsapi.PeriodScore{
+					{Period: 1, Home: 1, Away: 0},
+					{Period: 1, Home: 2, Away: 0},
+				},
+				Etag: "*",
+			},
+			assertion: func(t *testing.T, _ *sportsapi.Event, err error) {
+				assertInvalidArgument(t, err, "scores[1].period")
+			},
+		},
+		{
+			name: "negative score",
+			req: &sportsapi.UpdateScoreRequest{
+				EventId: 3,
+				Scores: []*sportsapi.PeriodScore{
+					{Period: 1, Home: -1, Away: 0},
+				},
+				Etag: "*",
+			},
+			assertion: func(t *testing.T, _ *sportsapi.Event, err error) {
+				assertInvalidArgument(t, err, "scores[0].home")
+			},
+		},
+		{
+			name: "missing etag",
+			req: &sportsapi.UpdateScoreRequest{
+				EventId:    1,
+				MatchState: sportsapi.Event_IN_PLAY,
+			},
+			assertion: func(t *testing.T, _ *sportsapi.Event, err error) {
+				assertInvalidArgument(t, err, "etag")
+			},
+		},
+		{
+			name: "stale etag",
+			req: &sportsapi.UpdateScoreRequest{
+				EventId:    1,
+				MatchState: sportsapi.Event_IN_PLAY,
+				Etag:       "1",
+			},
+			assertion: func(t *testing.T, _ *sportsapi.Event, err error) {
+				if status.Code(err) != codes.Aborted {
+					t.Fatalf("expected %v error, got %v", codes.Aborted, err)
+				}
+			},
+		},
+		{
+			name: "non-existing event ID",
+			req: &sportsapi.UpdateScoreRequest{
+				EventId:    999,
+				MatchState: sportsapi.Event_IN_PLAY,
+				Etag:       "*",
+			},
+			assertion: func(t *testing.T, _ *sportsapi.Event, err error) {
+				if status.Code(err) != codes.NotFound {
+					t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			event, err := client.UpdateScore(asAdmin(t.Context()), c.req)
+			c.assertion(t, event, err)
+		})
+	}
+
+	t.Run("admin only", func(t *testing.T) {
+		_, err := client.UpdateScore(
+			t.Context(),
+			&sportsapi.UpdateScoreRequest{
+				EventId:    3,
+				MatchState: sportsapi.Event_IN_PLAY,
+				Etag:       "*",
+			},
+		)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+
+		event, err := client.GetEvent(
+			t.Context(),
+			&sportsapi.GetEventRequest{EventId: 3},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if event.GetMatchState() != sportsapi.Event_PRE_MATCH {
+			t.Fatalf(
+				"expected match state %v, got %v",
+				sportsapi.Event_PRE_MATCH,
+				event.GetMatchState(),
+			)
+		}
+	})
+}
+
+// assertMatch is a test helper that asserts the match state and the scores of
+// an event.
+func assertMatch(
+	t *testing.T,
+	event *sportsapi.Event,
+	state sportsapi.Event_MatchState,
+	scores ...*sportsapi.PeriodScore,
+) {
+	t.Helper()
+
+	if event.GetMatchState() != state {
+		t.Fatalf(
+			"expected match state %v, got %v",
+			state,
+			event.GetMatchState(),
+		)
+	}
+
+	if len(event.GetScores()) != len(scores) {
+		t.Fatalf("expected scores %v, got %v", scores, event.GetScores())
+	}
+
+	for i, score := range scores {
+		if !proto.Equal(event.GetScores()[i], score) {
+			t.Fatalf("expected scores %v, got %v", scores, event.GetScores())
+		}
+	}
+}
+
+// assertFailedPrecondition is a test helper that asserts that the error is a
+// FailedPrecondition error.
+func assertFailedPrecondition(t *testing.T, err error) {
+	t.Helper()
+
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected %v error, got %v", codes.FailedPrecondition, err)
+	}
+}
@@ -4,12 +4,22 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"math/rand"
 	"os"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/danilvpetrov/entain/clock"
+	"github.com/danilvpetrov/entain/ingest"
 	"syreclabs.com/go/faker"
 )
 
+// SeedProvider is the name of the provider the seeded events are mapped to in
+// the external references. Their external IDs are their sequence numbers in
+// the test data file starting from 1.
+const SeedProvider = "seed"
+
 // testDataEvent represents a sports event from testdata file.
 type testDataEvent struct {
 	Name        string `json:"name"`
@@ -17,7 +27,8 @@ type testDataEvent struct {
 	Competition string `json:"competition"`
 }
 
-// SeedTestData seeds the database with test data. It returns the number of
+// SeedTestData seeds the database with test data from the given file, using a
+// Seeder with the default random source and clock. It returns the number of
 // seeded events.
 //
 // This function is intended to be used in tests only. Please avoid using it in
@@ -27,7 +38,44 @@ func SeedTestData(
 	db *sql.DB,
 	testDataFile string,
 ) (int, error) {
-	raw, err := os.ReadFile(testDataFile)
+	s := &Seeder{
+		DB:           db,
+		TestDataFile: testDataFile,
+	}
+	return s.Seed(ctx)
+}
+
+// Seeder seeds the database with test data. Seeding the same database again
+// updates the previously seeded events rather than adding new ones.
+//
+// It is intended to be used in tests only. Please avoid using it in a
+// production setup.
+type Seeder struct {
+	// DB is a database connection pool used to store the seeded data.
+	DB *sql.DB
+
+	// TestDataFile is the path to the JSON file with the names, categories and
+	// competitions of the seeded events.
+	TestDataFile string
+
+	// Rand is the source of randomness of the seeded data. Seeders with
+	// sources created from the same seed and with the same clock produce the
+	// same data. If it is nil, the data are random.
+	Rand *rand.Rand
+
+	// Clock is the source of the current time the advertised start times of
+	// the seeded events are spread around. If it is nil, clock.System is used.
+	Clock clock.Clock
+}
+
+// fakerMu serialises the seeders with a random source, as faker draws from a
+// global one.
+var fakerMu sync.Mutex
+
+// Seed seeds the database with test data. It returns the number of seeded
+// events.
+func (s *Seeder) Seed(ctx context.Context) (int, error) {
+	raw, err := os.ReadFile(s.TestDataFile)
 	if err != nil {
 		return 0, err
 	}
@@ -37,31 +85,44 @@ func SeedTestData(
 		return 0, err
 	}
 
-	for i, ev := range events {
-		if _, err := db.ExecContext(
-			ctx,
-			`INSERT OR IGNORE INTO events (
-				id,
-				name,
-				category,
-				competition,
-				visible,
-				advertised_start_time
-			)
-			VALUES (?, ?, ?, ?, ?, ?)`,
-			i+1,
-			ev.Name,
-			ev.Category,
-			ev.Competition,
-			faker.Number().Between(0, 1),
-			faker.Time().Between(
-				time.Now().AddDate(0, 0, -1),
-				time.Now().AddDate(0, 0, 2),
-			).Format(time.RFC3339),
-		); err != nil {
-			return 0, err
-		}
+	feed := s.generate(events)
+
+	sink := &IngestSink{DB: s.DB}
+	if err := sink.Upsert(ctx, SeedProvider, feed); err != nil {
+		return 0, err
 	}
 
 	return len(events), nil
 }
+
+// generate generates the seeded events from the events of the test data file.
+func (s *Seeder) generate(events []testDataEvent) *ingest.Feed {
+	if s.Rand != nil {
+		fakerMu.Lock()
+		defer fakerMu.Unlock()
+
+		faker.Seed(s.Rand.Int63())
+	}
+
+	now := clock.System.Now()
+	if s.Clock != nil {
+		now = s.Clock.Now()
+	}
+
+	feed := &ingest.Feed{}
+	for i, ev := range events {
+		feed.Events = append(feed.Events, ingest.Event{
+			AdvertisedStartTime: faker.Time().Between(
+				now.AddDate(0, 0, -1),
+				now.AddDate(0, 0, 2),
+			).Truncate(time.Second),
+			Category:    ev.Category,
+			Competition: ev.Competition,
+			ExternalID:  strconv.Itoa(i + 1),
+			Name:        ev.Name,
+			Visible:     faker.RandomInt(0, 1) == 1,
+		})
+	}
+
+	return feed
+}
@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"sync"
 	"time"
 
 	sportsapi "github.com/danilvpetrov/entain/api/sports"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/clock"
+	"github.com/danilvpetrov/entain/jurisdiction"
+	"github.com/danilvpetrov/entain/tenant"
 )
 
 // Service handles all requests related to sports. It implements
@@ -21,8 +23,35 @@ type Service struct {
 	// DB is a database connection pool used to perform queries against the
 	// underlying database.
 	DB *sql.DB
+
+	// MaxBatchSize is the maximum number of events that can be requested in a
+	// single BatchGetEvents call. If it is zero, DefaultMaxBatchSize is used.
+	MaxBatchSize int
+
+	// MaxImportSize is the maximum number of events that can be imported in a
+	// single ImportEvents call. If it is zero, DefaultMaxImportSize is used.
+	MaxImportSize int
+
+	// Clock is the source of the current time the statuses of events are
+	// computed at. If it is nil, clock.System is used.
+	Clock clock.Clock
+
+	// Jurisdictions is the set of rules restricting the categories of sports
+	// events offered in jurisdictions. If it is nil, events are offered in all
+	// jurisdictions.
+	Jurisdictions *jurisdiction.Rules
+
+	// watchers are the subscribers of WatchEvent calls.
+	watchers watchers
+	// updateMu serialises updates of events, so that subscribers receive the
+	// snapshots of an event in the order of the updates.
+	updateMu sync.Mutex
 }
 
+// DefaultMaxBatchSize is the default maximum number of events that can be
+// requested in a single BatchGetEvents call.
+const DefaultMaxBatchSize = 100
+
 // Make sure Service implements the sportsapi.SportsServer interface.
 var _ sportsapi.SportsServer = (*Service)(nil)
 
@@ -33,7 +62,32 @@ func (s *Service) ListEvents(
 ) (*sportsapi.ListEventsResponse, error) {
 	filterQuery, args := parseFilter(req)
 
-	orderBy, err := parseOrderBy(req)
+	if req.GetLocalDate() != "" {
+		dateFilter, dateArgs, err := s.localDateFilter(ctx, req.GetLocalDate())
+		if err != nil {
+			return nil, err
+		}
+		filterQuery += dateFilter
+		args = append(args, dateArgs...)
+	}
+
+	restriction, restrictionArgs := s.restrictionFilter(ctx)
+	filterQuery += restriction
+	args = append(args, restrictionArgs...)
+
+	from, fromArgs := eventsFrom(ctx)
+
+	orderBy, err := parseOrderBy(ctx, req, tenant.Name(ctx) != "")
+	if err != nil {
+		return nil, err
+	}
+
+	proj, err := parseReadMask(ctx, req.GetReadMask())
+	if err != nil {
+		return nil, err
+	}
+
+	now, err := s.asOf(ctx, req.GetAsOf())
 	if err != nil {
 		return nil, err
 	}
@@ -41,22 +95,19 @@ func (s *Service) ListEvents(
 	rows, err := s.DB.QueryContext(
 		ctx,
 		fmt.Sprintf(
-			`SELECT
-				id,
-				name,
-				category,
-				competition,
-				visible,
-				advertised_start_time
-			FROM events
-		 	WHERE id <> 0 %s %s`,
+			`SELECT %s
+			FROM %s
+		 	WHERE events.id <> 0 %s %s %s`,
+			proj.selectList(),
+			from,
+			lifecycleFilter(req.GetIncludeArchived()),
 			filterQuery,
 			orderBy,
 		),
-		args...,
+		append(fromArgs, args...)...,
 	)
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, apierror.Internal(ctx, err)
 	}
 	defer func() {
 		if err = rows.Close(); err != nil {
@@ -66,15 +117,19 @@ func (s *Service) ListEvents(
 
 	var events []*sportsapi.Event
 	for rows.Next() {
-		event, err := scanEvent(rows)
+		event, err := scanEvent(rows, proj, now)
 		if err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
+			return nil, apierror.Internal(ctx, err)
 		}
 		events = append(events, event)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := proj.loadScores(ctx, s.DB, events); err != nil {
+		return nil, apierror.Internal(ctx, err)
 	}
 
 	return &sportsapi.ListEventsResponse{
@@ -87,61 +142,166 @@ func (s *Service) GetEvent(
 	ctx context.Context,
 	req *sportsapi.GetEventRequest,
 ) (*sportsapi.Event, error) {
+	proj, err := parseReadMask(ctx, req.GetReadMask())
+	if err != nil {
+		return nil, err
+	}
+
+	from, args := eventsFrom(ctx)
+	restriction, restrictionArgs := s.restrictionFilter(ctx)
+
+	args = append(args, req.GetEventId())
 	row := s.DB.QueryRowContext(
 		ctx,
-		`SELECT
-			id,
-			name,
-			category,
-			competition,
-			visible,
-			advertised_start_time
-		FROM events
-		WHERE id = ?`,
-		req.GetEventId(),
+		fmt.Sprintf(
+			`SELECT %s
+			FROM %s
+			WHERE events.id = ? %s %s`,
+			proj.selectList(),
+			from,
+			lifecycleFilter(req.GetIncludeArchived()),
+			restriction,
+		),
+		append(args, restrictionArgs...)...,
 	)
 
-	event, err := scanEvent(row)
+	event, err := scanEvent(row, proj, s.now())
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Error(codes.NotFound, "event not found")
+			return nil, s.notFound(
+				ctx,
+				restriction,
+				req.GetEventId(),
+				req.GetIncludeArchived(),
+			)
 		}
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := proj.loadScores(
+		ctx,
+		s.DB,
+		[]*sportsapi.Event{event},
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
 	}
 
 	return event, nil
 }
 
-// scanner is an interface that abstracts sql.Row and sql.Rows types.
-type scanner interface {
-	Scan(dest ...any) error
-}
+// BatchGetEvents returns multiple events by their IDs.
+func (s *Service) BatchGetEvents(
+	ctx context.Context,
+	req *sportsapi.BatchGetEventsRequest,
+) (*sportsapi.BatchGetEventsResponse, error) {
+	ids := req.GetEventId()
 
-// scanEvent scans a sports event from the given scanner.
-func scanEvent(s scanner) (*sportsapi.Event, error) {
-	var (
-		event               sportsapi.Event
-		category            string
-		advertisedStartTime time.Time
-	)
-	if err := s.Scan(
-		&event.Id,
-		&event.Name,
-		&category,
-		&event.Competition,
-		&event.Visible,
-		&advertisedStartTime,
-	); err != nil {
+	maxBatchSize := s.MaxBatchSize
+	if maxBatchSize == 0 {
+		maxBatchSize = DefaultMaxBatchSize
+	}
+
+	if len(ids) > maxBatchSize {
+		return nil, apierror.InvalidArgument(
+			ctx,
+			"too many events requested",
+			apierror.FieldViolation{
+				Field: "event_id",
+				Description: fmt.Sprintf(
+					"at most %d IDs can be requested, got %d",
+					maxBatchSize,
+					len(ids),
+				),
+			},
+		)
+	}
+
+	from, args := eventsFrom(ctx)
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	// The events restricted in the jurisdiction are reported as missing.
+	restriction, restrictionArgs := s.restrictionFilter(ctx)
+	args = append(args, restrictionArgs...)
+
+	// All fields are read, including the ID required to match the events
+	// with the requested IDs.
+	proj, err := parseReadMask(ctx, nil)
+	if err != nil {
 		return nil, err
 	}
 
-	event.Category = sportsapi.Event_Category(
-		sportsapi.Event_Category_value[category],
+	rows, err := s.DB.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			`SELECT %s
+			FROM %s
+			WHERE events.id IN (%s) %s %s`,
+			proj.selectList(),
+			from,
+			placeholders(len(ids)),
+			lifecycleFilter(req.GetIncludeArchived()),
+			restriction,
+		),
+		args...,
 	)
-	event.AdvertisedStartTime = timestamppb.New(advertisedStartTime)
-	event.Status = computeEventStatus(advertisedStartTime)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	now := s.now()
+	var events []*sportsapi.Event
+	for rows.Next() {
+		event, err := scanEvent(rows, proj, now)
+		if err != nil {
+			return nil, apierror.Internal(ctx, err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
 
-	return &event, nil
+	if err := proj.loadScores(ctx, s.DB, events); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	found := make(map[int64]*sportsapi.Event, len(events))
+	for _, event := range events {
+		found[event.GetId()] = event
+	}
+
+	// Return the events in the order of the requested IDs.
+	resp := &sportsapi.BatchGetEventsResponse{
+		Events: make([]*sportsapi.Event, 0, len(found)),
+	}
+	for _, id := range ids {
+		if event, ok := found[id]; ok {
+			resp.Events = append(resp.Events, event)
+		} else {
+			resp.MissingEventId = append(resp.MissingEventId, id)
+		}
+	}
+
+	return resp, nil
+}
+
+// placeholders returns a comma-separated list of n query placeholders, for
+// use in IN clauses.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// scanner is an interface that abstracts sql.Row and sql.Rows types.
+type scanner interface {
+	Scan(dest ...any) error
 }
 
 // parseFilter builds SQL filter query and its arguments from the provided
@@ -152,28 +312,36 @@ func parseFilter(
 	var w strings.Builder
 
 	if len(req.GetCategory()) > 0 {
-		_, _ = w.WriteString(" AND category IN (")
+		_, _ = w.WriteString(" AND events.category IN (")
+		_, _ = w.WriteString(placeholders(len(req.GetCategory())))
+		_, _ = w.WriteString(")")
+		args = append(args, categoryArgs(req.GetCategory())...)
+	}
 
-		for i, cat := range req.GetCategory() {
-			_, _ = w.WriteString("?")
-			args = append(
-				args,
-				sportsapi.Event_Category_name[int32(cat)],
-			)
+	if ids := req.GetCompetitionId(); len(ids) > 0 {
+		_, _ = w.WriteString(" AND events.competition_id IN (")
+		_, _ = w.WriteString(placeholders(len(ids)))
+		_, _ = w.WriteString(")")
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
 
-			// If it's the last element, close the parenthesis and break.
-			// Otherwise, add a comma.
-			if i == len(req.GetCategory())-1 {
-				_, _ = w.WriteString(")")
-				break
+	if ids := req.GetParticipantId(); len(ids) > 0 {
+		_, _ = w.WriteString(" AND (events.home_participant_id IN (")
+		_, _ = w.WriteString(placeholders(len(ids)))
+		_, _ = w.WriteString(") OR events.away_participant_id IN (")
+		_, _ = w.WriteString(placeholders(len(ids)))
+		_, _ = w.WriteString("))")
+		for range 2 {
+			for _, id := range ids {
+				args = append(args, id)
 			}
-
-			_, _ = w.WriteString(", ")
 		}
 	}
 
 	if req.GetVisibleOnly() {
-		_, _ = w.WriteString(" AND visible = true")
+		_, _ = w.WriteString(" AND events.visible = true")
 	}
 
 	return w.String(), args
@@ -189,37 +357,70 @@ var conflictingOrdering = map[sportsapi.ListEventsRequest_OrderBy]sportsapi.List
 	sportsapi.ListEventsRequest_COMPETITION_DESC:           sportsapi.ListEventsRequest_COMPETITION_ASC,
 }
 
-func parseOrderBy(req *sportsapi.ListEventsRequest) (string, error) {
+// parseOrderBy builds SQL ORDER BY clause from the ordering of the request. If
+// positioned is true, the events are read as seen by a tenant, and the events
+// positioned for the tenant are ordered first, in the order of their
+// positions.
+func parseOrderBy(
+	ctx context.Context,
+	req *sportsapi.ListEventsRequest,
+	positioned bool,
+) (string, error) {
 	var w strings.Builder
 
-	if len(req.GetOrderBy()) == 0 {
+	switch {
+	case positioned && len(req.GetOrderBy()) == 0:
+		// The events that are not positioned are kept in the default order.
+		return " ORDER BY events.position IS NULL, events.position, events.id",
+			nil
+	case positioned:
+		w.WriteString(" ORDER BY events.position IS NULL, events.position, ")
+	case len(req.GetOrderBy()) == 0:
 		return "", nil
+	default:
+		w.WriteString(" ORDER BY ")
 	}
 
-	w.WriteString(" ORDER BY ")
 	visited := map[sportsapi.ListEventsRequest_OrderBy]bool{}
 
 	for i, order := range req.GetOrderBy() {
 		if visited[conflictingOrdering[order]] {
-			return "", status.Error(
-				codes.InvalidArgument,
+			return "", apierror.InvalidArgument(
+				ctx,
 				"conflicting order by fields",
+				apierror.FieldViolation{
+					Field: "order_by",
+					Description: fmt.Sprintf(
+						"%v conflicts with %v",
+						order,
+						conflictingOrdering[order],
+					),
+				},
 			)
 		}
 
 		switch order {
 		case sportsapi.ListEventsRequest_ADVERTISED_START_TIME_ASC:
-			_, _ = w.WriteString("advertised_start_time ASC")
+			_, _ = w.WriteString("events.advertised_start_time ASC")
 		case sportsapi.ListEventsRequest_ADVERTISED_START_TIME_DESC:
-			_, _ = w.WriteString("advertised_start_time DESC")
+			_, _ = w.WriteString("events.advertised_start_time DESC")
 		case sportsapi.ListEventsRequest_NAME_ASC:
-			_, _ = w.WriteString("name ASC")
+			_, _ = w.WriteString("events.name ASC")
 		case sportsapi.ListEventsRequest_NAME_DESC:
-			_, _ = w.WriteString("name DESC")
+			_, _ = w.WriteString("events.name DESC")
 		case sportsapi.ListEventsRequest_COMPETITION_ASC:
-			_, _ = w.WriteString("competition ASC")
+			_, _ = w.WriteString("competitions.name ASC")
 		case sportsapi.ListEventsRequest_COMPETITION_DESC:
-			_, _ = w.WriteString("competition DESC")
+			_, _ = w.WriteString("competitions.name DESC")
+		default:
+			return "", apierror.InvalidArgument(
+				ctx,
+				"unknown order by field",
+				apierror.FieldViolation{
+					Field:       "order_by",
+					Description: fmt.Sprintf("unknown value %v", order),
+				},
+			)
 		}
 
 		visited[order] = true
@@ -233,12 +434,12 @@ func parseOrderBy(req *sportsapi.ListEventsRequest) (string, error) {
 	return w.String(), nil
 }
 
-// computeEventStatus computes the status of a sports event based on its
-// advertised start time.
+// computeEventStatus computes the status of a sports event at the given time
+// based on its advertised start time.
 func computeEventStatus(
-	advertisedStartTime time.Time,
+	advertisedStartTime, now time.Time,
 ) sportsapi.Event_Status {
-	if advertisedStartTime.After(time.Now()) {
+	if advertisedStartTime.After(now) {
 		return sportsapi.Event_OPEN
 	}
 	return sportsapi.Event_CLOSED
@@ -6,7 +6,11 @@ import (
 	"time"
 
 	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/clock"
 	. "github.com/danilvpetrov/entain/sports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 const (
@@ -20,8 +24,10 @@ const (
 
 func TestListRaces(t *testing.T) { //nolint:gocognit // Explicit test cases.
 	db, numberOfSeedRecords := setupDatabase(t)
+	now := time.Now()
 	s := &Service{
-		DB: db,
+		DB:    db,
+		Clock: clock.Fixed(now),
 	}
 	client := setupServer(t, s)
 
@@ -312,6 +318,70 @@ func TestListRaces(t *testing.T) { //nolint:gocognit // Explicit test cases.
 				}
 			},
 		},
+		{
+			name: "filtered by competitions",
+			req: &sportsapi.ListEventsRequest{
+				CompetitionId: []int64{1},
+			},
+			assertion: func(t *testing.T, resp *sportsapi.ListEventsResponse, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if len(resp.GetEvents()) == 0 {
+					t.Fatal("expected at least one event")
+				}
+
+				for _, event := range resp.GetEvents() {
+					if event.GetCompetitionId() != 1 {
+						t.Errorf(
+							"unexpected competition ID %d for event %+v",
+							event.GetCompetitionId(),
+							event,
+						)
+					}
+				}
+			},
+		},
+		{
+			name: "filtered by participants",
+			req: &sportsapi.ListEventsRequest{
+				ParticipantId: []int64{1, 2},
+			},
+			assertion: func(t *testing.T, resp *sportsapi.ListEventsResponse, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if len(resp.GetEvents()) == 0 {
+					t.Fatal("expected at least one event")
+				}
+
+				for _, event := range resp.GetEvents() {
+					if !slices.Contains(
+						[]int64{1, 2},
+						event.GetHomeParticipantId(),
+					) && !slices.Contains(
+						[]int64{1, 2},
+						event.GetAwayParticipantId(),
+					) {
+						t.Errorf(
+							"expected event %+v to have participant 1 or 2",
+							event,
+						)
+					}
+				}
+			},
+		},
+		{
+			name: "invalid competition ID",
+			req: &sportsapi.ListEventsRequest{
+				CompetitionId: []int64{-1},
+			},
+			assertion: func(t *testing.T, _ *sportsapi.ListEventsResponse, err error) {
+				assertInvalidArgument(t, err, "competition_id[0]")
+			},
+		},
 		{
 			name: "conflicted orderings",
 			req: &sportsapi.ListEventsRequest{
@@ -321,11 +391,109 @@ func TestListRaces(t *testing.T) { //nolint:gocognit // Explicit test cases.
 				},
 			},
 			assertion: func(t *testing.T, _ *sportsapi.ListEventsResponse, err error) {
-				if err == nil {
-					t.Fatalf("expected error, got %v", err)
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf(
+						"expected %v error, got %v",
+						codes.InvalidArgument,
+						err,
+					)
+				}
+			},
+		},
+		{
+			name: "unspecified category",
+			req: &sportsapi.ListEventsRequest{
+				Category: []sportsapi.Event_Category{
+					sportsapi.Event_UNSPECIFIED_CATEGORY,
+				},
+			},
+			assertion: func(t *testing.T, _ *sportsapi.ListEventsResponse, err error) {
+				assertInvalidArgument(t, err, "category[0]")
+			},
+		},
+		{
+			name: "unknown category",
+			req: &sportsapi.ListEventsRequest{
+				Category: []sportsapi.Event_Category{999},
+			},
+			assertion: func(t *testing.T, _ *sportsapi.ListEventsResponse, err error) {
+				assertInvalidArgument(t, err, "category[0]")
+			},
+		},
+		{
+			name: "unknown ordering",
+			req: &sportsapi.ListEventsRequest{
+				OrderBy: []sportsapi.ListEventsRequest_OrderBy{999},
+			},
+			assertion: func(t *testing.T, _ *sportsapi.ListEventsResponse, err error) {
+				assertInvalidArgument(t, err, "order_by[0]")
+			},
+		},
+		{
+			name: "unspecified ordering",
+			req: &sportsapi.ListEventsRequest{
+				OrderBy: []sportsapi.ListEventsRequest_OrderBy{
+					sportsapi.ListEventsRequest_NAME_ASC,
+					sportsapi.ListEventsRequest_UNSPECIFIED,
+				},
+			},
+			assertion: func(t *testing.T, _ *sportsapi.ListEventsResponse, err error) {
+				assertInvalidArgument(t, err, "order_by[1]")
+			},
+		},
+		{
+			name: "duplicate orderings",
+			req: &sportsapi.ListEventsRequest{
+				OrderBy: []sportsapi.ListEventsRequest_OrderBy{
+					sportsapi.ListEventsRequest_NAME_ASC,
+					sportsapi.ListEventsRequest_NAME_ASC,
+				},
+			},
+			assertion: func(t *testing.T, _ *sportsapi.ListEventsResponse, err error) {
+				assertInvalidArgument(t, err, "order_by")
+			},
+		},
+		{
+			name: "read mask",
+			req: &sportsapi.ListEventsRequest{
+				ReadMask: &fieldmaskpb.FieldMask{
+					Paths: []string{"id", "name", "advertisedStartTime"},
+				},
+			},
+			assertion: func(t *testing.T, resp *sportsapi.ListEventsResponse, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if len(resp.GetEvents()) == 0 {
+					t.Fatal("expected at least one event")
+				}
+
+				for _, event := range resp.GetEvents() {
+					if event.GetId() == 0 ||
+						event.GetName() == "" ||
+						event.GetAdvertisedStartTime() == nil {
+						t.Fatalf("expected masked fields to be set, got %+v", event)
+					}
+
+					if event.GetCategory() != 0 ||
+						event.GetStatus() != 0 {
+						t.Fatalf("expected unmasked fields to be unset, got %+v", event)
+					}
 				}
 			},
 		},
+		{
+			name: "read mask with unknown field",
+			req: &sportsapi.ListEventsRequest{
+				ReadMask: &fieldmaskpb.FieldMask{
+					Paths: []string{"id", "unknown"},
+				},
+			},
+			assertion: func(t *testing.T, _ *sportsapi.ListEventsResponse, err error) {
+				assertInvalidArgument(t, err, "read_mask")
+			},
+		},
 		{
 			name: "status field is computed correctly",
 			req:  &sportsapi.ListEventsRequest{},
@@ -336,8 +504,7 @@ func TestListRaces(t *testing.T) { //nolint:gocognit // Explicit test cases.
 
 				for _, event := range resp.GetEvents() {
 					expected := sportsapi.Event_OPEN
-					now := time.Now()
-					if event.GetAdvertisedStartTime().AsTime().Before(now) {
+					if !event.GetAdvertisedStartTime().AsTime().After(now) {
 						expected = sportsapi.Event_CLOSED
 					}
 
@@ -392,15 +559,68 @@ func TestGetRace(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "gets event competition and participants",
+			req: &sportsapi.GetEventRequest{
+				EventId: 1,
+			},
+			assertion: func(t *testing.T, event *sportsapi.Event, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if event.GetCompetition() != "TT Elite Series - Men" ||
+					event.GetCompetitionId() == 0 {
+					t.Fatalf("expected competition to be set, got %+v", event)
+				}
+
+				if event.GetHomeParticipantId() == 0 ||
+					event.GetAwayParticipantId() == 0 ||
+					event.GetHomeParticipantId() == event.GetAwayParticipantId() {
+					t.Fatalf("expected participants to be set, got %+v", event)
+				}
+			},
+		},
 		{
 			name: "non-existing event ID",
 			req: &sportsapi.GetEventRequest{
 				EventId: 999,
 			},
 			assertion: func(t *testing.T, _ *sportsapi.Event, err error) {
-				if err == nil {
-					t.Fatalf("expected error, got %v", err)
+				if status.Code(err) != codes.NotFound {
+					t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+				}
+			},
+		},
+		{
+			name: "gets event status only",
+			req: &sportsapi.GetEventRequest{
+				EventId: 1,
+				ReadMask: &fieldmaskpb.FieldMask{
+					Paths: []string{"status"},
+				},
+			},
+			assertion: func(t *testing.T, event *sportsapi.Event, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				if event.GetStatus() == 0 {
+					t.Fatal("expected status to be set")
 				}
+
+				if event.GetId() != 0 || event.GetAdvertisedStartTime() != nil {
+					t.Fatalf("expected unmasked fields to be unset, got %+v", event)
+				}
+			},
+		},
+		{
+			name: "invalid event ID",
+			req: &sportsapi.GetEventRequest{
+				EventId: -1,
+			},
+			assertion: func(t *testing.T, _ *sportsapi.Event, err error) {
+				assertInvalidArgument(t, err, "event_id")
 			},
 		},
 	}
@@ -412,3 +632,73 @@ func TestGetRace(t *testing.T) {
 		})
 	}
 }
+
+func TestBatchGetEvents(t *testing.T) {
+	db, _ := setupDatabase(t)
+	s := &Service{
+		DB:           db,
+		MaxBatchSize: 3,
+	}
+	client := setupServer(t, s)
+
+	cases := []struct {
+		assertion func(
+			t *testing.T,
+			resp *sportsapi.BatchGetEventsResponse,
+			err error,
+		)
+		req  *sportsapi.BatchGetEventsRequest
+		name string
+	}{
+		{
+			name: "gets events by IDs in the requested order",
+			req: &sportsapi.BatchGetEventsRequest{
+				EventId: []int64{3, 999, 1},
+			},
+			assertion: func(t *testing.T, resp *sportsapi.BatchGetEventsResponse, err error) {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				var ids []int64
+				for _, event := range resp.GetEvents() {
+					ids = append(ids, event.GetId())
+				}
+
+				if !slices.Equal(ids, []int64{3, 1}) {
+					t.Fatalf("expected event IDs [3 1], got %v", ids)
+				}
+
+				if !slices.Equal(resp.GetMissingEventId(), []int64{999}) {
+					t.Fatalf(
+						"expected missing event IDs [999], got %v",
+						resp.GetMissingEventId(),
+					)
+				}
+			},
+		},
+		{
+			name: "no IDs",
+			req:  &sportsapi.BatchGetEventsRequest{},
+			assertion: func(t *testing.T, _ *sportsapi.BatchGetEventsResponse, err error) {
+				assertInvalidArgument(t, err, "event_id")
+			},
+		},
+		{
+			name: "too many IDs",
+			req: &sportsapi.BatchGetEventsRequest{
+				EventId: []int64{1, 2, 3, 4},
+			},
+			assertion: func(t *testing.T, _ *sportsapi.BatchGetEventsResponse, err error) {
+				assertInvalidArgument(t, err, "event_id")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resp, err := client.BatchGetEvents(t.Context(), c.req)
+			c.assertion(t, resp, err)
+		})
+	}
+}
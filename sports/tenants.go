@@ -0,0 +1,343 @@
+package sports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/danilvpetrov/entain/admin"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/audit"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// ListTenantOverrides returns the overrides of the visibility and the ordering
+// of sports events for a tenant, in the order of the IDs of the events.
+func (s *Service) ListTenantOverrides(
+	ctx context.Context,
+	req *sportsapi.ListTenantOverridesRequest,
+) (*sportsapi.ListTenantOverridesResponse, error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"tenant overrides can only be listed by admins",
+		)
+	}
+
+	rows, err := s.DB.QueryContext(
+		ctx,
+		`SELECT
+			tenant_events.tenant,
+			tenant_events.event_id,
+			tenant_events.visible,
+			tenant_events.position
+		FROM tenant_events
+		JOIN events ON events.id = tenant_events.event_id
+		WHERE tenant_events.tenant = ?
+		AND events.deleted_at IS NULL
+		ORDER BY tenant_events.event_id`,
+		req.GetTenant(),
+	)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err = rows.Close(); err != nil {
+			slog.Error("failed closing rows", slog.Any("error", err))
+		}
+	}()
+
+	resp := &sportsapi.ListTenantOverridesResponse{}
+	for rows.Next() {
+		override, err := scanTenantOverride(rows)
+		if err != nil {
+			return nil, apierror.Internal(ctx, err)
+		}
+		resp.Overrides = append(resp.Overrides, override)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	return resp, nil
+}
+
+// SetTenantOverride creates or replaces the override of the visibility and the
+// ordering of a sports event for a tenant.
+func (s *Service) SetTenantOverride(
+	ctx context.Context,
+	req *sportsapi.SetTenantOverrideRequest,
+) (_ *sportsapi.TenantOverride, err error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"tenant overrides can only be set by admins",
+		)
+	}
+
+	override := req.GetOverride()
+	if override.GetVisibility() == sportsapi.TenantOverride_UNSPECIFIED &&
+		override.GetPosition() == 0 {
+		return nil, apierror.InvalidArgument(
+			ctx,
+			"empty tenant override",
+			apierror.FieldViolation{
+				Field:       "override",
+				Description: "visibility or position must be set",
+			},
+		)
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var exists bool
+	if err := tx.QueryRowContext(
+		ctx,
+		`SELECT EXISTS (
+			SELECT 1 FROM events WHERE id = ? AND deleted_at IS NULL
+		)`,
+		override.GetEventId(),
+	).Scan(&exists); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if !exists {
+		return nil, apierror.NotFound(ctx, "EVENT_NOT_FOUND", "event not found")
+	}
+
+	before, err := readTenantOverride(
+		ctx,
+		tx,
+		override.GetTenant(),
+		override.GetEventId(),
+	)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	var (
+		visible  sql.Null[bool]
+		position sql.Null[int32]
+	)
+	switch override.GetVisibility() {
+	case sportsapi.TenantOverride_VISIBLE:
+		visible = sql.Null[bool]{V: true, Valid: true}
+	case sportsapi.TenantOverride_HIDDEN:
+		visible = sql.Null[bool]{V: false, Valid: true}
+	}
+	if p := override.GetPosition(); p != 0 {
+		position = sql.Null[int32]{V: p, Valid: true}
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`INSERT INTO tenant_events (tenant, event_id, visible, position)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (tenant, event_id) DO UPDATE SET
+			visible = excluded.visible,
+			position = excluded.position`,
+		override.GetTenant(),
+		override.GetEventId(),
+		visible,
+		position,
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := recordOverrideAudit(
+		ctx,
+		tx,
+		s.now(),
+		sportsapi.Sports_SetTenantOverride_FullMethodName,
+		override.GetTenant(),
+		override.GetEventId(),
+		before,
+		override,
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	return override, nil
+}
+
+// DeleteTenantOverride deletes the override of a sports event for a tenant.
+func (s *Service) DeleteTenantOverride(
+	ctx context.Context,
+	req *sportsapi.DeleteTenantOverrideRequest,
+) (_ *emptypb.Empty, err error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"tenant overrides can only be deleted by admins",
+		)
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := readTenantOverride(
+		ctx,
+		tx,
+		req.GetTenant(),
+		req.GetEventId(),
+	)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if before == nil {
+		return nil, apierror.NotFound(
+			ctx,
+			"TENANT_OVERRIDE_NOT_FOUND",
+			"tenant override not found",
+		)
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM tenant_events WHERE tenant = ? AND event_id = ?`,
+		req.GetTenant(),
+		req.GetEventId(),
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := recordOverrideAudit(
+		ctx,
+		tx,
+		s.now(),
+		sportsapi.Sports_DeleteTenantOverride_FullMethodName,
+		req.GetTenant(),
+		req.GetEventId(),
+		before,
+		nil,
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// readTenantOverride reads the override of the sports event with the given ID
+// for a tenant. It returns nil if there is no such override.
+func readTenantOverride(
+	ctx context.Context,
+	tx *sql.Tx,
+	tenant string,
+	eventID int64,
+) (*sportsapi.TenantOverride, error) {
+	override, err := scanTenantOverride(
+		tx.QueryRowContext(
+			ctx,
+			`SELECT tenant, event_id, visible, position
+			FROM tenant_events
+			WHERE tenant = ? AND event_id = ?`,
+			tenant,
+			eventID,
+		),
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+
+	return override, err
+}
+
+// scanTenantOverride scans a tenant override from the given scanner.
+func scanTenantOverride(s scanner) (*sportsapi.TenantOverride, error) {
+	var (
+		override sportsapi.TenantOverride
+		visible  sql.Null[bool]
+		position sql.Null[int32]
+	)
+
+	if err := s.Scan(
+		&override.Tenant,
+		&override.EventId,
+		&visible,
+		&position,
+	); err != nil {
+		return nil, err
+	}
+
+	if visible.Valid {
+		override.Visibility = sportsapi.TenantOverride_HIDDEN
+		if visible.V {
+			override.Visibility = sportsapi.TenantOverride_VISIBLE
+		}
+	}
+	override.Position = position.V
+
+	return &override, nil
+}
+
+// recordOverrideAudit appends an entry describing a change of the override of
+// the sports event with the given ID for a tenant to the audit log. The change
+// is recorded as a change of the event, with the names of the fields of the
+// override prefixed by "tenants.<tenant>.". Either override can be nil if it
+// was created or deleted by the change.
+func recordOverrideAudit(
+	ctx context.Context,
+	tx *sql.Tx,
+	now time.Time,
+	method string,
+	tenant string,
+	eventID int64,
+	before, after *sportsapi.TenantOverride,
+) error {
+	diff, err := audit.Diff(overrideFields(before), overrideFields(after))
+	if err != nil {
+		return err
+	}
+
+	for i := range diff {
+		diff[i].Field = "tenants." + tenant + "." + diff[i].Field
+	}
+
+	return insertAuditEntry(ctx, tx, now, method, eventID, diff)
+}
+
+// overrideFields returns the overridden fields of a tenant override, without
+// the tenant and the event it applies to. It returns nil if o is nil.
+func overrideFields(o *sportsapi.TenantOverride) *sportsapi.TenantOverride {
+	if o == nil {
+		return nil
+	}
+
+	fields := proto.CloneOf(o)
+	fields.Tenant = ""
+	fields.EventId = 0
+
+	return fields
+}
@@ -0,0 +1,352 @@
+package sports_test
+
+import (
+	"testing"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	. "github.com/danilvpetrov/entain/sports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTenantOverrides(t *testing.T) { //nolint:gocognit // Explicit test cases.
+	db, numOfRecords := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db})
+
+	event, err := client.GetEvent(
+		t.Context(),
+		&sportsapi.GetEventRequest{EventId: 1},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// The event is made visible for the tenant if it is hidden from other
+	// clients, and the other way around.
+	visibility := sportsapi.TenantOverride_VISIBLE
+	if event.GetVisible() {
+		visibility = sportsapi.TenantOverride_HIDDEN
+	}
+
+	t.Run("sets the overrides", func(t *testing.T) {
+		for _, o := range []*sportsapi.TenantOverride{
+			{Tenant: "brand-1", EventId: 1, Visibility: visibility},
+			{Tenant: "brand-1", EventId: 3, Position: 2},
+			{Tenant: "brand-1", EventId: 2, Position: 1},
+		} {
+			override, err := client.SetTenantOverride(
+				asAdmin(t.Context()),
+				&sportsapi.SetTenantOverrideRequest{Override: o},
+			)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if override.GetVisibility() != o.GetVisibility() ||
+				override.GetPosition() != o.GetPosition() {
+				t.Fatalf("expected override %v, got %v", o, override)
+			}
+		}
+	})
+
+	t.Run("gets the event as seen by the tenant", func(t *testing.T) {
+		r, err := client.GetEvent(
+			asTenant(t.Context(), "brand-1"),
+			&sportsapi.GetEventRequest{EventId: 1},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if r.GetVisible() == event.GetVisible() {
+			t.Fatalf("expected visible to be %v", !event.GetVisible())
+		}
+
+		resp, err := client.BatchGetEvents(
+			asTenant(t.Context(), "brand-1"),
+			&sportsapi.BatchGetEventsRequest{EventId: []int64{1}},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if resp.GetEvents()[0].GetVisible() == event.GetVisible() {
+			t.Fatalf("expected visible to be %v", !event.GetVisible())
+		}
+	})
+
+	t.Run("gets the event as seen by other tenants", func(t *testing.T) {
+		r, err := client.GetEvent(
+			asTenant(t.Context(), "brand-2"),
+			&sportsapi.GetEventRequest{EventId: 1},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if r.GetVisible() != event.GetVisible() {
+			t.Fatalf("expected visible to be %v", event.GetVisible())
+		}
+	})
+
+	t.Run("lists the visible events of the tenant", func(t *testing.T) {
+		resp, err := client.ListEvents(
+			asTenant(t.Context(), "brand-1"),
+			&sportsapi.ListEventsRequest{VisibleOnly: true},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		listed := false
+		for _, r := range resp.GetEvents() {
+			if !r.GetVisible() {
+				t.Fatalf("expected only visible events, got %v", r)
+			}
+			listed = listed || r.GetId() == 1
+		}
+
+		if listed == event.GetVisible() {
+			t.Fatalf("expected event 1 to be listed: %v", !event.GetVisible())
+		}
+	})
+
+	t.Run("exports the visible events of the tenant", func(t *testing.T) {
+		events := exportEvents(
+			asTenant(t.Context(), "brand-1"),
+			t,
+			client,
+			&sportsapi.ExportEventsRequest{VisibleOnly: true},
+		)
+
+		exported := false
+		for _, e := range events {
+			if !e.GetVisible() {
+				t.Fatalf("expected only visible events, got %v", e)
+			}
+			exported = exported || e.GetId() == 1
+		}
+
+		if exported == event.GetVisible() {
+			t.Fatalf("expected event 1 to be exported: %v", !event.GetVisible())
+		}
+	})
+
+	t.Run("lists the positioned events first", func(t *testing.T) {
+		resp, err := client.ListEvents(
+			asTenant(t.Context(), "brand-1"),
+			&sportsapi.ListEventsRequest{
+				OrderBy: []sportsapi.ListEventsRequest_OrderBy{
+					sportsapi.ListEventsRequest_NAME_ASC,
+				},
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		events := resp.GetEvents()
+		if len(events) != numOfRecords {
+			t.Fatalf(
+				"expected %d events, got %d",
+				numOfRecords,
+				len(events),
+			)
+		}
+
+		if events[0].GetId() != 2 || events[1].GetId() != 3 {
+			t.Fatalf(
+				"expected events 2 and 3 first, got %d and %d",
+				events[0].GetId(),
+				events[1].GetId(),
+			)
+		}
+
+		for i := 3; i < len(events); i++ {
+			if events[i].GetName() < events[i-1].GetName() {
+				t.Fatalf("expected the other events ordered by name ASC")
+			}
+		}
+	})
+
+	t.Run("lists the overrides", func(t *testing.T) {
+		resp, err := client.ListTenantOverrides(
+			asAdmin(t.Context()),
+			&sportsapi.ListTenantOverridesRequest{Tenant: "brand-1"},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		overrides := resp.GetOverrides()
+		if len(overrides) != 3 ||
+			overrides[0].GetVisibility() != visibility ||
+			overrides[1].GetPosition() != 1 ||
+			overrides[2].GetPosition() != 2 {
+			t.Fatalf("unexpected overrides %v", overrides)
+		}
+	})
+
+	t.Run("records the changes in the audit log", func(t *testing.T) {
+		resp, err := client.ListAuditEntries(
+			asAdmin(t.Context()),
+			&sportsapi.ListAuditEntriesRequest{
+				EntityType: "event",
+				EntityId:   1,
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(resp.GetEntries()) != 1 {
+			t.Fatalf("expected 1 entry, got %v", resp.GetEntries())
+		}
+
+		entry := resp.GetEntries()[0]
+		method := sportsapi.Sports_SetTenantOverride_FullMethodName
+		if entry.GetMethod() != method ||
+			len(entry.GetChanges()) != 1 ||
+			entry.GetChanges()[0].GetField() != "tenants.brand-1.visibility" {
+			t.Fatalf("unexpected entry %v", entry)
+		}
+	})
+
+	t.Run("watches the event as seen by the tenant", func(t *testing.T) {
+		stream, err := client.WatchEvent(
+			asTenant(t.Context(), "brand-1"),
+			&sportsapi.WatchEventRequest{EventId: 1},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		snapshot, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if snapshot.GetVisible() == event.GetVisible() {
+			t.Fatalf("expected visible to be %v", !event.GetVisible())
+		}
+
+		// The update is made on behalf of another tenant, which must not
+		// change how the watcher sees the published snapshot.
+		updated, err := client.UpdateScore(
+			asTenant(asAdmin(t.Context()), "brand-2"),
+			&sportsapi.UpdateScoreRequest{
+				EventId:    1,
+				MatchState: sportsapi.Event_IN_PLAY,
+				Etag:       "*",
+			},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if updated.GetVisible() != event.GetVisible() {
+			t.Fatalf("expected visible to be %v", event.GetVisible())
+		}
+
+		snapshot, err = stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if snapshot.GetMatchState() != sportsapi.Event_IN_PLAY ||
+			snapshot.GetVisible() == event.GetVisible() {
+			t.Fatalf("unexpected snapshot %v", snapshot)
+		}
+	})
+
+	t.Run("deletes the override", func(t *testing.T) {
+		_, err := client.DeleteTenantOverride(
+			asAdmin(t.Context()),
+			&sportsapi.DeleteTenantOverrideRequest{
+				Tenant:  "brand-1",
+				EventId: 1,
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		r, err := client.GetEvent(
+			asTenant(t.Context(), "brand-1"),
+			&sportsapi.GetEventRequest{EventId: 1},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if r.GetVisible() != event.GetVisible() {
+			t.Fatalf("expected visible to be %v", event.GetVisible())
+		}
+
+		_, err = client.DeleteTenantOverride(
+			asAdmin(t.Context()),
+			&sportsapi.DeleteTenantOverrideRequest{
+				Tenant:  "brand-1",
+				EventId: 1,
+			},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("empty override", func(t *testing.T) {
+		_, err := client.SetTenantOverride(
+			asAdmin(t.Context()),
+			&sportsapi.SetTenantOverrideRequest{
+				Override: &sportsapi.TenantOverride{
+					Tenant:  "brand-1",
+					EventId: 1,
+				},
+			},
+		)
+		assertInvalidArgument(t, err, "override")
+	})
+
+	t.Run("unknown event", func(t *testing.T) {
+		_, err := client.SetTenantOverride(
+			asAdmin(t.Context()),
+			&sportsapi.SetTenantOverrideRequest{
+				Override: &sportsapi.TenantOverride{
+					Tenant:   "brand-1",
+					EventId:  1_000_000,
+					Position: 1,
+				},
+			},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		_, err := client.SetTenantOverride(
+			asTenant(t.Context(), "brand-1"),
+			&sportsapi.SetTenantOverrideRequest{
+				Override: &sportsapi.TenantOverride{
+					Tenant:   "brand-1",
+					EventId:  1,
+					Position: 1,
+				},
+			},
+		)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+	})
+
+	t.Run("invalid tenant", func(t *testing.T) {
+		_, err := client.GetEvent(
+			asTenant(t.Context(), "Brand 1"),
+			&sportsapi.GetEventRequest{EventId: 1},
+		)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected %v error, got %v", codes.InvalidArgument, err)
+		}
+	})
+}
@@ -0,0 +1,367 @@
+package sports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/danilvpetrov/entain/admin"
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/etag"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// updatableEventFields is a list of the names of sportsapi.Event fields that
+// can be updated by UpdateEvent, in the order they are updated in.
+var updatableEventFields = []protoreflect.Name{
+	"name",
+	"category",
+	"visible",
+	"advertised_start_time",
+}
+
+// UpdateEvent updates the fields of a sports event listed in the update mask,
+// and notifies the subscribers watching the event.
+func (s *Service) UpdateEvent(
+	ctx context.Context,
+	req *sportsapi.UpdateEventRequest,
+) (*sportsapi.Event, error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"events can only be updated by admins",
+		)
+	}
+
+	event := req.GetEvent()
+	if event.GetId() <= 0 {
+		return nil, apierror.InvalidArgument(
+			ctx,
+			"invalid event ID",
+			apierror.FieldViolation{
+				Field:       "event.id",
+				Description: "value must be greater than 0",
+			},
+		)
+	}
+
+	set, args, err := parseUpdateMask(ctx, req.GetUpdateMask(), event)
+	if err != nil {
+		return nil, err
+	}
+
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	if err := s.updateEvent(
+		ctx,
+		event.GetId(),
+		req.GetEtag(),
+		set,
+		args,
+	); err != nil {
+		return nil, err
+	}
+
+	return s.publishEvent(ctx, event.GetId())
+}
+
+// updateEvent sets the given columns of a sports event within a single
+// transaction, along with the change and the audit entry describing it.
+// Archived events cannot be updated.
+func (s *Service) updateEvent(
+	ctx context.Context,
+	eventID int64,
+	tag string,
+	set string,
+	args []any,
+) (err error) {
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := checkVersion(ctx, tx, eventID, tag, false)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`UPDATE events SET `+set+` WHERE id = ?`,
+		append(args, eventID)...,
+	); err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	if err := recordChange(
+		ctx,
+		tx,
+		s.now(),
+		eventID,
+		sportsapi.Change_UPDATED,
+	); err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	after, err := readSnapshot(ctx, tx, eventID)
+	if err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	if err := recordAudit(
+		ctx,
+		tx,
+		s.now(),
+		sportsapi.Sports_UpdateEvent_FullMethodName,
+		eventID,
+		before,
+		after,
+	); err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return apierror.Internal(ctx, err)
+	}
+
+	return nil
+}
+
+// DeleteEvent deletes a sports event. The deleted event is kept as a
+// tombstone, so that it is not ingested again.
+func (s *Service) DeleteEvent(
+	ctx context.Context,
+	req *sportsapi.DeleteEventRequest,
+) (_ *emptypb.Empty, err error) {
+	if !admin.IsAdmin(ctx) {
+		return nil, apierror.PermissionDenied(
+			ctx,
+			"ADMIN_ONLY",
+			"events can only be deleted by admins",
+		)
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := checkVersion(
+		ctx,
+		tx,
+		req.GetEventId(),
+		req.GetEtag(),
+		true,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		`UPDATE events SET deleted_at = ? WHERE id = ?`,
+		s.now().UTC().Format(time.RFC3339),
+		req.GetEventId(),
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := recordChange(
+		ctx,
+		tx,
+		s.now(),
+		req.GetEventId(),
+		sportsapi.Change_DELETED,
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := recordAudit(
+		ctx,
+		tx,
+		s.now(),
+		sportsapi.Sports_DeleteEvent_FullMethodName,
+		req.GetEventId(),
+		before,
+		nil,
+	); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// checkVersion checks the etag of a request changing the sports event with the
+// given ID against the current version of the event, and returns the snapshot
+// of the event before the change. It must be called within the transaction
+// making the change, before the change is made.
+func checkVersion(
+	ctx context.Context,
+	tx *sql.Tx,
+	eventID int64,
+	tag string,
+	includeArchived bool,
+) (*sportsapi.Event, error) {
+	var version int64
+	if err := tx.QueryRowContext(
+		ctx,
+		`SELECT version
+		FROM events
+		WHERE events.id = ?`+lifecycleFilter(includeArchived),
+		eventID,
+	).Scan(&version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apierror.NotFound(
+				ctx,
+				"EVENT_NOT_FOUND",
+				"event not found",
+			)
+		}
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if err := etag.Check(ctx, tag, version); err != nil {
+		return nil, err
+	}
+
+	event, err := readSnapshot(ctx, tx, eventID)
+	if err != nil {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	return event, nil
+}
+
+// parseUpdateMask builds the SET clause of the query updating an event and its
+// arguments from the update mask of a request. If the mask is empty, all
+// updatable fields are updated.
+//
+// Paths of the mask are names of sportsapi.Event fields, either in snake_case
+// or in lowerCamelCase as they appear in JSON.
+func parseUpdateMask(
+	ctx context.Context,
+	mask *fieldmaskpb.FieldMask,
+	event *sportsapi.Event,
+) (string, []any, error) {
+	desc := event.ProtoReflect().Descriptor().Fields()
+	fields := map[protoreflect.Name]bool{}
+
+	for _, path := range mask.GetPaths() {
+		fd := desc.ByName(protoreflect.Name(path))
+		if fd == nil {
+			fd = desc.ByJSONName(path)
+		}
+		if fd == nil {
+			return "", nil, apierror.InvalidArgument(
+				ctx,
+				"invalid update mask",
+				apierror.FieldViolation{
+					Field:       "update_mask",
+					Description: fmt.Sprintf("unknown field %q", path),
+				},
+			)
+		}
+		if !slices.Contains(updatableEventFields, fd.Name()) {
+			return "", nil, apierror.InvalidArgument(
+				ctx,
+				"invalid update mask",
+				apierror.FieldViolation{
+					Field: "update_mask",
+					Description: fmt.Sprintf(
+						"field %q cannot be updated",
+						path,
+					),
+				},
+			)
+		}
+		fields[fd.Name()] = true
+	}
+
+	var (
+		set  []string
+		args []any
+	)
+
+	for _, name := range updatableEventFields {
+		if len(fields) > 0 && !fields[name] {
+			continue
+		}
+
+		switch name {
+		case "name":
+			if event.GetName() == "" {
+				return "", nil, apierror.InvalidArgument(
+					ctx,
+					"invalid event",
+					apierror.FieldViolation{
+						Field:       "event.name",
+						Description: "value is required",
+					},
+				)
+			}
+			set = append(set, "name = ?")
+			args = append(args, event.GetName())
+		case "category":
+			category := event.GetCategory().String()
+			if parseCategory(category) == sportsapi.Event_UNSPECIFIED_CATEGORY {
+				return "", nil, apierror.InvalidArgument(
+					ctx,
+					"invalid event",
+					apierror.FieldViolation{
+						Field:       "event.category",
+						Description: "value must be a known category",
+					},
+				)
+			}
+			set = append(set, "category = ?")
+			args = append(args, category)
+		case "visible":
+			set = append(set, "visible = ?")
+			args = append(args, event.GetVisible())
+		case "advertised_start_time":
+			if err := event.GetAdvertisedStartTime().CheckValid(); err != nil {
+				return "", nil, apierror.InvalidArgument(
+					ctx,
+					"invalid event",
+					apierror.FieldViolation{
+						Field:       "event.advertised_start_time",
+						Description: err.Error(),
+					},
+				)
+			}
+			set = append(set, "advertised_start_time = ?")
+			args = append(
+				args,
+				event.GetAdvertisedStartTime().
+					AsTime().
+					UTC().
+					Format(time.RFC3339),
+			)
+		}
+	}
+
+	return strings.Join(set, ", "), args, nil
+}
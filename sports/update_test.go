@@ -0,0 +1,218 @@
+package sports_test
+
+import (
+	"testing"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	. "github.com/danilvpetrov/entain/sports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestUpdateEvent(t *testing.T) {
+	db, _ := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db})
+
+	event, err := client.GetEvent(
+		t.Context(),
+		&sportsapi.GetEventRequest{EventId: 1},
+	)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if event.GetEtag() == "" {
+		t.Fatal("expected etag to be set")
+	}
+
+	t.Run("updates the fields in the mask", func(t *testing.T) {
+		updated, err := client.UpdateEvent(
+			asAdmin(t.Context()),
+			&sportsapi.UpdateEventRequest{
+				Event: &sportsapi.Event{
+					Id:       1,
+					Name:     "Renamed event",
+					Category: sportsapi.Event_BOXING,
+				},
+				UpdateMask: &fieldmaskpb.FieldMask{
+					Paths: []string{"name", "category"},
+				},
+				Etag: event.GetEtag(),
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if updated.GetName() != "Renamed event" ||
+			updated.GetCategory() != sportsapi.Event_BOXING {
+			t.Fatalf("expected event to be updated, got %v", updated)
+		}
+		if updated.GetVisible() != event.GetVisible() {
+			t.Fatal("expected visibility to be left as it was")
+		}
+		if updated.GetEtag() == event.GetEtag() {
+			t.Fatal("expected etag to change")
+		}
+
+		event = updated
+	})
+
+	t.Run("stale etag", func(t *testing.T) {
+		_, err := client.UpdateEvent(
+			asAdmin(t.Context()),
+			&sportsapi.UpdateEventRequest{
+				Event:      &sportsapi.Event{Id: 1, Name: "Stale event"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+				Etag:       "1",
+			},
+		)
+		if status.Code(err) != codes.Aborted {
+			t.Fatalf("expected %v error, got %v", codes.Aborted, err)
+		}
+	})
+
+	t.Run("etag in the If-Match metadata", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(
+			asAdmin(t.Context()),
+			"if-match",
+			`"`+event.GetEtag()+`"`,
+		)
+
+		updated, err := client.UpdateEvent(
+			ctx,
+			&sportsapi.UpdateEventRequest{
+				Event: &sportsapi.Event{
+					Id:      1,
+					Visible: !event.GetVisible(),
+				},
+				UpdateMask: &fieldmaskpb.FieldMask{
+					Paths: []string{"visible"},
+				},
+			},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		event = updated
+	})
+
+	t.Run("audit entries", func(t *testing.T) {
+		resp, err := client.ListAuditEntries(
+			asAdmin(t.Context()),
+			&sportsapi.ListAuditEntriesRequest{EntityId: 1},
+		)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		entries := resp.GetEntries()
+		if len(entries) != 2 {
+			t.Fatalf("expected 2 entries, got %v", entries)
+		}
+
+		if len(entries[0].GetChanges()) != 2 {
+			t.Fatalf(
+				"expected 2 changed fields, got %v",
+				entries[0].GetChanges(),
+			)
+		}
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		_, err := client.UpdateEvent(
+			asAdmin(t.Context()),
+			&sportsapi.UpdateEventRequest{
+				Event: &sportsapi.Event{Id: 1},
+				UpdateMask: &fieldmaskpb.FieldMask{
+					Paths: []string{"category"},
+				},
+				Etag: "*",
+			},
+		)
+		assertInvalidArgument(t, err, "event.category")
+	})
+
+	t.Run("missing etag", func(t *testing.T) {
+		_, err := client.UpdateEvent(
+			asAdmin(t.Context()),
+			&sportsapi.UpdateEventRequest{
+				Event:      &sportsapi.Event{Id: 1, Name: "Event"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+			},
+		)
+		assertInvalidArgument(t, err, "etag")
+	})
+
+	t.Run("field that cannot be updated", func(t *testing.T) {
+		_, err := client.UpdateEvent(
+			asAdmin(t.Context()),
+			&sportsapi.UpdateEventRequest{
+				Event: &sportsapi.Event{Id: 1},
+				UpdateMask: &fieldmaskpb.FieldMask{
+					Paths: []string{"matchState"},
+				},
+				Etag: "*",
+			},
+		)
+		assertInvalidArgument(t, err, "update_mask")
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		_, err := client.UpdateEvent(
+			t.Context(),
+			&sportsapi.UpdateEventRequest{
+				Event: &sportsapi.Event{Id: 1, Name: "Event"},
+				Etag:  "*",
+			},
+		)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+	})
+}
+
+func TestDeleteEvent(t *testing.T) {
+	db, _ := setupDatabase(t)
+	client := setupServer(t, &Service{DB: db})
+
+	t.Run("stale etag", func(t *testing.T) {
+		_, err := client.DeleteEvent(
+			asAdmin(t.Context()),
+			&sportsapi.DeleteEventRequest{EventId: 1, Etag: "2"},
+		)
+		if status.Code(err) != codes.Aborted {
+			t.Fatalf("expected %v error, got %v", codes.Aborted, err)
+		}
+	})
+
+	t.Run("admin only", func(t *testing.T) {
+		_, err := client.DeleteEvent(
+			t.Context(),
+			&sportsapi.DeleteEventRequest{EventId: 1, Etag: "1"},
+		)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("expected %v error, got %v", codes.PermissionDenied, err)
+		}
+	})
+
+	t.Run("deletes the event", func(t *testing.T) {
+		if _, err := client.DeleteEvent(
+			asAdmin(t.Context()),
+			&sportsapi.DeleteEventRequest{EventId: 1, Etag: "1"},
+		); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		_, err := client.GetEvent(
+			t.Context(),
+			&sportsapi.GetEventRequest{EventId: 1},
+		)
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+	})
+}
@@ -0,0 +1,186 @@
+package sports
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	"github.com/danilvpetrov/entain/apierror"
+	"github.com/danilvpetrov/entain/jurisdiction"
+	"github.com/danilvpetrov/entain/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// WatchEvent streams a sports event, sending its current snapshot followed by
+// a new snapshot every time its scores or match state change. The stream ends
+// once the match is finished or cancelled.
+//
+// Only the changes made through this instance of the service are streamed.
+func (s *Service) WatchEvent(
+	req *sportsapi.WatchEventRequest,
+	stream grpc.ServerStreamingServer[sportsapi.Event],
+) error {
+	ctx := stream.Context()
+
+	// Subscribe before reading the snapshot, so that no change made in
+	// between is missed.
+	updates, unsubscribe := s.watchers.subscribe(req.GetEventId())
+	defer unsubscribe()
+
+	event, err := s.GetEvent(
+		ctx,
+		&sportsapi.GetEventRequest{EventId: req.GetEventId()},
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+
+		if isMatchOver(event.GetMatchState()) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case event = <-updates:
+		}
+
+		if s.isRestricted(ctx, event.GetCategory()) {
+			return restricted(ctx)
+		}
+
+		event, err = s.asSeenByTenant(ctx, event)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// publishEvent reads the current snapshot of the sports event with the given
+// ID and publishes it to the watchers of the event, and returns it.
+//
+// The watchers may watch the event on behalf of different tenants and from
+// different jurisdictions, so the snapshot is read as seen by the clients that
+// are not tenants regardless of the restrictions, and each watcher applies the
+// overrides of its own tenant and the restrictions of its own jurisdiction.
+func (s *Service) publishEvent(
+	ctx context.Context,
+	eventID int64,
+) (*sportsapi.Event, error) {
+	event, err := s.GetEvent(
+		jurisdiction.WithCode(
+			tenant.WithName(ctx, ""),
+			jurisdiction.Unrestricted,
+		),
+		&sportsapi.GetEventRequest{EventId: eventID},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.watchers.publish(event)
+
+	return event, nil
+}
+
+// asSeenByTenant returns a published snapshot of a sports event as seen by the
+// tenant of the given context. The snapshot is shared by all watchers, so it
+// is copied rather than changed.
+func (s *Service) asSeenByTenant(
+	ctx context.Context,
+	event *sportsapi.Event,
+) (*sportsapi.Event, error) {
+	name := tenant.Name(ctx)
+	if name == "" {
+		return event, nil
+	}
+
+	var visible sql.Null[bool]
+	err := s.DB.QueryRowContext(
+		ctx,
+		`SELECT visible
+		FROM tenant_events
+		WHERE tenant = ? AND event_id = ?`,
+		name,
+		event.GetId(),
+	).Scan(&visible)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, apierror.Internal(ctx, err)
+	}
+
+	if !visible.Valid {
+		return event, nil
+	}
+
+	event = proto.CloneOf(event)
+	event.Visible = visible.V
+
+	return event, nil
+}
+
+// watchers keeps track of the subscribers watching sports events. The zero
+// value is ready to use.
+type watchers struct {
+	subs map[int64]map[chan *sportsapi.Event]struct{}
+	mu   sync.Mutex
+}
+
+// subscribe registers a subscriber of the event with the given ID. It returns
+// a channel that receives the snapshots of the event, and a function that
+// must be called to unsubscribe.
+//
+// A slow subscriber only receives the latest snapshot, the snapshots it has
+// not received yet are dropped.
+func (w *watchers) subscribe(
+	eventID int64,
+) (_ <-chan *sportsapi.Event, unsubscribe func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.subs == nil {
+		w.subs = map[int64]map[chan *sportsapi.Event]struct{}{}
+	}
+	if w.subs[eventID] == nil {
+		w.subs[eventID] = map[chan *sportsapi.Event]struct{}{}
+	}
+
+	ch := make(chan *sportsapi.Event, 1)
+	w.subs[eventID][ch] = struct{}{}
+
+	return ch, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		delete(w.subs[eventID], ch)
+		if len(w.subs[eventID]) == 0 {
+			delete(w.subs, eventID)
+		}
+	}
+}
+
+// publish sends a snapshot of an event to all its subscribers.
+func (w *watchers) publish(event *sportsapi.Event) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for ch := range w.subs[event.GetId()] {
+		// Replace the snapshot the subscriber has not received yet, if any.
+		select {
+		case <-ch:
+		default:
+		}
+
+		// This never blocks, as the mutex guarantees there are no other
+		// senders and the channel has just been drained.
+		ch <- event
+	}
+}
@@ -0,0 +1,104 @@
+package sports_test
+
+import (
+	"testing"
+
+	sportsapi "github.com/danilvpetrov/entain/api/sports"
+	. "github.com/danilvpetrov/entain/sports"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWatchEvent(t *testing.T) {
+	db, _ := setupDatabase(t)
+	s := &Service{
+		DB: db,
+	}
+	client := setupServer(t, s)
+
+	t.Run("streams changes until the match is over", func(t *testing.T) {
+		stream, err := client.WatchEvent(
+			t.Context(),
+			&sportsapi.WatchEventRequest{EventId: 1},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertMatch(t, event, sportsapi.Event_PRE_MATCH)
+
+		updates := []*sportsapi.UpdateScoreRequest{
+			{
+				EventId:    1,
+				MatchState: sportsapi.Event_IN_PLAY,
+				Etag:       "*",
+			},
+			{
+				EventId: 1,
+				Scores: []*sportsapi.PeriodScore{
+					{Period: 1, Home: 1, Away: 0},
+				},
+				Etag: "*",
+			},
+			{
+				EventId:    1,
+				MatchState: sportsapi.Event_FINISHED,
+				Etag:       "*",
+			},
+		}
+
+		for _, req := range updates {
+			expected, err := client.UpdateScore(asAdmin(t.Context()), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event, err := stream.Recv()
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertMatch(
+				t,
+				event,
+				expected.GetMatchState(),
+				expected.GetScores()...,
+			)
+		}
+
+		if _, err := stream.Recv(); err == nil {
+			t.Fatal("expected the stream to end once the match is finished")
+		}
+	})
+
+	t.Run("non-existing event ID", func(t *testing.T) {
+		stream, err := client.WatchEvent(
+			t.Context(),
+			&sportsapi.WatchEventRequest{EventId: 999},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = stream.Recv()
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("expected %v error, got %v", codes.NotFound, err)
+		}
+	})
+
+	t.Run("invalid event ID", func(t *testing.T) {
+		stream, err := client.WatchEvent(
+			t.Context(),
+			&sportsapi.WatchEventRequest{EventId: -1},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = stream.Recv()
+		assertInvalidArgument(t, err, "event_id")
+	})
+}
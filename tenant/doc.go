@@ -0,0 +1,9 @@
+// Package tenant identifies the brands the requests are made on behalf of.
+//
+// A tenant is a brand offering races and sports events to its own customers.
+// Each tenant may override the visibility and the ordering of races and sports
+// events, so that its customers see them differently. The tenant of a request
+// is carried by the "x-tenant-id" metadata of gRPC requests. The API gateway
+// forwards the X-Tenant-Id header of HTTP requests as such metadata, or sets it
+// to the tenant identified by the bearer token of the request.
+package tenant
@@ -0,0 +1,154 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/danilvpetrov/entain/apierror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataKey is the name of the gRPC metadata key that carries the name of
+// the tenant a request is made on behalf of.
+const MetadataKey = "x-tenant-id"
+
+// pattern is the pattern of valid tenant names.
+var pattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// IsValid returns true if the given string is a valid tenant name. Tenant
+// names are made of up to 63 lower-case letters, digits and dashes, and start
+// with a letter or a digit.
+func IsValid(name string) bool {
+	return pattern.MatchString(name)
+}
+
+// Tokens maps the bearer tokens identifying tenants to their names. Empty
+// tokens are ignored.
+type Tokens map[string]string
+
+// ParseTokens parses tenant tokens in the "<name>:<token>,<name>:<token>"
+// form.
+func ParseTokens(s string) (Tokens, error) {
+	tokens := Tokens{}
+	if s == "" {
+		return tokens, nil
+	}
+
+	for pair := range strings.SplitSeq(s, ",") {
+		name, token, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || name == "" || token == "" {
+			return nil, fmt.Errorf(
+				"tenant token %q is not in the <name>:<token> form",
+				pair,
+			)
+		}
+
+		if !IsValid(name) {
+			return nil, fmt.Errorf("tenant name %q is not valid", name)
+		}
+
+		if _, ok := tokens[token]; ok {
+			return nil, fmt.Errorf("tenant token of %q is not unique", name)
+		}
+
+		tokens[token] = name
+	}
+
+	return tokens, nil
+}
+
+// contextKey is the type of the key of the tenant name in a context.
+type contextKey struct{}
+
+// Name returns the name of the tenant the request of the given context is
+// made on behalf of, or an empty string if the request is not made on behalf
+// of a tenant.
+func Name(ctx context.Context) string {
+	name, _ := ctx.Value(contextKey{}).(string)
+	return name
+}
+
+// WithName returns a copy of the given context with the request made on
+// behalf of the tenant with the given name. If the name is empty, the request
+// is not made on behalf of a tenant.
+func WithName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, contextKey{}, name)
+}
+
+// UnaryServerInterceptor returns a gRPC server interceptor that marks the
+// requests bearing the "x-tenant-id" metadata as made on behalf of the tenant
+// it names. Requests naming an invalid tenant fail with
+// codes.InvalidArgument.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		ctx, err := withTenant(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a gRPC server interceptor that marks the
+// streaming calls bearing the "x-tenant-id" metadata as made on behalf of the
+// tenant it names. Calls naming an invalid tenant fail with
+// codes.InvalidArgument.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		_ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := withTenant(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// withTenant returns a context marked as made on behalf of the tenant named
+// by the incoming metadata of the given context, if any.
+func withTenant(ctx context.Context) (context.Context, error) {
+	values := metadata.ValueFromIncomingContext(ctx, MetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return ctx, nil
+	}
+
+	name := values[0]
+	if len(values) > 1 || !IsValid(name) {
+		return nil, apierror.InvalidArgument(
+			ctx,
+			"invalid tenant",
+			apierror.FieldViolation{
+				Field: MetadataKey,
+				Description: "a single tenant name made of lower-case " +
+					"letters, digits and dashes is required",
+			},
+		)
+	}
+
+	return WithName(ctx, name), nil
+}
+
+// serverStream is a grpc.ServerStream with a replaced context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
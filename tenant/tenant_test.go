@@ -0,0 +1,141 @@
+package tenant_test
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	. "github.com/danilvpetrov/entain/tenant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	cases := []struct {
+		md       metadata.MD
+		name     string
+		expected string
+		invalid  bool
+	}{
+		{
+			name:     "tenant",
+			md:       metadata.Pairs(MetadataKey, "brand-1"),
+			expected: "brand-1",
+		},
+		{
+			name: "missing tenant",
+			md:   metadata.MD{},
+		},
+		{
+			name: "empty tenant",
+			md:   metadata.Pairs(MetadataKey, ""),
+		},
+		{
+			name:    "invalid tenant",
+			md:      metadata.Pairs(MetadataKey, "Brand 1"),
+			invalid: true,
+		},
+		{
+			name: "multiple tenants",
+			md: metadata.Pairs(
+				MetadataKey, "brand-1",
+				MetadataKey, "brand-2",
+			),
+			invalid: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			interceptor := UnaryServerInterceptor()
+
+			var actual string
+			_, err := interceptor(
+				metadata.NewIncomingContext(t.Context(), c.md),
+				nil,
+				&grpc.UnaryServerInfo{},
+				func(ctx context.Context, _ any) (any, error) {
+					actual = Name(ctx)
+					return nil, nil
+				},
+			)
+
+			if c.invalid {
+				if status.Code(err) != codes.InvalidArgument {
+					t.Fatalf("expected invalid argument error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if actual != c.expected {
+				t.Fatalf("expected tenant %q, got %q", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseTokens(t *testing.T) {
+	cases := []struct {
+		expected Tokens
+		name     string
+		input    string
+		invalid  bool
+	}{
+		{
+			name:     "empty",
+			expected: Tokens{},
+		},
+		{
+			name:  "multiple tenants",
+			input: "brand-1:<token-1>, brand-2:<token-2>",
+			expected: Tokens{
+				"<token-1>": "brand-1",
+				"<token-2>": "brand-2",
+			},
+		},
+		{
+			name:    "missing name",
+			input:   ":<token>",
+			invalid: true,
+		},
+		{
+			name:    "missing token",
+			input:   "brand-1",
+			invalid: true,
+		},
+		{
+			name:    "invalid name",
+			input:   "Brand_1:<token>",
+			invalid: true,
+		},
+		{
+			name:    "duplicate token",
+			input:   "brand-1:<token>,brand-2:<token>",
+			invalid: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := ParseTokens(c.input)
+			if c.invalid {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !maps.Equal(actual, c.expected) {
+				t.Fatalf("expected %v, got %v", c.expected, actual)
+			}
+		})
+	}
+}
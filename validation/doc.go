@@ -0,0 +1,3 @@
+// Package validation enforces the validation rules declared in the *.proto
+// files of the APIs using protovalidate annotations.
+package validation
@@ -0,0 +1,108 @@
+package validation
+
+import (
+	"context"
+	"errors"
+
+	"buf.build/go/protovalidate"
+	"github.com/danilvpetrov/entain/apierror"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnaryServerInterceptor returns a gRPC server interceptor that validates
+// requests against the protovalidate rules declared in their *.proto files.
+// Invalid requests are rejected with codes.InvalidArgument code and a field
+// violation for each broken rule.
+func UnaryServerInterceptor() (grpc.UnaryServerInterceptor, error) {
+	v, err := protovalidate.New()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(
+		ctx context.Context,
+		req any,
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		if msg, ok := req.(proto.Message); ok {
+			if err := validate(ctx, v, msg); err != nil {
+				return nil, err
+			}
+		}
+
+		return handler(ctx, req)
+	}, nil
+}
+
+// StreamServerInterceptor returns a gRPC server interceptor that validates
+// every message received on a stream against the protovalidate rules declared
+// in their *.proto files. Invalid messages are rejected with
+// codes.InvalidArgument code and a field violation for each broken rule.
+func StreamServerInterceptor() (grpc.StreamServerInterceptor, error) {
+	v, err := protovalidate.New()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		_ *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		return handler(srv, &serverStream{ServerStream: ss, validator: v})
+	}, nil
+}
+
+// serverStream is a grpc.ServerStream that validates received messages.
+type serverStream struct {
+	grpc.ServerStream
+	validator protovalidate.Validator
+}
+
+// RecvMsg receives a message from the stream and validates it.
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if msg, ok := m.(proto.Message); ok {
+		return validate(s.Context(), s.validator, msg)
+	}
+
+	return nil
+}
+
+// validate validates a message, converting validation errors to
+// codes.InvalidArgument errors.
+func validate(
+	ctx context.Context,
+	v protovalidate.Validator,
+	msg proto.Message,
+) error {
+	err := v.Validate(msg)
+	if err == nil {
+		return nil
+	}
+
+	var verr *protovalidate.ValidationError
+	if !errors.As(err, &verr) {
+		// Compilation or runtime errors of validation rules are programming
+		// errors, clients cannot do anything about them.
+		return apierror.Internal(ctx, err)
+	}
+
+	violations := make([]apierror.FieldViolation, 0, len(verr.Violations))
+	for _, violation := range verr.Violations {
+		violations = append(violations, apierror.FieldViolation{
+			Field: protovalidate.FieldPathString(
+				violation.Proto.GetField(),
+			),
+			Description: violation.Proto.GetMessage(),
+		})
+	}
+
+	return apierror.InvalidArgument(ctx, "invalid request", violations...)
+}